@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen/diff"
+)
+
+// compare evaluates the old and new design packages and prints the changes
+// between the two. The old design is evaluated using the git revision rev if
+// not empty, the current working tree otherwise. compare exits with status 2
+// if the changes include breaking changes.
+func compare(oldPath, newPath, rev, format string, debug bool) {
+	if format != "json" && format != "text" {
+		fmt.Fprintf(os.Stderr, "invalid format %q, must be one of \"json\" or \"text\"\n", format)
+		os.Exit(1)
+	}
+	report, err := compareDesigns(oldPath, newPath, rev, debug)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	switch format {
+	case "text":
+		if len(report.Changes) == 0 {
+			fmt.Println("no changes")
+		}
+		for _, c := range report.Changes {
+			fmt.Println(c.String())
+		}
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+	if report.Breaking {
+		os.Exit(2)
+	}
+}
+
+// compareDesigns returns the changes between the old and new designs.
+func compareDesigns(oldPath, newPath, rev string, debug bool) (*diff.Report, error) {
+	var workDir string
+	if rev != "" {
+		dir, cleanup, err := checkout(rev, debug)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		if err := replaceGoa(dir); err != nil {
+			return nil, err
+		}
+		workDir = dir
+	}
+	old, err := snapshot(oldPath, workDir, debug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate design %s: %s", oldPath, err)
+	}
+	new, err := snapshot(newPath, "", debug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate design %s: %s", newPath, err)
+	}
+	return diff.Compare(old, new), nil
+}
+
+// snapshot evaluates the design package with the given import path and
+// returns the corresponding design snapshot. The design package is resolved
+// using the Go module containing workDir if not empty, the current working
+// directory otherwise. The Go module containing workDir must use the same
+// goa module as the current working directory, see replaceGoa.
+func snapshot(path, workDir string, debug bool) (*diff.Design, error) {
+	if workDir == "" {
+		if _, err := build.Import(path, ".", 0); err != nil {
+			return nil, err
+		}
+	}
+	tmp := NewGenerator("diff", path, ".")
+	tmp.WorkDir = workDir
+	if workDir != "" {
+		// The go.sum file of the old revision may lack the checksums of
+		// the dependencies of the current goa module.
+		tmp.BuildFlags = []string{"-mod=mod"}
+	}
+	if tmp.DesignVersion < 3 {
+		return nil, fmt.Errorf("goa diff requires a design using goa v3 or later")
+	}
+	if !debug {
+		defer tmp.Remove()
+	}
+	if err := tmp.Write(debug); err != nil {
+		return nil, err
+	}
+	if err := tmp.Compile(); err != nil {
+		return nil, err
+	}
+	lines, err := tmp.Run()
+	if err != nil {
+		return nil, err
+	}
+	var d diff.Design
+	if err := json.Unmarshal([]byte(strings.Join(lines, "\n")), &d); err != nil {
+		return nil, fmt.Errorf("invalid design snapshot: %s", err)
+	}
+	return &d, nil
+}
+
+// replaceGoa makes the Go module containing dir use the goa module used by
+// the current working directory. This guarantees that both designs are
+// evaluated with the same version of goa: the version pinned by an old
+// revision may predate the diff package required by the generator.
+func replaceGoa(dir string) error {
+	goaDir, err := goCmd(".", "list", "-m", "-f", "{{.Dir}}", "goa.design/goa/v3")
+	if err != nil {
+		return err
+	}
+	if goaDir == "" {
+		return fmt.Errorf("failed to locate the goa module source directory")
+	}
+	gomod, err := goCmd(dir, "env", "GOMOD")
+	if err != nil {
+		return err
+	}
+	if gomod == "" || gomod == os.DevNull {
+		return fmt.Errorf("%s is not in a Go module", dir)
+	}
+	_, err = goCmd(filepath.Dir(gomod), "mod", "edit", "-replace=goa.design/goa/v3="+goaDir)
+	return err
+}
+
+// checkout creates a git worktree for the given revision of the repository
+// containing the current working directory. It returns the directory in the
+// worktree that corresponds to the current working directory and a function
+// that deletes the worktree.
+func checkout(rev string, debug bool) (string, func(), error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", nil, err
+	}
+	top, err := git(wd, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, err
+	}
+	rel, err := filepath.Rel(top, wd)
+	if err != nil {
+		return "", nil, err
+	}
+	tree, err := ioutil.TempDir("", "goa-diff")
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := git(top, "worktree", "add", "--detach", tree, rev); err != nil {
+		os.RemoveAll(tree)
+		return "", nil, err
+	}
+	cleanup := func() {
+		if debug {
+			return
+		}
+		git(top, "worktree", "remove", "--force", tree)
+		os.RemoveAll(tree)
+	}
+	return filepath.Join(tree, rel), cleanup, nil
+}
+
+// git runs the git command with the given arguments in dir and returns its
+// trimmed output.
+func git(dir string, args ...string) (string, error) {
+	c := exec.Command("git", args...)
+	c.Dir = dir
+	out, err := c.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// goCmd runs the go command with the given arguments in dir and returns its
+// trimmed output.
+func goCmd(dir string, args ...string) (string, error) {
+	c := exec.Command("go", args...)
+	c.Dir = dir
+	c.Env = append(os.Environ(), "GO111MODULE=on")
+	out, err := c.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("go %s: %s\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -32,6 +32,15 @@ type Generator struct {
 	// DesignVersion is either 2 or 3.
 	DesignVersion int
 
+	// WorkDir is the directory in which the generator is compiled. It
+	// defaults to the current working directory and determines the Go
+	// module used to resolve the design package.
+	WorkDir string
+
+	// BuildFlags lists additional flags given to "go build" when compiling
+	// the generator.
+	BuildFlags []string
+
 	// bin is the filename of the generated generator.
 	bin string
 
@@ -93,6 +102,9 @@ func (g *Generator) Write(debug bool) error {
 		if cwd, err := os.Getwd(); err != nil {
 			wd = cwd
 		}
+		if g.WorkDir != "" {
+			wd = g.WorkDir
+		}
 		tmp, err := ioutil.TempDir(wd, "goa")
 		if err != nil {
 			return err
@@ -113,6 +125,7 @@ func (g *Generator) Write(debug bool) error {
 			ver = "v" + strconv.Itoa(g.DesignVersion) + "/"
 		}
 		imports := []*codegen.ImportSpec{
+			codegen.SimpleImport("encoding/json"),
 			codegen.SimpleImport("flag"),
 			codegen.SimpleImport("fmt"),
 			codegen.SimpleImport("os"),
@@ -121,8 +134,10 @@ func (g *Generator) Write(debug bool) error {
 			codegen.SimpleImport("strconv"),
 			codegen.SimpleImport("strings"),
 			codegen.SimpleImport("goa.design/goa/" + ver + "codegen"),
+			codegen.SimpleImport("goa.design/goa/" + ver + "codegen/diff"),
 			codegen.SimpleImport("goa.design/goa/" + ver + "codegen/generator"),
 			codegen.SimpleImport("goa.design/goa/" + ver + "eval"),
+			codegen.SimpleImport("goa.design/goa/" + ver + "expr"),
 			codegen.NewImport("goa", "goa.design/goa/"+ver+"pkg"),
 			codegen.NewImport("_", g.DesignPath),
 		}
@@ -143,7 +158,8 @@ func (g *Generator) Write(debug bool) error {
 
 // Compile compiles the generator.
 func (g *Generator) Compile() error {
-	return g.runGoCmd("build", "-o", g.bin)
+	args := append([]string{"build"}, g.BuildFlags...)
+	return g.runGoCmd(append(args, "-o", g.bin)...)
 }
 
 // Run runs the compiled binary and return the output lines.
@@ -280,6 +296,11 @@ const mainT = `func main() {
 		fail(err.Error())
 	}
 {{- end }}
+{{- if eq .Command "diff" }}
+	if err := json.NewEncoder(os.Stdout).Encode(diff.NewDesign(expr.Root)); err != nil {
+		fail(err.Error())
+	}
+{{- else }}
 {{- if gt .DesignVersion 2 }}
 	codegen.DesignVersion = ver
 {{- end }}
@@ -289,6 +310,7 @@ const mainT = `func main() {
 	}
 
 	fmt.Println(strings.Join(outputs, "\n"))
+{{- end }}
 }
 
 func fail(msg string, vals ...interface{}) {
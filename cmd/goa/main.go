@@ -13,9 +13,10 @@ import (
 
 func main() {
 	var (
-		cmd    string
-		path   string
-		offset int
+		cmd     string
+		path    string
+		newPath string
+		offset  int
 	)
 	{
 		if len(os.Args) == 1 {
@@ -33,6 +34,18 @@ func main() {
 			cmd = os.Args[1]
 			path = os.Args[2]
 			offset = 2
+		case "diff":
+			if len(os.Args) == 2 {
+				usage()
+			}
+			cmd = os.Args[1]
+			path = os.Args[2]
+			newPath = path
+			offset = 2
+			if len(os.Args) > 3 && !strings.HasPrefix(os.Args[3], "-") {
+				newPath = os.Args[3]
+				offset = 3
+			}
 		default:
 			usage()
 		}
@@ -40,6 +53,8 @@ func main() {
 
 	var (
 		output = "."
+		rev    string
+		format = "json"
 		debug  bool
 	)
 	if len(os.Args) > offset+1 {
@@ -48,6 +63,8 @@ func main() {
 			o    = fset.String("o", "", "output `directory`")
 			out  = fset.String("output", output, "output `directory`")
 		)
+		fset.StringVar(&rev, "rev", "", "git `revision` of the old design")
+		fset.StringVar(&format, "format", format, "report `format`")
 		fset.BoolVar(&debug, "debug", false, "Print debug information")
 
 		fset.Usage = usage
@@ -59,13 +76,22 @@ func main() {
 		}
 	}
 
+	if cmd == "diff" {
+		if path == newPath && rev == "" {
+			rev = "HEAD"
+		}
+		diffCmd(path, newPath, rev, format, debug)
+		return
+	}
+
 	gen(cmd, path, output, debug)
 }
 
 // help with tests
 var (
-	usage = help
-	gen   = generate
+	usage   = help
+	gen     = generate
+	diffCmd = compare
 )
 
 func generate(cmd, path, output string, debug bool) {
@@ -113,6 +139,7 @@ Learn more at https://goa.design.
 Usage:
   goa gen PACKAGE [--out DIRECTORY] [--debug]
   goa example PACKAGE [--out DIRECTORY] [--debug]
+  goa diff PACKAGE [NEWPACKAGE] [--rev REVISION] [--format FORMAT] [--debug]
   goa version
 
 Commands:
@@ -120,6 +147,11 @@ Commands:
         Generate service interfaces, endpoints, transport code and OpenAPI spec.
   example
         Generate example server and client tool.
+  diff
+        Report the changes between two designs and exit with status 2 if
+        any change breaks existing clients. The old design is PACKAGE at
+        git revision REVISION (HEAD by default) or PACKAGE in the current
+        working tree if NEWPACKAGE is provided and REVISION is not.
   version
         Print version information (exclusive with other flags and commands).
 
@@ -127,16 +159,27 @@ Args:
   PACKAGE
         Go import path to design package
 
+  NEWPACKAGE
+        Go import path to the new design package (diff only), defaults to
+        PACKAGE
+
 Flags:
   -o, -output DIRECTORY
         output directory, defaults to the current working directory
 
+  -rev REVISION
+        git revision used to evaluate the old design (diff only)
+
+  -format FORMAT
+        report format, one of "json" (default) or "text" (diff only)
+
   -debug
         Print debug information (mainly intended for goa developers)
 
 Example:
 
   goa gen goa.design/cellar/design -o gendir
+  goa diff goa.design/cellar/design --rev v1.0.0
 
 `)
 	os.Exit(1)
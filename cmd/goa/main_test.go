@@ -75,3 +75,63 @@ func TestCmdLine(t *testing.T) {
 		}
 	}
 }
+
+func TestDiffCmdLine(t *testing.T) {
+	const (
+		testPkg    = "/test"
+		testNewPkg = "/new"
+	)
+	var (
+		usageCalled      bool
+		oldPath, newPath string
+		rev, format      string
+	)
+
+	usage = func() { usageCalled = true }
+	diffCmd = func(o, n, r, f string, _ bool) { oldPath, newPath, rev, format = o, n, r, f }
+	defer func() {
+		usage = help
+		diffCmd = compare
+	}()
+
+	cases := map[string]struct {
+		CmdLine         string
+		ExpectedUsage   bool
+		ExpectedOldPath string
+		ExpectedNewPath string
+		ExpectedRev     string
+		ExpectedFormat  string
+	}{
+		"diff":          {"diff " + testPkg, false, testPkg, testPkg, "HEAD", "json"},
+		"diff rev":      {"diff " + testPkg + " -rev v1", false, testPkg, testPkg, "v1", "json"},
+		"diff packages": {"diff " + testPkg + " " + testNewPkg, false, testPkg, testNewPkg, "", "json"},
+		"diff format":   {"diff " + testPkg + " " + testNewPkg + " -rev v1 -format text", false, testPkg, testNewPkg, "v1", "text"},
+	}
+
+	for k, c := range cases {
+		{
+			args := strings.Split(c.CmdLine, " ")
+			os.Args = append([]string{"goa"}, args...)
+			usageCalled = false
+			oldPath, newPath, rev, format = "", "", "", ""
+		}
+
+		main()
+
+		if usageCalled != c.ExpectedUsage {
+			t.Errorf("%s: Expected usage to be %v but got %v", k, c.ExpectedUsage, usageCalled)
+		}
+		if oldPath != c.ExpectedOldPath {
+			t.Errorf("%s: Expected old path to be %s but got %s", k, c.ExpectedOldPath, oldPath)
+		}
+		if newPath != c.ExpectedNewPath {
+			t.Errorf("%s: Expected new path to be %s but got %s", k, c.ExpectedNewPath, newPath)
+		}
+		if rev != c.ExpectedRev {
+			t.Errorf("%s: Expected rev to be %s but got %s", k, c.ExpectedRev, rev)
+		}
+		if format != c.ExpectedFormat {
+			t.Errorf("%s: Expected format to be %s but got %s", k, c.ExpectedFormat, format)
+		}
+	}
+}
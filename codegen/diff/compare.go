@@ -0,0 +1,349 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	// Report lists the changes between two designs.
+	Report struct {
+		// Breaking is true if at least one of the changes is breaking.
+		Breaking bool `json:"breaking"`
+		// Changes lists the changes in the order they were detected.
+		Changes []*Change `json:"changes"`
+	}
+
+	// Change describes a single change between two designs.
+	Change struct {
+		// Kind identifies the type of change, see the Kind constants.
+		Kind Kind `json:"kind"`
+		// Breaking is true if the change may break existing clients.
+		Breaking bool `json:"breaking"`
+		// Path identifies the changed element, e.g.
+		// "service.method.payload.field".
+		Path string `json:"path"`
+		// Message describes the change.
+		Message string `json:"message"`
+	}
+
+	// Kind enumerates the types of changes.
+	Kind string
+
+	// comparer holds the state used to compare two designs.
+	comparer struct {
+		old, new *Design
+		report   *Report
+		// seen records the pairs of user types already compared for
+		// the current method to avoid infinite recursions.
+		seen map[string]bool
+	}
+)
+
+const (
+	// ServiceAdded indicates a service was added.
+	ServiceAdded Kind = "service-added"
+	// ServiceRemoved indicates a service was removed.
+	ServiceRemoved Kind = "service-removed"
+	// MethodAdded indicates a method was added.
+	MethodAdded Kind = "method-added"
+	// MethodRemoved indicates a method was removed.
+	MethodRemoved Kind = "method-removed"
+	// RouteAdded indicates a HTTP route was added.
+	RouteAdded Kind = "route-added"
+	// RouteRemoved indicates a HTTP route was removed or changed.
+	RouteRemoved Kind = "route-removed"
+	// TransportRemoved indicates a method is no longer exposed via a
+	// transport.
+	TransportRemoved Kind = "transport-removed"
+	// FieldAdded indicates an optional field was added.
+	FieldAdded Kind = "field-added"
+	// FieldRemoved indicates a field was removed.
+	FieldRemoved Kind = "field-removed"
+	// FieldRequired indicates a payload field became required or a
+	// required payload field was added.
+	FieldRequired Kind = "field-required"
+	// FieldOptional indicates a required field became optional.
+	FieldOptional Kind = "field-optional"
+	// FieldNumberChanged indicates a protobuf field number changed.
+	FieldNumberChanged Kind = "field-number-changed"
+	// TypeChanged indicates the type of an attribute changed.
+	TypeChanged Kind = "type-changed"
+	// EnumNarrowed indicates enum values were removed.
+	EnumNarrowed Kind = "enum-narrowed"
+	// EnumWidened indicates enum values were added.
+	EnumWidened Kind = "enum-widened"
+	// ErrorAdded indicates an error was added to a method.
+	ErrorAdded Kind = "error-added"
+	// ErrorRemoved indicates an error was removed from a method or from
+	// the method transport error responses.
+	ErrorRemoved Kind = "error-removed"
+	// ErrorStatusChanged indicates the status code of an error response
+	// changed.
+	ErrorStatusChanged Kind = "error-status-changed"
+)
+
+// Compare returns the changes needed to go from the old design to the new
+// design.
+func Compare(old, new *Design) *Report {
+	c := &comparer{old: old, new: new, report: &Report{}}
+	for _, os := range old.Services {
+		ns := new.Service(os.Name)
+		if ns == nil {
+			c.add(ServiceRemoved, true, os.Name, "service removed")
+			continue
+		}
+		for _, om := range os.Methods {
+			path := os.Name + "." + om.Name
+			nm := ns.Method(om.Name)
+			if nm == nil {
+				c.add(MethodRemoved, true, path, "method removed")
+				continue
+			}
+			c.compareMethod(path, om, nm)
+		}
+		for _, nm := range ns.Methods {
+			if os.Method(nm.Name) == nil {
+				c.add(MethodAdded, false, ns.Name+"."+nm.Name, "method added")
+			}
+		}
+	}
+	for _, ns := range new.Services {
+		if old.Service(ns.Name) == nil {
+			c.add(ServiceAdded, false, ns.Name, "service added")
+		}
+	}
+	return c.report
+}
+
+// String returns a human friendly representation of the change.
+func (c *Change) String() string {
+	level := "info"
+	if c.Breaking {
+		level = "BREAKING"
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", level, c.Path, c.Message, c.Kind)
+}
+
+// add records a change.
+func (c *comparer) add(kind Kind, breaking bool, path, format string, args ...interface{}) {
+	c.report.Changes = append(c.report.Changes, &Change{
+		Kind:     kind,
+		Breaking: breaking,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+	if breaking {
+		c.report.Breaking = true
+	}
+}
+
+// compareMethod compares two revisions of the same method.
+func (c *comparer) compareMethod(path string, old, new *Method) {
+	// User types shared by multiple methods must be reported under each
+	// method path.
+	c.seen = make(map[string]bool)
+	c.compareAttribute(path+".payload", old.Payload, new.Payload, true)
+	c.compareAttribute(path+".result", old.Result, new.Result, false)
+
+	for _, oe := range old.Errors {
+		if !contains(new.Errors, oe) {
+			c.add(ErrorRemoved, true, path, "error %q removed", oe)
+		}
+	}
+	for _, ne := range new.Errors {
+		if !contains(old.Errors, ne) {
+			c.add(ErrorAdded, false, path, "error %q added", ne)
+		}
+	}
+
+	switch {
+	case old.HTTP != nil && new.HTTP == nil:
+		c.add(TransportRemoved, true, path, "HTTP endpoint removed")
+	case old.HTTP != nil:
+		for _, r := range old.HTTP.Routes {
+			if !contains(new.HTTP.Routes, r) {
+				c.add(RouteRemoved, true, path, "HTTP route %q removed", r)
+			}
+		}
+		for _, r := range new.HTTP.Routes {
+			if !contains(old.HTTP.Routes, r) {
+				c.add(RouteAdded, false, path, "HTTP route %q added", r)
+			}
+		}
+		c.compareErrorCodes(path, "HTTP", old.HTTP.Errors, new.HTTP.Errors)
+	}
+
+	switch {
+	case old.GRPC != nil && new.GRPC == nil:
+		c.add(TransportRemoved, true, path, "gRPC endpoint removed")
+	case old.GRPC != nil:
+		c.compareErrorCodes(path, "gRPC", old.GRPC.Errors, new.GRPC.Errors)
+	}
+}
+
+// compareErrorCodes compares the error responses of a transport endpoint.
+func (c *comparer) compareErrorCodes(path, transport string, old, new map[string]int) {
+	for _, name := range sortedKeys(old) {
+		code, ok := new[name]
+		if !ok {
+			c.add(ErrorRemoved, true, path, "%s error response %q removed", transport, name)
+			continue
+		}
+		if code != old[name] {
+			c.add(ErrorStatusChanged, true, path, "%s error response %q status changed from %d to %d", transport, name, old[name], code)
+		}
+	}
+}
+
+// compareAttribute compares two revisions of the same attribute. payload is
+// true if the attribute describes data sent by clients, false if it describes
+// data received by clients.
+func (c *comparer) compareAttribute(path string, old, new *Attribute, payload bool) {
+	if old == nil || new == nil {
+		if old != nil || new != nil {
+			c.add(TypeChanged, true, path, "type changed from %s to %s", c.typeName(old), c.typeName(new))
+		}
+		return
+	}
+	c.compareEnum(path, old.Enum, new.Enum, payload)
+	if old.Ref != "" || new.Ref != "" {
+		key := fmt.Sprintf("%s|%s|%v", old.Ref, new.Ref, payload)
+		if c.seen[key] {
+			return
+		}
+		c.seen[key] = true
+	}
+	o, n := c.resolve(old, c.old), c.resolve(new, c.new)
+	if o == nil || n == nil {
+		return
+	}
+	if o != old || n != new {
+		// Compare the user type attributes.
+		c.compareAttribute(path, o, n, payload)
+		return
+	}
+	if o.Type != n.Type {
+		c.add(TypeChanged, true, path, "type changed from %s to %s", c.typeName(old), c.typeName(new))
+		return
+	}
+	switch o.Type {
+	case "array":
+		c.compareAttribute(path+"[*]", o.Elem, n.Elem, payload)
+	case "map":
+		c.compareAttribute(path+".key", o.Key, n.Key, payload)
+		c.compareAttribute(path+"[key]", o.Elem, n.Elem, payload)
+	case "object", "union":
+		c.compareFields(path, o, n, payload)
+	}
+}
+
+// compareFields compares the fields of two revisions of the same object.
+func (c *comparer) compareFields(path string, old, new *Attribute, payload bool) {
+	for _, of := range old.Fields {
+		fpath := path + "." + of.Name
+		nf := new.Field(of.Name)
+		if nf == nil {
+			// Removing a field clients rely on is breaking, removing
+			// a field clients send is not: the field is ignored.
+			c.add(FieldRemoved, !payload, fpath, "field removed")
+			continue
+		}
+		if of.Tag != 0 && nf.Tag != 0 && of.Tag != nf.Tag {
+			c.add(FieldNumberChanged, true, fpath, "protobuf field number changed from %d to %d", of.Tag, nf.Tag)
+		}
+		switch {
+		case payload && !of.Required && nf.Required:
+			c.add(FieldRequired, true, fpath, "field is now required")
+		case !payload && of.Required && !nf.Required:
+			c.add(FieldOptional, true, fpath, "field is now optional")
+		}
+		c.compareAttribute(fpath, of.Attribute, nf.Attribute, payload)
+	}
+	for _, nf := range new.Fields {
+		if old.Field(nf.Name) != nil {
+			continue
+		}
+		fpath := path + "." + nf.Name
+		if payload && nf.Required {
+			c.add(FieldRequired, true, fpath, "required field added")
+			continue
+		}
+		c.add(FieldAdded, false, fpath, "field added")
+	}
+}
+
+// compareEnum compares the enum values of two revisions of the same
+// attribute. Removing values breaks clients that send them, adding values
+// breaks clients that receive them.
+func (c *comparer) compareEnum(path string, old, new []string, payload bool) {
+	if len(old) == 0 {
+		if len(new) > 0 {
+			c.add(EnumNarrowed, payload, path, "enum validation added with values %s", strings.Join(new, ", "))
+		}
+		return
+	}
+	if len(new) == 0 {
+		c.add(EnumWidened, !payload, path, "enum validation removed")
+		return
+	}
+	var removed, added []string
+	for _, v := range old {
+		if !contains(new, v) {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range new {
+		if !contains(old, v) {
+			added = append(added, v)
+		}
+	}
+	if len(removed) > 0 {
+		c.add(EnumNarrowed, payload, path, "enum values %s removed", strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		c.add(EnumWidened, !payload, path, "enum values %s added", strings.Join(added, ", "))
+	}
+}
+
+// resolve returns the attribute of the user type referenced by att if any,
+// att otherwise.
+func (c *comparer) resolve(att *Attribute, d *Design) *Attribute {
+	if att.Ref == "" {
+		return att
+	}
+	return d.Types[att.Ref]
+}
+
+// typeName returns a human friendly name for the type of att.
+func (c *comparer) typeName(att *Attribute) string {
+	switch {
+	case att == nil:
+		return "empty"
+	case att.Ref != "":
+		return att.Ref
+	default:
+		return att.Type
+	}
+}
+
+// contains returns true if vals contains v.
+func contains(vals []string, v string) bool {
+	for _, val := range vals {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of m in lexical order.
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package diff
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/diff/testdata"
+)
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Old      func()
+		New      func()
+		Breaking bool
+		Expected []string
+	}{
+		{"identical", testdata.BaseDSL, testdata.BaseDSL, false, nil},
+		{"method-added", testdata.BaseDSL, testdata.MethodAddedDSL, false, []string{
+			"method-added Service.Other",
+		}},
+		{"method-removed", testdata.MethodAddedDSL, testdata.BaseDSL, true, []string{
+			"method-removed Service.Other",
+		}},
+		{"breaking", testdata.BaseDSL, testdata.BreakingDSL, true, []string{
+			"field-required Service.Method.payload.kind",
+			"enum-narrowed Service.Method.payload.kind",
+			"field-required Service.Method.payload.extra",
+			"field-number-changed Service.Method.result.id",
+			"field-removed Service.Method.result.name",
+			"error-removed Service.Method",
+			"route-removed Service.Method",
+			"route-added Service.Method",
+			"error-removed Service.Method",
+			"error-removed Service.Method",
+		}},
+		{"compatible", testdata.BaseDSL, testdata.CompatibleDSL, false, []string{
+			"field-removed Service.Method.payload.name",
+			"enum-widened Service.Method.payload.kind",
+			"field-added Service.Method.payload.extra",
+			"field-added Service.Method.result.extra",
+			"error-added Service.Method",
+			"route-added Service.Method",
+		}},
+		{"shared-type", testdata.SharedTypeDSL, testdata.SharedTypeChangedDSL, true, []string{
+			"field-required Service.Create.payload.name",
+			"field-required Service.Update.payload.name",
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			old := NewDesign(codegen.RunDSL(t, c.Old))
+			new := NewDesign(codegen.RunDSL(t, c.New))
+			report := Compare(old, new)
+			if report.Breaking != c.Breaking {
+				t.Errorf("got breaking %v, expected %v", report.Breaking, c.Breaking)
+			}
+			var actual []string
+			for _, ch := range report.Changes {
+				actual = append(actual, string(ch.Kind)+" "+ch.Path)
+			}
+			if len(actual) != len(c.Expected) {
+				t.Fatalf("got %d changes, expected %d:\n%v", len(actual), len(c.Expected), report.Changes)
+			}
+			for i, a := range actual {
+				if a != c.Expected[i] {
+					t.Errorf("change %d: got %q, expected %q", i, a, c.Expected[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// Design is a snapshot of the parts of a design that define the API
+	// contract. Snapshots can be serialized so that designs evaluated by
+	// different processes (e.g. two revisions of the same design package)
+	// can be compared.
+	Design struct {
+		// Services lists the design services.
+		Services []*Service `json:"services"`
+		// Types contains the user types indexed by name.
+		Types map[string]*Attribute `json:"types,omitempty"`
+	}
+
+	// Service is a snapshot of a service.
+	Service struct {
+		// Name is the service name.
+		Name string `json:"name"`
+		// Methods lists the service methods.
+		Methods []*Method `json:"methods"`
+	}
+
+	// Method is a snapshot of a service method.
+	Method struct {
+		// Name is the method name.
+		Name string `json:"name"`
+		// Payload is the method payload if any.
+		Payload *Attribute `json:"payload,omitempty"`
+		// Result is the method result if any.
+		Result *Attribute `json:"result,omitempty"`
+		// Errors lists the names of the errors returned by the method.
+		Errors []string `json:"errors,omitempty"`
+		// HTTP describes the HTTP endpoint if any.
+		HTTP *HTTPEndpoint `json:"http,omitempty"`
+		// GRPC describes the gRPC endpoint if any.
+		GRPC *GRPCEndpoint `json:"grpc,omitempty"`
+	}
+
+	// HTTPEndpoint is a snapshot of a HTTP endpoint.
+	HTTPEndpoint struct {
+		// Routes lists the endpoint routes formatted as "METHOD /path".
+		Routes []string `json:"routes"`
+		// Errors maps the error names to the corresponding HTTP status
+		// codes.
+		Errors map[string]int `json:"errors,omitempty"`
+	}
+
+	// GRPCEndpoint is a snapshot of a gRPC endpoint.
+	GRPCEndpoint struct {
+		// Errors maps the error names to the corresponding gRPC status
+		// codes.
+		Errors map[string]int `json:"errors,omitempty"`
+	}
+
+	// Attribute is a snapshot of an attribute.
+	Attribute struct {
+		// Type is the name of the primitive type or one of "array",
+		// "map", "object" or "union". Type is empty if the attribute
+		// is a user type.
+		Type string `json:"type,omitempty"`
+		// Ref is the name of the user type if any. The user type
+		// attribute is stored in the design Types.
+		Ref string `json:"ref,omitempty"`
+		// Enum lists the enum values formatted as strings.
+		Enum []string `json:"enum,omitempty"`
+		// Key is the map key attribute.
+		Key *Attribute `json:"key,omitempty"`
+		// Elem is the array or map element attribute.
+		Elem *Attribute `json:"elem,omitempty"`
+		// Fields lists the object or union fields.
+		Fields []*Field `json:"fields,omitempty"`
+	}
+
+	// Field is a snapshot of an object or union field.
+	Field struct {
+		// Name is the field name.
+		Name string `json:"name"`
+		// Required is true if the field is required.
+		Required bool `json:"required,omitempty"`
+		// Tag is the protobuf field number if any.
+		Tag int `json:"tag,omitempty"`
+		// Attribute is the field attribute.
+		Attribute *Attribute `json:"attribute"`
+	}
+)
+
+// NewDesign takes a snapshot of the given evaluated design root.
+func NewDesign(root *expr.RootExpr) *Design {
+	d := &Design{Types: make(map[string]*Attribute)}
+	for _, svc := range root.Services {
+		s := &Service{Name: svc.Name}
+		for _, m := range svc.Methods {
+			s.Methods = append(s.Methods, d.method(root, svc, m))
+		}
+		d.Services = append(d.Services, s)
+	}
+	return d
+}
+
+// Service returns the service with the given name, nil if there isn't one.
+func (d *Design) Service(name string) *Service {
+	for _, s := range d.Services {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Method returns the method with the given name, nil if there isn't one.
+func (s *Service) Method(name string) *Method {
+	for _, m := range s.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Field returns the field with the given name, nil if there isn't one.
+func (a *Attribute) Field(name string) *Field {
+	for _, f := range a.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// method takes a snapshot of the given method.
+func (d *Design) method(root *expr.RootExpr, svc *expr.ServiceExpr, m *expr.MethodExpr) *Method {
+	res := &Method{
+		Name:    m.Name,
+		Payload: d.attribute(m.Payload),
+		Result:  d.attribute(m.Result),
+	}
+	for _, e := range m.Errors {
+		res.Errors = append(res.Errors, e.Name)
+	}
+	sort.Strings(res.Errors)
+	if root.API == nil {
+		return res
+	}
+	if hs := root.API.HTTP.Service(svc.Name); hs != nil {
+		if e := hs.Endpoint(m.Name); e != nil {
+			h := &HTTPEndpoint{}
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					h.Routes = append(h.Routes, r.Method+" "+p)
+				}
+			}
+			sort.Strings(h.Routes)
+			for _, he := range e.HTTPErrors {
+				if h.Errors == nil {
+					h.Errors = make(map[string]int)
+				}
+				h.Errors[he.Name] = he.Response.StatusCode
+			}
+			res.HTTP = h
+		}
+	}
+	if gs := root.API.GRPC.Service(svc.Name); gs != nil {
+		for _, e := range gs.GRPCEndpoints {
+			if e.Name() != m.Name {
+				continue
+			}
+			g := &GRPCEndpoint{}
+			for _, ge := range e.GRPCErrors {
+				if g.Errors == nil {
+					g.Errors = make(map[string]int)
+				}
+				g.Errors[ge.Name] = ge.Response.StatusCode
+			}
+			res.GRPC = g
+		}
+	}
+	return res
+}
+
+// attribute takes a snapshot of the given attribute recording the user types
+// it uses in d.
+func (d *Design) attribute(att *expr.AttributeExpr) *Attribute {
+	if att == nil || att.Type == nil || att.Type == expr.Empty {
+		return nil
+	}
+	res := &Attribute{}
+	if att.Validation != nil {
+		for _, v := range att.Validation.Values {
+			res.Enum = append(res.Enum, fmt.Sprintf("%v", v))
+		}
+	}
+	switch t := att.Type.(type) {
+	case expr.UserType:
+		res.Ref = t.Name()
+		if _, ok := d.Types[t.Name()]; !ok {
+			d.Types[t.Name()] = nil // break recursions
+			d.Types[t.Name()] = d.attribute(t.Attribute())
+		}
+	case *expr.Array:
+		res.Type = "array"
+		res.Elem = d.attribute(t.ElemType)
+	case *expr.Map:
+		res.Type = "map"
+		res.Key = d.attribute(t.KeyType)
+		res.Elem = d.attribute(t.ElemType)
+	case *expr.Object:
+		res.Type = "object"
+		res.Fields = d.fields(att, t)
+	case *expr.Union:
+		res.Type = "union"
+		res.Fields = d.fields(att, t.Values)
+	default:
+		res.Type = t.Name()
+	}
+	return res
+}
+
+// fields takes a snapshot of the fields of the given object.
+func (d *Design) fields(parent *expr.AttributeExpr, obj *expr.Object) []*Field {
+	var res []*Field
+	for _, nat := range *obj {
+		f := &Field{
+			Name:      nat.Name,
+			Required:  parent.IsRequired(nat.Name),
+			Attribute: d.attribute(nat.Attribute),
+		}
+		if tag, ok := nat.Attribute.Meta["rpc:tag"]; ok && len(tag) > 0 {
+			f.Tag, _ = strconv.Atoi(tag[0])
+		}
+		res = append(res, f)
+	}
+	return res
+}
@@ -0,0 +1,153 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var BaseDSL = func() {
+	var payload = Type("Payload", func() {
+		Field(1, "name", String)
+		Field(2, "kind", String, func() {
+			Enum("a", "b")
+		})
+		Required("name")
+	})
+	var result = Type("Result", func() {
+		Field(1, "id", Int)
+		Field(2, "name", String)
+		Required("id")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(payload)
+			Result(result)
+			Error("not_found")
+			HTTP(func() {
+				POST("/items")
+				Response("not_found", StatusNotFound)
+			})
+			GRPC(func() {
+				Response("not_found", CodeNotFound)
+			})
+		})
+	})
+}
+
+var MethodAddedDSL = func() {
+	var payload = Type("Payload", func() {
+		Field(1, "name", String)
+		Field(2, "kind", String, func() {
+			Enum("a", "b")
+		})
+		Required("name")
+	})
+	var result = Type("Result", func() {
+		Field(1, "id", Int)
+		Field(2, "name", String)
+		Required("id")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(payload)
+			Result(result)
+			Error("not_found")
+			HTTP(func() {
+				POST("/items")
+				Response("not_found", StatusNotFound)
+			})
+			GRPC(func() {
+				Response("not_found", CodeNotFound)
+			})
+		})
+		Method("Other", func() {
+			HTTP(func() {
+				GET("/other")
+			})
+		})
+	})
+}
+
+var BreakingDSL = func() {
+	var payload = Type("Payload", func() {
+		Field(1, "name", String)
+		Field(2, "kind", String, func() {
+			Enum("a")
+		})
+		Field(3, "extra", String)
+		Required("name", "kind", "extra")
+	})
+	var result = Type("Result", func() {
+		Field(3, "id", Int)
+		Required("id")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(payload)
+			Result(result)
+			HTTP(func() {
+				PUT("/items")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var CompatibleDSL = func() {
+	var payload = Type("Payload", func() {
+		Field(2, "kind", String, func() {
+			Enum("a", "b", "c")
+		})
+		Field(3, "extra", String)
+	})
+	var result = Type("Result", func() {
+		Field(1, "id", Int)
+		Field(2, "name", String)
+		Field(3, "extra", String)
+		Required("id", "name")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(payload)
+			Result(result)
+			Error("not_found")
+			Error("bad_request")
+			HTTP(func() {
+				POST("/items")
+				POST("/v2/items")
+				Response("not_found", StatusNotFound)
+			})
+			GRPC(func() {
+				Response("not_found", CodeNotFound)
+			})
+		})
+	})
+}
+
+var SharedTypeDSL = func() {
+	var payload = Type("Payload", func() {
+		Field(1, "name", String)
+	})
+	Service("Service", func() {
+		Method("Create", func() {
+			Payload(payload)
+		})
+		Method("Update", func() {
+			Payload(payload)
+		})
+	})
+}
+
+var SharedTypeChangedDSL = func() {
+	var payload = Type("Payload", func() {
+		Field(1, "name", String)
+		Required("name")
+	})
+	Service("Service", func() {
+		Method("Create", func() {
+			Payload(payload)
+		})
+		Method("Update", func() {
+			Payload(payload)
+		})
+	})
+}
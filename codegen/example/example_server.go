@@ -209,7 +209,7 @@ func main() {
 	{
 	{{- range .Services }}
 		{{- if .Methods }}
-			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc)
+			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc{{ if .HasInterceptors }}, nil{{ end }})
 		{{- end }}
 	{{- end }}
 	}
@@ -15,7 +15,8 @@ The code generated for the service server and client includes:
 
 OpenAPI
 
-The OpenAPI generator generates a OpenAPI v2 specification for the service
-REST endpoints. This generator requires the design to define the HTTP transport.
+The OpenAPI generator generates both a OpenAPI v2 and a OpenAPI v3
+specification for the service REST endpoints. This generator requires the
+design to define the HTTP transport.
 */
 package generator
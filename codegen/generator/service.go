@@ -23,6 +23,12 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 				files = append(files, service.File(genpkg, s))
 				files = append(files, service.EndpointFile(genpkg, s))
 				files = append(files, service.ClientFile(s))
+				if f := service.InterceptorsFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.FieldMaskFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
 				if f := service.ViewsFile(genpkg, s); f != nil {
 					files = append(files, f)
 				}
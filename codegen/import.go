@@ -85,9 +85,36 @@ func getMetaTypeInfo(att *expr.AttributeExpr) (typeName string, importS *ImportS
 	return typeName, importS
 }
 
-// GetMetaTypeImports parses the attribute for all user defined imports
+// GetMetaTypeImports parses the attribute for all user defined imports. This
+// includes the packages implementing the custom validation formats used by the
+// attribute and its children.
 func GetMetaTypeImports(att *expr.AttributeExpr) []*ImportSpec {
-	return safelyGetMetaTypeImports(att, nil)
+	return append(safelyGetMetaTypeImports(att, nil), getFormatImports(att)...)
+}
+
+// getFormatImports returns the imports of the packages that implement the
+// custom validation formats used by the attribute and its children.
+func getFormatImports(att *expr.AttributeExpr) []*ImportSpec {
+	if att == nil {
+		return nil
+	}
+	var imports []*ImportSpec
+	seen := make(map[string]struct{})
+	Walk(att, func(a *expr.AttributeExpr) error {
+		if a.Validation == nil || a.Validation.Format == "" {
+			return nil
+		}
+		f := expr.Root.Format(a.Validation.Format)
+		if f == nil {
+			return nil
+		}
+		if _, ok := seen[f.PkgPath]; !ok {
+			seen[f.PkgPath] = struct{}{}
+			imports = append(imports, &ImportSpec{Path: f.PkgPath})
+		}
+		return nil
+	})
+	return imports
 }
 
 // safelyGetMetaTypeImports parses attributes while keeping track of previous usertypes to avoid infinite recursion
@@ -126,8 +153,8 @@ func safelyGetMetaTypeImports(att *expr.AttributeExpr, seen map[string]struct{})
 		if im != nil {
 			uniqueImports[*im] = struct{}{}
 		}
-	case *expr.Object:
-		for _, key := range *t {
+	case *expr.Object, *expr.Union:
+		for _, key := range *expr.AsObject(t) {
 			if key != nil {
 				_, im := getMetaTypeInfo(key.Attribute)
 				if im != nil {
@@ -148,6 +148,10 @@ func (s *NameScope) GoTypeDef(att *expr.AttributeExpr, ptr, useDefault bool) str
 		}
 		ss = append(ss, "}")
 		return strings.Join(ss, "\n")
+	case *expr.Union:
+		// Unions are represented as structs with one field per union value,
+		// only one of the fields may be set at a time.
+		return s.GoTypeDef(&expr.AttributeExpr{Type: actual.Values}, ptr, useDefault)
 	case expr.UserType:
 		return s.GoTypeName(att)
 	default:
@@ -199,7 +203,7 @@ func (s *NameScope) GoFullTypeName(att *expr.AttributeExpr, pkg string) string {
 		return fmt.Sprintf("map[%s]%s",
 			s.GoFullTypeRef(actual.KeyType, pkg),
 			s.GoFullTypeRef(actual.ElemType, pkg))
-	case *expr.Object:
+	case *expr.Object, *expr.Union:
 		return s.GoTypeDef(att, false, false)
 	case expr.UserType:
 		if actual == expr.ErrorResult {
@@ -226,7 +230,8 @@ func goTypeRef(name string, dt expr.DataType) string {
 }
 
 func isRawStruct(dt expr.DataType) bool {
-	if _, ok := dt.(*expr.Object); ok {
+	switch dt.(type) {
+	case *expr.Object, *expr.Union:
 		return true
 	}
 	if expr.IsObject(dt) {
@@ -36,13 +36,32 @@ func ClientFile(service *expr.ServiceExpr) *codegen.File {
 			Source: serviceClientInitT,
 			Data:   data,
 		}
-		sections = []*codegen.SectionTemplate{header, def, init}
+		use := &codegen.SectionTemplate{
+			Name:   "client-use",
+			Source: serviceClientUseT,
+			Data:   data,
+		}
+		sections = []*codegen.SectionTemplate{header, def, init, use}
 		for _, m := range data.Methods {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "client-method",
 				Source: serviceClientMethodT,
 				Data:   m,
 			})
+			if m.ViewSelector != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-method-with-view",
+					Source: serviceClientWithViewT,
+					Data:   m,
+				})
+			}
+			if m.Pagination != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-iterator",
+					Source: serviceClientIteratorT,
+					Data:   m,
+				})
+			}
 		}
 	}
 
@@ -60,15 +79,33 @@ type {{ .ClientVarName }} struct {
 
 // input: endpointsData
 const serviceClientInitT = `{{ printf "New%s initializes a %q service client given the endpoints." .ClientVarName .Name | comment }}
-func New{{ .ClientVarName }}({{ .ClientInitArgs }} goa.Endpoint) *{{ .ClientVarName }} {
+func New{{ .ClientVarName }}({{ .ClientInitArgs }} goa.Endpoint{{ if .HasInterceptors }}, ci ClientInterceptors{{ end }}) *{{ .ClientVarName }} {
 	return &{{ .ClientVarName }}{
 {{- range .Methods }}
+	{{- if .Intercepted }}
+		{{ .VarName }}Endpoint: Wrap{{ .VarName }}ClientEndpoint({{ .ArgName }}, ci),
+	{{- else }}
 		{{ .VarName }}Endpoint: {{ .ArgName }},
+	{{- end }}
 {{- end }}
 	}
 }
 `
 
+// input: endpointsData
+const serviceClientUseT = `{{ printf "Use applies the given middleware to all the %q service client endpoints." .Name | comment }}
+func (c *{{ .ClientVarName }}) Use(m func(goa.Endpoint) goa.Endpoint) {
+{{- range .Methods }}
+	c.{{ .VarName }}Endpoint = m(c.{{ .VarName }}Endpoint)
+{{- end }}
+}
+{{ range .Methods }}
+{{ printf "Use%s applies the given middleware to the %q endpoint of the %q service client." .VarName .Name .ServiceName | comment }}
+func (c *{{ $.ClientVarName }}) Use{{ .VarName }}(m func(goa.Endpoint) goa.Endpoint) {
+	c.{{ .VarName }}Endpoint = m(c.{{ .VarName }}Endpoint)
+}
+{{ end }}`
+
 // input: endpointsData
 const serviceClientMethodT = `
 {{ printf "%s calls the %q endpoint of the %q service." .VarName .Name .ServiceName | comment }}
@@ -80,6 +117,10 @@ const serviceClientMethodT = `
 //	- error: internal error
 {{- end }}
 func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .PayloadRef }}p {{ .PayloadRef }}{{ end }}) ({{ if .ClientStream }}res {{ .ClientStream.Interface }}, {{ else if .ResultRef }}res {{ .ResultRef }}, {{ end }}err error) {
+	{{- if .Idempotent }}
+	// Bind the idempotency key to the call so that retries reuse it.
+	ctx = goa.WithIdempotencyKey(ctx)
+	{{- end }}
 	{{- if .ResultRef }}
 	var ires interface{}
 	{{- end }}
@@ -94,3 +135,112 @@ func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .Payloa
 	{{- end }}
 }
 `
+
+// input: endpointMethodData
+const serviceClientWithViewT = `{{ printf "%sWithView calls the %q endpoint of the %q service requesting the result to be rendered with the given view." .VarName .Name .ServiceName | comment }}
+func (c *{{ .ClientVarName }}) {{ .VarName }}WithView(ctx context.Context, {{ if .PayloadRef }}p {{ .PayloadRef }}, {{ end }}view {{ .ViewSelector.TypeName }}) (res {{ .ResultRef }}, err error) {
+	return c.{{ .VarName }}(context.WithValue(ctx, goa.ViewKey, string(view)){{ if .PayloadRef }}, p{{ end }})
+}
+`
+
+// input: endpointMethodData
+const serviceClientIteratorT = `{{ printf "%s iterates over the items returned by the %q endpoint of the %q service fetching the subsequent pages as needed." .Pagination.IteratorName .Name .ServiceName | comment }}
+type {{ .Pagination.IteratorName }} struct {
+	client  *{{ .ClientVarName }}
+	payload {{ .PayloadRef }}
+	items   []{{ .Pagination.ItemRef }}
+	index   int
+	done    bool
+	err     error
+}
+
+{{ printf "%sIter returns an iterator over the items returned by the %q endpoint of the %q service starting with the page requested by p." .VarName .Name .ServiceName | comment }}
+func (c *{{ .ClientVarName }}) {{ .VarName }}Iter(p {{ .PayloadRef }}) *{{ .Pagination.IteratorName }} {
+	if p == nil {
+		p = &{{ .Payload }}{}
+	}
+	return &{{ .Pagination.IteratorName }}{client: c, payload: p}
+}
+
+// Next advances the iterator to the next item fetching the next page if
+// needed. Next returns false once all the items have been visited or if a
+// request fails, use Err to tell both cases apart.
+func (it *{{ .Pagination.IteratorName }}) Next(ctx context.Context) bool {
+	it.index++
+	for it.index >= len(it.items) {
+		if it.done || it.err != nil {
+			return false
+		}
+		res, err := it.client.{{ .VarName }}(ctx, it.payload)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = res.{{ .Pagination.ItemsField }}
+		it.index = 0
+		it.next(res)
+	}
+	return true
+}
+
+// Item returns the current item.
+func (it *{{ .Pagination.IteratorName }}) Item() {{ .Pagination.ItemRef }} {
+	return it.items[it.index]
+}
+
+// Err returns the error that stopped the iteration if any.
+func (it *{{ .Pagination.IteratorName }}) Err() error {
+	return it.err
+}
+
+// next computes the payload used to request the page following res and
+// records whether res is the last page.
+func (it *{{ .Pagination.IteratorName }}) next(res {{ .ResultRef }}) {
+	p := *it.payload
+{{- if .Pagination.CursorField }}
+	{{- if .Pagination.NextCursorPointer }}
+	if res.{{ .Pagination.NextCursorField }} == nil || *res.{{ .Pagination.NextCursorField }} == "" {
+		it.done = true
+		return
+	}
+	p.{{ .Pagination.CursorField }} = {{ if not .Pagination.CursorPointer }}*{{ end }}res.{{ .Pagination.NextCursorField }}
+	{{- else }}
+	if res.{{ .Pagination.NextCursorField }} == "" {
+		it.done = true
+		return
+	}
+		{{- if .Pagination.CursorPointer }}
+	cursor := res.{{ .Pagination.NextCursorField }}
+	p.{{ .Pagination.CursorField }} = &cursor
+		{{- else }}
+	p.{{ .Pagination.CursorField }} = res.{{ .Pagination.NextCursorField }}
+		{{- end }}
+	{{- end }}
+{{- else }}
+	n := len(res.{{ .Pagination.ItemsField }})
+	{{- if .Pagination.PageSizeField }}
+		{{- if .Pagination.PageSizePointer }}
+	if n == 0 || p.{{ .Pagination.PageSizeField }} != nil && n < *p.{{ .Pagination.PageSizeField }} {
+		{{- else }}
+	if n == 0 || n < p.{{ .Pagination.PageSizeField }} {
+		{{- end }}
+	{{- else }}
+	if n == 0 {
+	{{- end }}
+		it.done = true
+		return
+	}
+	{{- if .Pagination.OffsetPointer }}
+	var offset int
+	if p.{{ .Pagination.OffsetField }} != nil {
+		offset = *p.{{ .Pagination.OffsetField }}
+	}
+	offset += n
+	p.{{ .Pagination.OffsetField }} = &offset
+	{{- else }}
+	p.{{ .Pagination.OffsetField }} += n
+	{{- end }}
+{{- end }}
+	it.payload = &p
+}
+`
@@ -26,6 +26,11 @@ func TestClient(t *testing.T) {
 		{"streaming-payload-no-payload", testdata.StreamingPayloadNoPayloadMethodDSL, testdata.StreamingPayloadNoPayloadMethodClient},
 		{"bidirectional-streaming", testdata.BidirectionalStreamingMethodDSL, testdata.BidirectionalStreamingMethodClient},
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodClient},
+		{"with-interceptors", testdata.ResultInterceptorDSL, testdata.WithInterceptorsMethodClient},
+		{"view-selector", testdata.ViewSelectorMethodDSL, testdata.ViewSelectorMethodClient},
+		{"cursor-pagination", testdata.CursorPaginationDSL, testdata.CursorPaginationMethodClient},
+		{"offset-pagination", testdata.OffsetPaginationDSL, testdata.OffsetPaginationMethodClient},
+		{"idempotent", testdata.IdempotentMethodDSL, testdata.IdempotentMethodClient},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
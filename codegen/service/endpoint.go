@@ -30,6 +30,9 @@ type (
 		// Schemes contains the security schemes types used by the
 		// all the endpoints.
 		Schemes SchemesData
+		// HasInterceptors is true if at least one method uses
+		// interceptors.
+		HasInterceptors bool
 	}
 
 	// endpointMethodData describes a single endpoint method.
@@ -44,6 +47,51 @@ type (
 		ServiceName string
 		// ServiceVarName is the name of the owner service Go interface.
 		ServiceVarName string
+		// Intercepted is true if the method uses interceptors.
+		Intercepted bool
+		// Pagination describes the method pagination if the method is
+		// paginated.
+		Pagination *paginationData
+		// FieldMask is true if the method result is pruned using the
+		// field mask stored in the request context.
+		FieldMask bool
+		// Idempotent is true if the client identifies the requests made
+		// to the method with an idempotency key.
+		Idempotent bool
+	}
+
+	// paginationData contains the data needed to render the client
+	// iterator of a paginated method.
+	paginationData struct {
+		// IteratorName is the name of the iterator struct.
+		IteratorName string
+		// ItemRef is the reference to the type of the page items.
+		ItemRef string
+		// ItemsField is the name of the result field holding the page
+		// items.
+		ItemsField string
+		// CursorField is the name of the payload field holding the
+		// cursor if the method uses cursor pagination.
+		CursorField string
+		// CursorPointer is true if the cursor payload field is a pointer.
+		CursorPointer bool
+		// NextCursorField is the name of the result field holding the
+		// cursor of the next page if the method uses cursor pagination.
+		NextCursorField string
+		// NextCursorPointer is true if the next cursor result field is a
+		// pointer.
+		NextCursorPointer bool
+		// OffsetField is the name of the payload field holding the
+		// offset if the method uses offset pagination.
+		OffsetField string
+		// OffsetPointer is true if the offset payload field is a pointer.
+		OffsetPointer bool
+		// PageSizeField is the name of the payload field holding the
+		// page size if any.
+		PageSizeField string
+		// PageSizePointer is true if the page size payload field is a
+		// pointer.
+		PageSizePointer bool
 	}
 )
 
@@ -125,22 +173,70 @@ func endpointData(service *expr.ServiceExpr) *endpointsData {
 			ServiceName:    svc.Name,
 			ServiceVarName: serviceInterfaceName,
 			ClientVarName:  clientStructName,
+			Intercepted:    len(service.Method(m.Name).AllInterceptors()) > 0,
+			Pagination:     buildPaginationData(svc, m, service.Method(m.Name)),
+			FieldMask:      service.Method(m.Name).FieldMask != nil,
+			Idempotent:     isIdempotent(service.Name, m.Name),
 		}
 		names[i] = codegen.Goify(m.VarName, false)
 	}
 	desc := fmt.Sprintf("%s wraps the %q service endpoints.", endpointsStructName, service.Name)
 	return &endpointsData{
-		Name:           service.Name,
-		Description:    desc,
-		VarName:        endpointsStructName,
-		ClientVarName:  clientStructName,
-		ServiceVarName: serviceInterfaceName,
-		ClientInitArgs: strings.Join(names, ", "),
-		Methods:        methods,
-		Schemes:        svc.Schemes,
+		Name:            service.Name,
+		Description:     desc,
+		VarName:         endpointsStructName,
+		ClientVarName:   clientStructName,
+		ServiceVarName:  serviceInterfaceName,
+		ClientInitArgs:  strings.Join(names, ", "),
+		Methods:         methods,
+		Schemes:         svc.Schemes,
+		HasInterceptors: svc.HasInterceptors,
 	}
 }
 
+// buildPaginationData returns the data needed to render the client iterator
+// of the given method, nil if the method is not paginated.
+func buildPaginationData(svc *Data, md *MethodData, m *expr.MethodExpr) *paginationData {
+	pag := m.Pagination
+	if pag == nil {
+		return nil
+	}
+	items := expr.AsObject(m.Result.Type).Attribute(pag.ItemsAttribute)
+	data := &paginationData{
+		IteratorName: md.VarName + "Iterator",
+		ItemRef:      svc.Scope.GoTypeRef(expr.AsArray(items.Type).ElemType),
+		ItemsField:   codegen.Goify(pag.ItemsAttribute, true),
+	}
+	if pag.IsCursor() {
+		data.CursorField = codegen.Goify(pag.CursorAttribute, true)
+		data.CursorPointer = m.Payload.IsPrimitivePointer(pag.CursorAttribute, true)
+		data.NextCursorField = codegen.Goify(pag.NextCursorAttribute, true)
+		data.NextCursorPointer = m.Result.IsPrimitivePointer(pag.NextCursorAttribute, true)
+	} else {
+		data.OffsetField = codegen.Goify(pag.OffsetAttribute, true)
+		data.OffsetPointer = m.Payload.IsPrimitivePointer(pag.OffsetAttribute, true)
+	}
+	if pag.PageSizeAttribute != "" {
+		data.PageSizeField = codegen.Goify(pag.PageSizeAttribute, true)
+		data.PageSizePointer = m.Payload.IsPrimitivePointer(pag.PageSizeAttribute, true)
+	}
+	return data
+}
+
+// isIdempotent returns true if the HTTP endpoint of the given method is
+// idempotent.
+func isIdempotent(svc, method string) bool {
+	if expr.Root.API == nil || expr.Root.API.HTTP == nil {
+		return false
+	}
+	hs := expr.Root.API.HTTP.Service(svc)
+	if hs == nil {
+		return false
+	}
+	e := hs.Endpoint(method)
+	return e != nil && e.Idempotent
+}
+
 func payloadVar(e *endpointMethodData) string {
 	if e.ServerStream != nil {
 		return "ep.Payload"
@@ -159,14 +255,18 @@ type {{ .VarName }} struct {
 
 // input: endpointsData
 const serviceEndpointsInitT = `{{ printf "New%s wraps the methods of the %q service with endpoints." .VarName .Name | comment }}
-func New{{ .VarName }}(s {{ .ServiceVarName }}) *{{ .VarName }} {
+func New{{ .VarName }}(s {{ .ServiceVarName }}{{ if .HasInterceptors }}, si ServerInterceptors{{ end }}) *{{ .VarName }} {
 {{- if .Schemes }}
 	// Casting service to Auther interface
 	a := s.(Auther)
 {{- end }}
 	return &{{ .VarName }}{
 {{- range .Methods }}
+	{{- if .Intercepted }}
+		{{ .VarName }}: Wrap{{ .VarName }}ServerEndpoint(New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}), si),
+	{{- else }}
 		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
+	{{- end }}
 {{- end }}
 	}
 }
@@ -301,12 +401,38 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 {{- if .ServerStream }}
 	return nil, s.{{ .VarName }}(ctx, {{ if .PayloadRef }}{{ $payload }}, {{ end }}ep.Stream)
 {{- else if .ViewedResult }}
+	{{- if .ViewSelector }}
+		view := {{ .ViewSelector.Default }}
+		if name, ok := ctx.Value(goa.ViewKey).(string); ok && name != "" {
+			v, err := {{ .ViewSelector.ParseName }}(name)
+			if err != nil {
+				return nil, err
+			}
+			view = v
+		}
+		res, err := s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }}, view)
+	{{- else }}
 		res,{{ if not .ViewedResult.ViewName }} view,{{ end }} err := s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+	{{- end }}
 		if err != nil {
 			return nil, err
 		}
-		vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else }}view{{ end }})
+	{{- if .FieldMask }}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok {
+			Mask{{ .VarName }}Result(res, mask)
+		}
+	{{- end }}
+		vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else if .ViewSelector }}string(view){{ else }}view{{ end }})
 		return vres, nil
+{{- else if and .ResultRef .FieldMask }}
+		res, err := s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+		if err != nil {
+			return nil, err
+		}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok {
+			Mask{{ .VarName }}Result(res, mask)
+		}
+		return res, nil
 {{- else if .ResultRef }}
 		return s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
 {{- else }}
@@ -323,4 +449,9 @@ func (e *{{ .VarName }}) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.{{ .VarName }} = m(e.{{ .VarName }})
 {{- end }}
 }
-`
+{{ range .Methods }}
+{{ printf "Use%s applies the given middleware to the %q endpoint of the %q service." .VarName .Name .ServiceName | comment }}
+func (e *{{ $.VarName }}) Use{{ .VarName }}(m func(goa.Endpoint) goa.Endpoint) {
+	e.{{ .VarName }} = m(e.{{ .VarName }})
+}
+{{ end }}`
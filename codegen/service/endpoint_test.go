@@ -19,6 +19,7 @@ func TestEndpoint(t *testing.T) {
 	}{
 		{"single", testdata.SingleEndpointDSL, testdata.SingleEndpoint},
 		{"use", testdata.UseEndpointDSL, testdata.UseEndpoint},
+		{"use-method", testdata.UseMethodEndpointDSL, testdata.UseMethodEndpoint},
 		{"multiple", testdata.MultipleEndpointsDSL, testdata.MultipleEndpoints},
 		{"no-payload", testdata.NoPayloadEndpointDSL, testdata.NoPayloadEndpoint},
 		{"with-result", testdata.WithResultEndpointDSL, testdata.WithResultEndpoint},
@@ -31,6 +32,9 @@ func TestEndpoint(t *testing.T) {
 		{"streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethodEndpoint},
 		{"bidirectional-streaming", testdata.BidirectionalStreamingEndpointDSL, testdata.BidirectionalStreamingMethodEndpoint},
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodEndpoint},
+		{"with-interceptors", testdata.ResultInterceptorDSL, testdata.WithInterceptorsEndpoint},
+		{"with-field-mask", testdata.FieldMaskDSL, testdata.WithFieldMaskEndpoint},
+		{"view-selector", testdata.ViewSelectorMethodDSL, testdata.ViewSelectorMethodEndpoint},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
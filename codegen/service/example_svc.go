@@ -27,6 +27,9 @@ type (
 		// ResultView is the view to render the result. It is set only if the
 		// result type uses views.
 		ResultView string
+		// ViewSelectorFullRef is the fully qualified reference to the type
+		// enumerating the views selected by clients if any.
+		ViewSelectorFullRef string
 		// StreamInterface is the stream interface in the service package used
 		// by the endpoint implementation.
 		StreamInterface string
@@ -110,6 +113,9 @@ func basicEndpointSection(m *expr.MethodExpr, svcData *Data) *codegen.SectionTem
 			}
 			ed.ResultView = view
 		}
+		if md.ViewSelector != nil {
+			ed.ViewSelectorFullRef = svcData.PkgName + "." + md.ViewSelector.TypeName
+		}
 	}
 	if md.ServerStream != nil {
 		ed.StreamInterface = svcData.PkgName + "." + md.ServerStream.Interface
@@ -141,13 +147,13 @@ func New{{ .StructName }}(logger *log.Logger) {{ .PkgName }}.Service {
 {{- if .ServerStream }}
 func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .PayloadFullRef }}, p {{ .PayloadFullRef }}{{ end }}, stream {{ .StreamInterface }}) (err error) {
 {{- else }}
-func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .PayloadFullRef }}, p {{ .PayloadFullRef }}{{ end }}) ({{ if .ResultFullRef }}res {{ .ResultFullRef }}, {{ if .ViewedResult }}{{ if not .ViewedResult.ViewName }}view string, {{ end }}{{ end }} {{ end }}err error) {
+func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .PayloadFullRef }}, p {{ .PayloadFullRef }}{{ end }}{{ if .ViewSelectorFullRef }}, view {{ .ViewSelectorFullRef }}{{ end }}) ({{ if .ResultFullRef }}res {{ .ResultFullRef }}, {{ if .ViewedResult }}{{ if not (or .ViewedResult.ViewName .ViewSelector) }}view string, {{ end }}{{ end }} {{ end }}err error) {
 {{- end }}
 {{- if and (and .ResultFullRef .ResultIsStruct) (not .ServerStream) }}
   res = &{{ .ResultFullName }}{}
 {{- end }}
 {{- if .ViewedResult }}
-	{{- if not .ViewedResult.ViewName }}
+	{{- if not (or .ViewedResult.ViewName .ViewSelector) }}
 		{{- if .ServerStream }}
 			stream.SetView({{ printf "%q" .ResultView }})
 		{{- else }}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// fieldMaskData contains the data necessary to render the functions
+	// that validate field masks and prune method results.
+	fieldMaskData struct {
+		// Methods lists the methods that use field masks.
+		Methods []*fieldMaskMethodData
+		// Types lists the helper functions that prune user types.
+		Types []*fieldMaskTypeData
+	}
+
+	// fieldMaskMethodData describes the field mask of a single method.
+	fieldMaskMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the method Go name.
+		VarName string
+		// MaskName is the name of the field mask in requests.
+		MaskName string
+		// ResultRef is the reference to the method result type.
+		ResultRef string
+		// Helper is the name of the function that prunes the result.
+		Helper string
+		// PathsVar is the name of the variable listing the valid paths.
+		PathsVar string
+		// Paths lists the paths of the result fields.
+		Paths []string
+	}
+
+	// fieldMaskTypeData describes the function that prunes a user type.
+	fieldMaskTypeData struct {
+		// Name is the name of the function.
+		Name string
+		// TypeRef is the reference to the pruned type.
+		TypeRef string
+		// Fields lists the user type fields.
+		Fields []*fieldMaskFieldData
+	}
+
+	// fieldMaskFieldData describes how to prune a single field.
+	fieldMaskFieldData struct {
+		// Name is the attribute name.
+		Name string
+		// FieldName is the Go struct field name.
+		FieldName string
+		// Prunable is true if the field may be removed from the result,
+		// that is if it is neither required nor has a default value.
+		Prunable bool
+		// Helper is the name of the function that prunes the field
+		// value if the field is a user type or an array of user types.
+		Helper string
+		// Array is true if the field is an array of user types.
+		Array bool
+	}
+)
+
+// FieldMaskFile returns the file containing the functions that validate the
+// field masks and prune the results of the given service methods. It returns
+// nil if no method uses field masks.
+func FieldMaskFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	data := fieldMaskDataFor(service)
+	if data == nil {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "fieldmask.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" field masks", svc.PkgName,
+			[]*codegen.ImportSpec{
+				codegen.GoaImport(""),
+			}),
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "field-mask-method",
+			Source: fieldMaskMethodT,
+			Data:   m,
+		})
+	}
+	for _, t := range data.Types {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "field-mask-type",
+			Source: fieldMaskTypeT,
+			Data:   t,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// fieldMaskDataFor builds the data needed to render the field mask functions
+// of the given service. It returns nil if no method uses field masks.
+func fieldMaskDataFor(service *expr.ServiceExpr) *fieldMaskData {
+	var (
+		data *fieldMaskData
+		seen = make(map[string]struct{})
+		svc  = Services.Get(service.Name)
+	)
+	for _, m := range service.Methods {
+		if m.FieldMask == nil {
+			continue
+		}
+		if data == nil {
+			data = &fieldMaskData{}
+		}
+		md := svc.Method(m.Name)
+		data.Methods = append(data.Methods, &fieldMaskMethodData{
+			Name:      m.Name,
+			VarName:   md.VarName,
+			MaskName:  m.FieldMask.Name,
+			ResultRef: md.ResultRef,
+			Helper:    fieldMaskTypes(m.Result, svc.Scope, seen, data),
+			PathsVar:  codegen.Goify(md.VarName, false) + "FieldMaskPaths",
+			Paths:     expr.FieldMaskPaths(m.Result),
+		})
+	}
+	return data
+}
+
+// fieldMaskTypes appends the data needed to render the function that prunes
+// the given user type and the user types it references to data. It returns
+// the name of the function.
+func fieldMaskTypes(att *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}, data *fieldMaskData) string {
+	ut, ok := att.Type.(expr.UserType)
+	if !ok || !expr.IsObject(ut) {
+		return ""
+	}
+	name := "mask" + scope.GoTypeName(att)
+	if _, ok := seen[name]; ok {
+		return name
+	}
+	seen[name] = struct{}{}
+	td := &fieldMaskTypeData{Name: name, TypeRef: scope.GoTypeRef(att)}
+	data.Types = append(data.Types, td)
+	parent := ut.Attribute()
+	for _, nat := range *expr.AsObject(ut) {
+		fd := &fieldMaskFieldData{
+			Name:      nat.Name,
+			FieldName: codegen.Goify(nat.Name, true),
+			Prunable:  fieldMaskPrunable(parent, nat.Name, nat.Attribute),
+		}
+		child := nat.Attribute
+		if arr := expr.AsArray(child.Type); arr != nil {
+			child = arr.ElemType
+			fd.Array = true
+		}
+		if fd.Helper = fieldMaskTypes(child, scope, seen, data); fd.Helper == "" {
+			fd.Array = false
+		}
+		td.Fields = append(td.Fields, fd)
+	}
+	return name
+}
+
+// fieldMaskPrunable returns true if the field of the struct generated for
+// parent corresponding to the attribute with the given name can be set to nil.
+func fieldMaskPrunable(parent *expr.AttributeExpr, name string, att *expr.AttributeExpr) bool {
+	if parent.IsRequired(name) {
+		return false
+	}
+	switch att.Type.(type) {
+	case *expr.Array, *expr.Map:
+		return true
+	case expr.UserType:
+		if expr.IsObject(att.Type) {
+			return true
+		}
+	}
+	if att.Type == expr.Bytes || att.Type == expr.Any {
+		return true
+	}
+	return parent.IsPrimitivePointer(name, true)
+}
+
+// input: fieldMaskMethodData
+const fieldMaskMethodT = `{{ printf "Validate%sFieldMask returns an error if mask selects fields that are not defined by the %q method result." .VarName .Name | comment }}
+func Validate{{ .VarName }}FieldMask(mask goa.FieldMask) error {
+	return mask.Validate({{ printf "%q" .MaskName }}, {{ .PathsVar }}...)
+}
+
+{{ printf "Mask%sResult removes the fields of res that are not selected by mask. Fields that are required or that have a default value are always kept." .VarName | comment }}
+func Mask{{ .VarName }}Result(res {{ .ResultRef }}, mask goa.FieldMask) {
+	{{ .Helper }}(res, mask)
+}
+
+{{ printf "%s lists the paths of the %q method result fields." .PathsVar .Name | comment }}
+var {{ .PathsVar }} = []string{
+{{- range .Paths }}
+	{{ printf "%q" . }},
+{{- end }}
+}
+`
+
+// input: fieldMaskTypeData
+const fieldMaskTypeT = `{{ printf "%s removes the fields of v that are not selected by mask." .Name | comment }}
+func {{ .Name }}(v {{ .TypeRef }}, mask goa.FieldMask) {
+	if v == nil || len(mask) == 0 {
+		return
+	}
+{{- range .Fields }}
+	{{- if .Prunable }}
+	if !mask.Includes({{ printf "%q" .Name }}) {
+		v.{{ .FieldName }} = nil
+	}{{ if .Helper }} else {
+		{{- template "nested" . }}
+	}{{ end }}
+	{{- else if .Helper }}
+		{{- template "nested" . }}
+	{{- end }}
+{{- end }}
+}
+
+{{- define "nested" }}
+	{{- if .Array }}
+	for _, e := range v.{{ .FieldName }} {
+		{{ .Helper }}(e, mask.Sub({{ printf "%q" .Name }}))
+	}
+	{{- else }}
+	{{ .Helper }}(v.{{ .FieldName }}, mask.Sub({{ printf "%q" .Name }}))
+	{{- end }}
+{{- end }}
+`
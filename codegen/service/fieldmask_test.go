@@ -0,0 +1,45 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestFieldMask(t *testing.T) {
+	codegen.RunDSL(t, testdata.FieldMaskDSL)
+	if len(expr.Root.Services) != 1 {
+		t.Fatalf("got %d services, expected 1", len(expr.Root.Services))
+	}
+	fs := FieldMaskFile("goa.design/goa/example", expr.Root.Services[0])
+	if fs == nil {
+		t.Fatalf("got nil file, expected not nil")
+	}
+	buf := new(bytes.Buffer)
+	for _, s := range fs.SectionTemplates[1:] {
+		if err := s.Write(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Println(buf.String())
+		t.Fatal(err)
+	}
+	code := string(bs)
+	if code != testdata.FieldMaskCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.FieldMaskCode))
+	}
+}
+
+func TestFieldMaskFileNil(t *testing.T) {
+	codegen.RunDSL(t, testdata.SingleEndpointDSL)
+	if f := FieldMaskFile("goa.design/goa/example", expr.Root.Services[0]); f != nil {
+		t.Errorf("got file %q, expected nil", f.Path)
+	}
+}
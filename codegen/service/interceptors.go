@@ -0,0 +1,552 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// interceptorsData contains the data necessary to render the service
+	// interceptors.
+	interceptorsData struct {
+		// Name is the service name.
+		Name string
+		// Interceptors lists the interceptors used by the service
+		// methods.
+		Interceptors []*interceptorData
+		// Methods lists the intercepted methods.
+		Methods []*interceptedMethodData
+	}
+
+	// interceptorData describes a single interceptor.
+	interceptorData struct {
+		// Name is the interceptor name.
+		Name string
+		// VarName is the name of the interceptor interface method.
+		VarName string
+		// Description is the interceptor description.
+		Description string
+		// ServiceName is the name of the service.
+		ServiceName string
+		// InfoName is the name of the interceptor info struct.
+		InfoName string
+		// PayloadName is the name of the payload accessor interface if
+		// the interceptor accesses the payload.
+		PayloadName string
+		// ResultName is the name of the result accessor interface if the
+		// interceptor accesses the result.
+		ResultName string
+		// PayloadFields lists the payload accessor methods.
+		PayloadFields []*accessorData
+		// ResultFields lists the result accessor methods.
+		ResultFields []*accessorData
+		// Methods lists the methods the interceptor applies to.
+		Methods []*interceptorMethodData
+	}
+
+	// accessorData describes the accessor methods for a single attribute.
+	accessorData struct {
+		// AttName is the name of the attribute.
+		AttName string
+		// Name is the name of the getter method.
+		Name string
+		// TypeRef is the accessor type reference.
+		TypeRef string
+		// Read is true if the accessor includes a getter.
+		Read bool
+		// Write is true if the accessor includes a setter.
+		Write bool
+		// Pointer is true if the accessor type is a pointer to a
+		// primitive type.
+		Pointer bool
+	}
+
+	// interceptorMethodData describes the accessors of an interceptor for
+	// a single method.
+	interceptorMethodData struct {
+		// Name is the method name.
+		Name string
+		// PayloadRef is the reference to the method payload type.
+		PayloadRef string
+		// ResultRef is the reference to the method result type.
+		ResultRef string
+		// ViewedResultRef is the reference to the viewed result type
+		// returned by the server endpoint if the method result is a
+		// result type.
+		ViewedResultRef string
+		// PayloadImpl is the name of the payload accessor implementation.
+		PayloadImpl string
+		// ResultImpl is the name of the result accessor implementation.
+		ResultImpl string
+		// ProjectedImpl is the name of the accessor implementation for
+		// the projected result if the method result is a result type.
+		ProjectedImpl string
+		// Impls lists the accessor implementations.
+		Impls []*accessorImplData
+	}
+
+	// accessorImplData describes an accessor implementation.
+	accessorImplData struct {
+		// Name is the name of the implementation struct.
+		Name string
+		// Description is the implementation description.
+		Description string
+		// Kind is "payload" or "result".
+		Kind string
+		// Recv is the receiver name.
+		Recv string
+		// TypeRef is the reference to the accessed type.
+		TypeRef string
+		// Fields lists the accessors.
+		Fields []*accessorFieldData
+	}
+
+	// accessorFieldData describes how an accessor accesses the
+	// corresponding struct field.
+	accessorFieldData struct {
+		*accessorData
+		// FieldName is the name of the struct field, prefixed with the
+		// name of the projected field for result types.
+		FieldName string
+		// FieldPointer is true if the struct field is a pointer to a
+		// primitive type.
+		FieldPointer bool
+	}
+
+	// interceptedMethodData describes an intercepted method.
+	interceptedMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the Go method name.
+		VarName string
+		// Interceptors lists the interceptors applied to the method in
+		// reverse order so that the first interceptor runs first.
+		Interceptors []*interceptorData
+	}
+)
+
+// InterceptorsFile returns the file defining the server and client
+// interceptors interfaces and the endpoint wrappers of the given service. It
+// returns nil if the service methods do not use interceptors.
+func InterceptorsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	data := interceptorsDataFor(service)
+	if data == nil {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	svcName := codegen.SnakeCase(svc.VarName)
+	path := filepath.Join(codegen.Gendir, svcName, "interceptors.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" interceptors", svc.PkgName,
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				codegen.GoaImport(""),
+				{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
+			}),
+		{Name: "interceptors-interface", Source: interceptorsInterfaceT, Data: data},
+	}
+	for _, i := range data.Interceptors {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "interceptor-types",
+			Source: interceptorTypesT,
+			Data:   i,
+		})
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "interceptor-wrap-endpoint",
+			Source: interceptorWrapEndpointT,
+			Data:   m,
+		})
+	}
+	for _, i := range data.Interceptors {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "interceptor-wrapper",
+			Source: interceptorWrapperT,
+			Data:   i,
+		})
+		for _, m := range i.Methods {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "interceptor-accessors",
+				Source: interceptorAccessorsT,
+				Data:   m,
+			})
+		}
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// hasInterceptors returns true if at least one method of the given service
+// uses interceptors.
+func hasInterceptors(service *expr.ServiceExpr) bool {
+	for _, m := range service.Methods {
+		if len(m.AllInterceptors()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// interceptorsDataFor builds the data needed to render the interceptors of
+// the given service. It returns nil if the service does not use interceptors.
+func interceptorsDataFor(service *expr.ServiceExpr) *interceptorsData {
+	if !hasInterceptors(service) {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	data := &interceptorsData{Name: service.Name}
+	byName := make(map[string]*interceptorData)
+	for _, m := range service.Methods {
+		all := m.AllInterceptors()
+		if len(all) == 0 {
+			continue
+		}
+		md := svc.Method(m.Name)
+		im := &interceptedMethodData{Name: m.Name, VarName: md.VarName}
+		for _, ie := range all {
+			id, ok := byName[ie.Name]
+			if !ok {
+				id = buildInterceptorData(ie, service.Name, svc.Scope)
+				byName[ie.Name] = id
+				data.Interceptors = append(data.Interceptors, id)
+			}
+			if ie.AccessesPayload(m) || ie.AccessesResult(m) {
+				id.Methods = append(id.Methods, buildInterceptorMethodData(ie, id, m, md))
+			}
+			im.Interceptors = append([]*interceptorData{id}, im.Interceptors...)
+		}
+		data.Methods = append(data.Methods, im)
+	}
+	return data
+}
+
+// buildInterceptorData builds the data needed to render the given
+// interceptor.
+func buildInterceptorData(ie *expr.InterceptorExpr, svcName string, scope *codegen.NameScope) *interceptorData {
+	varName := codegen.Goify(ie.Name, true)
+	id := &interceptorData{
+		Name:          ie.Name,
+		VarName:       varName,
+		Description:   ie.Description,
+		ServiceName:   svcName,
+		InfoName:      scope.Unique(varName + "Info"),
+		PayloadFields: buildAccessorData(ie.ReadPayload, ie.WritePayload, scope),
+		ResultFields:  buildAccessorData(ie.ReadResult, ie.WriteResult, scope),
+	}
+	if ie.HasPayloadAccess() {
+		id.PayloadName = scope.Unique(varName + "Payload")
+	}
+	if ie.HasResultAccess() {
+		id.ResultName = scope.Unique(varName + "Result")
+	}
+	return id
+}
+
+// buildAccessorData returns the accessors for the attributes of read and
+// write.
+func buildAccessorData(read, write *expr.AttributeExpr, scope *codegen.NameScope) []*accessorData {
+	var (
+		fields []*accessorData
+		seen   = make(map[string]*accessorData)
+	)
+	add := func(att *expr.AttributeExpr, isRead bool) {
+		if att == nil {
+			return
+		}
+		for _, nat := range *expr.AsObject(att.Type) {
+			f, ok := seen[nat.Name]
+			if !ok {
+				ptr := att.IsPrimitivePointer(nat.Name, true)
+				ref := scope.GoTypeRef(nat.Attribute)
+				if ptr {
+					ref = "*" + ref
+				}
+				f = &accessorData{
+					AttName: nat.Name,
+					Name:    codegen.Goify(nat.Name, true),
+					TypeRef: ref,
+					Pointer: ptr,
+				}
+				seen[nat.Name] = f
+				fields = append(fields, f)
+			}
+			if isRead {
+				f.Read = true
+			} else {
+				f.Write = true
+			}
+		}
+	}
+	add(read, true)
+	add(write, false)
+	return fields
+}
+
+// buildInterceptorMethodData builds the data needed to render the accessors
+// of the given interceptor for method m. The server endpoints of methods that
+// return result types return the viewed result: the corresponding accessors
+// access the projected result where all attributes are pointers.
+func buildInterceptorMethodData(ie *expr.InterceptorExpr, id *interceptorData, m *expr.MethodExpr, md *MethodData) *interceptorMethodData {
+	lower := codegen.Goify(ie.Name, false)
+	imd := &interceptorMethodData{Name: m.Name}
+	fields := func(target *expr.AttributeExpr, accessors []*accessorData, projected bool) []*accessorFieldData {
+		res := make([]*accessorFieldData, len(accessors))
+		for i, a := range accessors {
+			tatt := expr.AsObject(target.Type).Attribute(a.AttName)
+			fd := &accessorFieldData{
+				accessorData: a,
+				FieldName:    codegen.GoifyAtt(tatt, a.AttName, true),
+				FieldPointer: target.IsPrimitivePointer(a.AttName, true),
+			}
+			if projected {
+				fd.FieldName = "Projected." + fd.FieldName
+				fd.FieldPointer = expr.IsPrimitive(tatt.Type)
+			}
+			res[i] = fd
+		}
+		return res
+	}
+	if ie.AccessesPayload(m) {
+		imd.PayloadRef = md.PayloadRef
+		imd.PayloadImpl = lower + md.VarName + "Payload"
+		imd.Impls = append(imd.Impls, &accessorImplData{
+			Name:        imd.PayloadImpl,
+			Description: fmt.Sprintf("%s implements the payload accessor for the %q method.", imd.PayloadImpl, m.Name),
+			Kind:        "payload",
+			Recv:        "p",
+			TypeRef:     md.PayloadRef,
+			Fields:      fields(m.Payload, id.PayloadFields, false),
+		})
+	}
+	if ie.AccessesResult(m) {
+		imd.ResultRef = md.ResultRef
+		imd.ResultImpl = lower + md.VarName + "Result"
+		imd.Impls = append(imd.Impls, &accessorImplData{
+			Name:        imd.ResultImpl,
+			Description: fmt.Sprintf("%s implements the result accessor for the %q method.", imd.ResultImpl, m.Name),
+			Kind:        "result",
+			Recv:        "r",
+			TypeRef:     md.ResultRef,
+			Fields:      fields(m.Result, id.ResultFields, false),
+		})
+		if md.ViewedResult != nil {
+			imd.ViewedResultRef = md.ViewedResult.FullRef
+			imd.ProjectedImpl = lower + md.VarName + "ProjectedResult"
+			imd.Impls = append(imd.Impls, &accessorImplData{
+				Name:        imd.ProjectedImpl,
+				Description: fmt.Sprintf("%s implements the result accessor for the projected result returned by the %q server endpoint.", imd.ProjectedImpl, m.Name),
+				Kind:        "result",
+				Recv:        "r",
+				TypeRef:     md.ViewedResult.FullRef,
+				Fields:      fields(m.Result, id.ResultFields, true),
+			})
+		}
+	}
+	return imd
+}
+
+// input: interceptorsData
+const interceptorsInterfaceT = `{{ printf "ServerInterceptors defines the interceptors that wrap the server endpoints of the %q service. The interceptor methods must call next to invoke the intercepted endpoint." .Name | comment }}
+type ServerInterceptors interface {
+{{- template "interceptor_methods" .Interceptors }}
+}
+
+{{ printf "ClientInterceptors defines the interceptors that wrap the client endpoints of the %q service. The interceptor methods must call next to invoke the intercepted endpoint." .Name | comment }}
+type ClientInterceptors interface {
+{{- template "interceptor_methods" .Interceptors }}
+}
+
+{{- define "interceptor_methods" }}
+{{- range . }}
+	{{- if .Description }}
+	{{ comment .Description }}
+	{{- else }}
+	{{ printf "%s intercepts the %q service endpoints." .VarName .ServiceName | comment }}
+	{{- end }}
+	{{ .VarName }}(ctx context.Context, info *{{ .InfoName }}, next goa.Endpoint) (interface{}, error)
+{{- end }}
+{{- end }}
+`
+
+// input: interceptorData
+const interceptorTypesT = `{{ printf "%s provides the %s interceptor with information about the intercepted request." .InfoName .VarName | comment }}
+type {{ .InfoName }} struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the method.
+	Method string
+	// RawPayload is the method payload.
+	RawPayload interface{}
+}
+{{- if .PayloadName }}
+
+{{ printf "%s provides type safe access to the payload attributes used by the %s interceptor." .PayloadName .VarName | comment }}
+type {{ .PayloadName }} interface {
+	{{- range .PayloadFields }}
+		{{- if .Read }}
+	{{ .Name }}() {{ .TypeRef }}
+		{{- end }}
+		{{- if .Write }}
+	Set{{ .Name }}({{ .TypeRef }})
+		{{- end }}
+	{{- end }}
+}
+{{- end }}
+{{- if .ResultName }}
+
+{{ printf "%s provides type safe access to the result attributes used by the %s interceptor." .ResultName .VarName | comment }}
+type {{ .ResultName }} interface {
+	{{- range .ResultFields }}
+		{{- if .Read }}
+	{{ .Name }}() {{ .TypeRef }}
+		{{- end }}
+		{{- if .Write }}
+	Set{{ .Name }}({{ .TypeRef }})
+		{{- end }}
+	{{- end }}
+}
+{{- end }}
+{{- if .PayloadName }}
+
+// Payload returns the accessor for the payload of the intercepted method, nil
+// if the payload is nil or if the method payload does not define the
+// attributes used by the interceptor.
+func (info *{{ .InfoName }}) Payload() {{ .PayloadName }} {
+	switch info.Method {
+	{{- range .Methods }}
+		{{- if .PayloadImpl }}
+	case {{ printf "%q" .Name }}:
+		p, ok := info.RawPayload.({{ .PayloadRef }})
+		if !ok || p == nil {
+			return nil
+		}
+		return &{{ .PayloadImpl }}{payload: p}
+		{{- end }}
+	{{- end }}
+	}
+	return nil
+}
+{{- end }}
+{{- if .ResultName }}
+
+// Result returns the accessor for the given result of the intercepted method,
+// nil if the result is nil or if the method result does not define the
+// attributes used by the interceptor. The server endpoints of methods that
+// return result types return the viewed result, the accessor then accesses
+// the projected result in which the attributes not rendered by the view are
+// nil.
+func (info *{{ .InfoName }}) Result(res interface{}) {{ .ResultName }} {
+	switch info.Method {
+	{{- range .Methods }}
+		{{- if .ViewedResultRef }}
+	case {{ printf "%q" .Name }}:
+		switch r := res.(type) {
+		case {{ .ResultRef }}:
+			if r != nil {
+				return &{{ .ResultImpl }}{result: r}
+			}
+		case {{ .ViewedResultRef }}:
+			if r != nil && r.Projected != nil {
+				return &{{ .ProjectedImpl }}{result: r}
+			}
+		}
+		{{- else if .ResultImpl }}
+	case {{ printf "%q" .Name }}:
+		r, ok := res.({{ .ResultRef }})
+		if !ok || r == nil {
+			return nil
+		}
+		return &{{ .ResultImpl }}{result: r}
+		{{- end }}
+	{{- end }}
+	}
+	return nil
+}
+{{- end }}
+`
+
+// input: interceptedMethodData
+const interceptorWrapEndpointT = `{{ printf "Wrap%sServerEndpoint wraps the %q server endpoint with the interceptors that apply to the method. The endpoint is returned unchanged if i is nil." .VarName .Name | comment }}
+func Wrap{{ .VarName }}ServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+{{- range .Interceptors }}
+	endpoint = wrap{{ .VarName }}(endpoint, i.{{ .VarName }}, {{ printf "%q" $.Name }})
+{{- end }}
+	return endpoint
+}
+
+{{ printf "Wrap%sClientEndpoint wraps the %q client endpoint with the interceptors that apply to the method. The endpoint is returned unchanged if i is nil." .VarName .Name | comment }}
+func Wrap{{ .VarName }}ClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+{{- range .Interceptors }}
+	endpoint = wrap{{ .VarName }}(endpoint, i.{{ .VarName }}, {{ printf "%q" $.Name }})
+{{- end }}
+	return endpoint
+}
+`
+
+// input: interceptorData
+const interceptorWrapperT = `{{ printf "wrap%s applies the %s interceptor method intercept to endpoint." .VarName .VarName | comment }}
+func wrap{{ .VarName }}(endpoint goa.Endpoint, intercept func(context.Context, *{{ .InfoName }}, goa.Endpoint) (interface{}, error), method string) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		info := &{{ .InfoName }}{
+			Service:    {{ printf "%q" .ServiceName }},
+			Method:     method,
+			RawPayload: req,
+		}
+		return intercept(ctx, info, endpoint)
+	}
+}
+`
+
+// input: interceptorMethodData
+const interceptorAccessorsT = `{{- range .Impls }}
+{{ comment .Description }}
+type {{ .Name }} struct {
+	{{ .Kind }} {{ .TypeRef }}
+}
+	{{- $impl := . }}
+	{{- range .Fields }}
+		{{- if .Read }}
+
+{{ printf "%s returns the value of the %q %s attribute." .Name .AttName $impl.Kind | comment }}
+func ({{ $impl.Recv }} *{{ $impl.Name }}) {{ .Name }}() {{ .TypeRef }} {
+			{{- if and .Pointer (not .FieldPointer) }}
+	v := {{ $impl.Recv }}.{{ $impl.Kind }}.{{ .FieldName }}
+	return &v
+			{{- else if and .FieldPointer (not .Pointer) }}
+	if {{ $impl.Recv }}.{{ $impl.Kind }}.{{ .FieldName }} == nil {
+		var zero {{ .TypeRef }}
+		return zero
+	}
+	return *{{ $impl.Recv }}.{{ $impl.Kind }}.{{ .FieldName }}
+			{{- else }}
+	return {{ $impl.Recv }}.{{ $impl.Kind }}.{{ .FieldName }}
+			{{- end }}
+}
+		{{- end }}
+		{{- if .Write }}
+
+{{ printf "Set%s sets the value of the %q %s attribute." .Name .AttName $impl.Kind | comment }}
+func ({{ $impl.Recv }} *{{ $impl.Name }}) Set{{ .Name }}(v {{ .TypeRef }}) {
+			{{- if and .Pointer (not .FieldPointer) }}
+	if v != nil {
+		{{ $impl.Recv }}.{{ $impl.Kind }}.{{ .FieldName }} = *v
+	}
+			{{- else if and .FieldPointer (not .Pointer) }}
+	{{ $impl.Recv }}.{{ $impl.Kind }}.{{ .FieldName }} = &v
+			{{- else }}
+	{{ $impl.Recv }}.{{ $impl.Kind }}.{{ .FieldName }} = v
+			{{- end }}
+}
+		{{- end }}
+	{{- end }}
+{{- end }}
+`
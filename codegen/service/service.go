@@ -65,6 +65,14 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				})
 			}
 		}
+		if m.ViewSelector != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "service-view-selector",
+				Source:  viewSelectorT,
+				Data:    m,
+				FuncMap: map[string]interface{}{"viewDescription": viewDescription},
+			})
+		}
 	}
 	for _, ut := range svc.userTypes {
 		if _, ok := seen[ut.Name]; !ok {
@@ -201,13 +209,24 @@ func streamInterfaceFor(typ string, m *MethodData, stream *StreamData) map[strin
 	}
 }
 
+// viewDescription returns the sentence appended to the comment of the view
+// selector constants that describe views.
+func viewDescription(desc string) string {
+	if desc == "" {
+		return ""
+	}
+	return " " + desc
+}
+
 // serviceT is the template used to write an service definition.
 const serviceT = `
 {{ comment .Description }}
 type Service interface {
 {{- range .Methods }}
 	{{ comment .Description }}
-	{{- if .ViewedResult }}
+	{{- if .ViewSelector }}
+		{{ comment "The view argument is the view selected by the client to render the result." }}
+	{{- else if .ViewedResult }}
 		{{- if not .ViewedResult.ViewName }}
 			{{ comment "The \"view\" return value must have one of the following views" }}
 			{{- range .ViewedResult.Views }}
@@ -222,7 +241,7 @@ type Service interface {
 	{{- if .ServerStream }}
 		{{ .VarName }}(context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}, {{ .ServerStream.Interface }}) (err error)
 	{{- else }}
-		{{ .VarName }}(context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}) ({{ if .Result }}res {{ .ResultRef }}, {{ if .ViewedResult }}{{ if not .ViewedResult.ViewName }}view string, {{ end }}{{ end }}{{ end }}err error)
+		{{ .VarName }}(context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}{{ if .ViewSelector }}, {{ .ViewSelector.TypeName }}{{ end }}) ({{ if .Result }}res {{ .ResultRef }}, {{ if .ViewedResult }}{{ if not (or .ViewedResult.ViewName .ViewSelector) }}view string, {{ end }}{{ end }}{{ end }}err error)
 	{{- end }}
 {{- end }}
 }
@@ -288,6 +307,27 @@ const resultT = `{{ comment .ResultDesc }}
 type {{ .Result }} {{ .ResultDef }}
 `
 
+// input: MethodData
+const viewSelectorT = `{{ printf "%s is the view selected by the client to render the %q method result." .ViewSelector.TypeName .Name | comment }}
+type {{ .ViewSelector.TypeName }} string
+
+const (
+{{- range .ViewSelector.Views }}
+	{{ printf "%s selects the %q view.%s" .ConstName .Name (viewDescription .Description) | comment }}
+	{{ .ConstName }} {{ $.ViewSelector.TypeName }} = {{ printf "%q" .Name }}
+{{- end }}
+)
+
+{{ printf "%s returns the %s with the given name. It returns a validation error if the %q method result does not define the view." .ViewSelector.ParseName .ViewSelector.TypeName .Name | comment }}
+func {{ .ViewSelector.ParseName }}(name string) ({{ .ViewSelector.TypeName }}, error) {
+	switch v := {{ .ViewSelector.TypeName }}(name); v {
+	case {{ range $i, $v := .ViewSelector.Views }}{{ if $i }}, {{ end }}{{ .ConstName }}{{ end }}:
+		return v, nil
+	}
+	return "", goa.InvalidEnumValueError({{ printf "%q" .ViewSelector.Name }}, name, []interface{}{ {{ range .ViewSelector.Views }}{{ printf "%q" .Name }}, {{ end }} })
+}
+`
+
 const userTypeT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
 `
@@ -58,6 +58,9 @@ type (
 		Scope *codegen.NameScope
 		// ViewScope initialized with all the viewed types.
 		ViewScope *codegen.NameScope
+		// HasInterceptors is true if at least one of the service methods
+		// uses interceptors.
+		HasInterceptors bool
 
 		// userTypes lists the type definitions that the service depends on.
 		userTypes []*UserTypeData
@@ -143,6 +146,9 @@ type (
 		// ViewedResult contains the data required to generate the code handling
 		// views if any.
 		ViewedResult *ViewedResultTypeData
+		// ViewSelector contains the data required to generate the code that
+		// lets clients select the view used to render the result if any.
+		ViewSelector *ViewSelectorData
 		// ServerStream indicates that the service method receives a payload
 		// stream or sends a result stream or both.
 		ServerStream *StreamData
@@ -310,6 +316,32 @@ type (
 		TypeVarName string
 	}
 
+	// ViewSelectorData contains the data used to generate the type that
+	// enumerates the views clients may select to render a method result.
+	ViewSelectorData struct {
+		// Name is the name of the view selector in requests.
+		Name string
+		// TypeName is the name of the type enumerating the views.
+		TypeName string
+		// ParseName is the name of the function that converts a view name
+		// into a value of the view type.
+		ParseName string
+		// Default is the name of the constant holding the default view.
+		Default string
+		// Views lists the views that clients may select.
+		Views []*ViewSelectorValueData
+	}
+
+	// ViewSelectorValueData describes a view that clients may select.
+	ViewSelectorValueData struct {
+		// Name is the view name.
+		Name string
+		// Description is the view description.
+		Description string
+		// ConstName is the name of the constant holding the view.
+		ConstName string
+	}
+
 	// ProjectedTypeData contains the data used to generate a projected type for
 	// the corresponding user type or result type in the service package. The
 	// generated type uses pointers for all fields. It also contains the data
@@ -474,6 +506,10 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 	{
 		scope = codegen.NewNameScope()
 		scope.Unique("Use") // Reserve "Use" for Endpoints struct Use method.
+		for _, m := range service.Methods {
+			// Reserve the names of the per-method Use helpers.
+			scope.Unique("Use" + codegen.Goify(m.Name, true))
+		}
 		viewScope = codegen.NewNameScope()
 		pkgName = scope.HashedUnique(service, strings.ToLower(codegen.Goify(service.Name, false)), "svc")
 		viewspkg = pkgName + "views"
@@ -579,6 +615,7 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 					m.ViewedResult = vrt
 				}
 			}
+			m.ViewSelector = buildViewSelectorData(e, m, scope)
 			methods[i] = m
 			for _, s := range m.Schemes {
 				schemes = schemes.Append(s)
@@ -607,6 +644,7 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		Schemes:           schemes,
 		Scope:             scope,
 		ViewScope:         viewScope,
+		HasInterceptors:   hasInterceptors(service),
 		errorTypes:        errTypes,
 		errorInits:        errorInits,
 		userTypes:         types,
@@ -657,6 +695,10 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 		for _, nat := range *dt {
 			data = append(data, collect(nat.Attribute)...)
 		}
+	case *expr.Union:
+		for _, nat := range *dt.Values {
+			data = append(data, collect(nat.Attribute)...)
+		}
 	case *expr.Array:
 		data = append(data, collect(dt.ElemType)...)
 	case *expr.Map:
@@ -988,6 +1030,11 @@ func collectProjectedTypes(projected, att *expr.AttributeExpr, viewspkg string,
 		for _, n := range *pt {
 			data = append(data, collect(n.Attribute, dt.Attribute(n.Name))...)
 		}
+	case *expr.Union:
+		dt := att.Type.(*expr.Union)
+		for _, n := range *pt.Values {
+			data = append(data, collect(n.Attribute, dt.Values.Attribute(n.Name))...)
+		}
 	}
 	return
 }
@@ -1623,3 +1670,31 @@ if {{ $.Source }}.{{ goify .Name true }} != nil {
 {{- end -}}
 `
 )
+
+// buildViewSelectorData builds the data needed to generate the type that
+// enumerates the views clients may select to render the result of the given
+// method. It returns nil if the method does not define a view selector.
+func buildViewSelectorData(e *expr.MethodExpr, m *MethodData, scope *codegen.NameScope) *ViewSelectorData {
+	vs := e.ViewSelector
+	if vs == nil || m.ViewedResult == nil {
+		return nil
+	}
+	tname := scope.Unique(m.VarName + "View")
+	data := &ViewSelectorData{
+		Name:      vs.Name,
+		TypeName:  tname,
+		ParseName: "Parse" + tname,
+	}
+	for _, v := range vs.Views() {
+		cname := scope.Unique(tname + codegen.Goify(v.Name, true))
+		data.Views = append(data.Views, &ViewSelectorValueData{
+			Name:        v.Name,
+			Description: v.Description,
+			ConstName:   cname,
+		})
+		if v.Name == expr.DefaultView {
+			data.Default = cname
+		}
+	}
+	return data
+}
@@ -45,6 +45,7 @@ func TestService(t *testing.T) {
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethod},
 		{"bidirectional-streaming-result-with-views", testdata.BidirectionalStreamingResultWithViewsMethodDSL, testdata.BidirectionalStreamingResultWithViewsMethod},
 		{"bidirectional-streaming-result-with-explicit-view", testdata.BidirectionalStreamingResultWithExplicitViewMethodDSL, testdata.BidirectionalStreamingResultWithExplicitViewMethod},
+		{"view-selector", testdata.ViewSelectorMethodDSL, testdata.ViewSelectorMethod},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -12,6 +12,18 @@ func NewClient(a goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the "SingleEndpoint" service client
+// endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.AEndpoint = m(c.AEndpoint)
+}
+
+// UseA applies the given middleware to the "A" endpoint of the
+// "SingleEndpoint" service client.
+func (c *Client) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	c.AEndpoint = m(c.AEndpoint)
+}
+
 // A calls the "A" endpoint of the "SingleEndpoint" service.
 func (c *Client) A(ctx context.Context, p *AType) (err error) {
 	_, err = c.AEndpoint(ctx, p)
@@ -34,6 +46,25 @@ func NewClient(b, c goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the "MultipleEndpoints" service
+// client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.BEndpoint = m(c.BEndpoint)
+	c.CEndpoint = m(c.CEndpoint)
+}
+
+// UseB applies the given middleware to the "B" endpoint of the
+// "MultipleEndpoints" service client.
+func (c *Client) UseB(m func(goa.Endpoint) goa.Endpoint) {
+	c.BEndpoint = m(c.BEndpoint)
+}
+
+// UseC applies the given middleware to the "C" endpoint of the
+// "MultipleEndpoints" service client.
+func (c *Client) UseC(m func(goa.Endpoint) goa.Endpoint) {
+	c.CEndpoint = m(c.CEndpoint)
+}
+
 // B calls the "B" endpoint of the "MultipleEndpoints" service.
 func (c *Client) B(ctx context.Context, p *BType) (err error) {
 	_, err = c.BEndpoint(ctx, p)
@@ -59,6 +90,18 @@ func NewClient(noPayload goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the "NoPayload" service client
+// endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.NoPayloadEndpoint = m(c.NoPayloadEndpoint)
+}
+
+// UseNoPayload applies the given middleware to the "NoPayload" endpoint of the
+// "NoPayload" service client.
+func (c *Client) UseNoPayload(m func(goa.Endpoint) goa.Endpoint) {
+	c.NoPayloadEndpoint = m(c.NoPayloadEndpoint)
+}
+
 // NoPayload calls the "NoPayload" endpoint of the "NoPayload" service.
 func (c *Client) NoPayload(ctx context.Context) (err error) {
 	_, err = c.NoPayloadEndpoint(ctx, nil)
@@ -79,6 +122,19 @@ func NewClient(streamingResultMethod goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the "StreamingResultService" service
+// client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.StreamingResultMethodEndpoint = m(c.StreamingResultMethodEndpoint)
+}
+
+// UseStreamingResultMethod applies the given middleware to the
+// "StreamingResultMethod" endpoint of the "StreamingResultService" service
+// client.
+func (c *Client) UseStreamingResultMethod(m func(goa.Endpoint) goa.Endpoint) {
+	c.StreamingResultMethodEndpoint = m(c.StreamingResultMethodEndpoint)
+}
+
 // StreamingResultMethod calls the "StreamingResultMethod" endpoint of the
 // "StreamingResultService" service.
 func (c *Client) StreamingResultMethod(ctx context.Context, p *APayload) (res StreamingResultMethodClientStream, err error) {
@@ -104,6 +160,19 @@ func NewClient(streamingResultNoPayloadMethod goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the
+// "StreamingResultNoPayloadService" service client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.StreamingResultNoPayloadMethodEndpoint = m(c.StreamingResultNoPayloadMethodEndpoint)
+}
+
+// UseStreamingResultNoPayloadMethod applies the given middleware to the
+// "StreamingResultNoPayloadMethod" endpoint of the
+// "StreamingResultNoPayloadService" service client.
+func (c *Client) UseStreamingResultNoPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	c.StreamingResultNoPayloadMethodEndpoint = m(c.StreamingResultNoPayloadMethodEndpoint)
+}
+
 // StreamingResultNoPayloadMethod calls the "StreamingResultNoPayloadMethod"
 // endpoint of the "StreamingResultNoPayloadService" service.
 func (c *Client) StreamingResultNoPayloadMethod(ctx context.Context) (res StreamingResultNoPayloadMethodClientStream, err error) {
@@ -129,6 +198,19 @@ func NewClient(streamingPayloadMethod goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the "StreamingPayloadService"
+// service client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.StreamingPayloadMethodEndpoint = m(c.StreamingPayloadMethodEndpoint)
+}
+
+// UseStreamingPayloadMethod applies the given middleware to the
+// "StreamingPayloadMethod" endpoint of the "StreamingPayloadService" service
+// client.
+func (c *Client) UseStreamingPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	c.StreamingPayloadMethodEndpoint = m(c.StreamingPayloadMethodEndpoint)
+}
+
 // StreamingPayloadMethod calls the "StreamingPayloadMethod" endpoint of the
 // "StreamingPayloadService" service.
 func (c *Client) StreamingPayloadMethod(ctx context.Context, p *BPayload) (res StreamingPayloadMethodClientStream, err error) {
@@ -154,6 +236,19 @@ func NewClient(streamingPayloadNoPayloadMethod goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the
+// "StreamingPayloadNoPayloadService" service client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.StreamingPayloadNoPayloadMethodEndpoint = m(c.StreamingPayloadNoPayloadMethodEndpoint)
+}
+
+// UseStreamingPayloadNoPayloadMethod applies the given middleware to the
+// "StreamingPayloadNoPayloadMethod" endpoint of the
+// "StreamingPayloadNoPayloadService" service client.
+func (c *Client) UseStreamingPayloadNoPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	c.StreamingPayloadNoPayloadMethodEndpoint = m(c.StreamingPayloadNoPayloadMethodEndpoint)
+}
+
 // StreamingPayloadNoPayloadMethod calls the "StreamingPayloadNoPayloadMethod"
 // endpoint of the "StreamingPayloadNoPayloadService" service.
 func (c *Client) StreamingPayloadNoPayloadMethod(ctx context.Context) (res StreamingPayloadNoPayloadMethodClientStream, err error) {
@@ -179,6 +274,19 @@ func NewClient(bidirectionalStreamingMethod goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the "BidirectionalStreamingService"
+// service client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.BidirectionalStreamingMethodEndpoint = m(c.BidirectionalStreamingMethodEndpoint)
+}
+
+// UseBidirectionalStreamingMethod applies the given middleware to the
+// "BidirectionalStreamingMethod" endpoint of the
+// "BidirectionalStreamingService" service client.
+func (c *Client) UseBidirectionalStreamingMethod(m func(goa.Endpoint) goa.Endpoint) {
+	c.BidirectionalStreamingMethodEndpoint = m(c.BidirectionalStreamingMethodEndpoint)
+}
+
 // BidirectionalStreamingMethod calls the "BidirectionalStreamingMethod"
 // endpoint of the "BidirectionalStreamingService" service.
 func (c *Client) BidirectionalStreamingMethod(ctx context.Context, p *BPayload) (res BidirectionalStreamingMethodClientStream, err error) {
@@ -204,6 +312,19 @@ func NewClient(bidirectionalStreamingNoPayloadMethod goa.Endpoint) *Client {
 	}
 }
 
+// Use applies the given middleware to all the
+// "BidirectionalStreamingNoPayloadService" service client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.BidirectionalStreamingNoPayloadMethodEndpoint = m(c.BidirectionalStreamingNoPayloadMethodEndpoint)
+}
+
+// UseBidirectionalStreamingNoPayloadMethod applies the given middleware to the
+// "BidirectionalStreamingNoPayloadMethod" endpoint of the
+// "BidirectionalStreamingNoPayloadService" service client.
+func (c *Client) UseBidirectionalStreamingNoPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	c.BidirectionalStreamingNoPayloadMethodEndpoint = m(c.BidirectionalStreamingNoPayloadMethodEndpoint)
+}
+
 // BidirectionalStreamingNoPayloadMethod calls the
 // "BidirectionalStreamingNoPayloadMethod" endpoint of the
 // "BidirectionalStreamingNoPayloadService" service.
@@ -216,3 +337,377 @@ func (c *Client) BidirectionalStreamingNoPayloadMethod(ctx context.Context) (res
 	return ires.(BidirectionalStreamingNoPayloadMethodClientStream), nil
 }
 `
+
+const WithInterceptorsMethodClient = `// Client is the "ResultInterceptor" service client.
+type Client struct {
+	AEndpoint goa.Endpoint
+	BEndpoint goa.Endpoint
+	CEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "ResultInterceptor" service client given the
+// endpoints.
+func NewClient(a, b, c goa.Endpoint, ci ClientInterceptors) *Client {
+	return &Client{
+		AEndpoint: WrapAClientEndpoint(a, ci),
+		BEndpoint: WrapBClientEndpoint(b, ci),
+		CEndpoint: WrapCClientEndpoint(c, ci),
+	}
+}
+
+// Use applies the given middleware to all the "ResultInterceptor" service
+// client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.AEndpoint = m(c.AEndpoint)
+	c.BEndpoint = m(c.BEndpoint)
+	c.CEndpoint = m(c.CEndpoint)
+}
+
+// UseA applies the given middleware to the "A" endpoint of the
+// "ResultInterceptor" service client.
+func (c *Client) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	c.AEndpoint = m(c.AEndpoint)
+}
+
+// UseB applies the given middleware to the "B" endpoint of the
+// "ResultInterceptor" service client.
+func (c *Client) UseB(m func(goa.Endpoint) goa.Endpoint) {
+	c.BEndpoint = m(c.BEndpoint)
+}
+
+// UseC applies the given middleware to the "C" endpoint of the
+// "ResultInterceptor" service client.
+func (c *Client) UseC(m func(goa.Endpoint) goa.Endpoint) {
+	c.CEndpoint = m(c.CEndpoint)
+}
+
+// A calls the "A" endpoint of the "ResultInterceptor" service.
+func (c *Client) A(ctx context.Context) (res *AResult, err error) {
+	var ires interface{}
+	ires, err = c.AEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return ires.(*AResult), nil
+}
+
+// B calls the "B" endpoint of the "ResultInterceptor" service.
+func (c *Client) B(ctx context.Context) (err error) {
+	_, err = c.BEndpoint(ctx, nil)
+	return
+}
+
+// C calls the "C" endpoint of the "ResultInterceptor" service.
+func (c *Client) C(ctx context.Context) (res *Cresult, err error) {
+	var ires interface{}
+	ires, err = c.CEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return ires.(*Cresult), nil
+}
+`
+
+const CursorPaginationMethodClient = `// Client is the "CursorPagination" service client.
+type Client struct {
+	ListEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "CursorPagination" service client given the
+// endpoints.
+func NewClient(list goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint: list,
+	}
+}
+
+// Use applies the given middleware to all the "CursorPagination" service
+// client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.ListEndpoint = m(c.ListEndpoint)
+}
+
+// UseList applies the given middleware to the "List" endpoint of the
+// "CursorPagination" service client.
+func (c *Client) UseList(m func(goa.Endpoint) goa.Endpoint) {
+	c.ListEndpoint = m(c.ListEndpoint)
+}
+
+// List calls the "List" endpoint of the "CursorPagination" service.
+func (c *Client) List(ctx context.Context, p *ListPayload) (res *ListResult, err error) {
+	var ires interface{}
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListIterator iterates over the items returned by the "List" endpoint of the
+// "CursorPagination" service fetching the subsequent pages as needed.
+type ListIterator struct {
+	client  *Client
+	payload *ListPayload
+	items   []string
+	index   int
+	done    bool
+	err     error
+}
+
+// ListIter returns an iterator over the items returned by the "List" endpoint
+// of the "CursorPagination" service starting with the page requested by p.
+func (c *Client) ListIter(p *ListPayload) *ListIterator {
+	if p == nil {
+		p = &ListPayload{}
+	}
+	return &ListIterator{client: c, payload: p}
+}
+
+// Next advances the iterator to the next item fetching the next page if
+// needed. Next returns false once all the items have been visited or if a
+// request fails, use Err to tell both cases apart.
+func (it *ListIterator) Next(ctx context.Context) bool {
+	it.index++
+	for it.index >= len(it.items) {
+		if it.done || it.err != nil {
+			return false
+		}
+		res, err := it.client.List(ctx, it.payload)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = res.Items
+		it.index = 0
+		it.next(res)
+	}
+	return true
+}
+
+// Item returns the current item.
+func (it *ListIterator) Item() string {
+	return it.items[it.index]
+}
+
+// Err returns the error that stopped the iteration if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// next computes the payload used to request the page following res and
+// records whether res is the last page.
+func (it *ListIterator) next(res *ListResult) {
+	p := *it.payload
+	if res.Next == nil || *res.Next == "" {
+		it.done = true
+		return
+	}
+	p.Cursor = res.Next
+	it.payload = &p
+}
+`
+
+const OffsetPaginationMethodClient = `// Client is the "OffsetPagination" service client.
+type Client struct {
+	ListEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "OffsetPagination" service client given the
+// endpoints.
+func NewClient(list goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint: list,
+	}
+}
+
+// Use applies the given middleware to all the "OffsetPagination" service
+// client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.ListEndpoint = m(c.ListEndpoint)
+}
+
+// UseList applies the given middleware to the "List" endpoint of the
+// "OffsetPagination" service client.
+func (c *Client) UseList(m func(goa.Endpoint) goa.Endpoint) {
+	c.ListEndpoint = m(c.ListEndpoint)
+}
+
+// List calls the "List" endpoint of the "OffsetPagination" service.
+func (c *Client) List(ctx context.Context, p *ListPayload) (res *ListResult, err error) {
+	var ires interface{}
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListIterator iterates over the items returned by the "List" endpoint of the
+// "OffsetPagination" service fetching the subsequent pages as needed.
+type ListIterator struct {
+	client  *Client
+	payload *ListPayload
+	items   []int
+	index   int
+	done    bool
+	err     error
+}
+
+// ListIter returns an iterator over the items returned by the "List" endpoint
+// of the "OffsetPagination" service starting with the page requested by p.
+func (c *Client) ListIter(p *ListPayload) *ListIterator {
+	if p == nil {
+		p = &ListPayload{}
+	}
+	return &ListIterator{client: c, payload: p}
+}
+
+// Next advances the iterator to the next item fetching the next page if
+// needed. Next returns false once all the items have been visited or if a
+// request fails, use Err to tell both cases apart.
+func (it *ListIterator) Next(ctx context.Context) bool {
+	it.index++
+	for it.index >= len(it.items) {
+		if it.done || it.err != nil {
+			return false
+		}
+		res, err := it.client.List(ctx, it.payload)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = res.Items
+		it.index = 0
+		it.next(res)
+	}
+	return true
+}
+
+// Item returns the current item.
+func (it *ListIterator) Item() int {
+	return it.items[it.index]
+}
+
+// Err returns the error that stopped the iteration if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// next computes the payload used to request the page following res and
+// records whether res is the last page.
+func (it *ListIterator) next(res *ListResult) {
+	p := *it.payload
+	n := len(res.Items)
+	if n == 0 || p.Limit != nil && n < *p.Limit {
+		it.done = true
+		return
+	}
+	var offset int
+	if p.Offset != nil {
+		offset = *p.Offset
+	}
+	offset += n
+	p.Offset = &offset
+	it.payload = &p
+}
+`
+
+const ViewSelectorMethodClient = `// Client is the "ViewSelectorService" service client.
+type Client struct {
+	ViewSelectorMethodEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "ViewSelectorService" service client given the
+// endpoints.
+func NewClient(viewSelectorMethod goa.Endpoint) *Client {
+	return &Client{
+		ViewSelectorMethodEndpoint: viewSelectorMethod,
+	}
+}
+
+// Use applies the given middleware to all the "ViewSelectorService" service
+// client endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.ViewSelectorMethodEndpoint = m(c.ViewSelectorMethodEndpoint)
+}
+
+// UseViewSelectorMethod applies the given middleware to the
+// "ViewSelectorMethod" endpoint of the "ViewSelectorService" service client.
+func (c *Client) UseViewSelectorMethod(m func(goa.Endpoint) goa.Endpoint) {
+	c.ViewSelectorMethodEndpoint = m(c.ViewSelectorMethodEndpoint)
+}
+
+// ViewSelectorMethod calls the "ViewSelectorMethod" endpoint of the
+// "ViewSelectorService" service.
+func (c *Client) ViewSelectorMethod(ctx context.Context, p *APayload) (res *MultipleViews, err error) {
+	var ires interface{}
+	ires, err = c.ViewSelectorMethodEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*MultipleViews), nil
+}
+
+// ViewSelectorMethodWithView calls the "ViewSelectorMethod" endpoint of the
+// "ViewSelectorService" service requesting the result to be rendered with the
+// given view.
+func (c *Client) ViewSelectorMethodWithView(ctx context.Context, p *APayload, view ViewSelectorMethodView) (res *MultipleViews, err error) {
+	return c.ViewSelectorMethod(context.WithValue(ctx, goa.ViewKey, string(view)), p)
+}
+`
+
+const IdempotentMethodClient = `// Client is the "Idempotent" service client.
+type Client struct {
+	PayEndpoint  goa.Endpoint
+	ListEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "Idempotent" service client given the endpoints.
+func NewClient(pay, list goa.Endpoint) *Client {
+	return &Client{
+		PayEndpoint:  pay,
+		ListEndpoint: list,
+	}
+}
+
+// Use applies the given middleware to all the "Idempotent" service client
+// endpoints.
+func (c *Client) Use(m func(goa.Endpoint) goa.Endpoint) {
+	c.PayEndpoint = m(c.PayEndpoint)
+	c.ListEndpoint = m(c.ListEndpoint)
+}
+
+// UsePay applies the given middleware to the "Pay" endpoint of the
+// "Idempotent" service client.
+func (c *Client) UsePay(m func(goa.Endpoint) goa.Endpoint) {
+	c.PayEndpoint = m(c.PayEndpoint)
+}
+
+// UseList applies the given middleware to the "List" endpoint of the
+// "Idempotent" service client.
+func (c *Client) UseList(m func(goa.Endpoint) goa.Endpoint) {
+	c.ListEndpoint = m(c.ListEndpoint)
+}
+
+// Pay calls the "Pay" endpoint of the "Idempotent" service.
+func (c *Client) Pay(ctx context.Context, p *PayPayload) (res string, err error) {
+	// Bind the idempotency key to the call so that retries reuse it.
+	ctx = goa.WithIdempotencyKey(ctx)
+	var ires interface{}
+	ires, err = c.PayEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(string), nil
+}
+
+// List calls the "List" endpoint of the "Idempotent" service.
+func (c *Client) List(ctx context.Context) (res string, err error) {
+	var ires interface{}
+	ires, err = c.ListEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return ires.(string), nil
+}
+`
@@ -19,6 +19,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.A = m(e.A)
 }
 
+// UseA applies the given middleware to the "A" endpoint of the
+// "SingleEndpoint" service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
 // NewAEndpoint returns an endpoint function that calls the method "A" of
 // service "SingleEndpoint".
 func NewAEndpoint(s Service) goa.Endpoint {
@@ -29,6 +35,59 @@ func NewAEndpoint(s Service) goa.Endpoint {
 }
 `
 
+const UseMethodEndpoint = `// Endpoints wraps the "UseMethodEndpoint" service endpoints.
+type Endpoints struct {
+	A            goa.Endpoint
+	UseAEndpoint goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "UseMethodEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A:            NewAEndpoint(s),
+		UseAEndpoint: NewUseAEndpointEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "UseMethodEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.UseAEndpoint = m(e.UseAEndpoint)
+}
+
+// UseA applies the given middleware to the "A" endpoint of the
+// "UseMethodEndpoint" service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// UseUseAEndpoint applies the given middleware to the "UseA" endpoint of the
+// "UseMethodEndpoint" service.
+func (e *Endpoints) UseUseAEndpoint(m func(goa.Endpoint) goa.Endpoint) {
+	e.UseAEndpoint = m(e.UseAEndpoint)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "UseMethodEndpoint".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewUseAEndpointEndpoint returns an endpoint function that calls the method
+// "UseA" of service "UseMethodEndpoint".
+func NewUseAEndpointEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		return nil, s.UseAEndpoint(ctx, p)
+	}
+}
+`
+
 const UseEndpoint = `// Endpoints wraps the "UseEndpoint" service endpoints.
 type Endpoints struct {
 	UseEndpoint goa.Endpoint
@@ -46,6 +105,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.UseEndpoint = m(e.UseEndpoint)
 }
 
+// UseUseEndpoint applies the given middleware to the "Use" endpoint of the
+// "UseEndpoint" service.
+func (e *Endpoints) UseUseEndpoint(m func(goa.Endpoint) goa.Endpoint) {
+	e.UseEndpoint = m(e.UseEndpoint)
+}
+
 // NewUseEndpointEndpoint returns an endpoint function that calls the method
 // "Use" of service "UseEndpoint".
 func NewUseEndpointEndpoint(s Service) goa.Endpoint {
@@ -78,6 +143,18 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.C = m(e.C)
 }
 
+// UseB applies the given middleware to the "B" endpoint of the
+// "MultipleEndpoints" service.
+func (e *Endpoints) UseB(m func(goa.Endpoint) goa.Endpoint) {
+	e.B = m(e.B)
+}
+
+// UseC applies the given middleware to the "C" endpoint of the
+// "MultipleEndpoints" service.
+func (e *Endpoints) UseC(m func(goa.Endpoint) goa.Endpoint) {
+	e.C = m(e.C)
+}
+
 // NewBEndpoint returns an endpoint function that calls the method "B" of
 // service "MultipleEndpoints".
 func NewBEndpoint(s Service) goa.Endpoint {
@@ -114,6 +191,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.NoPayload = m(e.NoPayload)
 }
 
+// UseNoPayload applies the given middleware to the "NoPayload" endpoint of the
+// "NoPayload" service.
+func (e *Endpoints) UseNoPayload(m func(goa.Endpoint) goa.Endpoint) {
+	e.NoPayload = m(e.NoPayload)
+}
+
 // NewNoPayloadEndpoint returns an endpoint function that calls the method
 // "NoPayload" of service "NoPayload".
 func NewNoPayloadEndpoint(s Service) goa.Endpoint {
@@ -140,6 +223,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.A = m(e.A)
 }
 
+// UseA applies the given middleware to the "A" endpoint of the "WithResult"
+// service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
 // NewAEndpoint returns an endpoint function that calls the method "A" of
 // service "WithResult".
 func NewAEndpoint(s Service) goa.Endpoint {
@@ -173,6 +262,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.A = m(e.A)
 }
 
+// UseA applies the given middleware to the "A" endpoint of the
+// "WithResultMultipleViews" service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
 // NewAEndpoint returns an endpoint function that calls the method "A" of
 // service "WithResultMultipleViews".
 func NewAEndpoint(s Service) goa.Endpoint {
@@ -217,6 +312,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.StreamingResultMethod = m(e.StreamingResultMethod)
 }
 
+// UseStreamingResultMethod applies the given middleware to the
+// "StreamingResultMethod" endpoint of the "StreamingResultEndpoint" service.
+func (e *Endpoints) UseStreamingResultMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.StreamingResultMethod = m(e.StreamingResultMethod)
+}
+
 // NewStreamingResultMethodEndpoint returns an endpoint function that calls the
 // method "StreamingResultMethod" of service "StreamingResultEndpoint".
 func NewStreamingResultMethodEndpoint(s Service) goa.Endpoint {
@@ -255,6 +356,13 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.StreamingResultNoPayloadMethod = m(e.StreamingResultNoPayloadMethod)
 }
 
+// UseStreamingResultNoPayloadMethod applies the given middleware to the
+// "StreamingResultNoPayloadMethod" endpoint of the
+// "StreamingResultNoPayloadEndpoint" service.
+func (e *Endpoints) UseStreamingResultNoPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.StreamingResultNoPayloadMethod = m(e.StreamingResultNoPayloadMethod)
+}
+
 // NewStreamingResultNoPayloadMethodEndpoint returns an endpoint function that
 // calls the method "StreamingResultNoPayloadMethod" of service
 // "StreamingResultNoPayloadEndpoint".
@@ -296,6 +404,13 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.StreamingResultWithViewsMethod = m(e.StreamingResultWithViewsMethod)
 }
 
+// UseStreamingResultWithViewsMethod applies the given middleware to the
+// "StreamingResultWithViewsMethod" endpoint of the
+// "StreamingResultWithViewsService" service.
+func (e *Endpoints) UseStreamingResultWithViewsMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.StreamingResultWithViewsMethod = m(e.StreamingResultWithViewsMethod)
+}
+
 // NewStreamingResultWithViewsMethodEndpoint returns an endpoint function that
 // calls the method "StreamingResultWithViewsMethod" of service
 // "StreamingResultWithViewsService".
@@ -337,6 +452,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.StreamingPayloadMethod = m(e.StreamingPayloadMethod)
 }
 
+// UseStreamingPayloadMethod applies the given middleware to the
+// "StreamingPayloadMethod" endpoint of the "StreamingPayloadEndpoint" service.
+func (e *Endpoints) UseStreamingPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.StreamingPayloadMethod = m(e.StreamingPayloadMethod)
+}
+
 // NewStreamingPayloadMethodEndpoint returns an endpoint function that calls
 // the method "StreamingPayloadMethod" of service "StreamingPayloadEndpoint".
 func NewStreamingPayloadMethodEndpoint(s Service) goa.Endpoint {
@@ -375,6 +496,13 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.StreamingPayloadNoPayloadMethod = m(e.StreamingPayloadNoPayloadMethod)
 }
 
+// UseStreamingPayloadNoPayloadMethod applies the given middleware to the
+// "StreamingPayloadNoPayloadMethod" endpoint of the
+// "StreamingPayloadNoPayloadService" service.
+func (e *Endpoints) UseStreamingPayloadNoPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.StreamingPayloadNoPayloadMethod = m(e.StreamingPayloadNoPayloadMethod)
+}
+
 // NewStreamingPayloadNoPayloadMethodEndpoint returns an endpoint function that
 // calls the method "StreamingPayloadNoPayloadMethod" of service
 // "StreamingPayloadNoPayloadService".
@@ -414,6 +542,13 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.StreamingPayloadNoResultMethod = m(e.StreamingPayloadNoResultMethod)
 }
 
+// UseStreamingPayloadNoResultMethod applies the given middleware to the
+// "StreamingPayloadNoResultMethod" endpoint of the
+// "StreamingPayloadNoResultService" service.
+func (e *Endpoints) UseStreamingPayloadNoResultMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.StreamingPayloadNoResultMethod = m(e.StreamingPayloadNoResultMethod)
+}
+
 // NewStreamingPayloadNoResultMethodEndpoint returns an endpoint function that
 // calls the method "StreamingPayloadNoResultMethod" of service
 // "StreamingPayloadNoResultService".
@@ -455,6 +590,13 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.BidirectionalStreamingMethod = m(e.BidirectionalStreamingMethod)
 }
 
+// UseBidirectionalStreamingMethod applies the given middleware to the
+// "BidirectionalStreamingMethod" endpoint of the
+// "BidirectionalStreamingEndpoint" service.
+func (e *Endpoints) UseBidirectionalStreamingMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.BidirectionalStreamingMethod = m(e.BidirectionalStreamingMethod)
+}
+
 // NewBidirectionalStreamingMethodEndpoint returns an endpoint function that
 // calls the method "BidirectionalStreamingMethod" of service
 // "BidirectionalStreamingEndpoint".
@@ -495,6 +637,13 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.BidirectionalStreamingNoPayloadMethod = m(e.BidirectionalStreamingNoPayloadMethod)
 }
 
+// UseBidirectionalStreamingNoPayloadMethod applies the given middleware to the
+// "BidirectionalStreamingNoPayloadMethod" endpoint of the
+// "BidirectionalStreamingNoPayloadService" service.
+func (e *Endpoints) UseBidirectionalStreamingNoPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.BidirectionalStreamingNoPayloadMethod = m(e.BidirectionalStreamingNoPayloadMethod)
+}
+
 // NewBidirectionalStreamingNoPayloadMethodEndpoint returns an endpoint
 // function that calls the method "BidirectionalStreamingNoPayloadMethod" of
 // service "BidirectionalStreamingNoPayloadService".
@@ -505,3 +654,188 @@ func NewBidirectionalStreamingNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	}
 }
 `
+
+const WithInterceptorsEndpoint = `// Endpoints wraps the "ResultInterceptor" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+	C goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "ResultInterceptor" service with
+// endpoints.
+func NewEndpoints(s Service, si ServerInterceptors) *Endpoints {
+	return &Endpoints{
+		A: WrapAServerEndpoint(NewAEndpoint(s), si),
+		B: WrapBServerEndpoint(NewBEndpoint(s), si),
+		C: WrapCServerEndpoint(NewCEndpoint(s), si),
+	}
+}
+
+// Use applies the given middleware to all the "ResultInterceptor" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+	e.C = m(e.C)
+}
+
+// UseA applies the given middleware to the "A" endpoint of the
+// "ResultInterceptor" service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// UseB applies the given middleware to the "B" endpoint of the
+// "ResultInterceptor" service.
+func (e *Endpoints) UseB(m func(goa.Endpoint) goa.Endpoint) {
+	e.B = m(e.B)
+}
+
+// UseC applies the given middleware to the "C" endpoint of the
+// "ResultInterceptor" service.
+func (e *Endpoints) UseC(m func(goa.Endpoint) goa.Endpoint) {
+	e.C = m(e.C)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "ResultInterceptor".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.A(ctx)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "ResultInterceptor".
+func NewBEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.B(ctx)
+	}
+}
+
+// NewCEndpoint returns an endpoint function that calls the method "C" of
+// service "ResultInterceptor".
+func NewCEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, view, err := s.C(ctx)
+		if err != nil {
+			return nil, err
+		}
+		vres := NewViewedCresult(res, view)
+		return vres, nil
+	}
+}
+`
+
+const WithFieldMaskEndpoint = `// Endpoints wraps the "FieldMask" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "FieldMask" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A: NewAEndpoint(s),
+		B: NewBEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "FieldMask" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+}
+
+// UseA applies the given middleware to the "A" endpoint of the "FieldMask"
+// service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// UseB applies the given middleware to the "B" endpoint of the "FieldMask"
+// service.
+func (e *Endpoints) UseB(m func(goa.Endpoint) goa.Endpoint) {
+	e.B = m(e.B)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "FieldMask".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*APayload)
+		res, err := s.A(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok {
+			MaskAResult(res, mask)
+		}
+		return res, nil
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "FieldMask".
+func NewBEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := s.B(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok {
+			MaskBResult(res, mask)
+		}
+		return res, nil
+	}
+}
+`
+
+const ViewSelectorMethodEndpoint = `// Endpoints wraps the "ViewSelectorService" service endpoints.
+type Endpoints struct {
+	ViewSelectorMethod goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "ViewSelectorService" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		ViewSelectorMethod: NewViewSelectorMethodEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "ViewSelectorService" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.ViewSelectorMethod = m(e.ViewSelectorMethod)
+}
+
+// UseViewSelectorMethod applies the given middleware to the
+// "ViewSelectorMethod" endpoint of the "ViewSelectorService" service.
+func (e *Endpoints) UseViewSelectorMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.ViewSelectorMethod = m(e.ViewSelectorMethod)
+}
+
+// NewViewSelectorMethodEndpoint returns an endpoint function that calls the
+// method "ViewSelectorMethod" of service "ViewSelectorService".
+func NewViewSelectorMethodEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*APayload)
+		view := ViewSelectorMethodViewDefault
+		if name, ok := ctx.Value(goa.ViewKey).(string); ok && name != "" {
+			v, err := ParseViewSelectorMethodView(name)
+			if err != nil {
+				return nil, err
+			}
+			view = v
+		}
+		res, err := s.ViewSelectorMethod(ctx, p, view)
+		if err != nil {
+			return nil, err
+		}
+		vres := NewViewedMultipleViews(res, string(view))
+		return vres, nil
+	}
+}
+`
@@ -23,6 +23,17 @@ var UseEndpointDSL = func() {
 	})
 }
 
+var UseMethodEndpointDSL = func() {
+	Service("UseMethodEndpoint", func() {
+		Method("A", func() {
+			Payload(String)
+		})
+		Method("UseA", func() {
+			Payload(String)
+		})
+	})
+}
+
 var MultipleEndpointsDSL = func() {
 	var BType = Type("BType", func() {
 		Attribute("b", String)
@@ -158,3 +169,66 @@ var BidirectionalStreamingEndpointDSL = func() {
 		})
 	})
 }
+
+var CursorPaginationDSL = func() {
+	Service("CursorPagination", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+				Attribute("limit", Int, func() {
+					Default(20)
+				})
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next", String)
+			})
+			Paginated(func() {
+				Cursor("cursor", "next")
+				PageSize("limit")
+				Items("items")
+			})
+		})
+	})
+}
+
+var OffsetPaginationDSL = func() {
+	Service("OffsetPagination", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("offset", Int)
+				Attribute("limit", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(Int))
+				Required("items")
+			})
+			Paginated(func() {
+				Offset("offset")
+				PageSize("limit")
+				Items("items")
+			})
+		})
+	})
+}
+
+var IdempotentMethodDSL = func() {
+	Service("Idempotent", func() {
+		Method("Pay", func() {
+			Payload(func() {
+				Attribute("amount", Int)
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/")
+				Idempotent()
+			})
+		})
+		Method("List", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
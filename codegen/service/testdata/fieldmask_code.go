@@ -0,0 +1,81 @@
+package testdata
+
+const FieldMaskCode = `// ValidateAFieldMask returns an error if mask selects fields that are not
+// defined by the "A" method result.
+func ValidateAFieldMask(mask goa.FieldMask) error {
+	return mask.Validate("fields", aFieldMaskPaths...)
+}
+
+// MaskAResult removes the fields of res that are not selected by mask. Fields
+// that are required or that have a default value are always kept.
+func MaskAResult(res *Pet, mask goa.FieldMask) {
+	maskPet(res, mask)
+}
+
+// aFieldMaskPaths lists the paths of the "A" method result fields.
+var aFieldMaskPaths = []string{
+	"id",
+	"name",
+	"kind",
+	"owner",
+	"owner.name",
+	"owner.email",
+	"previous_owners",
+	"previous_owners.name",
+	"previous_owners.email",
+	"tags",
+}
+
+// ValidateBFieldMask returns an error if mask selects fields that are not
+// defined by the "B" method result.
+func ValidateBFieldMask(mask goa.FieldMask) error {
+	return mask.Validate("select", bFieldMaskPaths...)
+}
+
+// MaskBResult removes the fields of res that are not selected by mask. Fields
+// that are required or that have a default value are always kept.
+func MaskBResult(res *Owner, mask goa.FieldMask) {
+	maskOwner(res, mask)
+}
+
+// bFieldMaskPaths lists the paths of the "B" method result fields.
+var bFieldMaskPaths = []string{
+	"name",
+	"email",
+}
+
+// maskPet removes the fields of v that are not selected by mask.
+func maskPet(v *Pet, mask goa.FieldMask) {
+	if v == nil || len(mask) == 0 {
+		return
+	}
+	if !mask.Includes("name") {
+		v.Name = nil
+	}
+	if !mask.Includes("owner") {
+		v.Owner = nil
+	} else {
+		maskOwner(v.Owner, mask.Sub("owner"))
+	}
+	if !mask.Includes("previous_owners") {
+		v.PreviousOwners = nil
+	} else {
+		for _, e := range v.PreviousOwners {
+			maskOwner(e, mask.Sub("previous_owners"))
+		}
+	}
+	if !mask.Includes("tags") {
+		v.Tags = nil
+	}
+}
+
+// maskOwner removes the fields of v that are not selected by mask.
+func maskOwner(v *Owner, mask goa.FieldMask) {
+	if v == nil || len(mask) == 0 {
+		return
+	}
+	if !mask.Includes("email") {
+		v.Email = nil
+	}
+}
+`
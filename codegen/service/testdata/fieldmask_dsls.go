@@ -0,0 +1,37 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FieldMaskDSL = func() {
+	var Owner = Type("Owner", func() {
+		Attribute("name", String)
+		Attribute("email", String)
+		Required("name")
+	})
+	var Pet = Type("Pet", func() {
+		Attribute("id", Int)
+		Attribute("name", String)
+		Attribute("kind", String, func() {
+			Default("dog")
+		})
+		Attribute("owner", Owner)
+		Attribute("previous_owners", ArrayOf(Owner))
+		Attribute("tags", ArrayOf(String))
+		Required("id")
+	})
+	Service("FieldMask", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("id", Int)
+			})
+			Result(Pet)
+			FieldMask()
+		})
+		Method("B", func() {
+			Result(Owner)
+			FieldMask("select")
+		})
+	})
+}
@@ -0,0 +1,608 @@
+package testdata
+
+const NoPayloadInterceptorCode = `// ServerInterceptors defines the interceptors that wrap the server endpoints
+// of the "NoPayloadInterceptor" service. The interceptor methods must call
+// next to invoke the intercepted endpoint.
+type ServerInterceptors interface {
+	// Log intercepts the "NoPayloadInterceptor" service endpoints.
+	Log(ctx context.Context, info *LogInfo, next goa.Endpoint) (interface{}, error)
+}
+
+// ClientInterceptors defines the interceptors that wrap the client endpoints
+// of the "NoPayloadInterceptor" service. The interceptor methods must call
+// next to invoke the intercepted endpoint.
+type ClientInterceptors interface {
+	// Log intercepts the "NoPayloadInterceptor" service endpoints.
+	Log(ctx context.Context, info *LogInfo, next goa.Endpoint) (interface{}, error)
+}
+
+// LogInfo provides the Log interceptor with information about the intercepted
+// request.
+type LogInfo struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the method.
+	Method string
+	// RawPayload is the method payload.
+	RawPayload interface{}
+}
+
+// WrapAServerEndpoint wraps the "A" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapAServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapLog(endpoint, i.Log, "A")
+	return endpoint
+}
+
+// WrapAClientEndpoint wraps the "A" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapAClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapLog(endpoint, i.Log, "A")
+	return endpoint
+}
+
+// WrapBServerEndpoint wraps the "B" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapBServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapLog(endpoint, i.Log, "B")
+	return endpoint
+}
+
+// WrapBClientEndpoint wraps the "B" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapBClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapLog(endpoint, i.Log, "B")
+	return endpoint
+}
+
+// wrapLog applies the Log interceptor method intercept to endpoint.
+func wrapLog(endpoint goa.Endpoint, intercept func(context.Context, *LogInfo, goa.Endpoint) (interface{}, error), method string) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		info := &LogInfo{
+			Service:    "NoPayloadInterceptor",
+			Method:     method,
+			RawPayload: req,
+		}
+		return intercept(ctx, info, endpoint)
+	}
+}
+`
+
+const PayloadInterceptorCode = `// ServerInterceptors defines the interceptors that wrap the server endpoints
+// of the "PayloadInterceptor" service. The interceptor methods must call next
+// to invoke the intercepted endpoint.
+type ServerInterceptors interface {
+	// Tenant sets the tenant ID.
+	Tenant(ctx context.Context, info *TenantInfo, next goa.Endpoint) (interface{}, error)
+}
+
+// ClientInterceptors defines the interceptors that wrap the client endpoints
+// of the "PayloadInterceptor" service. The interceptor methods must call next
+// to invoke the intercepted endpoint.
+type ClientInterceptors interface {
+	// Tenant sets the tenant ID.
+	Tenant(ctx context.Context, info *TenantInfo, next goa.Endpoint) (interface{}, error)
+}
+
+// TenantInfo provides the Tenant interceptor with information about the
+// intercepted request.
+type TenantInfo struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the method.
+	Method string
+	// RawPayload is the method payload.
+	RawPayload interface{}
+}
+
+// TenantPayload provides type safe access to the payload attributes used by
+// the Tenant interceptor.
+type TenantPayload interface {
+	Tenant() *string
+	SetTenant(*string)
+}
+
+// Payload returns the accessor for the payload of the intercepted method, nil
+// if the payload is nil or if the method payload does not define the
+// attributes used by the interceptor.
+func (info *TenantInfo) Payload() TenantPayload {
+	switch info.Method {
+	case "A":
+		p, ok := info.RawPayload.(*APayload)
+		if !ok || p == nil {
+			return nil
+		}
+		return &tenantAPayload{payload: p}
+	case "B":
+		p, ok := info.RawPayload.(*BPayload)
+		if !ok || p == nil {
+			return nil
+		}
+		return &tenantBPayload{payload: p}
+	}
+	return nil
+}
+
+// WrapAServerEndpoint wraps the "A" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapAServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapTenant(endpoint, i.Tenant, "A")
+	return endpoint
+}
+
+// WrapAClientEndpoint wraps the "A" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapAClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapTenant(endpoint, i.Tenant, "A")
+	return endpoint
+}
+
+// WrapBServerEndpoint wraps the "B" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapBServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapTenant(endpoint, i.Tenant, "B")
+	return endpoint
+}
+
+// WrapBClientEndpoint wraps the "B" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapBClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapTenant(endpoint, i.Tenant, "B")
+	return endpoint
+}
+
+// wrapTenant applies the Tenant interceptor method intercept to endpoint.
+func wrapTenant(endpoint goa.Endpoint, intercept func(context.Context, *TenantInfo, goa.Endpoint) (interface{}, error), method string) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		info := &TenantInfo{
+			Service:    "PayloadInterceptor",
+			Method:     method,
+			RawPayload: req,
+		}
+		return intercept(ctx, info, endpoint)
+	}
+}
+
+// tenantAPayload implements the payload accessor for the "A" method.
+type tenantAPayload struct {
+	payload *APayload
+}
+
+// Tenant returns the value of the "tenant" payload attribute.
+func (p *tenantAPayload) Tenant() *string {
+	return p.payload.Tenant
+}
+
+// SetTenant sets the value of the "tenant" payload attribute.
+func (p *tenantAPayload) SetTenant(v *string) {
+	p.payload.Tenant = v
+}
+
+// tenantBPayload implements the payload accessor for the "B" method.
+type tenantBPayload struct {
+	payload *BPayload
+}
+
+// Tenant returns the value of the "tenant" payload attribute.
+func (p *tenantBPayload) Tenant() *string {
+	v := p.payload.Tenant
+	return &v
+}
+
+// SetTenant sets the value of the "tenant" payload attribute.
+func (p *tenantBPayload) SetTenant(v *string) {
+	if v != nil {
+		p.payload.Tenant = *v
+	}
+}
+`
+
+const ResultInterceptorCode = `// ServerInterceptors defines the interceptors that wrap the server endpoints
+// of the "ResultInterceptor" service. The interceptor methods must call next
+// to invoke the intercepted endpoint.
+type ServerInterceptors interface {
+	// Redact intercepts the "ResultInterceptor" service endpoints.
+	Redact(ctx context.Context, info *RedactInfo, next goa.Endpoint) (interface{}, error)
+	// Log intercepts the "ResultInterceptor" service endpoints.
+	Log(ctx context.Context, info *LogInfo, next goa.Endpoint) (interface{}, error)
+}
+
+// ClientInterceptors defines the interceptors that wrap the client endpoints
+// of the "ResultInterceptor" service. The interceptor methods must call next
+// to invoke the intercepted endpoint.
+type ClientInterceptors interface {
+	// Redact intercepts the "ResultInterceptor" service endpoints.
+	Redact(ctx context.Context, info *RedactInfo, next goa.Endpoint) (interface{}, error)
+	// Log intercepts the "ResultInterceptor" service endpoints.
+	Log(ctx context.Context, info *LogInfo, next goa.Endpoint) (interface{}, error)
+}
+
+// RedactInfo provides the Redact interceptor with information about the
+// intercepted request.
+type RedactInfo struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the method.
+	Method string
+	// RawPayload is the method payload.
+	RawPayload interface{}
+}
+
+// RedactResult provides type safe access to the result attributes used by the
+// Redact interceptor.
+type RedactResult interface {
+	Count() int
+	SetSecret(*string)
+}
+
+// Result returns the accessor for the given result of the intercepted method,
+// nil if the result is nil or if the method result does not define the
+// attributes used by the interceptor. The server endpoints of methods that
+// return result types return the viewed result, the accessor then accesses
+// the projected result in which the attributes not rendered by the view are
+// nil.
+func (info *RedactInfo) Result(res interface{}) RedactResult {
+	switch info.Method {
+	case "A":
+		r, ok := res.(*AResult)
+		if !ok || r == nil {
+			return nil
+		}
+		return &redactAResult{result: r}
+	case "C":
+		switch r := res.(type) {
+		case *Cresult:
+			if r != nil {
+				return &redactCResult{result: r}
+			}
+		case *resultinterceptorviews.Cresult:
+			if r != nil && r.Projected != nil {
+				return &redactCProjectedResult{result: r}
+			}
+		}
+	}
+	return nil
+}
+
+// LogInfo provides the Log interceptor with information about the intercepted
+// request.
+type LogInfo struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the method.
+	Method string
+	// RawPayload is the method payload.
+	RawPayload interface{}
+}
+
+// WrapAServerEndpoint wraps the "A" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapAServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapRedact(endpoint, i.Redact, "A")
+	return endpoint
+}
+
+// WrapAClientEndpoint wraps the "A" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapAClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapRedact(endpoint, i.Redact, "A")
+	return endpoint
+}
+
+// WrapBServerEndpoint wraps the "B" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapBServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapLog(endpoint, i.Log, "B")
+	endpoint = wrapRedact(endpoint, i.Redact, "B")
+	return endpoint
+}
+
+// WrapBClientEndpoint wraps the "B" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapBClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapLog(endpoint, i.Log, "B")
+	endpoint = wrapRedact(endpoint, i.Redact, "B")
+	return endpoint
+}
+
+// WrapCServerEndpoint wraps the "C" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapCServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapRedact(endpoint, i.Redact, "C")
+	return endpoint
+}
+
+// WrapCClientEndpoint wraps the "C" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapCClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapRedact(endpoint, i.Redact, "C")
+	return endpoint
+}
+
+// wrapRedact applies the Redact interceptor method intercept to endpoint.
+func wrapRedact(endpoint goa.Endpoint, intercept func(context.Context, *RedactInfo, goa.Endpoint) (interface{}, error), method string) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		info := &RedactInfo{
+			Service:    "ResultInterceptor",
+			Method:     method,
+			RawPayload: req,
+		}
+		return intercept(ctx, info, endpoint)
+	}
+}
+
+// redactAResult implements the result accessor for the "A" method.
+type redactAResult struct {
+	result *AResult
+}
+
+// Count returns the value of the "count" result attribute.
+func (r *redactAResult) Count() int {
+	return r.result.Count
+}
+
+// SetSecret sets the value of the "secret" result attribute.
+func (r *redactAResult) SetSecret(v *string) {
+	r.result.Secret = v
+}
+
+// redactCResult implements the result accessor for the "C" method.
+type redactCResult struct {
+	result *Cresult
+}
+
+// Count returns the value of the "count" result attribute.
+func (r *redactCResult) Count() int {
+	return r.result.Count
+}
+
+// SetSecret sets the value of the "secret" result attribute.
+func (r *redactCResult) SetSecret(v *string) {
+	r.result.Secret = v
+}
+
+// redactCProjectedResult implements the result accessor for the projected
+// result returned by the "C" server endpoint.
+type redactCProjectedResult struct {
+	result *resultinterceptorviews.Cresult
+}
+
+// Count returns the value of the "count" result attribute.
+func (r *redactCProjectedResult) Count() int {
+	if r.result.Projected.Count == nil {
+		var zero int
+		return zero
+	}
+	return *r.result.Projected.Count
+}
+
+// SetSecret sets the value of the "secret" result attribute.
+func (r *redactCProjectedResult) SetSecret(v *string) {
+	r.result.Projected.Secret = v
+}
+
+// wrapLog applies the Log interceptor method intercept to endpoint.
+func wrapLog(endpoint goa.Endpoint, intercept func(context.Context, *LogInfo, goa.Endpoint) (interface{}, error), method string) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		info := &LogInfo{
+			Service:    "ResultInterceptor",
+			Method:     method,
+			RawPayload: req,
+		}
+		return intercept(ctx, info, endpoint)
+	}
+}
+`
+
+const APIInterceptorCode = `// ServerInterceptors defines the interceptors that wrap the server endpoints
+// of the "APIInterceptor" service. The interceptor methods must call next to
+// invoke the intercepted endpoint.
+type ServerInterceptors interface {
+	// Audit intercepts the "APIInterceptor" service endpoints.
+	Audit(ctx context.Context, info *AuditInfo, next goa.Endpoint) (interface{}, error)
+	// Log intercepts the "APIInterceptor" service endpoints.
+	Log(ctx context.Context, info *LogInfo, next goa.Endpoint) (interface{}, error)
+	// Trace intercepts the "APIInterceptor" service endpoints.
+	Trace(ctx context.Context, info *TraceInfo, next goa.Endpoint) (interface{}, error)
+}
+
+// ClientInterceptors defines the interceptors that wrap the client endpoints
+// of the "APIInterceptor" service. The interceptor methods must call next to
+// invoke the intercepted endpoint.
+type ClientInterceptors interface {
+	// Audit intercepts the "APIInterceptor" service endpoints.
+	Audit(ctx context.Context, info *AuditInfo, next goa.Endpoint) (interface{}, error)
+	// Log intercepts the "APIInterceptor" service endpoints.
+	Log(ctx context.Context, info *LogInfo, next goa.Endpoint) (interface{}, error)
+	// Trace intercepts the "APIInterceptor" service endpoints.
+	Trace(ctx context.Context, info *TraceInfo, next goa.Endpoint) (interface{}, error)
+}
+
+// AuditInfo provides the Audit interceptor with information about the
+// intercepted request.
+type AuditInfo struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the method.
+	Method string
+	// RawPayload is the method payload.
+	RawPayload interface{}
+}
+
+// AuditPayload provides type safe access to the payload attributes used by the
+// Audit interceptor.
+type AuditPayload interface {
+	User() *string
+}
+
+// Payload returns the accessor for the payload of the intercepted method, nil
+// if the payload is nil or if the method payload does not define the
+// attributes used by the interceptor.
+func (info *AuditInfo) Payload() AuditPayload {
+	switch info.Method {
+	case "A":
+		p, ok := info.RawPayload.(*APayload)
+		if !ok || p == nil {
+			return nil
+		}
+		return &auditAPayload{payload: p}
+	}
+	return nil
+}
+
+// LogInfo provides the Log interceptor with information about the intercepted
+// request.
+type LogInfo struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the method.
+	Method string
+	// RawPayload is the method payload.
+	RawPayload interface{}
+}
+
+// TraceInfo provides the Trace interceptor with information about the
+// intercepted request.
+type TraceInfo struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the method.
+	Method string
+	// RawPayload is the method payload.
+	RawPayload interface{}
+}
+
+// WrapAServerEndpoint wraps the "A" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapAServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapTrace(endpoint, i.Trace, "A")
+	endpoint = wrapLog(endpoint, i.Log, "A")
+	endpoint = wrapAudit(endpoint, i.Audit, "A")
+	return endpoint
+}
+
+// WrapAClientEndpoint wraps the "A" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapAClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapTrace(endpoint, i.Trace, "A")
+	endpoint = wrapLog(endpoint, i.Log, "A")
+	endpoint = wrapAudit(endpoint, i.Audit, "A")
+	return endpoint
+}
+
+// WrapCServerEndpoint wraps the "C" server endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapCServerEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapLog(endpoint, i.Log, "C")
+	endpoint = wrapAudit(endpoint, i.Audit, "C")
+	return endpoint
+}
+
+// WrapCClientEndpoint wraps the "C" client endpoint with the interceptors that
+// apply to the method. The endpoint is returned unchanged if i is nil.
+func WrapCClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	if i == nil {
+		return endpoint
+	}
+	endpoint = wrapLog(endpoint, i.Log, "C")
+	endpoint = wrapAudit(endpoint, i.Audit, "C")
+	return endpoint
+}
+
+// wrapAudit applies the Audit interceptor method intercept to endpoint.
+func wrapAudit(endpoint goa.Endpoint, intercept func(context.Context, *AuditInfo, goa.Endpoint) (interface{}, error), method string) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		info := &AuditInfo{
+			Service:    "APIInterceptor",
+			Method:     method,
+			RawPayload: req,
+		}
+		return intercept(ctx, info, endpoint)
+	}
+}
+
+// auditAPayload implements the payload accessor for the "A" method.
+type auditAPayload struct {
+	payload *APayload
+}
+
+// User returns the value of the "user" payload attribute.
+func (p *auditAPayload) User() *string {
+	return p.payload.User
+}
+
+// wrapLog applies the Log interceptor method intercept to endpoint.
+func wrapLog(endpoint goa.Endpoint, intercept func(context.Context, *LogInfo, goa.Endpoint) (interface{}, error), method string) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		info := &LogInfo{
+			Service:    "APIInterceptor",
+			Method:     method,
+			RawPayload: req,
+		}
+		return intercept(ctx, info, endpoint)
+	}
+}
+
+// wrapTrace applies the Trace interceptor method intercept to endpoint.
+func wrapTrace(endpoint goa.Endpoint, intercept func(context.Context, *TraceInfo, goa.Endpoint) (interface{}, error), method string) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		info := &TraceInfo{
+			Service:    "APIInterceptor",
+			Method:     method,
+			RawPayload: req,
+		}
+		return intercept(ctx, info, endpoint)
+	}
+}
+`
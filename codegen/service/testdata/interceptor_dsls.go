@@ -0,0 +1,109 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var NoPayloadInterceptorDSL = func() {
+	Service("NoPayloadInterceptor", func() {
+		Interceptor("Log")
+		Method("A", func() {
+			Payload(String)
+		})
+		Method("B", func() {
+			Result(String)
+		})
+	})
+}
+
+var PayloadInterceptorDSL = func() {
+	Service("PayloadInterceptor", func() {
+		Interceptor("Tenant", func() {
+			Description("Tenant sets the tenant ID.")
+			ReadPayload(func() {
+				Attribute("tenant", String)
+			})
+			WritePayload(func() {
+				Attribute("tenant", String)
+			})
+		})
+		Method("A", func() {
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+		})
+		Method("B", func() {
+			Payload(func() {
+				Attribute("tenant", String)
+				Required("tenant")
+			})
+		})
+	})
+}
+
+var ResultInterceptorDSL = func() {
+	var AResult = Type("AResult", func() {
+		Attribute("count", Int)
+		Attribute("secret", String)
+		Required("count")
+	})
+	var CResult = ResultType("application/vnd.cresult", func() {
+		Attribute("count", Int)
+		Attribute("secret", String)
+		Required("count")
+		View("default", func() {
+			Attribute("count")
+			Attribute("secret")
+		})
+		View("tiny", func() {
+			Attribute("count")
+		})
+	})
+	Service("ResultInterceptor", func() {
+		Interceptor("Redact", func() {
+			ReadResult(func() {
+				Attribute("count", Int)
+				Required("count")
+			})
+			WriteResult(func() {
+				Attribute("secret", String)
+			})
+		})
+		Method("A", func() {
+			Result(AResult)
+		})
+		Method("B", func() {
+			Interceptor("Log")
+		})
+		Method("C", func() {
+			Result(CResult)
+		})
+	})
+}
+
+var APIInterceptorDSL = func() {
+	API("api", func() {
+		Interceptor("Audit", func() {
+			ReadPayload(func() {
+				Attribute("user", String)
+			})
+		})
+	})
+	Service("APIInterceptor", func() {
+		Interceptor("Log")
+		Method("A", func() {
+			Interceptor("Trace")
+			Payload(func() {
+				Attribute("user", String)
+			})
+		})
+		Method("C", func() {
+			Payload(func() {
+				Attribute("id", Int)
+			})
+		})
+		Method("B", func() {
+			StreamingPayload(String)
+		})
+	})
+}
@@ -2195,3 +2195,122 @@ func newMultipleViewsViewTiny(res *MultipleViews) *bidirectionalstreamingresultw
 	return vres
 }
 `
+
+const ViewSelectorMethod = `
+// Service is the ViewSelectorService service interface.
+type Service interface {
+	// ViewSelectorMethod implements ViewSelectorMethod.
+	// The view argument is the view selected by the client to render the result.
+	ViewSelectorMethod(context.Context, *APayload, ViewSelectorMethodView) (res *MultipleViews, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "ViewSelectorService"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"ViewSelectorMethod"}
+
+// APayload is the payload type of the ViewSelectorService service
+// ViewSelectorMethod method.
+type APayload struct {
+}
+
+// MultipleViews is the result type of the ViewSelectorService service
+// ViewSelectorMethod method.
+type MultipleViews struct {
+	A *string
+	B *string
+}
+
+// ViewSelectorMethodView is the view selected by the client to render the
+// "ViewSelectorMethod" method result.
+type ViewSelectorMethodView string
+
+const (
+	// ViewSelectorMethodViewDefault selects the "default" view.
+	ViewSelectorMethodViewDefault ViewSelectorMethodView = "default"
+	// ViewSelectorMethodViewTiny selects the "tiny" view. Tiny renders a only.
+	ViewSelectorMethodViewTiny ViewSelectorMethodView = "tiny"
+)
+
+// ParseViewSelectorMethodView returns the ViewSelectorMethodView with the
+// given name. It returns a validation error if the "ViewSelectorMethod" method
+// result does not define the view.
+func ParseViewSelectorMethodView(name string) (ViewSelectorMethodView, error) {
+	switch v := ViewSelectorMethodView(name); v {
+	case ViewSelectorMethodViewDefault, ViewSelectorMethodViewTiny:
+		return v, nil
+	}
+	return "", goa.InvalidEnumValueError("view", name, []interface{}{"default", "tiny"})
+}
+
+// NewMultipleViews initializes result type MultipleViews from viewed result
+// type MultipleViews.
+func NewMultipleViews(vres *viewselectorserviceviews.MultipleViews) *MultipleViews {
+	var res *MultipleViews
+	switch vres.View {
+	case "default", "":
+		res = newMultipleViews(vres.Projected)
+	case "tiny":
+		res = newMultipleViewsTiny(vres.Projected)
+	}
+	return res
+}
+
+// NewViewedMultipleViews initializes viewed result type MultipleViews from
+// result type MultipleViews using the given view.
+func NewViewedMultipleViews(res *MultipleViews, view string) *viewselectorserviceviews.MultipleViews {
+	var vres *viewselectorserviceviews.MultipleViews
+	switch view {
+	case "default", "":
+		p := newMultipleViewsView(res)
+		vres = &viewselectorserviceviews.MultipleViews{p, "default"}
+	case "tiny":
+		p := newMultipleViewsViewTiny(res)
+		vres = &viewselectorserviceviews.MultipleViews{p, "tiny"}
+	}
+	return vres
+}
+
+// newMultipleViews converts projected type MultipleViews to service type
+// MultipleViews.
+func newMultipleViews(vres *viewselectorserviceviews.MultipleViewsView) *MultipleViews {
+	res := &MultipleViews{
+		A: vres.A,
+		B: vres.B,
+	}
+	return res
+}
+
+// newMultipleViewsTiny converts projected type MultipleViews to service type
+// MultipleViews.
+func newMultipleViewsTiny(vres *viewselectorserviceviews.MultipleViewsView) *MultipleViews {
+	res := &MultipleViews{
+		A: vres.A,
+	}
+	return res
+}
+
+// newMultipleViewsView projects result type MultipleViews to projected type
+// MultipleViewsView using the "default" view.
+func newMultipleViewsView(res *MultipleViews) *viewselectorserviceviews.MultipleViewsView {
+	vres := &viewselectorserviceviews.MultipleViewsView{
+		A: res.A,
+		B: res.B,
+	}
+	return vres
+}
+
+// newMultipleViewsViewTiny projects result type MultipleViews to projected
+// type MultipleViewsView using the "tiny" view.
+func newMultipleViewsViewTiny(res *MultipleViews) *viewselectorserviceviews.MultipleViewsView {
+	vres := &viewselectorserviceviews.MultipleViewsView{
+		A: res.A,
+	}
+	return vres
+}
+`
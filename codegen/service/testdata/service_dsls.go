@@ -529,12 +529,12 @@ var NamesWithSpacesDSL = func() {
 		})
 	})
 	var APayload = Type("Payload With Space", func() {
-		Attribute("String", String)
+		Field(1, "String", String)
 	})
 	var AResult = ResultType("application/vnd.goa.result", func() {
 		TypeName("Result With Space")
 		Attributes(func() {
-			Attribute("Int", Int)
+			Field(1, "Int", Int)
 		})
 	})
 	Service("Service With Spaces", func() {
@@ -548,3 +548,28 @@ var NamesWithSpacesDSL = func() {
 		})
 	})
 }
+
+var ViewSelectorMethodDSL = func() {
+	var RTWithViews = ResultType("application/vnd.result.multiple.views", func() {
+		TypeName("MultipleViews")
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", String)
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Description("Tiny renders a only.")
+			Attribute("a")
+		})
+	})
+	Service("ViewSelectorService", func() {
+		Method("ViewSelectorMethod", func() {
+			Payload(APayload)
+			Result(RTWithViews)
+			ViewSelector()
+		})
+	})
+}
@@ -29,6 +29,7 @@ func ViewsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		header := codegen.Header(service.Name+" views", "views",
 			[]*codegen.ImportSpec{
 				codegen.GoaImport(""),
+				{Path: "fmt"},
 				{Path: "unicode/utf8"},
 			})
 		sections = []*codegen.SectionTemplate{header}
@@ -212,5 +212,14 @@ var TestTypesDSL = func() {
 			})
 			Required("required_int", "required_string", "required_bytes", "required_any", "required_array", "required_map")
 		})
+
+		_ = Type("WithUnion", func() {
+			Attribute("name", String)
+			OneOf("value", func() {
+				Attribute("string", String)
+				Attribute("int", Int)
+				Attribute("simple", Simple)
+			})
+		})
 	)
 }
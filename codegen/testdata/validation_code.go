@@ -179,17 +179,17 @@ const (
 	}
 	if target.RequiredInteger != nil {
 		if err2 := ValidateInteger(target.RequiredInteger); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.required_integer"))
 		}
 	}
 	if target.DefaultString != nil {
 		if err2 := ValidateString(target.DefaultString); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.default_string"))
 		}
 	}
 	if target.Float != nil {
 		if err2 := ValidateFloat(target.Float); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.float"))
 		}
 	}
 }
@@ -201,17 +201,17 @@ const (
 	}
 	if target.RequiredInteger != nil {
 		if err2 := ValidateInteger(target.RequiredInteger); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.required_integer"))
 		}
 	}
 	if target.DefaultString != nil {
 		if err2 := ValidateString(target.DefaultString); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.default_string"))
 		}
 	}
 	if target.Float != nil {
 		if err2 := ValidateFloat(target.Float); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.float"))
 		}
 	}
 }
@@ -222,27 +222,27 @@ const (
 	}
 	if target.RequiredInteger != nil {
 		if err2 := ValidateInteger(target.RequiredInteger); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.required_integer"))
 		}
 	}
 	if target.DefaultString != nil {
 		if err2 := ValidateString(target.DefaultString); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.default_string"))
 		}
 	}
 	if target.Float != nil {
 		if err2 := ValidateFloat(target.Float); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.float"))
 		}
 	}
 }
 `
 
 	UserTypeArrayValidationCode = `func Validate() (err error) {
-	for _, e := range target.Array {
+	for i, e := range target.Array {
 		if e != nil {
 			if err2 := ValidateFloat(e); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestedError(err2, fmt.Sprintf("target.array[%v]", i)))
 			}
 		}
 	}
@@ -259,9 +259,9 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
+	for i, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("target.array[%v]", i), e, []interface{}{0, 1, 1, 2, 3, 5}))
 		}
 	}
 }
@@ -277,9 +277,9 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
+	for i, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("target.array[%v]", i), e, []interface{}{0, 1, 1, 2, 3, 5}))
 		}
 	}
 }
@@ -295,9 +295,9 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
+	for i, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("target.array[%v]", i), e, []interface{}{0, 1, 1, 2, 3, 5}))
 		}
 	}
 }
@@ -316,7 +316,7 @@ const (
 	for k, v := range target.Map {
 		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
 		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("target.map[%v]", k), v, 5, false))
 		}
 	}
 }
@@ -335,7 +335,7 @@ const (
 	for k, v := range target.Map {
 		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
 		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("target.map[%v]", k), v, 5, false))
 		}
 	}
 }
@@ -354,7 +354,119 @@ const (
 	for k, v := range target.Map {
 		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
 		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("target.map[%v]", k), v, 5, false))
+		}
+	}
+}
+`
+
+	NumberRequiredValidationCode = `func Validate() (err error) {
+	if target.RequiredInteger <= 0 {
+		err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.required_integer", target.RequiredInteger, 0, true))
+	}
+	if target.RequiredInteger%5 != 0 {
+		err = goa.MergeErrors(err, goa.InvalidMultipleOfError("target.required_integer", target.RequiredInteger, 5))
+	}
+	if target.Float != nil {
+		if *target.Float >= 1.5 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.float", *target.Float, 1.5, false))
+		}
+	}
+	if target.Float != nil {
+		err = goa.MergeErrors(err, goa.ValidateMultipleOf("target.float", float64(*target.Float), 0.5))
+	}
+	err = goa.MergeErrors(err, goa.ValidateUniqueItems("target.array", target.Array))
+}
+`
+
+	NumberPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredInteger == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_integer", "target"))
+	}
+	if target.RequiredInteger != nil {
+		if *target.RequiredInteger <= 0 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.required_integer", *target.RequiredInteger, 0, true))
+		}
+	}
+	if target.RequiredInteger != nil {
+		if *target.RequiredInteger%5 != 0 {
+			err = goa.MergeErrors(err, goa.InvalidMultipleOfError("target.required_integer", *target.RequiredInteger, 5))
+		}
+	}
+	if target.Float != nil {
+		if *target.Float >= 1.5 {
+			err = goa.MergeErrors(err, goa.InvalidExclusiveRangeError("target.float", *target.Float, 1.5, false))
+		}
+	}
+	if target.Float != nil {
+		err = goa.MergeErrors(err, goa.ValidateMultipleOf("target.float", float64(*target.Float), 0.5))
+	}
+	err = goa.MergeErrors(err, goa.ValidateUniqueItems("target.array", target.Array))
+}
+`
+
+	CustomFormatRequiredValidationCode = `func Validate() (err error) {
+	if err2 := formats.ValidateULID(target.RequiredUlid); err2 != nil {
+		err = goa.MergeErrors(err, goa.InvalidFormatError("target.required_ulid", target.RequiredUlid, "ulid", err2))
+	}
+	if target.Ulid != nil {
+		if err2 := formats.ValidateULID(*target.Ulid); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFormatError("target.ulid", *target.Ulid, "ulid", err2))
+		}
+	}
+}
+`
+
+	CustomFormatPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredUlid == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_ulid", "target"))
+	}
+	if target.RequiredUlid != nil {
+		if err2 := formats.ValidateULID(*target.RequiredUlid); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFormatError("target.required_ulid", *target.RequiredUlid, "ulid", err2))
+		}
+	}
+	if target.Ulid != nil {
+		if err2 := formats.ValidateULID(*target.Ulid); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFormatError("target.ulid", *target.Ulid, "ulid", err2))
+		}
+	}
+}
+`
+
+	UnionRequiredValidationCode = `func Validate() (err error) {
+	if target.Value == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("value", "target"))
+	}
+	if target.Value != nil {
+		if err2 := ValidateUnionValue(target.Value); err2 != nil {
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.value"))
+		}
+	}
+}
+`
+
+	UnionValueValidationCode = `func Validate() (err error) {
+	{
+		var n int
+		if target.Integer != nil {
+			n++
+		}
+		if target.String != nil {
+			n++
+		}
+		if n != 1 {
+			err = goa.MergeErrors(err, goa.InvalidUnionError("target", []string{"integer", "string"}, n))
+		}
+	}
+	if target.Integer != nil {
+		if err2 := ValidateInteger(target.Integer); err2 != nil {
+			err = goa.MergeErrors(err, goa.NestedError(err2, "target.integer"))
+		}
+	}
+	if target.String != nil {
+		if utf8.RuneCountInString(*target.String) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("target.string", *target.String, utf8.RuneCountInString(*target.String), 1, true))
 		}
 	}
 }
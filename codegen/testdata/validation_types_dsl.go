@@ -3,6 +3,8 @@ package testdata
 import . "goa.design/goa/v3/dsl"
 
 var ValidationTypesDSL = func() {
+	var ULID = RegisterFormat("ulid", "formats.ValidateULID", "example.com/formats")
+
 	var (
 		IntegerT = Type("Integer", func() {
 			Attribute("required_integer", Int, func() {
@@ -95,5 +97,40 @@ var ValidationTypesDSL = func() {
 			})
 			Required("required_map")
 		})
+
+		_ = Type("Number", func() {
+			Attribute("required_integer", Int, func() {
+				ExclusiveMinimum(0)
+				MultipleOf(5)
+			})
+			Attribute("float", Float64, func() {
+				ExclusiveMaximum(1.5)
+				MultipleOf(0.5)
+			})
+			Attribute("array", ArrayOf(String), func() {
+				UniqueItems()
+			})
+			Required("required_integer")
+		})
+
+		_ = Type("CustomFormat", func() {
+			Attribute("required_ulid", String, func() {
+				Format(ULID)
+			})
+			Attribute("ulid", String, func() {
+				Format(ULID)
+			})
+			Required("required_ulid")
+		})
+
+		_ = Type("Union", func() {
+			OneOf("value", func() {
+				Attribute("integer", IntegerT)
+				Attribute("string", String, func() {
+					MinLength(1)
+				})
+			})
+			Required("value")
+		})
 	)
 }
@@ -11,15 +11,18 @@ import (
 )
 
 var (
-	enumValT     *template.Template
-	formatValT   *template.Template
-	patternValT  *template.Template
-	minMaxValT   *template.Template
-	lengthValT   *template.Template
-	requiredValT *template.Template
-	arrayValT    *template.Template
-	mapValT      *template.Template
-	userValT     *template.Template
+	enumValT        *template.Template
+	formatValT      *template.Template
+	patternValT     *template.Template
+	minMaxValT      *template.Template
+	multipleOfValT  *template.Template
+	lengthValT      *template.Template
+	uniqueItemsValT *template.Template
+	requiredValT    *template.Template
+	arrayValT       *template.Template
+	mapValT         *template.Template
+	userValT        *template.Template
+	unionValT       *template.Template
 )
 
 func init() {
@@ -29,16 +32,20 @@ func init() {
 		"constant": constant,
 		"add":      func(a, b int) int { return a + b },
 		"isset":    func(i interface{}) bool { return i != nil },
+		"context":  contextCode,
 	}
 	enumValT = template.Must(template.New("enum").Funcs(fm).Parse(enumValTmpl))
 	formatValT = template.Must(template.New("format").Funcs(fm).Parse(formatValTmpl))
 	patternValT = template.Must(template.New("pattern").Funcs(fm).Parse(patternValTmpl))
 	minMaxValT = template.Must(template.New("minMax").Funcs(fm).Parse(minMaxValTmpl))
+	multipleOfValT = template.Must(template.New("multipleOf").Funcs(fm).Parse(multipleOfValTmpl))
 	lengthValT = template.Must(template.New("length").Funcs(fm).Parse(lengthValTmpl))
+	uniqueItemsValT = template.Must(template.New("uniqueItems").Funcs(fm).Parse(uniqueItemsValTmpl))
 	requiredValT = template.Must(template.New("req").Funcs(fm).Parse(requiredValTmpl))
 	arrayValT = template.Must(template.New("array").Funcs(fm).Parse(arrayValTmpl))
 	mapValT = template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl))
 	userValT = template.Must(template.New("user").Funcs(fm).Parse(userValTmpl))
+	unionValT = template.Must(template.New("union").Funcs(fm).Parse(unionValTmpl))
 }
 
 // ValidationCode produces Go code that runs the validations defined in the
@@ -53,7 +60,10 @@ func init() {
 //
 // target is the variable name against which the validation code is generated
 //
-// context is used to produce helpful messages in case of error.
+// context is used to produce helpful messages in case of error. The contexts
+// of array elements and map values computed by RecursiveValidationCode embed
+// the loop variables holding the element index and map key so that the
+// generated code reports the actual index and key of the failing element.
 //
 func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target, context string) string {
 	validation := att.Validation
@@ -62,8 +72,12 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			validation = ut.Attribute().Validation
 		}
 	}
+	union := expr.AsUnion(att.Type)
 	if validation == nil {
-		return ""
+		if union == nil {
+			return ""
+		}
+		validation = &expr.ValidationExpr{}
 	}
 	var (
 		kind            = att.Type.Kind()
@@ -102,6 +116,7 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 	}
 	if format := validation.Format; format != "" {
 		data["format"] = string(format)
+		data["customFormat"] = expr.Root.Format(format)
 		if val := runTemplate(formatValT, data); val != "" {
 			res = append(res, val)
 		}
@@ -128,6 +143,31 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			res = append(res, val)
 		}
 	}
+	if min := validation.ExclusiveMinimum; min != nil {
+		data["min"] = *min
+		data["isMin"] = true
+		data["isExclusive"] = true
+		delete(data, "max")
+		if val := runTemplate(minMaxValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	if max := validation.ExclusiveMaximum; max != nil {
+		data["max"] = *max
+		data["isMin"] = false
+		data["isExclusive"] = true
+		delete(data, "min")
+		if val := runTemplate(minMaxValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	if multipleOf := validation.MultipleOf; multipleOf != nil {
+		data["multipleOf"] = *multipleOf
+		data["isInteger"] = kind != expr.Float32Kind && kind != expr.Float64Kind
+		if val := runTemplate(multipleOfValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
 	if minLength := validation.MinLength; minLength != nil {
 		data["minLength"] = minLength
 		data["isMinLength"] = true
@@ -144,6 +184,11 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			res = append(res, val)
 		}
 	}
+	if validation.UniqueItems {
+		if val := runTemplate(uniqueItemsValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
 	if req := validation.Required; len(req) > 0 {
 		obj := expr.AsObject(att.Type)
 		for _, r := range req {
@@ -162,6 +207,16 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			res = append(res, runTemplate(requiredValT, data))
 		}
 	}
+	if union != nil {
+		var fields, names []string
+		for _, nat := range *union.Values {
+			fields = append(fields, attCtx.Scope.Field(nat.Attribute, nat.Name, true))
+			names = append(names, nat.Name)
+		}
+		data["fields"] = fields
+		data["names"] = names
+		res = append(res, runTemplate(unionValT, data))
+	}
 	return strings.Join(res, "\n")
 }
 
@@ -198,11 +253,12 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 		first = false
 	}
 
-	runUserValT := func(name, target string) string {
+	runUserValT := func(name, target, context string) string {
 		var buf bytes.Buffer
 		data := map[string]interface{}{
-			"name":   Goify(name, true),
-			"target": target,
+			"name":    Goify(name, true),
+			"target":  target,
+			"context": context,
 		}
 		if err := userValT.Execute(&buf, data); err != nil {
 			panic(err) // bug
@@ -228,15 +284,18 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 			ctx = attCtx.Dup()
 			ctx.Pointer = false
 		}
-		val := recurseValidationCode(a.ElemType, ctx, true, "e", context+"[*]", seen).String()
+		index := loopVar("i", context)
+		elemCtx := context + "[" + contextVar(index) + "]"
+		val := recurseValidationCode(a.ElemType, ctx, true, "e", elemCtx, seen).String()
 		if val != "" {
 			switch a.ElemType.Type.(type) {
 			case expr.UserType:
 				// For user and result types, call the Validate method
-				val = runUserValT(attCtx.Scope.Name(a.ElemType, ctx.Pkg), "e")
+				val = runUserValT(attCtx.Scope.Name(a.ElemType, ctx.Pkg), "e", elemCtx)
 			}
 			data := map[string]interface{}{
 				"target":     target,
+				"index":      index,
 				"validation": val,
 			}
 			if !first {
@@ -251,25 +310,28 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 	} else if m := expr.AsMap(att.Type); m != nil {
 		ctx := attCtx.Dup()
 		ctx.Pointer = false
-		keyVal := recurseValidationCode(m.KeyType, ctx, true, "k", context+".key", seen).String()
-		valueVal := recurseValidationCode(m.ElemType, ctx, true, "v", context+"[key]", seen).String()
+		key := loopVar("k", context)
+		valueCtx := context + "[" + contextVar(key) + "]"
+		keyVal := recurseValidationCode(m.KeyType, ctx, true, key, context+".key", seen).String()
+		valueVal := recurseValidationCode(m.ElemType, ctx, true, "v", valueCtx, seen).String()
 		if keyVal != "" || valueVal != "" {
 			if keyVal != "" {
 				if _, ok := m.KeyType.Type.(expr.UserType); ok {
-					keyVal = runUserValT(ctx.Scope.Name(m.KeyType, ctx.Pkg), "k")
+					keyVal = runUserValT(ctx.Scope.Name(m.KeyType, ctx.Pkg), key, context+".key")
 				} else {
 					keyVal = "\n" + keyVal
 				}
 			}
 			if valueVal != "" {
 				if _, ok := m.ElemType.Type.(expr.UserType); ok {
-					valueVal = runUserValT(ctx.Scope.Name(m.ElemType, ctx.Pkg), "v")
+					valueVal = runUserValT(ctx.Scope.Name(m.ElemType, ctx.Pkg), "v", valueCtx)
 				} else {
 					valueVal = "\n" + valueVal
 				}
 			}
 			data := map[string]interface{}{
 				"target":          target,
+				"key":             key,
 				"keyValidation":   keyVal,
 				"valueValidation": valueVal,
 			}
@@ -292,7 +354,8 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 		// We need to check empirically whether there are validations to be
 		// generated, we can't just generate and check whether something was
 		// generated to avoid infinite recursions.
-		hasValidations := attCtx.Pointer && ut.Attribute().Validation != nil
+		hasValidations := attCtx.Pointer && ut.Attribute().Validation != nil ||
+			expr.IsUnion(ut) // unions always validate that exactly one value is set
 		if !hasValidations {
 			done := errors.New("done")
 			Walk(ut.Attribute(), func(a *expr.AttributeExpr) error {
@@ -328,7 +391,12 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 			if expr.IsArray(nat.Attribute.Type) {
 				buf.Write(recurseValidationCode(nat.Attribute, attCtx, att.IsRequired(nat.Name), tgt, context, seen).Bytes())
 			} else {
-				if err := userValT.Execute(&buf, map[string]interface{}{"name": Goify(attCtx.Scope.Name(nat.Attribute, attCtx.Pkg), true), "target": tgt}); err != nil {
+				data := map[string]interface{}{
+					"name":    Goify(attCtx.Scope.Name(nat.Attribute, attCtx.Pkg), true),
+					"target":  tgt,
+					"context": fmt.Sprintf("%s.%s", context, nat.Name),
+				}
+				if err := userValT.Execute(&buf, data); err != nil {
 					panic(err) // bug
 				}
 			}
@@ -353,6 +421,47 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 	return validation
 }
 
+// contextVar returns the placeholder used to embed the loop variable with the
+// given name in a validation context.
+func contextVar(name string) string {
+	return "\x00" + name + "\x00"
+}
+
+// loopVar returns the name of the loop variable used to iterate over the
+// array elements or map keys validated in the given context. The name is
+// suffixed with the nesting depth so that nested loops do not shadow the
+// variables referenced by the inner contexts.
+func loopVar(prefix, context string) string {
+	if depth := strings.Count(context, "\x00") / 2; depth > 0 {
+		return fmt.Sprintf("%s%d", prefix, depth)
+	}
+	return prefix
+}
+
+// contextCode returns the Go code that produces the given validation context.
+// The code is a string literal unless the context embeds loop variables in
+// which case it is a call to fmt.Sprintf, e.g. the context "body.items[i]"
+// where i is a loop variable produces 'fmt.Sprintf("body.items[%v]", i)'.
+func contextCode(context string) string {
+	parts := strings.Split(context, "\x00")
+	if len(parts) == 1 {
+		return fmt.Sprintf("%q", context)
+	}
+	var (
+		format string
+		vars   []string
+	)
+	for i, p := range parts {
+		if i%2 == 1 {
+			format += "%v"
+			vars = append(vars, p)
+			continue
+		}
+		format += strings.Replace(p, "%", "%%", -1)
+	}
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", format, strings.Join(vars, ", "))
+}
+
 // toSlice returns Go code that represents the given slice.
 func toSlice(val []interface{}) string {
 	elems := make([]string, len(val))
@@ -408,17 +517,17 @@ func constant(formatName string) string {
 }
 
 const (
-	arrayValTmpl = `for _, e := range {{ .target }} {
+	arrayValTmpl = `for {{ .index }}, e := range {{ .target }} {
 {{ .validation }}
 }`
 
-	mapValTmpl = `for {{if .keyValidation }}k{{ else }}_{{ end }}, {{ if .valueValidation }}v{{ else }}_{{ end }} := range {{ .target }} {
+	mapValTmpl = `for {{ .key }}, {{ if .valueValidation }}v{{ else }}_{{ end }} := range {{ .target }} {
 {{- .keyValidation }}
 {{- .valueValidation }}
 }`
 
 	userValTmpl = `if err2 := Validate{{ .name }}({{ .target }}); err2 != nil {
-        err = goa.MergeErrors(err, err2)
+        err = goa.MergeErrors(err, goa.NestedError(err2, {{ context .context }}))
 }`
 
 	enumValTmpl = `{{ if isset .zeroVal -}}
@@ -427,7 +536,7 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 if {{ .target }} != nil {
 {{ end -}}
 if !({{ oneof .targetVal .values }}) {
-        err = goa.MergeErrors(err, goa.InvalidEnumValueError({{ printf "%q" .context }}, {{ .targetVal }}, {{ slice .values }}))
+        err = goa.MergeErrors(err, goa.InvalidEnumValueError({{ context .context }}, {{ .targetVal }}, {{ slice .values }}))
 {{ if or (isset .zeroVal) .isPointer -}}
 }
 {{ end -}}
@@ -438,7 +547,7 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 {{ else if .isPointer -}}
 if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidatePattern({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}))
+        err = goa.MergeErrors(err, goa.ValidatePattern({{ context .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}))
 {{- if or (isset .zeroVal) .isPointer }}
 }
 {{- end }}`
@@ -448,8 +557,14 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 {{ else if .isPointer -}}
 if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidateFormat({{ printf "%q" .context }}, {{ .targetVal}}, {{ constant .format }}))
-{{ if or (isset .zeroVal) .isPointer -}}
+{{ if .customFormat -}}
+if err2 := {{ .customFormat.Function }}({{ .targetVal }}); err2 != nil {
+        err = goa.MergeErrors(err, goa.InvalidFormatError({{ context .context }}, {{ .targetVal }}, {{ printf "%q" .format }}, err2))
+}
+{{- else -}}
+        err = goa.MergeErrors(err, goa.ValidateFormat({{ context .context }}, {{ .targetVal}}, {{ constant .format }}))
+{{- end }}
+{{- if or (isset .zeroVal) .isPointer }}
 }
 {{- end }}`
 
@@ -458,13 +573,31 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 {{ else if .isPointer -}}
 if {{ .target }} != nil {
 {{ end -}}
-        if {{ .targetVal }} {{ if .isMin }}<{{ else }}>{{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
+        if {{ .targetVal }} {{ if .isMin }}<{{ else }}>{{ end }}{{ if .isExclusive }}={{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
+        err = goa.MergeErrors(err, goa.{{ if .isExclusive }}InvalidExclusiveRangeError{{ else }}InvalidRangeError{{ end }}({{ context .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
 {{ if or (isset .zeroVal) .isPointer -}}
 }
 {{ end -}}
 }`
 
+	multipleOfValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ .zeroVal }} {
+{{ else if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+{{ if .isInteger -}}
+        if {{ .targetVal }}%{{ .multipleOf }} != 0 {
+        err = goa.MergeErrors(err, goa.InvalidMultipleOfError({{ context .context }}, {{ .targetVal }}, {{ .multipleOf }}))
+}
+{{- else -}}
+        err = goa.MergeErrors(err, goa.ValidateMultipleOf({{ context .context }}, float64({{ .targetVal }}), {{ .multipleOf }}))
+{{- end }}
+{{- if or (isset .zeroVal) .isPointer }}
+}
+{{- end }}`
+
+	uniqueItemsValTmpl = `err = goa.MergeErrors(err, goa.ValidateUniqueItems({{ context .context }}, {{ .target }}))`
+
 	lengthValTmpl = `{{ $target := or (and (or (or .array .map) .nonzero) .target) .targetVal -}}
 {{ if and (isset .zeroVal) .string -}}
 if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }}{{ end }} {
@@ -472,12 +605,24 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 if {{ .target }} != nil {
 {{ end -}}
 if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }} {{ if .isMinLength }}<{{ else }}>{{ end }} {{ if .isMinLength }}{{ .minLength }}{{ else }}{{ .maxLength }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}))
+        err = goa.MergeErrors(err, goa.InvalidLengthError({{ context .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}))
 }{{- if and (or (isset .zeroVal) .isPointer) .string }}
 }
 {{- end }}`
 
+	unionValTmpl = `{
+	var n int
+{{- range .fields }}
+	if {{ $.target }}.{{ . }} != nil {
+		n++
+	}
+{{- end }}
+	if n != 1 {
+		err = goa.MergeErrors(err, goa.InvalidUnionError({{ context .context }}, {{ printf "%#v" .names }}, n))
+	}
+}`
+
 	requiredValTmpl = `if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
-        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}))
+        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ context $.context }}))
 }`
 )
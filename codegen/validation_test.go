@@ -19,6 +19,10 @@ func TestRecursiveValidationCode(t *testing.T) {
 		arrayUT  = root.UserType("ArrayUserType")
 		arrayT   = root.UserType("Array")
 		mapT     = root.UserType("Map")
+		numberT  = root.UserType("Number")
+		formatT  = root.UserType("CustomFormat")
+		unionT   = root.UserType("Union")
+		valueT   = expr.AsObject(unionT).Attribute("value").Type.(expr.UserType)
 	)
 	cases := []struct {
 		Name       string
@@ -47,6 +51,12 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"map-required", mapT, true, false, false, testdata.MapRequiredValidationCode},
 		{"map-pointer", mapT, false, true, false, testdata.MapPointerValidationCode},
 		{"map-use-default", mapT, false, false, true, testdata.MapUseDefaultValidationCode},
+		{"number-required", numberT, true, false, false, testdata.NumberRequiredValidationCode},
+		{"number-pointer", numberT, false, true, false, testdata.NumberPointerValidationCode},
+		{"custom-format-required", formatT, true, false, false, testdata.CustomFormatRequiredValidationCode},
+		{"custom-format-pointer", formatT, false, true, false, testdata.CustomFormatPointerValidationCode},
+		{"union-required", unionT, true, false, false, testdata.UnionRequiredValidationCode},
+		{"union-value", valueT, true, false, false, testdata.UnionValueValidationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -62,6 +62,12 @@ func walk(at *expr.AttributeExpr, walker func(*expr.AttributeExpr) error, seen m
 				return err
 			}
 		}
+	case *expr.Union:
+		for _, cat := range *actual.Values {
+			if err := walk(cat.Attribute, walker, seen); err != nil {
+				return err
+			}
+		}
 	case *expr.UserTypeExpr:
 		return walkUt(actual)
 	case *expr.ResultTypeExpr:
@@ -2,6 +2,7 @@ package dsl
 
 import (
 	"fmt"
+	"strings"
 
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
@@ -26,10 +27,12 @@ import (
 // * An object defined inline using Attribute to define the type fields
 // recursively.
 //
+// * A union defined using the OneOf function.
+//
 // * The special type Any to indicate that the attribute may take any of the
 // types listed above.
 //
-// Attribute must appear in ResultType, Type, Attribute or Attributes.
+// Attribute must appear in ResultType, Type, Attribute, Attributes or OneOf.
 //
 // Attribute accepts one to four arguments, the valid usages of the function
 // are:
@@ -130,7 +133,9 @@ func Attribute(name string, args ...interface{}) {
 		if parent.Type == nil {
 			parent.Type = &expr.Object{}
 		}
-		if _, ok := parent.Type.(*expr.Object); !ok {
+		switch parent.Type.(type) {
+		case *expr.Object, *expr.Union:
+		default:
 			eval.ReportError("can't define child attribute %#v on attribute of type %s", name, parent.Type.Name())
 			return
 		}
@@ -170,7 +175,128 @@ func Attribute(name string, args ...interface{}) {
 		}
 	}
 
-	parent.Type.(*expr.Object).Set(name, attr)
+	expr.AsObject(parent.Type).Set(name, attr)
+}
+
+// OneOf defines an attribute whose type is a union. A union value holds exactly
+// one of the attributes listed in the OneOf DSL. Unions are generated as
+// structs with one field per union value in Go, as tagged objects in JSON (the
+// single key is the name of the value) and as oneof fields in protobuf. The
+// name of the generated type is the name of the parent type (if any) followed
+// by the name of the attribute, "FigureShape" in the example below.
+//
+// OneOf must appear in ResultType, Type, Attribute or Attributes.
+//
+// OneOf accepts two or three arguments: the attribute name, an optional
+// description and the DSL listing the union values using Attribute. Union
+// values are always optional and may not define default values.
+//
+// Example:
+//
+//    var Figure = Type("Figure", func() {
+//        OneOf("shape", "Shape of figure", func() {
+//            Attribute("circle", Circle)
+//            Attribute("square", Square)
+//            Attribute("label", String)
+//        })
+//        Required("shape")
+//    })
+//
+func OneOf(name string, args ...interface{}) {
+	var (
+		parent *expr.AttributeExpr
+		tname  = strings.Title(name)
+	)
+	{
+		switch def := eval.Current().(type) {
+		case *expr.AttributeExpr:
+			parent = def
+		case expr.CompositeExpr:
+			parent = def.Attribute()
+		default:
+			eval.IncompatibleDSL()
+			return
+		}
+		if parent == nil {
+			eval.ReportError("invalid syntax, union %#v has no parent", name)
+			return
+		}
+		if ut := parentUserType(parent); ut != nil {
+			tname = ut.Name() + tname
+		}
+		if parent.Type == nil {
+			parent.Type = &expr.Object{}
+		}
+		if _, ok := parent.Type.(*expr.Object); !ok {
+			eval.ReportError("can't define union %#v on attribute of type %s", name, parent.Type.Name())
+			return
+		}
+	}
+
+	var (
+		description string
+		fn          func()
+		ok          bool
+	)
+	switch len(args) {
+	case 1:
+		if fn, ok = args[0].(func()); !ok {
+			eval.InvalidArgError("func()", args[0])
+			return
+		}
+	case 2:
+		if description, ok = args[0].(string); !ok {
+			eval.InvalidArgError("string", args[0])
+			return
+		}
+		if fn, ok = args[1].(func()); !ok {
+			eval.InvalidArgError("func()", args[1])
+			return
+		}
+	default:
+		eval.ReportError("invalid arguments in call to OneOf, must be (name, func) or (name, description, func)")
+		return
+	}
+
+	union := &expr.Union{TypeName: tname, Values: &expr.Object{}}
+	ut := &expr.UserTypeExpr{
+		TypeName:      tname,
+		AttributeExpr: &expr.AttributeExpr{Type: union, Description: description},
+	}
+	if !eval.Execute(fn, ut.AttributeExpr) {
+		return
+	}
+	if len(*union.Values) == 0 {
+		eval.ReportError("union %#v must define at least one value", name)
+		return
+	}
+	if v := ut.AttributeExpr.Validation; v != nil && len(v.Required) > 0 {
+		eval.ReportError("union %#v values cannot be required", name)
+		return
+	}
+	for _, nat := range *union.Values {
+		if nat.Attribute.DefaultValue != nil {
+			eval.ReportError("union %#v value %#v cannot have a default value", name, nat.Name)
+			return
+		}
+	}
+	parent.Type.(*expr.Object).Set(name, &expr.AttributeExpr{Type: ut, Description: description})
+}
+
+// parentUserType returns the user or result type defined by att if any, nil
+// otherwise.
+func parentUserType(att *expr.AttributeExpr) expr.UserType {
+	for _, ut := range expr.Root.Types {
+		if ut.Attribute() == att {
+			return ut
+		}
+	}
+	for _, rt := range expr.Root.ResultTypes {
+		if rt.Attribute() == att {
+			return rt
+		}
+	}
+	return nil
 }
 
 // Field is syntactic sugar to define an attribute with the "rpc:tag" meta
@@ -282,6 +408,15 @@ func Example(args ...interface{}) {
 		}
 		arg = args[1]
 	}
+	if f, ok := eval.Current().(*expr.FormatExpr); ok {
+		v, ok := arg.(string)
+		if !ok {
+			eval.InvalidArgError("example value (string)", arg)
+			return
+		}
+		f.Example = v
+		return
+	}
 	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
 		ex := &expr.ExampleExpr{Summary: summary}
 		if dsl, ok := arg.(func()); ok {
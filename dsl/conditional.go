@@ -0,0 +1,115 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ETag identifies the method result attribute holding the entity tag of the
+// resource. The attribute must be a string containing a valid entity tag,
+// e.g. "\"v1\"" or "W/\"v1\"", unquoted values are compared as if they were
+// quoted.
+//
+// The attribute is mapped to the ETag header of the success responses unless
+// mapped explicitly. The generated server code compares the entity tag with
+// the If-None-Match header of GET and HEAD requests and writes a 304 Not
+// Modified response without encoding the result body when they match. The
+// generated client sets the If-None-Match header from the value stored in the
+// request context under the goahttp.IfNoneMatchKey key and returns a
+// "not_modified" error when the server responds with 304 Not Modified.
+//
+// ETag must appear in a HTTP endpoint expression.
+//
+// ETag takes one argument: the name of the result attribute.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//        })
+//        Result(func() {
+//            Attribute("name", String)
+//            Attribute("etag", String)
+//            Attribute("updated_at", String)
+//        })
+//        HTTP(func() {
+//            GET("/bottles/{id}")
+//            ETag("etag")
+//            LastModified("updated_at")
+//        })
+//    })
+//
+func ETag(resultAttr string) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.ETag = resultAttr
+}
+
+// LastModified identifies the method result attribute holding the date of
+// the last modification of the resource. The attribute must be a string
+// containing a HTTP date, e.g. "Mon, 02 Jan 2006 15:04:05 GMT" (see
+// http.TimeFormat).
+//
+// The attribute is mapped to the Last-Modified header of the success responses
+// unless mapped explicitly. The generated server code compares the date with
+// the If-Modified-Since header of GET and HEAD requests and writes a 304 Not
+// Modified response without encoding the result body when the resource was
+// not modified since. The If-None-Match header takes precedence when both
+// headers are present. The generated client sets the If-Modified-Since header
+// from the value stored in the request context under the
+// goahttp.IfModifiedSinceKey key.
+//
+// LastModified must appear in a HTTP endpoint expression.
+//
+// LastModified takes one argument: the name of the result attribute.
+func LastModified(resultAttr string) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.LastModified = resultAttr
+}
+
+// IfMatch identifies the method payload attribute holding the If-Match header
+// of conditional requests made to mutating routes. The attribute must be a
+// string and the endpoint must define a route using a method other than GET
+// or HEAD.
+//
+// The attribute is mapped to the If-Match request header unless mapped
+// explicitly. The service implementation compares the value with the current
+// entity tag of the resource using goa.CheckIfMatch which returns a
+// "precondition_failed" error. IfMatch adds the error to the method and maps
+// it to 412 Precondition Failed responses unless the design defines the error
+// explicitly.
+//
+// IfMatch must appear in a HTTP endpoint expression.
+//
+// IfMatch takes one argument: the name of the payload attribute.
+//
+// Example:
+//
+//    Method("update", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//            Attribute("name", String)
+//            Attribute("version", String)
+//        })
+//        HTTP(func() {
+//            PUT("/bottles/{id}")
+//            IfMatch("version")
+//        })
+//    })
+//
+func IfMatch(payloadAttr string) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.IfMatch = payloadAttr
+}
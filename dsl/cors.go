@@ -0,0 +1,152 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Origin defines a cross-origin resource sharing (CORS) policy: the origin
+// allowed to make cross-origin requests to the HTTP endpoints and the
+// requests it may make. The generated server answers the preflight OPTIONS
+// requests made to the endpoint routes and adds the CORS headers to the
+// responses of requests made by the allowed origins.
+//
+// Origin must appear in an API, Service or Method HTTP expression. The
+// policies defined in a service apply to all the service endpoints, the
+// policies defined in the API apply to all the API endpoints. A policy
+// defined for a given origin in a method overrides the policy defined for
+// the same origin in the service which overrides the policy defined in the
+// API. The preflight requests made to a path shared by the routes of multiple
+// services are answered by the handler mounted by the first service in the
+// design, the handler applies the policies of all the services.
+//
+// Origin accepts one or two arguments. The first argument is the origin:
+// either "*" to allow any origin, a regular expression delimited by slashes
+// that must match the entire origin or the origin itself, e.g.
+// "https://goa.design". The optional second
+// argument is a function listing the requests allowed using AllowMethods,
+// AllowHeaders, ExposeHeaders, MaxAge and AllowCredentials. The methods
+// allowed default to the methods of the endpoint routes.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        HTTP(func() {
+//            Origin("https://goa.design")
+//        })
+//    })
+//
+//    var _ = Service("calc", func() {
+//        HTTP(func() {
+//            Origin("/.*\\.goa\\.design/", func() {
+//                AllowHeaders("X-Shared-Secret")
+//                ExposeHeaders("X-Time")
+//                MaxAge(600)
+//                AllowCredentials()
+//            })
+//        })
+//        Method("add", func() {
+//            HTTP(func() {
+//                GET("/add/{a}/{b}")
+//                Origin("*")
+//            })
+//        })
+//    })
+//
+func Origin(origin string, fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Origin")
+		return
+	}
+	o := &expr.HTTPOriginExpr{Origin: origin}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.Origins = append(e.API.HTTP.Origins, o)
+	case *expr.HTTPServiceExpr:
+		e.Origins = append(e.Origins, o)
+	case *expr.HTTPEndpointExpr:
+		e.Origins = append(e.Origins, o)
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fn) > 0 {
+		eval.Execute(fn[0], o)
+	}
+}
+
+// AllowMethods lists the HTTP methods the origin may use in cross-origin
+// requests. The methods are listed in the Access-Control-Allow-Methods header
+// of the responses to preflight requests.
+//
+// AllowMethods must appear in an Origin expression.
+//
+// AllowMethods accepts one or more HTTP method names.
+func AllowMethods(methods ...string) {
+	if o, ok := origin(); ok {
+		o.Methods = append(o.Methods, methods...)
+	}
+}
+
+// AllowHeaders lists the request headers the origin may send in cross-origin
+// requests, "*" allows any header. The headers are listed in the
+// Access-Control-Allow-Headers header of the responses to preflight requests.
+//
+// AllowHeaders must appear in an Origin expression.
+//
+// AllowHeaders accepts one or more header names.
+func AllowHeaders(headers ...string) {
+	if o, ok := origin(); ok {
+		o.Headers = append(o.Headers, headers...)
+	}
+}
+
+// ExposeHeaders lists the response headers the client is allowed to read in
+// addition to the CORS-safelisted headers. The headers are listed in the
+// Access-Control-Expose-Headers header of the responses.
+//
+// ExposeHeaders must appear in an Origin expression.
+//
+// ExposeHeaders accepts one or more header names.
+func ExposeHeaders(headers ...string) {
+	if o, ok := origin(); ok {
+		o.ExposedHeaders = append(o.ExposedHeaders, headers...)
+	}
+}
+
+// MaxAge sets the number of seconds the client may cache the result of
+// preflight requests using the Access-Control-Max-Age header.
+//
+// MaxAge must appear in an Origin expression.
+//
+// MaxAge accepts one argument: the number of seconds.
+func MaxAge(seconds int) {
+	if o, ok := origin(); ok {
+		o.MaxAge = seconds
+	}
+}
+
+// AllowCredentials indicates that cross-origin requests may include
+// credentials such as cookies, authorization headers or TLS client
+// certificates using the Access-Control-Allow-Credentials header.
+//
+// AllowCredentials must appear in an Origin expression. AllowCredentials
+// cannot be used with the "*" origin, the CORS specification forbids
+// credentialed requests from any origin.
+//
+// AllowCredentials takes no argument.
+func AllowCredentials() {
+	if o, ok := origin(); ok {
+		o.Credentials = true
+	}
+}
+
+// origin returns the current origin expression if any, it reports an
+// incompatible DSL error otherwise.
+func origin() (*expr.HTTPOriginExpr, bool) {
+	o, ok := eval.Current().(*expr.HTTPOriginExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+	}
+	return o, ok
+}
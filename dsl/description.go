@@ -8,7 +8,7 @@ import (
 // Description sets the expression description.
 //
 // Description may appear in API, Docs, Type or Attribute.
-// Description may also appear in Response and FileServer.
+// Description may also appear in Response, FileServer and Interceptor.
 //
 // Description accepts one arguments: the description string.
 //
@@ -46,6 +46,10 @@ func Description(d string) {
 		e.Description = d
 	case *expr.GRPCResponseExpr:
 		e.Description = d
+	case *expr.FormatExpr:
+		e.Description = d
+	case *expr.InterceptorExpr:
+		e.Description = d
 	default:
 		eval.IncompatibleDSL()
 	}
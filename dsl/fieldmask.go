@@ -0,0 +1,60 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// FieldMask lets clients select the method result fields returned by the
+// server. The generated HTTP transport reads the field mask from a query
+// string parameter containing a comma separated list of field paths (e.g.
+// ?fields=id,author.name) while the generated gRPC transport adds a
+// google.protobuf.FieldMask field to the request message. The paths of nested
+// fields use dots to separate the field names.
+//
+// The server validates the field mask against the method result and responds
+// with a validation error if it selects fields that do not exist. The fields
+// that are not selected are removed from the result before it is encoded.
+// Fields that are required or that have a default value are always returned.
+// Clients set the field mask by storing a goa.FieldMask in the request context
+// under the goa.FieldMaskKey key.
+//
+// FieldMask must appear in a Method expression. The method result must be an
+// object.
+//
+// FieldMask accepts an optional argument: the name of the query string
+// parameter and of the request message field, "fields" by default.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//        })
+//        Result(Bottle)
+//        FieldMask()
+//        HTTP(func() {
+//            GET("/bottles/{id}")
+//        })
+//    })
+//
+func FieldMask(name ...string) {
+	if len(name) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	n := expr.DefaultFieldMaskName
+	if len(name) > 0 {
+		n = name[0]
+	}
+	if n == "" {
+		eval.ReportError("field mask name cannot be empty")
+		return
+	}
+	m.FieldMask = &expr.FieldMaskExpr{Method: m, Name: n}
+}
@@ -75,6 +75,18 @@ const (
 	StatusNetworkAuthenticationRequired = expr.StatusNetworkAuthenticationRequired
 )
 
+const (
+	// CookieSameSiteLax sets the SameSite attribute of the response cookies to
+	// "Lax".
+	CookieSameSiteLax = "Lax"
+	// CookieSameSiteStrict sets the SameSite attribute of the response cookies
+	// to "Strict".
+	CookieSameSiteStrict = "Strict"
+	// CookieSameSiteNone sets the SameSite attribute of the response cookies to
+	// "None".
+	CookieSameSiteNone = "None"
+)
+
 // HTTP defines the HTTP transport specific properties of an API, a service or a
 // single method. The function maps the method payload and result types to HTTP
 // properties such as parameters (via path wildcards or query strings), request
@@ -162,12 +174,16 @@ func HTTP(fns ...func()) {
 }
 
 // Consumes adds a MIME type to the list of MIME types the APIs supports when
-// accepting requests. While the DSL supports any MIME type, the code generator
-// only knows to generate the code for "application/json", "application/xml" and
-// "application/gob". The service code must provide the decoders for other MIME
-// types.
+// accepting requests. While the DSL supports any MIME type, the default
+// decoders only handle the MIME types registered in goahttp.Codecs, that is
+// "application/json", "application/xml", "application/gob",
+// "application/msgpack", "application/cbor" and "application/x-protobuf" by
+// default. The service code must register a codec with goahttp.RegisterCodec
+// or provide the decoders for other MIME types.
 //
-// Consumes must appear in the HTTP expression of API.
+// Consumes must appear in the HTTP expression of API, a Service or a Method.
+// Methods inherit the list of their service and use the list of the API if
+// neither defines one.
 //
 // Consumes accepts one or more strings corresponding to the MIME types.
 //
@@ -181,22 +197,45 @@ func HTTP(fns ...func()) {
 //        })
 //    })
 //
+//    Method("import", func() {
+//        // ...
+//        HTTP(func() {
+//            POST("/import")
+//            Consumes("application/msgpack")
+//        })
+//    })
+//
 func Consumes(args ...string) {
 	switch e := eval.Current().(type) {
 	case *expr.RootExpr:
 		e.API.HTTP.Consumes = append(e.API.HTTP.Consumes, args...)
+	case *expr.HTTPServiceExpr:
+		e.Consumes = append(e.Consumes, args...)
+	case *expr.HTTPEndpointExpr:
+		e.Consumes = append(e.Consumes, args...)
 	default:
 		eval.IncompatibleDSL()
 	}
 }
 
 // Produces adds a MIME type to the list of MIME types the APIs supports when
-// writing responses. While the DSL supports any MIME type, the code generator
-// only knows to generate the code for "application/json", "application/xml" and
-// "application/gob". The service code must provide the encoders for other MIME
-// types.
-//
-// Produces must appear in the HTTP expression of API.
+// writing responses. While the DSL supports any MIME type, the default
+// encoders only handle the MIME types registered in goahttp.Codecs, that is
+// "application/json", "application/xml", "application/gob",
+// "application/msgpack", "application/cbor" and "application/x-protobuf" by
+// default. The service code must register a codec with goahttp.RegisterCodec
+// for other MIME types.
+//
+// The MIME types are listed in order of preference. The generated servers
+// negotiate the response content type by matching the MIME types that have a
+// registered codec against the request Accept header, taking quality values
+// and wildcards into account, and return a "not_acceptable" error if none of
+// these MIME types is acceptable. The error is added to the methods and mapped
+// to 406 Not Acceptable responses unless the design defines it explicitly.
+// Services and methods inherit the list of the API unless they define their
+// own.
+//
+// Produces must appear in the HTTP expression of API, a Service or a Method.
 //
 // Produces accepts one or more strings corresponding to the MIME types.
 //
@@ -210,10 +249,22 @@ func Consumes(args ...string) {
 //        })
 //    })
 //
+//    Method("export", func() {
+//        // ...
+//        HTTP(func() {
+//            GET("/export")
+//            Produces("text/csv", "application/json")
+//        })
+//    })
+//
 func Produces(args ...string) {
 	switch e := eval.Current().(type) {
 	case *expr.RootExpr:
 		e.API.HTTP.Produces = append(e.API.HTTP.Produces, args...)
+	case *expr.HTTPServiceExpr:
+		e.Produces = append(e.Produces, args...)
+	case *expr.HTTPEndpointExpr:
+		e.Produces = append(e.Produces, args...)
 	default:
 		eval.IncompatibleDSL()
 	}
@@ -382,6 +433,116 @@ func Header(name string, args ...interface{}) {
 	h.Remap()
 }
 
+// Cookie describes a single HTTP cookie. The properties (description, type,
+// validation etc.) of a cookie are inherited from the request or response type
+// attribute with the same name by default. Cookies must be of a primitive type.
+//
+// Cookie must appear in a specific method HTTP expression (to define the
+// request cookies read from the Cookie header) or a Response expression (to
+// define the cookies set with the Set-Cookie header). The properties of the
+// response cookies are defined with CookieMaxAge, CookieSecure,
+// CookieHTTPOnly, CookieSameSite, CookiePath and CookieDomain.
+//
+// Cookie accepts the same arguments as the Attribute function. The cookie name
+// may define a mapping between the attribute name and the cookie name when
+// they differ. The mapping syntax is "name of attribute:name of cookie".
+//
+// Example:
+//
+//    var _ = Service("account", func() {
+//        Method("login", func() {
+//            Payload(func() {
+//                Attribute("csrf", String)
+//                Attribute("user", String)
+//            })
+//            Result(func() {
+//                Attribute("session", String)
+//            })
+//            HTTP(func() {
+//                POST("/login")
+//                Cookie("csrf:XSRF-TOKEN")
+//                Response(StatusOK, func() {
+//                    Cookie("session:SID") // Set-Cookie: SID=...
+//                    CookieMaxAge(3600)
+//                    CookieSecure()
+//                    CookieHTTPOnly()
+//                    CookieSameSite(CookieSameSiteStrict)
+//                })
+//            })
+//        })
+//    })
+//
+func Cookie(name string, args ...interface{}) {
+	c := cookies(eval.Current())
+	if c == nil {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("cookie name cannot be empty")
+	}
+	eval.Execute(func() { Attribute(name, args...) }, c.AttributeExpr)
+	c.Remap()
+}
+
+// CookieMaxAge sets the cookie "Max-Age" attribute of the response cookies.
+//
+// CookieMaxAge must appear in a Response expression.
+//
+// CookieMaxAge accepts one argument: the number of seconds after which the
+// cookies expire.
+func CookieMaxAge(n int) {
+	setCookieProp("maxage", fmt.Sprintf("%d", n))
+}
+
+// CookieSecure sets the cookie "Secure" attribute of the response cookies.
+//
+// CookieSecure must appear in a Response expression.
+func CookieSecure() {
+	setCookieProp("secure", "Secure")
+}
+
+// CookieHTTPOnly sets the cookie "HttpOnly" attribute of the response cookies.
+//
+// CookieHTTPOnly must appear in a Response expression.
+func CookieHTTPOnly() {
+	setCookieProp("httponly", "HttpOnly")
+}
+
+// CookieSameSite sets the cookie "SameSite" attribute of the response cookies.
+//
+// CookieSameSite must appear in a Response expression.
+//
+// CookieSameSite accepts one argument: one of CookieSameSiteLax,
+// CookieSameSiteStrict or CookieSameSiteNone.
+func CookieSameSite(mode string) {
+	switch mode {
+	case CookieSameSiteLax, CookieSameSiteStrict, CookieSameSiteNone:
+	default:
+		eval.ReportError("invalid cookie SameSite value %q, must be one of %q, %q or %q", mode, CookieSameSiteLax, CookieSameSiteStrict, CookieSameSiteNone)
+		return
+	}
+	setCookieProp("samesite", mode)
+}
+
+// CookiePath sets the cookie "Path" attribute of the response cookies.
+//
+// CookiePath must appear in a Response expression.
+//
+// CookiePath accepts one argument: the cookie path.
+func CookiePath(path string) {
+	setCookieProp("path", path)
+}
+
+// CookieDomain sets the cookie "Domain" attribute of the response cookies.
+//
+// CookieDomain must appear in a Response expression.
+//
+// CookieDomain accepts one argument: the cookie domain.
+func CookieDomain(domain string) {
+	setCookieProp("domain", domain)
+}
+
 // Params groups a set of Param expressions. It makes it possible to list
 // required parameters using the Required function.
 //
@@ -546,6 +707,17 @@ func MapParams(args ...interface{}) {
 // multipart content into the payload. The example command generates a default
 // implementation for the user decoder and encoder.
 //
+// When the request body is an object goa also generates default decoder and
+// encoder functions used when nil is given in place of the user provided
+// functions. The default functions map each body attribute to the multipart
+// part with the same name: primitive values and arrays of primitive values
+// are written as form fields, Bytes attributes as file parts and other types
+// as parts containing their JSON encoding. The default decoder rejects
+// parts larger than goahttp.MultipartMaxPartSize and requests whose parts
+// are larger than goahttp.MultipartMaxSize in total with a
+// "request_too_large" error that the endpoint maps to 413 Request Entity Too
+// Large responses.
+//
 func MultipartRequest() {
 	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
 	if !ok {
@@ -555,6 +727,115 @@ func MultipartRequest() {
 	e.MultipartRequest = true
 }
 
+// FormBody indicates that HTTP requests made to the method encode the body
+// using the application/x-www-form-urlencoded content type used by HTML form
+// posts. The body must be an object. Nested objects are encoded using
+// bracketed keys (e.g. "address[city]=Paris"), arrays of primitive values by
+// repeating the key and arrays of objects using indexed keys (e.g.
+// "items[0][name]=pen").
+//
+// FormBody must appear in a HTTP endpoint expression. Endpoints also use form
+// bodies when application/x-www-form-urlencoded is the first MIME type listed
+// in the API Consumes expression.
+//
+// The generated client sets the request Content-Type header to
+// application/x-www-form-urlencoded and goahttp.RequestEncoder encodes the
+// body accordingly. goahttp.RequestDecoder decodes form bodies based on the
+// request Content-Type header.
+//
+// Example:
+//
+//    Method("subscribe", func() {
+//        Payload(Subscription)
+//        HTTP(func() {
+//            POST("/subscriptions")
+//            FormBody()
+//        })
+//    })
+//
+func FormBody() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.FormBody = true
+}
+
+// ServerSentEvents indicates that the HTTP endpoint streams the method results
+// using server-sent events (text/event-stream content type) instead of
+// websockets. The method must define a StreamingResult and no
+// StreamingPayload. Each result is sent as a single event whose data is the
+// JSON encoded response body. Errors returned by the method once events have
+// been sent are reported in an event named "error" whose data is the JSON
+// encoded error response, the generated client returns the corresponding
+// service error.
+//
+// ServerSentEvents must appear in a HTTP endpoint expression.
+//
+// Unlike websockets, server-sent events can be consumed by browsers using the
+// EventSource API and go through HTTP proxies. The route may use any HTTP
+// method, note however that the EventSource API only issues GET requests.
+//
+// Example:
+//
+//    Method("watch", func() {
+//        StreamingResult(Event)
+//        HTTP(func() {
+//            GET("/events")
+//            ServerSentEvents()
+//        })
+//    })
+//
+func ServerSentEvents() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.SSE = true
+}
+
+// NDJSON indicates that the HTTP endpoint streams the method payloads and/or
+// results as newline-delimited JSON (application/x-ndjson content type) in
+// chunked request and response bodies instead of websockets. The method must
+// define a StreamingPayload, a StreamingResult or both. Each value is sent as
+// the JSON encoded request or response body followed by a newline.
+//
+// NDJSON must appear in a HTTP endpoint expression.
+//
+// Unlike websockets, NDJSON streams do not require upgrading the connection
+// and thus go through HTTP load balancers and proxies that do not allow
+// Upgrade. Methods that define a StreamingPayload must use a route with a
+// method other than GET (typically POST) as the request body contains the
+// stream, the non-streaming payload attributes must thus be mapped to
+// headers or parameters. Methods that define both a StreamingPayload and a
+// StreamingResult require HTTP/2 so that the server may send results while
+// the client is still sending payloads, the generated server rejects requests
+// made using HTTP/1.x with a 505 HTTP Version Not Supported response. Errors
+// returned by the method once results have been sent are reported in the
+// Goa-Error HTTP trailer and returned by the client stream Recv method.
+//
+// Example:
+//
+//    Method("upload", func() {
+//        StreamingPayload(Chunk)
+//        StreamingResult(Progress)
+//        HTTP(func() {
+//            POST("/upload")
+//            NDJSON()
+//        })
+//    })
+//
+func NDJSON() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.NDJSON = true
+}
+
 // Body describes a HTTP request or response body.
 //
 // Body must appear in a Method HTTP expression to define the request body or in
@@ -801,6 +1082,60 @@ func ContentType(typ string) {
 	}
 }
 
+// ProblemDetails causes error responses to be encoded as RFC 7807 problem
+// details documents using the "application/problem+json" content type. The
+// documents contain the standard "type", "title", "status", "detail" and
+// "instance" members, the attributes of the error type are encoded as
+// extension members. The detail and instance members are initialized with the
+// message and ID of errors that use the default error type. The generated
+// clients decode the documents into the error types.
+//
+// ProblemDetails may appear in the HTTP expression of API in which case it
+// applies to all the error responses or in the Response expression of a
+// specific error.
+//
+// ProblemDetails accepts an optional argument: the problem type URI. The type
+// defaults to "about:blank". When used in the API HTTP expression the URI is
+// the default type for all the error responses that do not define one.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        HTTP(func() {
+//            ProblemDetails()
+//        })
+//    })
+//
+//    var _ = Service("bottle", func() {
+//        Error("not_found")
+//        HTTP(func() {
+//            Response("not_found", StatusNotFound, func() {
+//                ProblemDetails("https://cellar.goa.design/problems/not-found")
+//            })
+//        })
+//    })
+//
+func ProblemDetails(typeURI ...string) {
+	if len(typeURI) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	var typ string
+	if len(typeURI) > 0 {
+		typ = typeURI[0]
+	}
+	switch actual := eval.Current().(type) {
+	case *expr.RootExpr:
+		actual.API.HTTP.ProblemDetails = true
+		actual.API.HTTP.ProblemType = typ
+	case *expr.HTTPResponseExpr:
+		actual.ProblemDetails = true
+		actual.ProblemType = typ
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
 // headers returns the mapped attribute containing the headers for the given
 // expression if it's either the root, a service or an endpoint - nil otherwise.
 func headers(exp eval.Expression) *expr.MappedAttributeExpr {
@@ -832,6 +1167,42 @@ func headers(exp eval.Expression) *expr.MappedAttributeExpr {
 	}
 }
 
+// cookies returns the mapped attribute containing the cookies for the given
+// expression if it's either an endpoint or a response - nil otherwise.
+func cookies(exp eval.Expression) *expr.MappedAttributeExpr {
+	switch e := exp.(type) {
+	case *expr.HTTPEndpointExpr:
+		if e.Cookies == nil {
+			e.Cookies = expr.NewEmptyMappedAttributeExpr()
+		}
+		return e.Cookies
+	case *expr.HTTPResponseExpr:
+		if e.Cookies == nil {
+			e.Cookies = expr.NewEmptyMappedAttributeExpr()
+		}
+		return e.Cookies
+	default:
+		return nil
+	}
+}
+
+// setCookieProp stores the given cookie property in the metadata of the
+// response cookies.
+func setCookieProp(name, value string) {
+	c, ok := eval.Current().(*expr.HTTPResponseExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if c.Cookies == nil {
+		c.Cookies = expr.NewEmptyMappedAttributeExpr()
+	}
+	if c.Cookies.Meta == nil {
+		c.Cookies.Meta = expr.MetaExpr{}
+	}
+	c.Cookies.Meta["cookie:"+name] = []string{value}
+}
+
 // params returns the mapped attribute containing the path and query params for
 // the given expression if it's either the root, a API server, a service or an
 // endpoint - nil otherwise.
@@ -0,0 +1,48 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Idempotent indicates that retrying a request made to the HTTP endpoint has
+// the same effect as making it once. Clients identify requests with the
+// Idempotency-Key header: the generated server stores the response of the
+// first request made with a given key and replays it when the request is
+// retried instead of calling the service method again.
+//
+// The generated server constructor accepts a goahttp.IdempotencyStore used
+// to store the responses, the in-memory store returned by
+// goahttp.NewMemoryIdempotencyStore is used if nil. Request bodies larger
+// than goahttp.IdempotentMaxBodySize are rejected. The generated service
+// client binds a new random key to each call using goa.WithIdempotencyKey
+// unless the context already holds one so that the retries made by client
+// endpoint middlewares such as middleware.RetryEndpoint reuse the key. The
+// generated HTTP client sets the Idempotency-Key header to the key stored in
+// the request context under goa.IdempotencyKeyKey. Use goa.WithIdempotencyKey
+// to reuse a key across calls.
+//
+// Idempotent must appear in a HTTP endpoint expression that defines a route
+// using a method other than GET or HEAD.
+//
+// Idempotent takes no argument.
+//
+// Example:
+//
+//    Method("pay", func() {
+//        Payload(Payment)
+//        Result(Receipt)
+//        HTTP(func() {
+//            POST("/payments")
+//            Idempotent()
+//        })
+//    })
+//
+func Idempotent() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.Idempotent = true
+}
@@ -0,0 +1,155 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Interceptor defines an interceptor that wraps the server and client
+// endpoints of the methods it applies to. The generated service package
+// includes the ServerInterceptors and ClientInterceptors interfaces with one
+// method per interceptor, they are given to NewEndpoints and NewClient
+// respectively so that servers and clients may use different
+// implementations. The interceptor methods are given typed accessors to the
+// payload and result attributes listed with ReadPayload, WritePayload,
+// ReadResult and WriteResult so that the same code may inspect or modify the
+// payloads and results of different methods.
+//
+// Interceptor must appear in API, Service or Method. An interceptor defined
+// in API applies to all the API methods, an interceptor defined in Service
+// applies to all the service methods. The accessors of such interceptors are
+// only available for the methods whose payload or result define all the
+// attributes they list. Interceptors do not apply to streaming methods. Interceptors run in the order they are defined starting with the
+// API interceptors followed by the service interceptors and the method
+// interceptors.
+//
+// Interceptor accepts two arguments: the interceptor name and an optional DSL.
+//
+// Example:
+//
+//    var _ = Service("catalog", func() {
+//        Interceptor("Tenant", func() {
+//            Description("Tenant injects the tenant ID in the payload")
+//            WritePayload(func() {
+//                Attribute("tenant_id", String)
+//            })
+//        })
+//        Method("list", func() {
+//            Payload(func() {
+//                Attribute("tenant_id", String)
+//                Attribute("page", Int)
+//            })
+//            Result(CollectionOf(Item))
+//        })
+//    })
+//
+func Interceptor(name string, fn ...func()) {
+	if name == "" {
+		eval.ReportError("interceptor name cannot be empty")
+		return
+	}
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	i := &expr.InterceptorExpr{Name: name}
+	if len(fn) > 0 {
+		if !eval.Execute(fn[0], i) {
+			return
+		}
+	}
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		e.Interceptors = append(e.Interceptors, i)
+	case *expr.ServiceExpr:
+		e.Interceptors = append(e.Interceptors, i)
+	case *expr.MethodExpr:
+		e.Interceptors = append(e.Interceptors, i)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// ReadPayload lists the payload attributes read by the interceptor. The
+// payload of the methods the interceptor is defined in must define the
+// attributes, the payload of the methods an API or service interceptor
+// applies to may omit them. The attributes must have the same types in the
+// payloads that define them and the attributes listed as required must be
+// required or have a default value.
+//
+// ReadPayload must appear in Interceptor.
+//
+// ReadPayload accepts a single argument: the DSL listing the attributes.
+//
+// Example:
+//
+//    Interceptor("Audit", func() {
+//        ReadPayload(func() {
+//            Attribute("user_id", String)
+//            Required("user_id")
+//        })
+//    })
+//
+func ReadPayload(fn func()) {
+	if i, ok := interceptorDefinition(); ok {
+		i.ReadPayload = interceptorAttribute(fn)
+	}
+}
+
+// WritePayload lists the payload attributes written by the interceptor, see
+// ReadPayload.
+//
+// WritePayload must appear in Interceptor.
+//
+// WritePayload accepts a single argument: the DSL listing the attributes.
+func WritePayload(fn func()) {
+	if i, ok := interceptorDefinition(); ok {
+		i.WritePayload = interceptorAttribute(fn)
+	}
+}
+
+// ReadResult lists the result attributes read by the interceptor, see
+// ReadPayload. The server endpoints of methods that return result types
+// return the result projected onto the selected view: the accessors read and
+// write the projected result in which the attributes not rendered by the view
+// are not set. Interceptors may only access the attributes of result types
+// that have primitive types or that are arrays or maps of primitive types.
+//
+// ReadResult must appear in Interceptor.
+//
+// ReadResult accepts a single argument: the DSL listing the attributes.
+func ReadResult(fn func()) {
+	if i, ok := interceptorDefinition(); ok {
+		i.ReadResult = interceptorAttribute(fn)
+	}
+}
+
+// WriteResult lists the result attributes written by the interceptor, see
+// ReadResult.
+//
+// WriteResult must appear in Interceptor.
+//
+// WriteResult accepts a single argument: the DSL listing the attributes.
+func WriteResult(fn func()) {
+	if i, ok := interceptorDefinition(); ok {
+		i.WriteResult = interceptorAttribute(fn)
+	}
+}
+
+// interceptorDefinition returns true and current context if it is an
+// InterceptorExpr, otherwise it returns false.
+func interceptorDefinition() (*expr.InterceptorExpr, bool) {
+	i, ok := eval.Current().(*expr.InterceptorExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+	}
+	return i, ok
+}
+
+// interceptorAttribute returns the object attribute initialized by running
+// fn.
+func interceptorAttribute(fn func()) *expr.AttributeExpr {
+	att := &expr.AttributeExpr{Type: &expr.Object{}}
+	eval.Execute(fn, att)
+	return att
+}
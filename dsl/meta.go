@@ -93,6 +93,19 @@ import (
 //        })
 //    })
 //
+// - "protoc:path" makes goa compile the generated .proto files with the
+// protocol buffer compiler and its Go plugin instead of generating the .pb.go
+// files itself. The value is the path to the protoc command, defaults to
+// "protoc" (looked up in PATH). Applicable to API and services.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("protoc:path", "/usr/local/bin/protoc")
+//    })
+//
+// The "swagger:" prefixed keys below may also be written with the "openapi:"
+// prefix, e.g. "openapi:generate" or "openapi:extension:x-api", both are
+// applied to the OpenAPI v2 and v3 specifications.
+//
 // - "swagger:generate" specifies whether Swagger specification should be
 // generated. Defaults to true. Applicable to services, methods and file
 // servers.
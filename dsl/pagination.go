@@ -0,0 +1,132 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Paginated indicates that the method returns its results one page at a time.
+// The DSL identifies the payload attributes used to request a page and the
+// result attributes that hold the page items and the information needed to
+// request the next page.
+//
+// Methods that use cursor pagination define the payload attribute holding the
+// cursor of the requested page and the result attribute holding the cursor of
+// the next page with Cursor. Methods that use offset pagination define the
+// payload attribute holding the offset of the first item with Offset. In both
+// cases PageSize optionally defines the payload attribute holding the maximum
+// number of items and Items defines the result attribute holding the page
+// items.
+//
+// The generated HTTP transport maps the cursor or offset and page size
+// attributes to query string parameters unless they are explicitly mapped
+// otherwise and sets the Link response header to the URL of the next page. The
+// generated service client includes an iterator that transparently fetches
+// the subsequent pages.
+//
+// Paginated must appear in a Method expression.
+//
+// Paginated takes a single argument which is the defining DSL.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("cursor", String)
+//            Attribute("limit", Int, func() {
+//                Default(20)
+//            })
+//        })
+//        Result(func() {
+//            Attribute("items", ArrayOf(Bottle))
+//            Attribute("next", String)
+//        })
+//        Paginated(func() {
+//            Cursor("cursor", "next")
+//            PageSize("limit")
+//            Items("items")
+//        })
+//        HTTP(func() {
+//            GET("/bottles")
+//        })
+//    })
+//
+func Paginated(fn func()) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p := &expr.PaginationExpr{Method: m}
+	if !eval.Execute(fn, p) {
+		return
+	}
+	m.Pagination = p
+}
+
+// Cursor defines the payload attribute holding the cursor of the requested
+// page and the result attribute holding the cursor of the next page. Both
+// attributes must be strings. An empty or missing next cursor indicates that
+// there are no more pages.
+//
+// Cursor must appear in a Paginated expression.
+//
+// Cursor takes two arguments: the name of the payload attribute and the name
+// of the result attribute.
+func Cursor(payloadAttr, resultAttr string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.CursorAttribute = payloadAttr
+	p.NextCursorAttribute = resultAttr
+}
+
+// Offset defines the payload attribute holding the offset of the first item
+// of the requested page. The attribute must be an int. The offset of the next
+// page is computed by adding the number of items in the page to the offset.
+// An empty page or a page with fewer items than the page size indicates that
+// there are no more pages.
+//
+// Offset must appear in a Paginated expression.
+//
+// Offset takes one argument: the name of the payload attribute.
+func Offset(payloadAttr string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.OffsetAttribute = payloadAttr
+}
+
+// PageSize defines the payload attribute holding the maximum number of items
+// returned in a page. The attribute must be an int.
+//
+// PageSize must appear in a Paginated expression.
+//
+// PageSize takes one argument: the name of the payload attribute.
+func PageSize(payloadAttr string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.PageSizeAttribute = payloadAttr
+}
+
+// Items defines the result attribute holding the page items. The attribute
+// must be an array.
+//
+// Items must appear in a Paginated expression.
+//
+// Items takes one argument: the name of the result attribute.
+func Items(resultAttr string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.ItemsAttribute = resultAttr
+}
@@ -16,7 +16,9 @@ import (
 // service and client commands. It is also consumed by the OpenAPI specification
 // generator. There is one specification generated per server. The first URI of
 // the first host is used to set the OpenAPI v2 specification 'host' and
-// 'basePath' values.
+// 'basePath' values. The OpenAPI v3 specification lists all the HTTP URIs of
+// all the hosts in its 'servers' section, the host variables are described by
+// the corresponding server variables.
 //
 // Server must appear in a API expression.
 //
@@ -1,6 +1,7 @@
 package dsl
 
 import (
+	"math"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -142,6 +143,8 @@ func Enum(vals ...interface{}) {
 //
 // FormatRFC1123: RFC1123 date time
 //
+// Additional formats may be registered with RegisterFormat.
+//
 // Example:
 //
 //    Attribute("created_at", String, func() {
@@ -163,6 +166,60 @@ func Format(f expr.ValidationFormat) {
 	}
 }
 
+// RegisterFormat registers a custom validation format that can be used with
+// the Format function. The generated code validates values using the given Go
+// function which must have the signature func(string) error.
+//
+// RegisterFormat must appear at the top level of the design package. It
+// returns the format name to be given to Format.
+//
+// RegisterFormat accepts three or four arguments: the format name, the
+// qualified name of the Go validation function, the import path of the package
+// that defines the function and an optional DSL function that may use
+// Description and Example. The example is used when generating examples for
+// attributes that use the format.
+//
+// Example:
+//
+//    var FormatULID = RegisterFormat("ulid", "formats.ValidateULID", "example.com/formats", func() {
+//        Description("Universally Unique Lexicographically Sortable Identifier")
+//        Example("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+//    })
+//
+//    var _ = Type("Order", func() {
+//        Attribute("id", String, func() {
+//            Format(FormatULID)
+//        })
+//    })
+//
+func RegisterFormat(name, function, pkgPath string, fn ...func()) expr.ValidationFormat {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return ""
+	}
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments")
+		return ""
+	}
+	f := expr.ValidationFormat(name)
+	if (&expr.AttributeExpr{}).IsSupportedValidationFormat(f) {
+		eval.ReportError("format %q is already defined", name)
+		return ""
+	}
+	if function == "" || pkgPath == "" {
+		eval.ReportError("format %q must define a validation function and its package import path", name)
+		return ""
+	}
+	format := &expr.FormatExpr{Name: f, Function: function, PkgPath: pkgPath}
+	if len(fn) == 1 {
+		if !eval.Execute(fn[0], format) {
+			return ""
+		}
+	}
+	expr.Root.Formats = append(expr.Root.Formats, format)
+	return f
+}
+
 // Pattern adds a "pattern" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor33.
 //
@@ -274,6 +331,63 @@ func Maximum(val interface{}) {
 	}
 }
 
+// ExclusiveMinimum adds an "exclusiveMinimum" validation to the attribute.
+// The attribute value must be strictly greater than the given value.
+// See http://json-schema.org/latest/json-schema-validation.html#rfc.section.6.2.5.
+//
+// Example:
+//
+//    Attribute("integer", Int, func() {
+//        ExclusiveMinimum(0)
+//    })
+//
+func ExclusiveMinimum(val interface{}) {
+	if a, f, ok := numberValidation("exclusive minimum", val); ok {
+		a.Validation.ExclusiveMinimum = &f
+	}
+}
+
+// ExclusiveMaximum adds an "exclusiveMaximum" validation to the attribute.
+// The attribute value must be strictly lesser than the given value.
+// See http://json-schema.org/latest/json-schema-validation.html#rfc.section.6.2.3.
+//
+// Example:
+//
+//    Attribute("number", Float64, func() {
+//        ExclusiveMaximum(1.0)
+//    })
+//
+func ExclusiveMaximum(val interface{}) {
+	if a, f, ok := numberValidation("exclusive maximum", val); ok {
+		a.Validation.ExclusiveMaximum = &f
+	}
+}
+
+// MultipleOf adds a "multipleOf" validation to the attribute. The attribute
+// value must be a multiple of the given strictly positive value. The value
+// must be an integer if the attribute is an integer.
+// See http://json-schema.org/latest/json-schema-validation.html#rfc.section.6.2.1.
+//
+// Example:
+//
+//    Attribute("integer", Int, func() {
+//        MultipleOf(5)
+//    })
+//
+func MultipleOf(val interface{}) {
+	if a, f, ok := numberValidation("multiple of", val); ok {
+		if f <= 0 {
+			eval.ReportError("invalid multiple of value %#v, must be strictly positive", val)
+			return
+		}
+		if a.Type != nil && isIntegerKind(a.Type.Kind()) && f != math.Trunc(f) {
+			eval.ReportError("invalid multiple of value %#v, must be an integer", val)
+			return
+		}
+		a.Validation.MultipleOf = &f
+	}
+}
+
 // MinLength adds a "minItems" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor45.
 //
@@ -341,6 +455,29 @@ func MaxLength(val int) {
 	}
 }
 
+// UniqueItems adds a "uniqueItems" validation to the attribute. The elements
+// of the array must all be distinct.
+// See http://json-schema.org/latest/json-schema-validation.html#rfc.section.6.4.5.
+//
+// Example:
+//
+//    Attribute("tags", ArrayOf(String), func() {
+//        UniqueItems()
+//    })
+//
+func UniqueItems() {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.ArrayKind {
+			incompatibleAttributeType("unique items", a.Type.Name(), "an array")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.UniqueItems = true
+	}
+}
+
 // Required adds a "required" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor61.
 //
@@ -377,6 +514,52 @@ func Required(names ...string) {
 	}
 }
 
+// numberValidation checks that the current attribute is a number and parses
+// val. It initializes the attribute validation and returns the attribute and
+// the parsed value on success, reports an error and returns false otherwise.
+func numberValidation(validation string, val interface{}) (*expr.AttributeExpr, float64, bool) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil, 0, false
+	}
+	if a.Type != nil {
+		kind := a.Type.Kind()
+		if !isIntegerKind(kind) && kind != expr.Float32Kind && kind != expr.Float64Kind {
+			incompatibleAttributeType(validation, a.Type.Name(), "an integer or a number")
+			return nil, 0, false
+		}
+	}
+	var f float64
+	switch v := val.(type) {
+	case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+		f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
+	case string:
+		var err error
+		f, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			eval.ReportError("invalid number value %#v", v)
+			return nil, 0, false
+		}
+	default:
+		eval.ReportError("invalid number value %#v", v)
+		return nil, 0, false
+	}
+	if a.Validation == nil {
+		a.Validation = &expr.ValidationExpr{}
+	}
+	return a, f, true
+}
+
+// isIntegerKind returns true if kind is one of the integer kinds.
+func isIntegerKind(kind expr.Kind) bool {
+	switch kind {
+	case expr.IntKind, expr.UIntKind, expr.Int32Kind, expr.UInt32Kind, expr.Int64Kind, expr.UInt64Kind:
+		return true
+	}
+	return false
+}
+
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
 func incompatibleAttributeType(validation, actual, expected string) {
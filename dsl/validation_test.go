@@ -44,3 +44,89 @@ func TestFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestMultipleOf(t *testing.T) {
+	cases := map[string]struct {
+		Type     expr.DataType
+		Value    interface{}
+		Expected float64
+		Error    bool
+	}{
+		"integer":          {expr.Int, 5, 5, false},
+		"number":           {expr.Float64, 0.5, 0.5, false},
+		"string":           {expr.Float32, "0.25", 0.25, false},
+		"zero":             {expr.Int, 0, 0, true},
+		"negative":         {expr.Float64, -1, 0, true},
+		"decimal-integer":  {expr.Int64, 0.5, 0, true},
+		"invalid-type":     {expr.String, 2, 0, true},
+		"invalid-argument": {expr.Int, true, 0, true},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		expr := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(func() { MultipleOf(tc.Value) }, expr)
+		if tc.Error {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: MultipleOf did not fail", k)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: MultipleOf failed unexpectedly with %s", k, eval.Context.Errors)
+		}
+		if expr.Validation == nil || expr.Validation.MultipleOf == nil {
+			t.Errorf("%s: MultipleOf not set on %+v", k, expr)
+		} else if *expr.Validation.MultipleOf != tc.Expected {
+			t.Errorf("%s: got multiple of %v, expected %v", k, *expr.Validation.MultipleOf, tc.Expected)
+		}
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	cases := map[string]struct {
+		Name     string
+		Function string
+		PkgPath  string
+		DSL      func()
+		Example  string
+		Error    bool
+	}{
+		"valid":            {"ulid", "formats.ValidateULID", "example.com/formats", nil, "", false},
+		"with-dsl":         {"ulid", "formats.ValidateULID", "example.com/formats", func() { Example("01ARZ3NDEKTSV4RRFFQ69G5FAV") }, "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		"builtin-format":   {"uuid", "formats.ValidateUUID", "example.com/formats", nil, "", true},
+		"missing-function": {"ulid", "", "example.com/formats", nil, "", true},
+		"missing-package":  {"ulid", "formats.ValidateULID", "", nil, "", true},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		expr.Root = &expr.RootExpr{}
+		var fns []func()
+		if tc.DSL != nil {
+			fns = append(fns, tc.DSL)
+		}
+		name := RegisterFormat(tc.Name, tc.Function, tc.PkgPath, fns...)
+		if tc.Error {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: RegisterFormat did not fail", k)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Fatalf("%s: RegisterFormat failed unexpectedly with %s", k, eval.Context.Errors)
+		}
+		f := expr.Root.Format(name)
+		if f == nil {
+			t.Fatalf("%s: format %q not registered", k, name)
+		}
+		if f.Function != tc.Function || f.PkgPath != tc.PkgPath || f.Example != tc.Example {
+			t.Errorf("%s: got %+v", k, f)
+		}
+		att := &expr.AttributeExpr{Type: expr.String}
+		eval.Execute(func() { Format(name) }, att)
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: Format failed unexpectedly with %s", k, eval.Context.Errors)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ViewSelector lets clients select the view used to render the method result.
+// The generated HTTP transport reads the view name from a query string
+// parameter (e.g. ?view=tiny) or from the parameter with the same name of the
+// Accept header media type (e.g. Accept: application/json; view=tiny) while
+// the generated gRPC transport reads it from the request metadata.
+//
+// The generated service package defines a type enumerating the result views
+// that is passed to the service method instead of having the method return
+// the view. The server validates the view selected by the client and responds
+// with a validation error if the result type does not define it, the default
+// view is used if the client does not select any. The generated client
+// includes a method to request a specific view.
+//
+// ViewSelector must appear in a Method expression. The method result must be
+// a result type and must not set a view with View.
+//
+// ViewSelector accepts an optional argument: the name of the query string
+// parameter, of the Accept header media type parameter and of the gRPC
+// metadata, "view" by default.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//        })
+//        Result(StoredBottle)
+//        ViewSelector()
+//        HTTP(func() {
+//            GET("/bottles/{id}")
+//        })
+//    })
+//
+func ViewSelector(name ...string) {
+	if len(name) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	n := expr.DefaultViewSelectorName
+	if len(name) > 0 {
+		n = name[0]
+	}
+	if n == "" {
+		eval.ReportError("view selector name cannot be empty")
+		return
+	}
+	m.ViewSelector = &expr.ViewSelectorExpr{Method: m, Name: n}
+}
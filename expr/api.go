@@ -36,6 +36,9 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Interceptors lists the interceptors that apply to all the API
+		// service methods.
+		Interceptors []*InterceptorExpr
 		// HTTP contains the HTTP specific API level expressions.
 		HTTP *HTTPExpr
 		// GRPC contains the gRPC specific API level expressions.
@@ -150,6 +153,9 @@ func (a *APIExpr) Finalize() {
 	if len(a.Servers) == 0 {
 		a.Servers = []*ServerExpr{a.DefaultServer()}
 	}
+	for _, i := range a.Interceptors {
+		i.Finalize()
+	}
 }
 
 // EvalName is the qualified name of the expression.
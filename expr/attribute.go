@@ -75,6 +75,18 @@ type (
 		// Maximum represents a maximum value validation as described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor17.
 		Maximum *float64
+		// ExclusiveMinimum represents an exclusive minimum value
+		// validation as described at
+		// http://json-schema.org/latest/json-schema-validation.html#rfc.section.6.2.5.
+		ExclusiveMinimum *float64
+		// ExclusiveMaximum represents an exclusive maximum value
+		// validation as described at
+		// http://json-schema.org/latest/json-schema-validation.html#rfc.section.6.2.3.
+		ExclusiveMaximum *float64
+		// MultipleOf represents a multiple of validation as described
+		// at
+		// http://json-schema.org/latest/json-schema-validation.html#rfc.section.6.2.1.
+		MultipleOf *float64
 		// MinLength represents an minimum length validation as
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor29.
@@ -83,6 +95,10 @@ type (
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor26.
 		MaxLength *int
+		// UniqueItems represents a unique items validation as
+		// described at
+		// http://json-schema.org/latest/json-schema-validation.html#rfc.section.6.4.5.
+		UniqueItems bool
 		// Required list the required fields of object attributes as
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor61.
@@ -588,12 +604,24 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 	if v.Maximum == nil || (other.Maximum != nil && *v.Maximum < *other.Maximum) {
 		v.Maximum = other.Maximum
 	}
+	if v.ExclusiveMinimum == nil || (other.ExclusiveMinimum != nil && *v.ExclusiveMinimum > *other.ExclusiveMinimum) {
+		v.ExclusiveMinimum = other.ExclusiveMinimum
+	}
+	if v.ExclusiveMaximum == nil || (other.ExclusiveMaximum != nil && *v.ExclusiveMaximum < *other.ExclusiveMaximum) {
+		v.ExclusiveMaximum = other.ExclusiveMaximum
+	}
+	if v.MultipleOf == nil {
+		v.MultipleOf = other.MultipleOf
+	}
 	if v.MinLength == nil || (other.MinLength != nil && *v.MinLength > *other.MinLength) {
 		v.MinLength = other.MinLength
 	}
 	if v.MaxLength == nil || (other.MaxLength != nil && *v.MaxLength < *other.MaxLength) {
 		v.MaxLength = other.MaxLength
 	}
+	if !v.UniqueItems {
+		v.UniqueItems = other.UniqueItems
+	}
 	v.AddRequired(other.Required...)
 }
 
@@ -635,6 +663,9 @@ func (v *ValidationExpr) HasRequiredOnly() bool {
 	if (v.Minimum != nil) || (v.Maximum != nil) || (v.MinLength != nil) || (v.MaxLength != nil) {
 		return false
 	}
+	if (v.ExclusiveMinimum != nil) || (v.ExclusiveMaximum != nil) || (v.MultipleOf != nil) || v.UniqueItems {
+		return false
+	}
 	return true
 }
 
@@ -646,18 +677,23 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		copy(req, v.Required)
 	}
 	return &ValidationExpr{
-		Values:    v.Values,
-		Format:    v.Format,
-		Pattern:   v.Pattern,
-		Minimum:   v.Minimum,
-		Maximum:   v.Maximum,
-		MinLength: v.MinLength,
-		MaxLength: v.MaxLength,
-		Required:  req,
+		Values:           v.Values,
+		Format:           v.Format,
+		Pattern:          v.Pattern,
+		Minimum:          v.Minimum,
+		Maximum:          v.Maximum,
+		ExclusiveMinimum: v.ExclusiveMinimum,
+		ExclusiveMaximum: v.ExclusiveMaximum,
+		MultipleOf:       v.MultipleOf,
+		MinLength:        v.MinLength,
+		MaxLength:        v.MaxLength,
+		UniqueItems:      v.UniqueItems,
+		Required:         req,
 	}
 }
 
-// IsSupportedValidationFormat checks if the validation format is supported by goa.
+// IsSupportedValidationFormat checks if the validation format is supported by
+// goa or was registered with the RegisterFormat DSL.
 func (a *AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 	switch vf {
 	case FormatDate:
@@ -689,7 +725,7 @@ func (a *AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 	case FormatRFC1123:
 		return true
 	}
-	return false
+	return Root.Format(vf) != nil
 }
 
 // walkAttribute iterates over the given attribute, its bases and references
@@ -78,6 +78,8 @@ func (d *dupper) DupType(t DataType) DataType {
 			res.Set(nat.Name, d.DupAttribute(nat.Attribute))
 		}
 		return res
+	case *Union:
+		return &Union{TypeName: actual.TypeName, Values: d.DupType(actual.Values).(*Object)}
 	case *Map:
 		return &Map{
 			KeyType:  d.DupAttribute(actual.KeyType),
@@ -127,7 +127,34 @@ func hasMinMaxValidation(a *AttributeExpr) bool {
 	if a.Validation == nil {
 		return false
 	}
-	return a.Validation.Minimum != nil || a.Validation.Maximum != nil
+	return a.Validation.Minimum != nil || a.Validation.Maximum != nil ||
+		a.Validation.ExclusiveMinimum != nil || a.Validation.ExclusiveMaximum != nil
+}
+
+// minMaxBounds returns the inclusive bounds resulting from the minimum,
+// maximum, exclusive minimum and exclusive maximum validations of a.
+func minMaxBounds(a *AttributeExpr) (min, max *float64) {
+	min, max = a.Validation.Minimum, a.Validation.Maximum
+	isFloat := a.Type.Kind() == Float32Kind || a.Type.Kind() == Float64Kind
+	if emin := a.Validation.ExclusiveMinimum; emin != nil {
+		v := math.Floor(*emin) + 1
+		if isFloat {
+			v = math.Nextafter(*emin, math.Inf(1))
+		}
+		if min == nil || v > *min {
+			min = &v
+		}
+	}
+	if emax := a.Validation.ExclusiveMaximum; emax != nil {
+		v := math.Ceil(*emax) - 1
+		if isFloat {
+			v = math.Nextafter(*emax, math.Inf(-1))
+		}
+		if max == nil || v < *max {
+			max = &v
+		}
+	}
+	return
 }
 
 // byLength generates a random size array of examples based on what's given.
@@ -203,6 +230,12 @@ func byFormat(a *AttributeExpr, r *Random) interface{} {
 	}[format]; ok {
 		return res
 	}
+	if f := Root.Format(format); f != nil {
+		if f.Example != "" {
+			return f.Example
+		}
+		return r.faker.Characters(10)
+	}
 	panic("Validation: unknown format '" + format + "'") // bug
 }
 
@@ -229,12 +262,14 @@ func byMinMax(a *AttributeExpr, r *Random) interface{} {
 		min  = math.Inf(-1)
 		max  = math.Inf(1)
 		sign = 1
+
+		minp, maxp = minMaxBounds(a)
 	)
-	if a.Validation.Maximum != nil {
-		max = *a.Validation.Maximum
+	if maxp != nil {
+		max = *maxp
 	}
-	if a.Validation.Minimum != nil {
-		min = *a.Validation.Minimum
+	if minp != nil {
+		min = *minp
 	} else {
 		sign = -1
 		min = max
@@ -321,14 +356,15 @@ func checkMinMaxValue(a *AttributeExpr, example interface{}) bool {
 	if !hasMinMaxValidation(a) {
 		return true
 	}
-	if min := a.Validation.Minimum; min != nil {
+	min, max := minMaxBounds(a)
+	if min != nil {
 		if v, ok := example.(int); ok && float64(v) < *min {
 			return false
 		} else if v, ok := example.(float64); ok && v < *min {
 			return false
 		}
 	}
-	if max := a.Validation.Maximum; max != nil {
+	if max != nil {
 		if v, ok := example.(int); ok && float64(v) > *max {
 			return false
 		} else if v, ok := example.(float64); ok && v > *max {
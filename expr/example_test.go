@@ -49,6 +49,34 @@ func TestByFormatUUID(t *testing.T) {
 	}
 }
 
+func TestByExclusiveMinMax(t *testing.T) {
+	var (
+		zero = 0.0
+		one  = 1.0
+		two  = 2.0
+	)
+	cases := []struct {
+		Name       string
+		Type       expr.DataType
+		Validation *expr.ValidationExpr
+		Expected   interface{}
+	}{
+		{"int-exclusive-bounds", expr.Int, &expr.ValidationExpr{ExclusiveMinimum: &zero, ExclusiveMaximum: &two}, 1},
+		{"int-exclusive-minimum", expr.Int, &expr.ValidationExpr{ExclusiveMinimum: &one, Maximum: &two}, 2},
+		{"int-exclusive-maximum", expr.Int, &expr.ValidationExpr{Minimum: &one, ExclusiveMaximum: &two}, 1},
+	}
+	r := expr.NewRandom("test")
+	for _, k := range cases {
+		t.Run(k.Name, func(t *testing.T) {
+			att := expr.AttributeExpr{Type: k.Type, Validation: k.Validation}
+			example := att.Example(r)
+			if example != k.Expected {
+				t.Errorf("got %#v, expected %#v", example, k.Expected)
+			}
+		})
+	}
+}
+
 func TestExample(t *testing.T) {
 	cases := []struct {
 		Name     string
@@ -0,0 +1,81 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// FieldMaskExpr describes the field mask that lets clients select the
+	// method result fields returned by the server.
+	FieldMaskExpr struct {
+		// Method is the method whose result fields may be selected.
+		Method *MethodExpr
+		// Name is the name of the HTTP query string parameter and of the
+		// gRPC request message field holding the field mask.
+		Name string
+	}
+)
+
+// DefaultFieldMaskName is the default name of the HTTP query string parameter
+// and of the gRPC request message field holding the field mask.
+const DefaultFieldMaskName = "fields"
+
+// EvalName returns the generic expression name used in error messages.
+func (f *FieldMaskExpr) EvalName() string {
+	return fmt.Sprintf("field mask %q of %s", f.Name, f.Method.EvalName())
+}
+
+// Validate makes sure the method result is an object and that the field mask
+// name does not clash with a payload attribute.
+func (f *FieldMaskExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	m := f.Method
+	if m.IsStreaming() {
+		verr.Add(f, "streaming methods cannot use field masks")
+		return verr
+	}
+	if !IsObject(m.Result.Type) {
+		verr.Add(f, "result must be an object")
+	}
+	if obj := AsObject(m.Payload.Type); obj != nil && obj.Attribute(f.Name) != nil {
+		verr.Add(f, "payload already defines attribute %q, pass a different name to FieldMask", f.Name)
+	}
+	return verr
+}
+
+// FieldMaskPaths returns the paths of the fields of the given attribute that
+// may be selected by a field mask. The paths of the fields of nested objects
+// are prefixed with the name of the parent field and a dot. Recursive types
+// are only traversed once.
+func FieldMaskPaths(att *AttributeExpr) []string {
+	return fieldMaskPaths(att, "", make(map[string]struct{}))
+}
+
+func fieldMaskPaths(att *AttributeExpr, prefix string, seen map[string]struct{}) []string {
+	var paths []string
+	if ut, ok := att.Type.(UserType); ok {
+		if _, ok := seen[ut.ID()]; ok {
+			return nil
+		}
+		seen[ut.ID()] = struct{}{}
+		defer delete(seen, ut.ID())
+	}
+	obj := AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	for _, nat := range *obj {
+		path := prefix + nat.Name
+		paths = append(paths, path)
+		child := nat.Attribute
+		if arr := AsArray(child.Type); arr != nil {
+			child = arr.ElemType
+		}
+		if _, ok := child.Type.(UserType); ok {
+			paths = append(paths, fieldMaskPaths(child, path+".", seen)...)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,30 @@
+package expr
+
+import "fmt"
+
+type (
+	// FormatExpr describes a custom validation format registered with the
+	// RegisterFormat DSL. Attributes that use the format with the Format DSL
+	// get validated by calling the format Go validation function.
+	FormatExpr struct {
+		// Name is the name of the format as given to the Format DSL.
+		Name ValidationFormat
+		// Description is the optional description of the format.
+		Description string
+		// Function is the qualified name of the Go function that
+		// validates the values, e.g. "formats.ValidateULID". The
+		// function must have the signature func(string) error.
+		Function string
+		// PkgPath is the import path of the package that defines
+		// Function.
+		PkgPath string
+		// Example is an optional example value used when generating
+		// examples for attributes using the format.
+		Example string
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (f *FormatExpr) EvalName() string {
+	return fmt.Sprintf("format %q", f.Name)
+}
@@ -2,10 +2,20 @@ package expr
 
 import (
 	"fmt"
+	"strconv"
 
 	"goa.design/goa/v3/eval"
 )
 
+const (
+	// maxFieldNumber is the largest protocol buffer field number.
+	maxFieldNumber = 1<<29 - 1
+	// reservedFieldNumberStart and reservedFieldNumberEnd delimit the field
+	// numbers reserved for the protocol buffer implementation.
+	reservedFieldNumberStart = 19000
+	reservedFieldNumberEnd   = 19999
+)
+
 type (
 	// GRPCEndpointExpr describes a gRPC endpoint. It embeds a MethodExpr
 	// and adds gRPC specific properties.
@@ -182,7 +192,7 @@ func (e *GRPCEndpointExpr) Validate() error {
 				msgFields = pobj
 			}
 			if len(*msgFields) > 0 {
-				validateRPCTags(msgFields, e)
+				verr.Merge(validateRPCTags(msgFields, e))
 			}
 		}
 	} else {
@@ -404,22 +414,44 @@ func validateMessage(msgAtt, serviceAtt *AttributeExpr, e *GRPCEndpointExpr, req
 }
 
 // validateRPCTags verifies whether every attribute in the object type has
-// "rpc:tag" set in the meta and the tag numbers are unique.
+// "rpc:tag" set in the meta and the tag numbers are unique. Union attributes
+// are generated as protocol buffer oneof fields so the tags are set on the
+// union values instead.
 func validateRPCTags(fields *Object, e *GRPCEndpointExpr) *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
 	foundRPC := make(map[string]string)
-	for _, nat := range *fields {
+	validateTag := func(nat *NamedAttributeExpr) {
 		if tag, ok := nat.Attribute.Meta["rpc:tag"]; !ok {
 			verr.Add(e, "attribute %q does not have \"rpc:tag\" defined in the meta", nat.Name)
+		} else if n, err := strconv.ParseUint(tag[0], 10, 32); err != nil || !validFieldNumber(n) {
+			verr.Add(e, "field number %s in attribute %q is invalid, field numbers must be between 1 and %d excluding %d to %d", tag[0], nat.Name, maxFieldNumber, reservedFieldNumberStart, reservedFieldNumberEnd)
 		} else if a, ok := foundRPC[tag[0]]; ok {
 			verr.Add(e, "field number %s in attribute %q already exists for attribute %q", tag[0], nat.Name, a)
 		} else {
 			foundRPC[tag[0]] = nat.Name
 		}
 	}
+	for _, nat := range *fields {
+		if u := AsUnion(nat.Attribute.Type); u != nil {
+			for _, unat := range *u.Values {
+				validateTag(unat)
+			}
+			continue
+		}
+		validateTag(nat)
+	}
 	return verr
 }
 
+// validFieldNumber returns true if n can be used as a protocol buffer field
+// number.
+func validFieldNumber(n uint64) bool {
+	if n < 1 || n > maxFieldNumber {
+		return false
+	}
+	return n < reservedFieldNumberStart || n > reservedFieldNumberEnd
+}
+
 // validateMetadata validates the gRPC metadata. It compares the given metadata
 // with the service type (Payload or Result) and ensures all the attributes
 // defined in the metadata type are found in the service type.
@@ -23,6 +23,17 @@ service "Service" gRPC endpoint "Method": Error "invalid_error_type" type is Any
 service "Service" gRPC endpoint "Method": Map element type is Any type which is not supported in gRPC`,
 			},
 		},
+		"endpoint-with-one-of": {
+			DSL: testdata.GRPCEndpointWithOneOf,
+		},
+		"endpoint-with-invalid-tags": {
+			DSL: testdata.GRPCEndpointWithInvalidTags,
+			Errors: []string{`service "Service" gRPC endpoint "Method": attribute "first_name" does not have "rpc:tag" defined in the meta
+service "Service" gRPC endpoint "Method": field number 0 in attribute "last_name" is invalid, field numbers must be between 1 and 536870911 excluding 19000 to 19999
+service "Service" gRPC endpoint "Method": field number 19000 in attribute "age" is invalid, field numbers must be between 1 and 536870911 excluding 19000 to 19999
+service "Service" gRPC endpoint "Method": field number 1 in attribute "name" already exists for attribute "id"`,
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -26,6 +26,15 @@ type (
 		Services []*HTTPServiceExpr
 		// Errors lists the error HTTP responses.
 		Errors []*HTTPErrorExpr
+		// ProblemDetails is true if all the error responses use the
+		// RFC 7807 problem details format.
+		ProblemDetails bool
+		// ProblemType is the default problem type URI of the error
+		// responses that use the problem details format.
+		ProblemType string
+		// Origins lists the cross-origin resource sharing policies
+		// common to all the API endpoints.
+		Origins []*HTTPOriginExpr
 	}
 )
 
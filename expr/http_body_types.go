@@ -25,6 +25,7 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 	var (
 		payload   = a.MethodExpr.Payload
 		headers   = a.Headers
+		cookies   = a.Cookies
 		params    = a.Params
 		userField string
 		passField string
@@ -46,10 +47,10 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 		}
 	}
 
-	bodyOnly := headers.IsEmpty() && params.IsEmpty() && a.MapQueryParams == nil
+	bodyOnly := headers.IsEmpty() && cookies.IsEmpty() && params.IsEmpty() && a.MapQueryParams == nil
 
-	// 1. If Payload is not an object then check whether there are params or
-	// headers defined and if so return empty type (payload encoded in
+	// 1. If Payload is not an object then check whether there are params,
+	// headers or cookies defined and if so return empty type (payload encoded in
 	// request params or headers) otherwise return payload type (payload
 	// encoded in request body).
 	if !IsObject(payload.Type) {
@@ -61,9 +62,10 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 		return &AttributeExpr{Type: Empty}
 	}
 
-	// 2. Remove header and param attributes
+	// 2. Remove header, cookie and param attributes
 	body := NewMappedAttributeExpr(payload)
 	removeAttributes(body, headers)
+	removeAttributes(body, cookies)
 	removeAttributes(body, params)
 	if a.MapQueryParams != nil && *a.MapQueryParams != "" {
 		removeAttribute(body, *a.MapQueryParams)
@@ -154,11 +156,11 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 	}
 
 	// 1. If attribute is not an object then check whether there are headers
-	// defined and if so return empty type (attr encoded in response
-	// headers) otherwise return renamed attr type (attr encoded in
-	// response body).
+	// or cookies defined and if so return empty type (attr encoded in
+	// response headers or cookies) otherwise return renamed attr type (attr
+	// encoded in response body).
 	if !IsObject(attr.Type) {
-		if resp.Headers.IsEmpty() {
+		if resp.Headers.IsEmpty() && resp.Cookies.IsEmpty() {
 			attr = DupAtt(attr)
 			renameType(attr, name, "Response") // Do not use ResponseBody as it could clash with name of element
 			return attr
@@ -166,9 +168,10 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 		return &AttributeExpr{Type: Empty}
 	}
 
-	// 2. Remove header attributes
+	// 2. Remove header and cookie attributes
 	body := NewMappedAttributeExpr(attr)
 	removeAttributes(body, resp.Headers)
+	removeAttributes(body, resp.Cookies)
 
 	// 3. Return empty type if no attribute left
 	if len(*AsObject(body.Type)) == 0 {
@@ -193,6 +196,7 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 	for i, v := range rt.Views {
 		mv := NewMappedAttributeExpr(v.AttributeExpr)
 		removeAttributes(mv, resp.Headers)
+		removeAttributes(mv, resp.Cookies)
 		nv := &ViewExpr{
 			AttributeExpr: mv.Attribute(),
 			Name:          v.Name,
@@ -305,6 +309,8 @@ func appendSuffix(dt DataType, suffix string, seen ...map[string]struct{}) {
 		for _, nat := range *actual {
 			appendSuffix(nat.Attribute.Type, suffix, seen...)
 		}
+	case *Union:
+		appendSuffix(actual.Values, suffix, seen...)
 	case *Array:
 		appendSuffix(actual.ElemType.Type, suffix, seen...)
 	case *Map:
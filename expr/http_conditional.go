@@ -0,0 +1,79 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+// HasValidators returns true if the endpoint defines the ETag or Last-Modified
+// validators of its result. The generated code of such endpoints handles
+// conditional GET and HEAD requests.
+func (e *HTTPEndpointExpr) HasValidators() bool {
+	return e.ETag != "" || e.LastModified != ""
+}
+
+// prepareConditional maps the ETag and LastModified result attributes to the
+// ETag and Last-Modified headers of the success responses and the IfMatch
+// payload attribute to the If-Match request header unless mapped explicitly.
+func (e *HTTPEndpointExpr) prepareConditional() {
+	for _, r := range e.Responses {
+		if r.StatusCode >= 300 {
+			continue
+		}
+		mapConditionalHeader(r.Headers, e.MethodExpr.Result, e.ETag, "ETag")
+		mapConditionalHeader(r.Headers, e.MethodExpr.Result, e.LastModified, "Last-Modified")
+	}
+	mapConditionalHeader(e.Headers, e.MethodExpr.Payload, e.IfMatch, "If-Match")
+}
+
+// validateConditional makes sure the attributes used by conditional requests
+// are strings defined in the method payload and result.
+func (e *HTTPEndpointExpr) validateConditional() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !e.HasValidators() && e.IfMatch == "" {
+		return verr
+	}
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(e, "ETag, LastModified and IfMatch cannot be used with streaming methods.")
+		return verr
+	}
+	check := func(fn, kind string, parent *AttributeExpr, name string) {
+		if name == "" {
+			return
+		}
+		if !IsObject(parent.Type) {
+			verr.Add(e, "%s requires the method %s to be an object.", fn, kind)
+			return
+		}
+		att := parent.Find(name)
+		if att == nil {
+			verr.Add(e, "%s attribute %q is not defined in the method %s.", fn, name, kind)
+			return
+		}
+		if att.Type != String {
+			verr.Add(e, "%s attribute %q must be a string.", fn, name)
+		}
+	}
+	check("ETag", "result", e.MethodExpr.Result, e.ETag)
+	check("LastModified", "result", e.MethodExpr.Result, e.LastModified)
+	check("IfMatch", "payload", e.MethodExpr.Payload, e.IfMatch)
+	if e.IfMatch != "" && !e.hasMutatingRoute() {
+		verr.Add(e, "IfMatch requires a route with a method other than GET or HEAD.")
+	}
+	return verr
+}
+
+// mapConditionalHeader maps the attribute of parent with the given name to
+// the given header unless name is empty, the attribute does not exist or is
+// already mapped.
+func mapConditionalHeader(headers *MappedAttributeExpr, parent *AttributeExpr, name, header string) {
+	if name == "" || headers.Find(name) != nil || !IsObject(parent.Type) {
+		return
+	}
+	att := parent.Find(name)
+	if att == nil {
+		return // reported by validateConditional
+	}
+	headers.Merge(NewMappedAttributeExpr(&AttributeExpr{
+		Type: &Object{&NamedAttributeExpr{Name: name + ":" + header, Attribute: DupAtt(att)}},
+	}))
+}
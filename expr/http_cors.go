@@ -0,0 +1,142 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPOriginExpr describes a cross-origin resource sharing (CORS)
+	// policy, that is the origin allowed to make cross-origin requests
+	// to the HTTP endpoints and the requests it may make.
+	HTTPOriginExpr struct {
+		// Origin is the allowed origin. It is either "*" to allow any
+		// origin, a regular expression delimited by slashes or the
+		// origin itself.
+		Origin string
+		// Methods lists the HTTP methods allowed in preflight requests.
+		// Prepare initializes it with the methods of the endpoint
+		// routes if not set.
+		Methods []string
+		// Headers lists the request headers allowed in preflight
+		// requests, "*" allows any header.
+		Headers []string
+		// ExposedHeaders lists the response headers exposed to the
+		// client.
+		ExposedHeaders []string
+		// MaxAge is the number of seconds the result of preflight
+		// requests may be cached, 0 if not set.
+		MaxAge int
+		// Credentials indicates that the requests may include
+		// credentials (cookies, authorization headers or TLS client
+		// certificates).
+		Credentials bool
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (o *HTTPOriginExpr) EvalName() string {
+	return fmt.Sprintf("origin %q", o.Origin)
+}
+
+// IsRegexp returns true if the origin is a regular expression.
+func (o *HTTPOriginExpr) IsRegexp() bool {
+	return len(o.Origin) > 1 && strings.HasPrefix(o.Origin, "/") && strings.HasSuffix(o.Origin, "/")
+}
+
+// Regexp returns the regular expression delimited by slashes in the origin.
+// The expression is anchored so that it must match the entire request origin.
+func (o *HTTPOriginExpr) Regexp() string {
+	return "^(?:" + o.Origin[1:len(o.Origin)-1] + ")$"
+}
+
+// Dup creates a copy of the origin expression.
+func (o *HTTPOriginExpr) Dup() *HTTPOriginExpr {
+	return &HTTPOriginExpr{
+		Origin:         o.Origin,
+		Methods:        append([]string{}, o.Methods...),
+		Headers:        append([]string{}, o.Headers...),
+		ExposedHeaders: append([]string{}, o.ExposedHeaders...),
+		MaxAge:         o.MaxAge,
+		Credentials:    o.Credentials,
+	}
+}
+
+// prepareOrigins merges the origins defined in the API, the parent service
+// and the endpoint designs. Origins defined in the endpoint override the
+// service origins with the same value which override the API origins. The
+// allowed methods default to the methods of the endpoint routes.
+func (e *HTTPEndpointExpr) prepareOrigins() {
+	var origins []*HTTPOriginExpr
+	merge := func(os []*HTTPOriginExpr) {
+		for _, o := range os {
+			found := false
+			for i, eo := range origins {
+				if eo.Origin == o.Origin {
+					origins[i] = o.Dup()
+					found = true
+					break
+				}
+			}
+			if !found {
+				origins = append(origins, o.Dup())
+			}
+		}
+	}
+	if Root.API != nil && Root.API.HTTP != nil {
+		merge(Root.API.HTTP.Origins)
+	}
+	merge(e.Service.Origins)
+	merge(e.Origins)
+	for _, o := range origins {
+		if len(o.Methods) > 0 {
+			continue
+		}
+		for _, r := range e.Routes {
+			if !containsString(o.Methods, r.Method) {
+				o.Methods = append(o.Methods, r.Method)
+			}
+		}
+	}
+	e.Origins = origins
+}
+
+// validateOrigins makes sure the origins and the preflight request settings
+// are valid.
+func (e *HTTPEndpointExpr) validateOrigins() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	for _, o := range e.Origins {
+		if o.Origin == "" {
+			verr.Add(e, "origin cannot be empty")
+		} else if o.IsRegexp() {
+			if _, err := regexp.Compile(o.Origin[1 : len(o.Origin)-1]); err != nil {
+				verr.Add(e, "invalid regular expression in origin %q: %s", o.Origin, err)
+			}
+		}
+		if o.Origin == "*" && o.Credentials {
+			verr.Add(e, "origin \"*\" cannot allow credentials, list the allowed origins instead")
+		}
+		for _, m := range o.Methods {
+			if m == "" || strings.ToUpper(m) != m || strings.ContainsAny(m, " \t,") {
+				verr.Add(e, "invalid method %q in origin %q, methods must be upper case HTTP method names", m, o.Origin)
+			}
+		}
+		if o.MaxAge < 0 {
+			verr.Add(e, "max age of origin %q cannot be negative, got %d", o.Origin, o.MaxAge)
+		}
+	}
+	return verr
+}
+
+// containsString returns true if vals contains v.
+func containsString(vals []string, v string) bool {
+	for _, val := range vals {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package expr_test
+
+import (
+	"regexp"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+)
+
+func TestHTTPOriginExprRegexp(t *testing.T) {
+	o := &expr.HTTPOriginExpr{Origin: "/.*\\.goa\\.design/"}
+	cases := []struct {
+		Origin  string
+		Matches bool
+	}{
+		{"https://api.goa.design", true},
+		{"https://x.goa.design.evil.com", false},
+		{"https://goa.design", false},
+	}
+	re := regexp.MustCompile(o.Regexp())
+	for _, c := range cases {
+		t.Run(c.Origin, func(t *testing.T) {
+			if m := re.MatchString(c.Origin); m != c.Matches {
+				t.Errorf("got match %v, expected %v", m, c.Matches)
+			}
+		})
+	}
+}
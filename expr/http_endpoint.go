@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"mime"
 	"path"
 	"strings"
 
@@ -9,6 +10,27 @@ import (
 	"goa.design/goa/v3/eval"
 )
 
+const (
+	// PreconditionFailedErrorName is the name of the error returned by
+	// goa.CheckIfMatch when the If-Match header of a request does not match
+	// the entity tag of the resource. Endpoints that define IfMatch map it
+	// to 412 Precondition Failed responses.
+	PreconditionFailedErrorName = "precondition_failed"
+
+	// NotAcceptableErrorName is the name of the error returned by the
+	// generated servers when none of the media types produced by the
+	// endpoint matches the Accept header of the request. Endpoints that
+	// negotiate the response content type map it to 406 Not Acceptable
+	// responses.
+	NotAcceptableErrorName = "not_acceptable"
+
+	// RequestTooLargeErrorName is the name of the error returned by the
+	// generated servers when the content of a multipart request exceeds
+	// the size limits. Endpoints that use MultipartRequest map it to 413
+	// Request Entity Too Large responses.
+	RequestTooLargeErrorName = "request_too_large"
+)
+
 type (
 	// HTTPEndpointExpr describes a HTTP endpoint. It embeds a MethodExpr and
 	// adds HTTP specific properties.
@@ -38,6 +60,8 @@ type (
 		Params *MappedAttributeExpr
 		// Headers defines the HTTP request headers.
 		Headers *MappedAttributeExpr
+		// Cookies defines the HTTP request cookies.
+		Cookies *MappedAttributeExpr
 		// Body describes the HTTP request body.
 		Body *AttributeExpr
 		// StreamingBody describes the body transferred through the websocket
@@ -54,6 +78,46 @@ type (
 		// MultipartRequest indicates that the request content type for
 		// the endpoint is a multipart type.
 		MultipartRequest bool
+		// FormBody indicates that the request body is encoded using
+		// the application/x-www-form-urlencoded content type.
+		FormBody bool
+		// SSE indicates that the endpoint streams the method results
+		// using server-sent events instead of websockets.
+		SSE bool
+		// NDJSON indicates that the endpoint streams the method payloads
+		// and/or results as newline-delimited JSON in chunked request
+		// and response bodies instead of websockets.
+		NDJSON bool
+		// ETag is the name of the result attribute holding the entity
+		// tag of the resource if any. The attribute is mapped to the
+		// ETag response header.
+		ETag string
+		// LastModified is the name of the result attribute holding the
+		// HTTP date of the last modification of the resource if any. The
+		// attribute is mapped to the Last-Modified response header.
+		LastModified string
+		// IfMatch is the name of the payload attribute mapped to the
+		// If-Match request header of conditional requests made to
+		// mutating routes if any.
+		IfMatch string
+		// Idempotent indicates that the server replays the response of
+		// requests retried with the same Idempotency-Key header.
+		Idempotent bool
+		// Produces lists the mime types generated by the endpoint in
+		// order of preference. Prepare initializes it with the mime
+		// types listed in the parent service or API design if not set.
+		// The server negotiates the response content type against the
+		// request Accept header when not empty.
+		Produces []string
+		// Consumes lists the mime types accepted by the endpoint.
+		// Prepare initializes it with the mime types listed in the
+		// parent service if not set. The mime types listed in the API
+		// design apply when empty.
+		Consumes []string
+		// Origins lists the cross-origin resource sharing policies of
+		// the endpoint. Prepare merges the policies defined in the
+		// parent service and API design.
+		Origins []*HTTPOriginExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -107,6 +171,82 @@ func (e *HTTPEndpointExpr) HasAbsoluteRoutes() bool {
 	return true
 }
 
+// hasMutatingRoute returns true if the endpoint defines a route with a method
+// other than GET or HEAD.
+func (e *HTTPEndpointExpr) hasMutatingRoute() bool {
+	for _, r := range e.Routes {
+		if r.Method != "GET" && r.Method != "HEAD" {
+			return true
+		}
+	}
+	return false
+}
+
+// NegotiatesContentType returns true if the server negotiates the response
+// content type from the mime types listed in Produces, that is if Produces is
+// not empty, the method is not streaming and the endpoint defines a success
+// response with a body that does not set its content type explicitly.
+func (e *HTTPEndpointExpr) NegotiatesContentType() bool {
+	if len(e.Produces) == 0 || e.MethodExpr.IsStreaming() {
+		return false
+	}
+	for _, r := range e.Responses {
+		if r.ContentType == "" && r.Body != nil && r.Body.Type != Empty {
+			return true
+		}
+	}
+	return false
+}
+
+// addImplicitError adds the error with the given name returned by the code
+// generated for the endpoint to the method and maps it to a response with
+// the given status code unless the design defines them explicitly. The error
+// uses the ErrorResult type. The HTTP error is mapped after the API level
+// errors are looked up so that the design can override the mapping at any
+// level.
+func (e *HTTPEndpointExpr) addImplicitError(name string, status int) {
+	if e.MethodExpr.Error(name) == nil {
+		e.MethodExpr.Errors = append(e.MethodExpr.Errors, &ErrorExpr{
+			AttributeExpr: &AttributeExpr{Type: ErrorResult},
+			Name:          name,
+		})
+	}
+	for _, er := range e.HTTPErrors {
+		if er.Name == name {
+			return
+		}
+	}
+	for _, er := range Root.API.HTTP.Errors {
+		if er.Name == name {
+			return
+		}
+	}
+	resp := &HTTPResponseExpr{StatusCode: status, Parent: e}
+	resp.Prepare()
+	e.HTTPErrors = append(e.HTTPErrors, &HTTPErrorExpr{Name: name, Response: resp})
+}
+
+// IsFormBody returns true if the endpoint request body is encoded using the
+// application/x-www-form-urlencoded content type, either because the
+// endpoint uses FormBody or because the content type is the first one listed
+// in the endpoint, service or API Consumes expression and the endpoint body
+// is an object.
+func (e *HTTPEndpointExpr) IsFormBody() bool {
+	if e.FormBody {
+		return true
+	}
+	if e.MultipartRequest || e.MethodExpr.IsStreaming() || e.Body == nil || e.Body.Type == Empty || !IsObject(e.Body.Type) {
+		return false
+	}
+	if len(e.Consumes) > 0 {
+		return e.Consumes[0] == "application/x-www-form-urlencoded"
+	}
+	if Root.API == nil || Root.API.HTTP == nil || len(Root.API.HTTP.Consumes) == 0 {
+		return false
+	}
+	return Root.API.HTTP.Consumes[0] == "application/x-www-form-urlencoded"
+}
+
 // PathParams computes a mapped attribute containing the subset of e.Params that
 // describe path parameters.
 func (e *HTTPEndpointExpr) PathParams() *MappedAttributeExpr {
@@ -197,6 +337,44 @@ func (e *HTTPEndpointExpr) Prepare() {
 
 	e.Headers = headers
 	e.Params = params
+	if e.Cookies == nil {
+		e.Cookies = NewEmptyMappedAttributeExpr()
+	}
+
+	// Inherit the produced mime types from the parent service or API. The
+	// API mime types are only inherited if explicitly set in the design as
+	// HTTPExpr.Finalize initializes them with defaults.
+	if len(e.Produces) == 0 {
+		if len(e.Service.Produces) > 0 {
+			e.Produces = append([]string{}, e.Service.Produces...)
+		} else if Root.API != nil && Root.API.HTTP != nil {
+			e.Produces = append([]string{}, Root.API.HTTP.Produces...)
+		}
+	}
+
+	// Inherit the accepted mime types from the parent service.
+	if len(e.Consumes) == 0 && len(e.Service.Consumes) > 0 {
+		e.Consumes = append([]string{}, e.Service.Consumes...)
+	}
+
+	e.prepareOrigins()
+
+	// Map the pagination attributes to query string parameters unless
+	// mapped explicitly.
+	if pag := e.MethodExpr.Pagination; pag != nil && e.Body == nil && e.MethodExpr.Payload != nil {
+		for _, name := range pag.PayloadAttributes() {
+			if params.Find(name) != nil || headers.Find(name) != nil {
+				continue
+			}
+			att := e.MethodExpr.Payload.Find(name)
+			if att == nil {
+				continue // reported by pagination validation
+			}
+			params.Merge(NewMappedAttributeExpr(&AttributeExpr{
+				Type: &Object{&NamedAttributeExpr{Name: name, Attribute: DupAtt(att)}},
+			}))
+		}
+	}
 
 	// Initialize path params that are not defined explicitly in
 	for _, r := range e.Routes {
@@ -232,6 +410,7 @@ func (e *HTTPEndpointExpr) Prepare() {
 	for _, r := range e.Responses {
 		r.Prepare()
 	}
+	e.prepareConditional()
 	for _, er := range e.HTTPErrors {
 		er.Response.Prepare()
 	}
@@ -297,6 +476,9 @@ func (e *HTTPEndpointExpr) Validate() error {
 				verr.Add(r, "Multiple response definitions with status code %d", r.StatusCode)
 			}
 		}
+		if r.ProblemDetails {
+			verr.Add(r, "ProblemDetails can only be used in error responses.")
+		}
 		if r.Tag[0] == "" {
 			allTagged = false
 		} else {
@@ -316,13 +498,61 @@ func (e *HTTPEndpointExpr) Validate() error {
 	if hasTags && allTagged {
 		verr.Add(e, "All responses define a Tag, at least one response must define no Tag.")
 	}
+	if e.SSE && e.MethodExpr.Stream != ServerStreamKind {
+		verr.Add(e, "ServerSentEvents requires the method to define a StreamingResult and no StreamingPayload.")
+	}
+	if e.NDJSON {
+		if !e.MethodExpr.IsStreaming() {
+			verr.Add(e, "NDJSON requires the method to define a StreamingPayload or a StreamingResult.")
+		}
+		if e.SSE {
+			verr.Add(e, "NDJSON and ServerSentEvents cannot be used together.")
+		}
+		if e.MethodExpr.StreamingPayload.Type != Empty && e.Body != nil {
+			verr.Add(e, "Body cannot be used with NDJSON when the method defines a StreamingPayload, the request body contains the stream.")
+		}
+	}
+	if e.Idempotent {
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "Idempotent cannot be used with streaming methods.")
+		}
+		if !e.hasMutatingRoute() {
+			verr.Add(e, "Idempotent requires a route with a method other than GET or HEAD.")
+		}
+	}
+	verr.Merge(e.validateOrigins())
+	for _, p := range e.Produces {
+		if mt, _, err := mime.ParseMediaType(p); err != nil {
+			verr.Add(e, "invalid mime type %q in Produces: %s", p, err)
+		} else if strings.Contains(mt, "*") {
+			verr.Add(e, "mime type %q in Produces cannot contain wildcards.", p)
+		}
+	}
+	if e.FormBody {
+		if e.MultipartRequest {
+			verr.Add(e, "FormBody and MultipartRequest cannot be used together.")
+		}
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "FormBody cannot be used with streaming methods.")
+		}
+		body := e.MethodExpr.Payload
+		if e.Body != nil {
+			body = e.Body
+		}
+		if isEmpty(body) || !IsObject(body.Type) {
+			verr.Add(e, "FormBody requires the request body to be an object.")
+		}
+	}
 	if hasTags && !IsObject(e.MethodExpr.Result.Type) {
 		verr.Add(e, "Some responses define a Tag but the method Result type is not an object.")
 	}
 
+	verr.Merge(e.validateConditional())
+
 	// Make sure parameters and headers use compatible types
 	verr.Merge(e.validateParams())
 	verr.Merge(e.validateHeaders())
+	verr.Merge(e.validateCookies())
 
 	// Validate body attribute (required fields exist etc.)
 	if e.Body != nil {
@@ -348,6 +578,9 @@ func (e *HTTPEndpointExpr) Validate() error {
 		if !e.Headers.IsEmpty() {
 			verr.Add(e, "Headers are set but Payload is not defined.")
 		}
+		if !e.Cookies.IsEmpty() {
+			verr.Add(e, "Cookies are set but Payload is not defined.")
+		}
 		return verr
 	}
 	if IsArray(e.MethodExpr.Payload.Type) {
@@ -507,6 +740,7 @@ func (e *HTTPEndpointExpr) Finalize() {
 	// payload attributes.
 	initAttr(e.Params, e.MethodExpr.Payload)
 	initAttr(e.Headers, e.MethodExpr.Payload)
+	initAttr(e.Cookies, e.MethodExpr.Payload)
 
 	if e.Body != nil {
 		// rename type to add RequestBody suffix so that we don't end with
@@ -533,6 +767,18 @@ func (e *HTTPEndpointExpr) Finalize() {
 		r.Body.Finalize()
 	}
 
+	// Add the errors returned by the generated code when evaluating the
+	// If-Match and Accept headers and when decoding multipart requests.
+	if e.IfMatch != "" {
+		e.addImplicitError(PreconditionFailedErrorName, StatusPreconditionFailed)
+	}
+	if e.NegotiatesContentType() {
+		e.addImplicitError(NotAcceptableErrorName, StatusNotAcceptable)
+	}
+	if e.MultipartRequest {
+		e.addImplicitError(RequestTooLargeErrorName, StatusRequestEntityTooLarge)
+	}
+
 	// Lookup undefined HTTP errors in API.
 	for _, err := range e.MethodExpr.Errors {
 		found := false
@@ -689,6 +935,34 @@ func (e *HTTPEndpointExpr) validateHeaders() *eval.ValidationErrors {
 	return verr
 }
 
+// validateCookies makes sure cookies are of a primitive type and the method
+// payload contains the cookies.
+func (e *HTTPEndpointExpr) validateCookies() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.Cookies.IsEmpty() || isEmpty(e.MethodExpr.Payload) {
+		return verr
+	}
+	if !IsObject(e.MethodExpr.Payload.Type) {
+		verr.Add(e, "Payload type is not an object but HTTP endpoint defines cookies. Cookies can only be used with object payloads.")
+		return verr
+	}
+	cookies := DupMappedAtt(e.Cookies)
+	initAttr(cookies, e.MethodExpr.Payload)
+	WalkMappedAttr(cookies, func(name, _ string, a *AttributeExpr) error {
+		if e.MethodExpr.Payload.Find(name) == nil {
+			verr.Add(e, "cookie %q not found in payload.", name)
+			return nil
+		}
+		if !IsPrimitive(a.Type) {
+			verr.Add(e, "cookie %q must be a primitive type", name)
+			return nil
+		}
+		verr.Merge(a.Validate(fmt.Sprintf("cookie %q", name), e))
+		return nil
+	})
+	return verr
+}
+
 // EvalName returns the generic definition name used in error messages.
 func (r *RouteExpr) EvalName() string {
 	return fmt.Sprintf(`route %s "%s" of %s`, r.Method, r.Path, r.Endpoint.EvalName())
@@ -735,7 +1009,11 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 	}
 
 	// For streaming endpoints, websockets does not support verbs other than GET
-	if r.Endpoint.MethodExpr.IsStreaming() {
+	if r.Endpoint.NDJSON {
+		if r.Endpoint.MethodExpr.StreamingPayload.Type != Empty && r.Method == "GET" {
+			verr.Add(r, "NDJSON streaming payloads cannot be sent with the \"GET\" method.")
+		}
+	} else if r.Endpoint.MethodExpr.IsStreaming() && !r.Endpoint.SSE {
 		if r.Method != "GET" {
 			verr.Add(r, "Streaming endpoint supports only \"GET\" method. Got %q.", r.Method)
 		}
@@ -63,6 +63,75 @@ func TestHTTPEndpointValidation(t *testing.T) {
 		"endpoint-has-parent": {
 			DSL: testdata.EndpointHasParent,
 		},
+		"endpoint-server-sent-events": {
+			DSL: testdata.EndpointServerSentEvents,
+		},
+		"endpoint-ndjson": {
+			DSL: testdata.EndpointNDJSON,
+		},
+		"endpoint-ndjson-invalid": {
+			DSL: testdata.EndpointNDJSONInvalid,
+			Errors: []string{
+				"route GET \"/\" of service \"Service\" HTTP endpoint \"Method\": NDJSON streaming payloads cannot be sent with the \"GET\" method.\nservice \"Service\" HTTP endpoint \"Method\": ServerSentEvents requires the method to define a StreamingResult and no StreamingPayload.\nservice \"Service\" HTTP endpoint \"Method\": NDJSON and ServerSentEvents cannot be used together.\nservice \"Service\" HTTP endpoint \"Method\": Body cannot be used with NDJSON when the method defines a StreamingPayload, the request body contains the stream.",
+			},
+		},
+		"endpoint-problem-details-invalid": {
+			DSL: testdata.EndpointProblemDetailsInvalid,
+			Errors: []string{
+				"HTTP response of service \"Service\" HTTP endpoint \"Method\": ProblemDetails can only be used in error responses.\nHTTP error bad_request: ProblemDetails cannot be used with ContentType, problem details documents use the \"application/problem+json\" content type.",
+			},
+		},
+		"endpoint-conditional": {
+			DSL: testdata.EndpointConditional,
+		},
+		"endpoint-conditional-invalid": {
+			DSL: testdata.EndpointConditionalInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": ETag attribute \"etag\" must be a string.\nservice \"Service\" HTTP endpoint \"Method\": LastModified attribute \"updated_at\" is not defined in the method result.\nservice \"Service\" HTTP endpoint \"Method\": IfMatch attribute \"version\" must be a string.\nservice \"Service\" HTTP endpoint \"Method\": IfMatch requires a route with a method other than GET or HEAD.",
+			},
+		},
+		"endpoint-idempotent-invalid": {
+			DSL: testdata.EndpointIdempotentInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": Idempotent cannot be used with streaming methods.\nservice \"Service\" HTTP endpoint \"Method\": Idempotent requires a route with a method other than GET or HEAD.",
+			},
+		},
+		"endpoint-cookie-invalid": {
+			DSL: testdata.EndpointCookieInvalid,
+			Errors: []string{
+				"HTTP response of service \"Service\" HTTP endpoint \"Method\": cookie \"token\" has no equivalent attribute in result type, use notation 'attribute_name:cookie_name' to identify corresponding result type attribute.\nservice \"Service\" HTTP endpoint \"Method\": cookie \"session\" must be a primitive type\nservice \"Service\" HTTP endpoint \"Method\": cookie \"csrf\" not found in payload.",
+			},
+		},
+		"endpoint-form-body-invalid": {
+			DSL: testdata.EndpointFormBodyInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": FormBody and MultipartRequest cannot be used together.\nservice \"Service\" HTTP endpoint \"Method\": FormBody requires the request body to be an object.",
+			},
+		},
+		"endpoint-produces-invalid": {
+			DSL: testdata.EndpointProducesInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": invalid mime type \"application/\" in Produces: mime: expected token after slash\nservice \"Service\" HTTP endpoint \"Method\": mime type \"text/*\" in Produces cannot contain wildcards.",
+			},
+		},
+		"endpoint-origin-invalid": {
+			DSL: testdata.EndpointOriginInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": invalid regular expression in origin \"/[a-z/\": error parsing regexp: missing closing ]: `[a-z`\nservice \"Service\" HTTP endpoint \"Method\": invalid method \"get\" in origin \"/[a-z/\", methods must be upper case HTTP method names\nservice \"Service\" HTTP endpoint \"Method\": max age of origin \"/[a-z/\" cannot be negative, got -1",
+			},
+		},
+		"endpoint-origin-wildcard-credentials": {
+			DSL: testdata.EndpointOriginWildcardCredentials,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": origin \"*\" cannot allow credentials, list the allowed origins instead",
+			},
+		},
+		"endpoint-server-sent-events-no-streaming-result": {
+			DSL: testdata.EndpointServerSentEventsNoStreamingResult,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": ServerSentEvents requires the method to define a StreamingResult and no StreamingPayload.",
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -135,3 +204,30 @@ func TestHTTPEndpointFinalization(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPEndpointImplicitErrors(t *testing.T) {
+	cases := map[string]struct {
+		Name   string
+		Status int
+	}{
+		"Show":   {expr.NotAcceptableErrorName, expr.StatusNotAcceptable},
+		"Update": {expr.PreconditionFailedErrorName, expr.StatusConflict},
+		"Delete": {expr.PreconditionFailedErrorName, expr.StatusPreconditionFailed},
+		"Upload": {expr.RequestTooLargeErrorName, expr.StatusRequestEntityTooLarge},
+	}
+	root := expr.RunDSL(t, testdata.EndpointImplicitErrors)
+	for _, e := range root.API.HTTP.Services[0].HTTPEndpoints {
+		c := cases[e.Name()]
+		t.Run(e.Name(), func(t *testing.T) {
+			if e.MethodExpr.Error(c.Name) == nil {
+				t.Errorf("got no error %q in method", c.Name)
+			}
+			if len(e.HTTPErrors) != 1 {
+				t.Fatalf("got %d HTTP errors, expected 1", len(e.HTTPErrors))
+			}
+			if herr := e.HTTPErrors[0]; herr.Name != c.Name || herr.Response.StatusCode != c.Status {
+				t.Errorf("got HTTP error %q with status %d, expected %q with status %d", herr.Name, herr.Response.StatusCode, c.Name, c.Status)
+			}
+		})
+	}
+}
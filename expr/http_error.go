@@ -41,6 +41,9 @@ func (e *HTTPErrorExpr) Validate() *eval.ValidationErrors {
 			verr.Add(e, "Error %#v does not match an error defined in the API", e.Name)
 		}
 	}
+	if e.Response.ProblemDetails && e.Response.ContentType != "" {
+		verr.Add(e, "ProblemDetails cannot be used with ContentType, problem details documents use the %q content type.", "application/problem+json")
+	}
 	return verr
 }
 
@@ -60,6 +63,12 @@ func (e *HTTPErrorExpr) Finalize(a *HTTPEndpointExpr) {
 	if e.Response.Body == nil {
 		e.Response.Body = httpErrorResponseBody(a, e)
 	}
+	if api := Root.API.HTTP; api.ProblemDetails {
+		e.Response.ProblemDetails = true
+		if e.Response.ProblemType == "" {
+			e.Response.ProblemType = api.ProblemType
+		}
+	}
 
 	// Initialize response content type if result is media type.
 	if e.Response.Body.Type == Empty {
@@ -84,6 +84,10 @@ type (
 		Description string
 		// Headers describe the HTTP response headers.
 		Headers *MappedAttributeExpr
+		// Cookies describe the HTTP response cookies. The properties of
+		// the cookies (MaxAge, Secure etc.) are stored in the Meta of
+		// the mapped attribute under the "cookie:" prefix.
+		Cookies *MappedAttributeExpr
 		// Response body if any
 		Body *AttributeExpr
 		// Response Content-Type header value
@@ -96,6 +100,12 @@ type (
 		Parent eval.Expression
 		// Meta is a list of key/value pairs
 		Meta MetaExpr
+		// ProblemDetails is true if the response body is encoded as a
+		// RFC 7807 problem details document. Only applies to error
+		// responses.
+		ProblemDetails bool
+		// ProblemType is the problem type URI, "about:blank" if empty.
+		ProblemType string
 	}
 )
 
@@ -114,6 +124,9 @@ func (r *HTTPResponseExpr) Prepare() {
 	if r.Headers == nil {
 		r.Headers = NewEmptyMappedAttributeExpr()
 	}
+	if r.Cookies == nil {
+		r.Cookies = NewEmptyMappedAttributeExpr()
+	}
 }
 
 // Validate checks that the response definition is consistent: its status is set
@@ -131,6 +144,9 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 		if !r.Headers.IsEmpty() {
 			verr.Add(r, "response defines headers but result is empty")
 		}
+		if !r.Cookies.IsEmpty() {
+			verr.Add(r, "response defines cookies but result is empty")
+		}
 		return verr
 	}
 
@@ -182,6 +198,22 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 			verr.Add(r, "response defines more than one header but result type is not an object")
 		}
 	}
+	if !r.Cookies.IsEmpty() {
+		verr.Merge(r.Cookies.Validate("HTTP response cookies", r))
+		if !IsObject(e.MethodExpr.Result.Type) {
+			verr.Add(r, "response defines cookies but result type is not an object")
+		} else {
+			cookies := DupMappedAtt(r.Cookies)
+			initAttr(cookies, e.MethodExpr.Result)
+			for _, c := range *AsObject(cookies.Type) {
+				if !hasAttribute(c.Name) {
+					verr.Add(r, "cookie %q has no equivalent attribute in%s result type, use notation 'attribute_name:cookie_name' to identify corresponding result type attribute.", c.Name, inview)
+				} else if !IsPrimitive(c.Attribute.Type) {
+					verr.Add(r, "cookie %q must be a primitive type", c.Name)
+				}
+			}
+		}
+	}
 	if r.Body != nil {
 		verr.Merge(r.Body.Validate("HTTP response body", r))
 		if att, ok := r.Body.Meta["origin:attribute"]; ok {
@@ -241,6 +273,10 @@ func (r *HTTPResponseExpr) Finalize(a *HTTPEndpointExpr, svcAtt *AttributeExpr)
 		}
 	}
 	initAttr(r.Headers, svcAtt)
+	if r.Cookies == nil {
+		r.Cookies = NewEmptyMappedAttributeExpr()
+	}
+	initAttr(r.Cookies, svcAtt)
 }
 
 // Dup creates a copy of the response expression.
@@ -251,11 +287,17 @@ func (r *HTTPResponseExpr) Dup() *HTTPResponseExpr {
 		ContentType: r.ContentType,
 		Parent:      r.Parent,
 		Meta:        r.Meta,
+
+		ProblemDetails: r.ProblemDetails,
+		ProblemType:    r.ProblemType,
 	}
 	if r.Body != nil {
 		res.Body = DupAtt(r.Body)
 	}
 	res.Headers = DupMappedAtt(r.Headers)
+	if r.Cookies != nil {
+		res.Cookies = DupMappedAtt(r.Cookies)
+	}
 	return &res
 }
 
@@ -35,6 +35,15 @@ type (
 		HTTPEndpoints []*HTTPEndpointExpr
 		// HTTPErrors lists HTTP errors that apply to all endpoints.
 		HTTPErrors []*HTTPErrorExpr
+		// Produces lists the mime types generated by the service
+		// endpoints in order of preference if any.
+		Produces []string
+		// Consumes lists the mime types accepted by the service
+		// endpoints if any.
+		Consumes []string
+		// Origins lists the cross-origin resource sharing policies
+		// common to all the service endpoints.
+		Origins []*HTTPOriginExpr
 		// FileServers is the list of static asset serving endpoints
 		FileServers []*HTTPFileServerExpr
 		// Meta is a set of key/value pairs with semantic that is
@@ -0,0 +1,192 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// InterceptorExpr describes an interceptor. Interceptors wrap the
+	// server and client endpoints of the methods they apply to and may
+	// read or write the attributes of the method payload and result listed
+	// in the interceptor definition.
+	InterceptorExpr struct {
+		// Name is the interceptor name.
+		Name string
+		// Description of the interceptor.
+		Description string
+		// ReadPayload lists the payload attributes read by the
+		// interceptor.
+		ReadPayload *AttributeExpr
+		// WritePayload lists the payload attributes written by the
+		// interceptor.
+		WritePayload *AttributeExpr
+		// ReadResult lists the result attributes read by the interceptor.
+		ReadResult *AttributeExpr
+		// WriteResult lists the result attributes written by the
+		// interceptor.
+		WriteResult *AttributeExpr
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (i *InterceptorExpr) EvalName() string {
+	return fmt.Sprintf("interceptor %q", i.Name)
+}
+
+// HasPayloadAccess returns true if the interceptor reads or writes payload
+// attributes.
+func (i *InterceptorExpr) HasPayloadAccess() bool {
+	return i.ReadPayload != nil || i.WritePayload != nil
+}
+
+// HasResultAccess returns true if the interceptor reads or writes result
+// attributes.
+func (i *InterceptorExpr) HasResultAccess() bool {
+	return i.ReadResult != nil || i.WriteResult != nil
+}
+
+// AccessesPayload returns true if the interceptor accesses the attributes of
+// the payload of method m, that is if it reads or writes payload attributes
+// and the payload defines all of them.
+func (i *InterceptorExpr) AccessesPayload(m *MethodExpr) bool {
+	return definesAll(m.Payload, i.ReadPayload, i.WritePayload)
+}
+
+// AccessesResult returns true if the interceptor accesses the attributes of
+// the result of method m, that is if it reads or writes result attributes
+// and the result defines all of them.
+func (i *InterceptorExpr) AccessesResult(m *MethodExpr) bool {
+	return definesAll(m.Result, i.ReadResult, i.WriteResult)
+}
+
+// validateMethod validates that the method payload and result define the
+// attributes accessed by the interceptor with compatible types. inherited is
+// true if the interceptor is defined in the API or the service of the method:
+// such interceptors only access the payload and result of the methods that
+// define all the attributes they list.
+func (i *InterceptorExpr) validateMethod(m *MethodExpr, inherited bool) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if m.IsStreaming() {
+		verr.Add(m, "interceptor %q cannot be used on streaming methods", i.Name)
+		return verr
+	}
+	validate := func(kind string, target *AttributeExpr, atts ...*AttributeExpr) {
+		_, isResultType := target.Type.(*ResultTypeExpr)
+		seen := make(map[string]struct{})
+		for _, att := range atts {
+			if att == nil {
+				continue
+			}
+			tobj := AsObject(target.Type)
+			if tobj == nil {
+				if !inherited {
+					verr.Add(m, "interceptor %q accesses %s attributes but the %s of method %q is not an object", i.Name, kind, kind, m.Name)
+				}
+				return
+			}
+			for _, nat := range *AsObject(att.Type) {
+				if _, ok := seen[nat.Name]; ok {
+					continue
+				}
+				seen[nat.Name] = struct{}{}
+				tatt := tobj.Attribute(nat.Name)
+				if tatt == nil {
+					if !inherited {
+						verr.Add(m, "interceptor %q accesses %s attribute %q but the %s of method %q does not define it", i.Name, kind, nat.Name, kind, m.Name)
+					}
+					continue
+				}
+				if !Equal(nat.Attribute.Type, tatt.Type) {
+					verr.Add(m, "interceptor %q accesses %s attribute %q with type %s but the type of the attribute is %s in method %q", i.Name, kind, nat.Name, nat.Attribute.Type.Name(), tatt.Type.Name(), m.Name)
+					continue
+				}
+				if isResultType && !hasPrimitiveValues(tatt.Type) {
+					verr.Add(m, "interceptor %q accesses %s attribute %q of result type %q with type %s, only attributes of primitive types or arrays and maps of primitive types can be accessed in result types", i.Name, kind, nat.Name, target.Type.Name(), tatt.Type.Name())
+					continue
+				}
+				if att.IsRequired(nat.Name) && target.IsPrimitivePointer(nat.Name, true) {
+					verr.Add(m, "interceptor %q requires %s attribute %q but the attribute is neither required nor has a default value in method %q", i.Name, kind, nat.Name, m.Name)
+				}
+			}
+		}
+	}
+	validate("payload", m.Payload, i.ReadPayload, i.WritePayload)
+	validate("result", m.Result, i.ReadResult, i.WriteResult)
+	return verr
+}
+
+// Finalize finalizes the attributes accessed by the interceptor.
+func (i *InterceptorExpr) Finalize() {
+	for _, att := range []*AttributeExpr{i.ReadPayload, i.WritePayload, i.ReadResult, i.WriteResult} {
+		if att != nil {
+			att.Finalize()
+		}
+	}
+}
+
+// AllInterceptors returns the interceptors that apply to the method: the API
+// interceptors followed by the service interceptors and the method
+// interceptors. API and service interceptors do not apply to streaming
+// methods.
+func (m *MethodExpr) AllInterceptors() []*InterceptorExpr {
+	var res []*InterceptorExpr
+	if !m.IsStreaming() {
+		if Root.API != nil {
+			res = append(res, Root.API.Interceptors...)
+		}
+		if m.Service != nil {
+			res = append(res, m.Service.Interceptors...)
+		}
+	}
+	return append(res, m.Interceptors...)
+}
+
+// definesInterceptor returns true if i is defined in the method rather than
+// in its service or API.
+func (m *MethodExpr) definesInterceptor(i *InterceptorExpr) bool {
+	for _, mi := range m.Interceptors {
+		if mi == i {
+			return true
+		}
+	}
+	return false
+}
+
+// definesAll returns true if at least one of atts is not nil and target is an
+// object that defines all the attributes of atts.
+func definesAll(target *AttributeExpr, atts ...*AttributeExpr) bool {
+	found := false
+	for _, att := range atts {
+		if att == nil {
+			continue
+		}
+		found = true
+		tobj := AsObject(target.Type)
+		if tobj == nil {
+			return false
+		}
+		for _, nat := range *AsObject(att.Type) {
+			if tobj.Attribute(nat.Name) == nil {
+				return false
+			}
+		}
+	}
+	return found
+}
+
+// hasPrimitiveValues returns true if dt is a primitive type or an array or a
+// map of primitive values. Result types are projected onto types where such
+// attributes keep the same Go type.
+func hasPrimitiveValues(dt DataType) bool {
+	switch actual := dt.(type) {
+	case Primitive:
+		return true
+	case *Array:
+		return hasPrimitiveValues(actual.ElemType.Type)
+	case *Map:
+		return hasPrimitiveValues(actual.KeyType.Type) && hasPrimitiveValues(actual.ElemType.Type)
+	}
+	return false
+}
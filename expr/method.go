@@ -31,6 +31,19 @@ type (
 		// schemes. Incoming requests must validate at least one
 		// requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Interceptors lists the interceptors defined on the method, see
+		// AllInterceptors for the complete list of interceptors that
+		// apply to the method.
+		Interceptors []*InterceptorExpr
+		// Pagination describes how the method paginates its results if
+		// it does.
+		Pagination *PaginationExpr
+		// FieldMask describes the field mask used by clients to select
+		// the result fields if any.
+		FieldMask *FieldMaskExpr
+		// ViewSelector describes how clients select the view used to
+		// render the result if they do.
+		ViewSelector *ViewSelectorExpr
 		// Service that owns method.
 		Service *ServiceExpr
 		// Meta is an arbitrary set of key/value pairs, see dsl.Meta
@@ -157,6 +170,36 @@ func (m *MethodExpr) Validate() error {
 	if m.Result.Type != Empty {
 		verr.Merge(m.Result.Validate("result", m))
 	}
+	if m.Pagination != nil {
+		if err := m.Pagination.Validate(); err != nil {
+			if verrs, ok := err.(*eval.ValidationErrors); ok {
+				verr.Merge(verrs)
+			}
+		}
+	}
+	if m.FieldMask != nil {
+		if err := m.FieldMask.Validate(); err != nil {
+			if verrs, ok := err.(*eval.ValidationErrors); ok {
+				verr.Merge(verrs)
+			}
+		}
+	}
+	if m.ViewSelector != nil {
+		if err := m.ViewSelector.Validate(); err != nil {
+			if verrs, ok := err.(*eval.ValidationErrors); ok {
+				verr.Merge(verrs)
+			}
+		}
+	}
+	seen := make(map[string]struct{})
+	for _, i := range m.AllInterceptors() {
+		if _, ok := seen[i.Name]; ok {
+			verr.Add(m, "interceptor %q is defined more than once", i.Name)
+			continue
+		}
+		seen[i.Name] = struct{}{}
+		verr.Merge(i.validateMethod(m, !m.definesInterceptor(i)))
+	}
 	for i, e := range m.Errors {
 		if err := e.Validate(); err != nil {
 			if verrs, ok := err.(*eval.ValidationErrors); ok {
@@ -230,6 +273,9 @@ func (m *MethodExpr) Finalize() {
 	for _, e := range m.Errors {
 		e.Finalize()
 	}
+	for _, i := range m.Interceptors {
+		i.Finalize()
+	}
 
 	// Inherit security requirements
 	noreq := false
@@ -26,6 +26,34 @@ service "InvalidSecuritySchemesService" method "InheritedSecureMethod": payload
 service "InvalidSecuritySchemesService" method "InheritedSecureMethod": payload of method "InheritedSecureMethod" of service "InvalidSecuritySchemesService" does not define an API key attribute, use APIKey to define one
 service "InvalidSecuritySchemesService" method "InheritedSecureMethod": security scope "not:found" not found in any of the security schemes.`,
 		},
+		{"invalid-interceptors", testdata.InvalidInterceptorsDSL,
+			`service "InvalidInterceptorsService" method "MissingAttribute": interceptor "Audit" accesses payload attribute "user" but the payload of method "MissingAttribute" does not define it
+service "InvalidInterceptorsService" method "InvalidType": interceptor "Tenant" accesses payload attribute "tenant" with type string but the type of the attribute is int in method "InvalidType"
+service "InvalidInterceptorsService" method "NotRequired": interceptor "Tenant" requires payload attribute "tenant" but the attribute is neither required nor has a default value in method "NotRequired"
+service "InvalidInterceptorsService" method "ResultType": interceptor "Redact" accesses result attribute "owner" of result type "Intercepted" with type InterceptedOwner, only attributes of primitive types or arrays and maps of primitive types can be accessed in result types
+service "InvalidInterceptorsService" method "Streaming": interceptor "Log" cannot be used on streaming methods
+service "InvalidInterceptorsService" method "Duplicate": interceptor "Tenant" is defined more than once`,
+		},
+		{"invalid-pagination", testdata.InvalidPaginationDSL,
+			`pagination of service "InvalidPaginationService" method "NoCursorNorOffset": pagination must define either a cursor with Cursor or an offset with Offset
+pagination of service "InvalidPaginationService" method "MissingItems": pagination must define the result attribute containing the page items with Items
+pagination of service "InvalidPaginationService" method "InvalidTypes": payload attribute "cursor" must be a string
+pagination of service "InvalidPaginationService" method "InvalidTypes": result does not define attribute "next"
+pagination of service "InvalidPaginationService" method "InvalidTypes": payload attribute "limit" must be an int
+pagination of service "InvalidPaginationService" method "InvalidTypes": result attribute "items" must be an array
+pagination of service "InvalidPaginationService" method "Streaming": streaming methods cannot be paginated`,
+		},
+		{"invalid-field-mask", testdata.InvalidFieldMaskDSL,
+			`field mask "fields" of service "InvalidFieldMaskService" method "NotObject": result must be an object
+field mask "fields" of service "InvalidFieldMaskService" method "Clash": payload already defines attribute "fields", pass a different name to FieldMask
+field mask "select" of service "InvalidFieldMaskService" method "Streaming": streaming methods cannot use field masks`,
+		},
+		{"invalid-view-selector", testdata.InvalidViewSelectorDSL,
+			`view selector "view" of service "InvalidViewSelectorService" method "NotResultType": result must be a result type
+view selector "view" of service "InvalidViewSelectorService" method "ExplicitView": result must not set a view with View
+view selector "view" of service "InvalidViewSelectorService" method "Clash": payload already defines attribute "view", pass a different name to ViewSelector
+view selector "v" of service "InvalidViewSelectorService" method "Streaming": streaming methods cannot use view selectors`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
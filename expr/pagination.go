@@ -0,0 +1,102 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// PaginationExpr describes how a method paginates its results. Cursor
+	// pagination uses an opaque cursor returned in the result to request
+	// the next page while offset pagination uses the number of items
+	// returned so far.
+	PaginationExpr struct {
+		// Method is the paginated method.
+		Method *MethodExpr
+		// CursorAttribute is the name of the payload attribute holding
+		// the cursor of the requested page (cursor pagination).
+		CursorAttribute string
+		// NextCursorAttribute is the name of the result attribute
+		// holding the cursor of the next page (cursor pagination).
+		NextCursorAttribute string
+		// OffsetAttribute is the name of the payload attribute holding
+		// the offset of the first item of the requested page (offset
+		// pagination).
+		OffsetAttribute string
+		// PageSizeAttribute is the name of the payload attribute holding
+		// the maximum number of items in a page if any.
+		PageSizeAttribute string
+		// ItemsAttribute is the name of the result attribute holding the
+		// page items.
+		ItemsAttribute string
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (p *PaginationExpr) EvalName() string {
+	return "pagination of " + p.Method.EvalName()
+}
+
+// IsCursor returns true if the method uses cursor pagination.
+func (p *PaginationExpr) IsCursor() bool {
+	return p.CursorAttribute != ""
+}
+
+// PayloadAttributes returns the names of the payload attributes used to
+// request a page.
+func (p *PaginationExpr) PayloadAttributes() []string {
+	var names []string
+	if p.IsCursor() {
+		names = append(names, p.CursorAttribute)
+	} else if p.OffsetAttribute != "" {
+		names = append(names, p.OffsetAttribute)
+	}
+	if p.PageSizeAttribute != "" {
+		names = append(names, p.PageSizeAttribute)
+	}
+	return names
+}
+
+// Validate makes sure the pagination attributes are defined in the method
+// payload and result with the proper types.
+func (p *PaginationExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	m := p.Method
+	if m.IsStreaming() {
+		verr.Add(p, "streaming methods cannot be paginated")
+		return verr
+	}
+	if p.IsCursor() == (p.OffsetAttribute != "") {
+		verr.Add(p, "pagination must define either a cursor with Cursor or an offset with Offset")
+		return verr
+	}
+	if p.ItemsAttribute == "" {
+		verr.Add(p, "pagination must define the result attribute containing the page items with Items")
+	}
+	check := func(kind string, parent *AttributeExpr, name string, valid func(DataType) bool, typ string) {
+		if name == "" {
+			return
+		}
+		obj := AsObject(parent.Type)
+		if obj == nil {
+			verr.Add(p, "%s must be an object to define attribute %q", kind, name)
+			return
+		}
+		att := obj.Attribute(name)
+		if att == nil {
+			verr.Add(p, "%s does not define attribute %q", kind, name)
+			return
+		}
+		if !valid(att.Type) {
+			verr.Add(p, "%s attribute %q must be %s", kind, name, typ)
+		}
+	}
+	isString := func(dt DataType) bool { return dt == String }
+	isInt := func(dt DataType) bool { return dt == Int }
+	isArray := func(dt DataType) bool { return IsArray(dt) }
+	check("payload", m.Payload, p.CursorAttribute, isString, "a string")
+	check("result", m.Result, p.NextCursorAttribute, isString, "a string")
+	check("payload", m.Payload, p.OffsetAttribute, isInt, "an int")
+	check("payload", m.Payload, p.PageSizeAttribute, isInt, "an int")
+	check("result", m.Result, p.ItemsAttribute, isArray, "an array")
+	return verr
+}
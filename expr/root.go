@@ -32,6 +32,8 @@ type (
 		Creations []*TypeMap
 		// Schemes list the registered security schemes.
 		Schemes []*SchemeExpr
+		// Formats list the registered custom validation formats.
+		Formats []*FormatExpr
 	}
 
 	// MetaExpr is a set of key/value pairs
@@ -161,6 +163,17 @@ func (r *RootExpr) UserType(name string) UserType {
 	return nil
 }
 
+// Format returns the custom validation format with the given name, nil if
+// there isn't one.
+func (r *RootExpr) Format(name ValidationFormat) *FormatExpr {
+	for _, f := range r.Formats {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
 // GeneratedResultType returns the generated result type expression with the given
 // id, nil if there isn't one.
 func (r *RootExpr) GeneratedResultType(id string) *ResultTypeExpr {
@@ -26,6 +26,9 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Interceptors lists the interceptors that apply to all the
+		// service methods.
+		Interceptors []*InterceptorExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -75,7 +78,7 @@ func (s *ServiceExpr) Hash() string {
 	return "_service_+" + s.Name
 }
 
-// Validate validates the service methods and errors.
+// Validate validates the service methods, errors and interceptors.
 func (s *ServiceExpr) Validate() error {
 	verr := new(eval.ValidationErrors)
 	for _, e := range s.Errors {
@@ -85,6 +88,16 @@ func (s *ServiceExpr) Validate() error {
 			}
 		}
 	}
+	interceptors := make(map[string]*InterceptorExpr)
+	for _, m := range s.Methods {
+		for _, i := range m.Interceptors {
+			if other, ok := interceptors[i.Name]; ok && other != i {
+				verr.Add(s, "interceptor %q is defined in multiple methods, define it in the service or the API instead", i.Name)
+				continue
+			}
+			interceptors[i.Name] = i
+		}
+	}
 	return verr
 }
 
@@ -93,6 +106,9 @@ func (s *ServiceExpr) Finalize() {
 	for _, e := range s.Errors {
 		e.Finalize()
 	}
+	for _, i := range s.Interceptors {
+		i.Finalize()
+	}
 }
 
 // Validate checks that the error name is found in the result meta for
@@ -251,3 +251,297 @@ var GRPCEndpointWithAnyType = func() {
 		})
 	})
 }
+
+var GRPCEndpointWithOneOf = func() {
+	var Figure = Type("Figure", func() {
+		Field(1, "name", String)
+		OneOf("shape", func() {
+			Field(2, "circle", Int)
+			Field(3, "square", Int)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(Figure)
+			GRPC(func() {
+				Response(CodeOK, func() {
+					Message(func() {
+						Attribute("name")
+						Attribute("shape")
+					})
+				})
+			})
+		})
+	})
+}
+
+var GRPCEndpointWithInvalidTags = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("first_name", String)
+				Field(0, "last_name", String)
+				Field(19000, "age", Int)
+			})
+			Result(func() {
+				Field(1, "id", String)
+				Field(1, "name", String)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var EndpointServerSentEvents = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			StreamingResult(String)
+			HTTP(func() {
+				POST("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var EndpointServerSentEventsNoStreamingResult = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var EndpointNDJSON = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				POST("/")
+				Header("name")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var EndpointNDJSONInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			StreamingPayload(String)
+			HTTP(func() {
+				GET("/")
+				Body("name")
+				NDJSON()
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var EndpointProblemDetailsInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(String)
+			Error("bad_request")
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					ProblemDetails()
+				})
+				Response("bad_request", StatusBadRequest, func() {
+					ProblemDetails()
+					ContentType("application/json")
+				})
+			})
+		})
+	})
+}
+
+var EndpointConditional = func() {
+	Service("Service", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(func() {
+				Attribute("name", String)
+				Attribute("etag", String)
+				Attribute("updated_at", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				ETag("etag")
+				LastModified("updated_at")
+			})
+		})
+		Method("Update", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("version", String)
+			})
+			HTTP(func() {
+				PUT("/{id}")
+				IfMatch("version")
+			})
+		})
+	})
+}
+
+var EndpointConditionalInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("version", Int)
+			})
+			Result(func() {
+				Attribute("etag", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				ETag("etag")
+				LastModified("updated_at")
+				IfMatch("version")
+			})
+		})
+	})
+}
+
+var EndpointIdempotentInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				Idempotent()
+			})
+		})
+	})
+}
+
+var EndpointCookieInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("session", ArrayOf(String))
+			})
+			Result(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Cookie("session")
+				Cookie("csrf")
+				Response(StatusOK, func() {
+					Cookie("token")
+				})
+			})
+		})
+	})
+}
+
+var EndpointFormBodyInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+				FormBody()
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var EndpointProducesInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Produces("application/json", "application/", "text/*")
+			})
+		})
+	})
+}
+
+var EndpointOriginWildcardCredentials = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Origin("*", func() {
+					AllowCredentials()
+				})
+			})
+		})
+	})
+}
+
+var EndpointOriginInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Origin("/[a-z/", func() {
+					AllowMethods("get")
+					MaxAge(-1)
+				})
+			})
+		})
+	})
+}
+
+var EndpointImplicitErrors = func() {
+	Service("Service", func() {
+		Method("Show", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Produces("application/json")
+			})
+		})
+		Method("Update", func() {
+			Payload(func() {
+				Attribute("version", String)
+			})
+			Error("precondition_failed")
+			HTTP(func() {
+				PUT("/")
+				IfMatch("version")
+				Response("precondition_failed", StatusConflict)
+			})
+		})
+		Method("Delete", func() {
+			Payload(func() {
+				Attribute("version", String)
+			})
+			HTTP(func() {
+				DELETE("/")
+				IfMatch("version")
+			})
+		})
+		Method("Upload", func() {
+			Payload(func() {
+				Attribute("file", Bytes)
+			})
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+			})
+		})
+	})
+}
@@ -40,3 +40,175 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var InvalidInterceptorsDSL = func() {
+	var InterceptedOwner = Type("InterceptedOwner", func() {
+		Attribute("name", String)
+	})
+	var InterceptedResultType = ResultType("application/vnd.intercepted", func() {
+		Attribute("secret", String)
+		Attribute("owner", InterceptedOwner)
+	})
+	Service("InvalidInterceptorsService", func() {
+		Interceptor("Tenant", func() {
+			ReadPayload(func() {
+				Attribute("tenant", String)
+				Required("tenant")
+			})
+		})
+		Method("MissingAttribute", func() {
+			Interceptor("Audit", func() {
+				ReadPayload(func() {
+					Attribute("user", String)
+				})
+			})
+			Payload(func() {
+				Attribute("id", Int)
+			})
+		})
+		Method("InvalidType", func() {
+			Payload(func() {
+				Attribute("tenant", Int)
+			})
+		})
+		Method("NotRequired", func() {
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+		})
+		Method("ResultType", func() {
+			Interceptor("Redact", func() {
+				WriteResult(func() {
+					Attribute("secret", String)
+					Attribute("owner", InterceptedOwner)
+				})
+			})
+			Payload(func() {
+				Attribute("tenant", String)
+				Required("tenant")
+			})
+			Result(InterceptedResultType)
+		})
+		Method("Streaming", func() {
+			Interceptor("Log")
+			StreamingPayload(String)
+		})
+		Method("Duplicate", func() {
+			Interceptor("Tenant")
+			Payload(func() {
+				Attribute("tenant", String, func() {
+					Default("acme")
+				})
+			})
+		})
+	})
+}
+
+
+var InvalidPaginationDSL = func() {
+	Service("InvalidPaginationService", func() {
+		Method("NoCursorNorOffset", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated(func() {
+				Items("items")
+			})
+		})
+		Method("MissingItems", func() {
+			Payload(func() {
+				Attribute("offset", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated(func() {
+				Offset("offset")
+			})
+		})
+		Method("InvalidTypes", func() {
+			Payload(func() {
+				Attribute("cursor", Int)
+				Attribute("limit", String)
+			})
+			Result(func() {
+				Attribute("items", String)
+			})
+			Paginated(func() {
+				Cursor("cursor", "next")
+				PageSize("limit")
+				Items("items")
+			})
+		})
+		Method("Streaming", func() {
+			Payload(func() {
+				Attribute("offset", Int)
+			})
+			StreamingResult(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated(func() {
+				Offset("offset")
+				Items("items")
+			})
+		})
+	})
+}
+
+var InvalidFieldMaskDSL = func() {
+	Service("InvalidFieldMaskService", func() {
+		Method("NotObject", func() {
+			Result(String)
+			FieldMask()
+		})
+		Method("Clash", func() {
+			Payload(func() {
+				Attribute("fields", String)
+			})
+			Result(func() {
+				Attribute("name", String)
+			})
+			FieldMask()
+		})
+		Method("Streaming", func() {
+			StreamingResult(func() {
+				Attribute("name", String)
+			})
+			FieldMask("select")
+		})
+	})
+}
+
+var InvalidViewSelectorDSL = func() {
+	var RT = ResultType("application/vnd.rt", func() {
+		Attributes(func() {
+			Attribute("name", String)
+		})
+	})
+	Service("InvalidViewSelectorService", func() {
+		Method("NotResultType", func() {
+			Result(String)
+			ViewSelector()
+		})
+		Method("ExplicitView", func() {
+			Result(RT, func() {
+				View("default")
+			})
+			ViewSelector()
+		})
+		Method("Clash", func() {
+			Payload(func() {
+				Attribute("view", String)
+			})
+			Result(RT)
+			ViewSelector()
+		})
+		Method("Streaming", func() {
+			StreamingResult(RT)
+			ViewSelector("v")
+		})
+	})
+}
@@ -53,6 +53,17 @@ type (
 	// Note: not a map because order matters.
 	Object []*NamedAttributeExpr
 
+	// Union is the type used to describe sum types: a value of a union type
+	// holds exactly one of the union values. Union values are described
+	// using an object whose attributes are the possible values, this object
+	// is the type returned by AsObject.
+	Union struct {
+		// TypeName is the name of the union type.
+		TypeName string
+		// Values lists the union possible values.
+		Values *Object
+	}
+
 	// UserType is the interface implemented by all user type
 	// implementations. Plugins may leverage this interface to introduce
 	// their own types.
@@ -119,6 +130,8 @@ const (
 	ResultTypeKind
 	// AnyKind represents an unknown type.
 	AnyKind
+	// UnionKind represents a union (sum) type.
+	UnionKind
 )
 
 const (
@@ -172,7 +185,8 @@ var Empty = &UserTypeExpr{
 
 // Convenience methods
 
-// AsObject returns the type underlying object if any, nil otherwise.
+// AsObject returns the type underlying object if any, nil otherwise. The object
+// underlying a union type is the object describing the union values.
 func AsObject(dt DataType) *Object {
 	switch t := dt.(type) {
 	case *UserTypeExpr:
@@ -181,6 +195,22 @@ func AsObject(dt DataType) *Object {
 		return AsObject(t.Type)
 	case *Object:
 		return t
+	case *Union:
+		return t.Values
+	default:
+		return nil
+	}
+}
+
+// AsUnion returns the type underlying union if any, nil otherwise.
+func AsUnion(dt DataType) *Union {
+	switch t := dt.(type) {
+	case *UserTypeExpr:
+		return AsUnion(t.Type)
+	case *ResultTypeExpr:
+		return AsUnion(t.Type)
+	case *Union:
+		return t
 	default:
 		return nil
 	}
@@ -223,6 +253,9 @@ func IsArray(dt DataType) bool { return AsArray(dt) != nil }
 // IsMap returns true if the data type is a map.
 func IsMap(dt DataType) bool { return AsMap(dt) != nil }
 
+// IsUnion returns true if the data type is a union.
+func IsUnion(dt DataType) bool { return AsUnion(dt) != nil }
+
 // IsPrimitive returns true if the data type is a primitive type.
 func IsPrimitive(dt DataType) bool {
 	switch t := dt.(type) {
@@ -244,6 +277,7 @@ func IsPrimitive(dt DataType) bool {
 //    - array types have elements whose types are equal
 //    - map types have keys and elements whose types are equal
 //    - objects have the same attribute names and the attribute types are equal
+//    - unions have the same value names and the value types are equal
 //
 // Note: calling Equal is not equivalent to evaluation dt.Hash() == dt2.Hash()
 // as the former may return true for two user types with different names and
@@ -293,6 +327,8 @@ func equal(dt, dt2 DataType, seen ...map[string]*[]*bool) *[]*bool {
 			bs = append(bs, *equal(nat.Attribute.Type, at.Type, s)...)
 		}
 		return &bs
+	case *Union:
+		return equal(actual.Values, AsUnion(dt2).Values, s)
 	case UserType:
 		key := actual.Name() + "=" + dt2.Name()
 		if v, ok := s[key]; ok {
@@ -576,6 +612,48 @@ func (o *Object) Example(r *Random) interface{} {
 	return res
 }
 
+// Kind implements DataKind.
+func (u *Union) Kind() Kind { return UnionKind }
+
+// Name returns the union type name.
+func (u *Union) Name() string { return u.TypeName }
+
+// Hash returns a unique hash value for u.
+func (u *Union) Hash() string {
+	h := "_union_+" + u.TypeName
+	for _, nat := range *u.Values {
+		h += "+" + nat.Name + "/" + nat.Attribute.Type.Hash()
+	}
+	return h
+}
+
+// IsCompatible returns true if val is compatible with one of the union values.
+func (u *Union) IsCompatible(val interface{}) bool {
+	if u.Values.IsCompatible(val) {
+		return true
+	}
+	for _, nat := range *u.Values {
+		if nat.Attribute.Type.IsCompatible(val) {
+			return true
+		}
+	}
+	return false
+}
+
+// Example returns a random value of the union: an object with a single key
+// corresponding to one of the union values.
+func (u *Union) Example(r *Random) interface{} {
+	if len(*u.Values) == 0 {
+		return nil
+	}
+	nat := (*u.Values)[r.Int()%len(*u.Values)]
+	v := nat.Attribute.Example(r)
+	if v == nil {
+		return nil
+	}
+	return map[string]interface{}{nat.Name: v}
+}
+
 // Kind implements DataKind.
 func (m *Map) Kind() Kind { return MapKind }
 
@@ -702,7 +780,7 @@ func toReflectType(dtype DataType) reflect.Type {
 		return reflect.TypeOf("")
 	case BytesKind:
 		return reflect.TypeOf([]byte{})
-	case ObjectKind, UserTypeKind, ResultTypeKind:
+	case ObjectKind, UserTypeKind, ResultTypeKind, UnionKind:
 		return reflect.TypeOf(map[string]interface{}{})
 	case ArrayKind:
 		return reflect.SliceOf(toReflectType(dtype.(*Array).ElemType.Type))
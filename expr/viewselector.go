@@ -0,0 +1,59 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// ViewSelectorExpr describes how clients select the view used to
+	// render the method result.
+	ViewSelectorExpr struct {
+		// Method is the method whose result view is selected by clients.
+		Method *MethodExpr
+		// Name is the name of the HTTP query string parameter, of the
+		// HTTP Accept header media type parameter and of the gRPC request
+		// metadata holding the view name.
+		Name string
+	}
+)
+
+// DefaultViewSelectorName is the default name of the HTTP query string
+// parameter, of the HTTP Accept header media type parameter and of the gRPC
+// request metadata holding the name of the view selected by the client.
+const DefaultViewSelectorName = "view"
+
+// EvalName returns the generic expression name used in error messages.
+func (v *ViewSelectorExpr) EvalName() string {
+	return fmt.Sprintf("view selector %q of %s", v.Name, v.Method.EvalName())
+}
+
+// Views returns the views that clients may select.
+func (v *ViewSelectorExpr) Views() []*ViewExpr {
+	if rt, ok := v.Method.Result.Type.(*ResultTypeExpr); ok {
+		return rt.Views
+	}
+	return nil
+}
+
+// Validate makes sure the method result is a result type that does not set
+// an explicit view and that the view selector name does not clash with a
+// payload attribute.
+func (v *ViewSelectorExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	m := v.Method
+	if m.IsStreaming() {
+		verr.Add(v, "streaming methods cannot use view selectors")
+		return verr
+	}
+	if _, ok := m.Result.Type.(*ResultTypeExpr); !ok {
+		verr.Add(v, "result must be a result type")
+	} else if _, ok := m.Result.Meta["view"]; ok {
+		verr.Add(v, "result must not set a view with View")
+	}
+	if obj := AsObject(m.Payload.Type); obj != nil && obj.Attribute(v.Name) != nil {
+		verr.Add(v, "payload already defines attribute %q, pass a different name to ViewSelector", v.Name)
+	}
+	return verr
+}
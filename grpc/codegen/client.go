@@ -131,6 +131,7 @@ func clientEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
 				{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
 				{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
+				{Path: "google.golang.org/genproto/protobuf/field_mask"},
 			}),
 		}
 		fm := transTmplFuncs(svc)
@@ -220,7 +221,7 @@ func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 				{{- end }}
 			{{- end }}
 			case *goapb.ErrorResponse:
-				return nil, goagrpc.NewServiceError(message)
+				return nil, goagrpc.NewServiceError(message, goagrpc.DecodeViolations(err)...)
 			default:
 				return nil, goa.Fault(err.Error())
 			}
@@ -240,6 +241,19 @@ func Build{{ .Method.VarName }}Func(grpccli {{ .PkgName }}.{{ .ClientInterface }
 		for _, opt := range cliopts {
 			opts = append(opts, opt)
 		}
+	{{- if .FieldMask }}
+		if reqpb == nil {
+			reqpb = &{{ .Request.ClientConvert.TgtName }}{}
+		}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok {
+			reqpb.({{ .Request.ClientConvert.TgtRef }}).{{ .FieldMask.FieldName }} = &field_mask.FieldMask{Paths: mask}
+		}
+	{{- end }}
+	{{- if .ViewSelector }}
+		if view, ok := ctx.Value(goa.ViewKey).(string); ok && view != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, {{ printf "%q" .ViewSelector }}, view)
+		}
+	{{- end }}
 		if reqpb != nil {
 			return grpccli.{{ .Method.VarName }}(ctx{{ if not .Method.StreamingPayload }}, reqpb.({{ .Request.ClientConvert.TgtRef }}){{ end }}, opts...)
 		}
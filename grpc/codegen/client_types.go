@@ -74,6 +74,7 @@ func clientType(genpkg string, svc *expr.GRPCServiceExpr, seen map[string]struct
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC client types", "client",
 				[]*codegen.ImportSpec{
+					{Path: "fmt"},
 					{Path: "unicode/utf8"},
 					codegen.GoaImport(""),
 					{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
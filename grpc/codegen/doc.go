@@ -5,13 +5,17 @@ compiler (protoc) generated clients and servers.
 
 The code generator uses "proto3" syntax for generating the proto files.
 
-The code generator compiles the proto files using the protocol buffer compiler
-(protoc) with the gRPC in Go plugin. It hooks up the generated protocol buffer
-types to the goa generated types as follows:
+The code generator compiles the proto files in-process: it parses and validates
+the generated proto files and produces the Go protocol buffer types and gRPC
+stubs with the same generator as the protoc Go plugin (protoc-gen-go with the
+grpc plugin). Designs may set the "protoc:path" meta on the API or a service to
+run the protocol buffer compiler (protoc) instead. It hooks up the generated
+protocol buffer types to the goa generated types as follows:
 
-	* It generates a server that implements the protoc-generated gRPC server interface.
-	* It generates a client that invokes the protoc-generated gRPC client.
+	* It generates a server that implements the generated gRPC server interface.
+	* It generates a client that invokes the generated gRPC client.
 	* It generates encoders and decoders that transforms the protocol buffer types and gRPC metadata into goa types and vice versa.
 	* It generates validations to validate the protocol buffer message types and gRPC metadata fields with the validations set in the design.
+	* It generates the conversions that make it possible for the HTTP application/x-protobuf codec to encode the HTTP request and response bodies of services that also define a gRPC transport using the protocol buffer message types.
 */
 package codegen
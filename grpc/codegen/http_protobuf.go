@@ -0,0 +1,294 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+type (
+	// HTTPProtoData contains the data needed to register the conversions
+	// between an HTTP body type and the protocol buffer message used to
+	// encode it with the HTTP application/x-protobuf codec.
+	HTTPProtoData struct {
+		// BodyName is the name of the HTTP body type.
+		BodyName string
+		// BodyRef is the reference to the HTTP body type.
+		BodyRef string
+		// MessageName is the qualified name of the protocol buffer message
+		// type.
+		MessageName string
+		// MessageRef is the reference to the protocol buffer message type.
+		MessageRef string
+		// ToMessage is the constructor that builds the protocol buffer
+		// message from the HTTP body if any.
+		ToMessage *InitData
+		// FromMessage is the constructor that builds the HTTP body from the
+		// protocol buffer message if any.
+		FromMessage *InitData
+	}
+)
+
+// HTTPProtoFiles returns the files that register the conversions used by the
+// HTTP application/x-protobuf codec for every service that defines both an
+// HTTP and a gRPC transport. The conversions encode the HTTP request and
+// response bodies using the protocol buffer messages generated for the gRPC
+// transport.
+func HTTPProtoFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.API.GRPC.Services {
+		hs := root.API.HTTP.Service(svc.Name())
+		if hs == nil {
+			continue
+		}
+		if f := httpProtoFile(genpkg, svc, hs, true); f != nil {
+			fw = append(fw, f)
+		}
+		if f := httpProtoFile(genpkg, svc, hs, false); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// httpProtoFile returns the file containing the protocol buffer conversions
+// of the HTTP server (svr is true) or client (svr is false) bodies of the
+// given service. It returns nil if no body can be encoded with the protocol
+// buffer messages.
+func httpProtoFile(genpkg string, svc *expr.GRPCServiceExpr, hs *expr.HTTPServiceExpr, svr bool) *codegen.File {
+	var (
+		data    []*HTTPProtoData
+		helpers []*codegen.TransformFunctionData
+
+		sd  = GRPCServices.Get(svc.Name())
+		hsd = httpcodegen.HTTPServices.Get(svc.Name())
+	)
+	{
+		seen := make(map[string]struct{})
+		add := func(body, msg *expr.AttributeExpr, request bool) {
+			d, h := buildHTTPProtoData(body, msg, request, svr, sd, hsd)
+			if d == nil {
+				return
+			}
+			if _, ok := seen[d.BodyName]; ok {
+				return
+			}
+			seen[d.BodyName] = struct{}{}
+			data = append(data, d)
+			helpers = codegen.AppendHelpers(helpers, h)
+		}
+		for _, e := range svc.GRPCEndpoints {
+			he := hs.Endpoint(e.Name())
+			if he == nil || e.MethodExpr.IsStreaming() {
+				continue
+			}
+			add(he.Body, e.Request, true)
+			for _, resp := range he.Responses {
+				if svr {
+					for _, body := range projectedBodies(resp.Body, e.MethodExpr, sd) {
+						add(body, e.Response.Message, false)
+					}
+				} else {
+					add(resp.Body, e.Response.Message, false)
+				}
+			}
+		}
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	var (
+		fpath    string
+		sections []*codegen.SectionTemplate
+	)
+	{
+		pkg := "client"
+		if svr {
+			pkg = "server"
+		}
+		svcName := codegen.SnakeCase(sd.Service.VarName)
+		fpath = filepath.Join(codegen.Gendir, "http", svcName, pkg, "protobuf.go")
+		sections = []*codegen.SectionTemplate{
+			codegen.Header(svc.Name()+" HTTP "+pkg+" protocol buffer conversions", pkg,
+				[]*codegen.ImportSpec{
+					{Path: "github.com/golang/protobuf/proto"},
+					{Path: "goa.design/goa/v3/http", Name: "goahttp"},
+					{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: sd.PkgName},
+				}),
+			{
+				Name:   "http-proto-register",
+				Source: httpProtoRegisterT,
+				Data:   data,
+			},
+		}
+		for _, d := range data {
+			for _, init := range []*InitData{d.ToMessage, d.FromMessage} {
+				if init == nil {
+					continue
+				}
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "http-proto-init",
+					Source: typeInitT,
+					Data:   init,
+				})
+			}
+		}
+		for _, h := range helpers {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "http-proto-transform-helper",
+				Source: transformHelperT,
+				Data:   h,
+			})
+		}
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// projectedBodies returns the response bodies rendered by the HTTP server:
+// one body per view if the method result is a result type with views, the
+// given body otherwise.
+func projectedBodies(body *expr.AttributeExpr, m *expr.MethodExpr, sd *ServiceData) []*expr.AttributeExpr {
+	md := sd.Service.Method(m.Name)
+	if md.ViewedResult == nil || body.Type == expr.Empty {
+		return []*expr.AttributeExpr{body}
+	}
+	if _, ok := body.Meta["origin:attribute"]; ok {
+		return []*expr.AttributeExpr{body}
+	}
+	views := make([]string, len(md.ViewedResult.Views))
+	for i, v := range md.ViewedResult.Views {
+		views[i] = v.Name
+	}
+	if v, ok := m.Result.Meta["view"]; ok && len(v) > 0 {
+		views = v[:1]
+	}
+	var bodies []*expr.AttributeExpr
+	for _, view := range views {
+		att := expr.DupAtt(body)
+		if rt, ok := att.Type.(*expr.ResultTypeExpr); ok {
+			p, err := expr.Project(rt, view)
+			if err != nil {
+				panic(err) // bug
+			}
+			att.Type = p
+		}
+		bodies = append(bodies, att)
+	}
+	return bodies
+}
+
+// buildHTTPProtoData returns the data needed to register the conversions
+// between the given HTTP body and protocol buffer message. It returns nil if
+// the body is not an object user type or if its attributes are not all
+// defined by the message.
+//
+// request is true if the body is a request body, svr is true if the
+// conversions are generated for the server side.
+func buildHTTPProtoData(body, msg *expr.AttributeExpr, request, svr bool, sd *ServiceData, hsd *httpcodegen.ServiceData) (*HTTPProtoData, []*codegen.TransformFunctionData) {
+	ut, ok := body.Type.(expr.UserType)
+	if !ok || !expr.IsObject(ut) || isEmpty(ut) {
+		return nil, nil
+	}
+	if _, ok := body.Meta["origin:attribute"]; ok {
+		return nil, nil
+	}
+	if !coversAttributes(msg, body) {
+		return nil, nil
+	}
+	var (
+		toMessage   *InitData
+		fromMessage *InitData
+		helpers     []*codegen.TransformFunctionData
+
+		// encode is true if the body is encoded (client requests and server
+		// responses), false if it is decoded.
+		encode  = request != svr
+		varname = codegen.Goify(ut.Name(), true)
+		kind    = "response"
+		pbCtx   = protoBufTypeContext(sd.PkgName, sd.Scope)
+		httpCtx = codegen.NewAttributeContext(!encode, false, encode, "", hsd.Scope)
+	)
+	if request {
+		kind = "request"
+	}
+	build := func(source, target *expr.AttributeExpr, sourceVar string, srcCtx, tgtCtx *codegen.AttributeContext, proto bool, name, desc string) *InitData {
+		code, hs, err := protoBufTransform(source, target, sourceVar, "v", srcCtx, tgtCtx, proto)
+		if err != nil {
+			fmt.Println(err.Error()) // TBD validate DSL so errors are not possible
+			return nil
+		}
+		helpers = codegen.AppendHelpers(helpers, hs)
+		return &InitData{
+			Name:        name,
+			Description: desc,
+			Args: []*InitArgData{{
+				Name:     sourceVar,
+				Ref:      sourceVar,
+				TypeName: srcCtx.Scope.Name(source, srcCtx.Pkg),
+				TypeRef:  srcCtx.Scope.Ref(source, srcCtx.Pkg),
+			}},
+			ReturnVarName:  "v",
+			ReturnTypeRef:  tgtCtx.Scope.Ref(target, tgtCtx.Pkg),
+			ReturnIsStruct: true,
+			Code:           code,
+		}
+	}
+	if encode {
+		name := "NewProtoFrom" + varname
+		toMessage = build(body, msg, "body", httpCtx, pbCtx, true, name,
+			fmt.Sprintf("%s builds the gRPC %s message from the HTTP %s body %s.", name, kind, kind, varname))
+		if toMessage == nil {
+			return nil, nil
+		}
+	} else {
+		name := "New" + varname + "FromProto"
+		fromMessage = build(msg, body, "message", pbCtx, httpCtx, false, name,
+			fmt.Sprintf("%s builds the HTTP %s body %s from the gRPC %s message.", name, kind, varname, kind))
+		if fromMessage == nil {
+			return nil, nil
+		}
+	}
+	return &HTTPProtoData{
+		BodyName:    varname,
+		BodyRef:     hsd.Scope.GoTypeRef(body),
+		MessageName: pbCtx.Scope.Name(msg, pbCtx.Pkg),
+		MessageRef:  pbCtx.Scope.Ref(msg, pbCtx.Pkg),
+		ToMessage:   toMessage,
+		FromMessage: fromMessage,
+	}, helpers
+}
+
+// coversAttributes returns true if msg defines all the attributes of body.
+func coversAttributes(msg, body *expr.AttributeExpr) bool {
+	mo := expr.AsObject(msg.Type)
+	if mo == nil {
+		return false
+	}
+	for _, nat := range *expr.AsObject(body.Type) {
+		if mo.Attribute(nat.Name) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// input: []*HTTPProtoData
+const httpProtoRegisterT = `func init() {
+{{- range . }}
+	goahttp.RegisterProtoMessage(&{{ .BodyName }}{}, &goahttp.ProtoConverter{
+		NewMessage: func() proto.Message { return &{{ .MessageName }}{} },
+	{{- if .ToMessage }}
+		ToMessage: func(body interface{}) proto.Message { return {{ .ToMessage.Name }}(body.({{ .BodyRef }})) },
+	{{- end }}
+	{{- if .FromMessage }}
+		FromMessage: func(msg proto.Message) interface{} { return {{ .FromMessage.Name }}(msg.({{ .MessageRef }})) },
+	{{- end }}
+	})
+{{- end }}
+}
+`
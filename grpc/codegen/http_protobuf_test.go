@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestHTTPProtoFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code []string
+	}{
+		{"http-and-grpc", testdata.HTTPAndGRPCDSL, []string{testdata.HTTPAndGRPCServerProtoCode, testdata.HTTPAndGRPCClientProtoCode}},
+		{"grpc-only", testdata.MessageWithOneOfDSL, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			fs := HTTPProtoFiles("", expr.Root)
+			if len(fs) != len(c.Code) {
+				t.Fatalf("got %d files, expected %d", len(fs), len(c.Code))
+			}
+			for i, f := range fs {
+				var buf bytes.Buffer
+				for _, s := range f.SectionTemplates[1:] {
+					if err := s.Write(&buf); err != nil {
+						t.Fatal(err)
+					}
+				}
+				code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+				if code != c.Code[i] {
+					t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code[i]))
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -29,6 +30,11 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 	data := GRPCServices.Get(svc.Name())
 	svcName := codegen.SnakeCase(data.Service.VarName)
 	path := filepath.Join(codegen.Gendir, "grpc", svcName, pbPkgName, svcName+".proto")
+	pkg := codegen.SnakeCase(codegen.Goify(svcName, false))
+	var imports []string
+	if data.HasFieldMask() {
+		imports = append(imports, fieldMaskProto)
+	}
 
 	sections := []*codegen.SectionTemplate{
 		// header comments
@@ -46,11 +52,17 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Source: protoStartT,
 			Data: map[string]interface{}{
 				"ProtoVersion": ProtoVersion,
-				"Pkg":          codegen.SnakeCase(codegen.Goify(svcName, false)),
+				"Pkg":          pkg,
+				"Imports":      imports,
 			},
 		},
 		// service definition
-		&codegen.SectionTemplate{Name: "grpc-service", Source: serviceT, Data: data},
+		&codegen.SectionTemplate{
+			Name:    "grpc-service",
+			Source:  serviceT,
+			Data:    data,
+			FuncMap: map[string]interface{}{"rpcComment": rpcComment},
+		},
 	}
 
 	// message definition
@@ -58,19 +70,41 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		sections = append(sections, &codegen.SectionTemplate{Name: "grpc-message", Source: messageT, Data: m})
 	}
 
+	// compile the proto file in-process unless the design requires protoc
+	finalize := protocGenGo
+	if cmd := protocPath(svc); cmd != "" {
+		finalize = func(path string) error { return runProtoc(cmd, path) }
+	}
+
 	return &codegen.File{
 		Path:             path,
 		SectionTemplates: sections,
-		FinalizeFunc:     protoc,
+		FinalizeFunc:     finalize,
 	}
 }
 
-func protoc(path string) error {
+// protocPath returns the path to the protoc command set with the "protoc:path"
+// meta on the service or the API, empty string if none.
+func protocPath(svc *expr.GRPCServiceExpr) string {
+	for _, m := range []expr.MetaExpr{svc.ServiceExpr.Meta, expr.Root.API.Meta} {
+		if p, ok := m["protoc:path"]; ok {
+			if len(p) > 0 && p[0] != "" {
+				return p[0]
+			}
+			return "protoc"
+		}
+	}
+	return ""
+}
+
+// runProtoc compiles the proto file at the given path using the given
+// protoc command.
+func runProtoc(protoc, path string) error {
 	dir := filepath.Dir(path)
 	os.MkdirAll(dir, 0777)
 
 	args := []string{"--go_out=plugins=grpc:.", path, "--proto_path", dir}
-	cmd := exec.Command("protoc", args...)
+	cmd := exec.Command(protoc, args...)
 	cmd.Dir = filepath.Dir(path)
 
 	if output, err := cmd.CombinedOutput(); err != nil {
@@ -97,6 +131,11 @@ syntax = {{ printf "%q" .ProtoVersion }};
 package {{ .Pkg }};
 
 option go_package = "{{ .Pkg }}pb";
+{{- if .Imports }}
+{{ range .Imports }}
+import {{ printf "%q" . }};
+{{- end }}
+{{- end }}
 `
 
 	// input: ServiceData
@@ -105,6 +144,9 @@ option go_package = "{{ .Pkg }}pb";
 service {{ .Name }} {
 	{{- range .Endpoints }}
 	{{ if .Method.Description }}{{ .Method.Description | comment }}{{ end }}
+	{{- if .PaginationDoc }}
+	{{ rpcComment .PaginationDoc }}
+	{{- end }}
 	{{- $serverStream := or (eq .Method.StreamKind 3) (eq .Method.StreamKind 4) }}
 	{{- $clientStream := or (eq .Method.StreamKind 2) (eq .Method.StreamKind 4) }}
 	rpc {{ .Method.VarName }} ({{ if $clientStream }}stream {{ end }}{{ .Request.Message.VarName }}) returns ({{ if $serverStream }}stream {{ end }}{{ .Response.Message.VarName }});
@@ -117,3 +159,9 @@ service {{ .Name }} {
 message {{ .VarName }}{{ .Def }}
 `
 )
+
+// rpcComment returns the comment rendering s indented to be placed in a
+// service definition.
+func rpcComment(s string) string {
+	return strings.Replace(codegen.Comment(s), "\n", "\n\t", -1)
+}
@@ -1,6 +1,9 @@
 package codegen
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"goa.design/goa/v3/codegen"
@@ -39,10 +42,7 @@ func TestProtoFiles(t *testing.T) {
 			if code != c.Code {
 				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
 			}
-			fpath := codegen.CreateTempFile(t, code)
-			if err := protoc(fpath); err != nil {
-				t.Fatalf("error occurred when compiling proto file %q: %s", fpath, err)
-			}
+			compileProto(t, code)
 		})
 	}
 }
@@ -62,6 +62,7 @@ func TestMessageDefSection(t *testing.T) {
 		{"primitive", testdata.MessagePrimitiveDSL, testdata.MessagePrimitiveCode},
 		{"with-metadata", testdata.MessageWithMetadataDSL, testdata.MessageWithMetadataCode},
 		{"with-security-attributes", testdata.MessageWithSecurityAttrsDSL, testdata.MessageWithSecurityAttrsCode},
+		{"with-one-of", testdata.MessageWithOneOfDSL, testdata.MessageWithOneOfCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -79,10 +80,24 @@ func TestMessageDefSection(t *testing.T) {
 			if msgCode != c.Code {
 				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, msgCode, codegen.Diff(t, msgCode, c.Code))
 			}
-			fpath := codegen.CreateTempFile(t, code+msgCode)
-			if err := protoc(fpath); err != nil {
-				t.Fatalf("error occurred when compiling proto file %q: %s", fpath, err)
-			}
+			compileProto(t, code+msgCode)
 		})
 	}
 }
+
+// compileProto generates the Go code for the given protocol buffer
+// definition and fails the test if the definition is invalid.
+func compileProto(t *testing.T, code string) {
+	dir, err := ioutil.TempDir("", "goa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fpath := filepath.Join(dir, "test.proto")
+	if err := ioutil.WriteFile(fpath, []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := protocGenGo(fpath); err != nil {
+		t.Fatalf("error occurred when compiling proto file %q: %s", fpath, err)
+	}
+}
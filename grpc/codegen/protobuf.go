@@ -131,6 +131,10 @@ func makeProtoBufMessageR(att *expr.AttributeExpr, tname *string, scope *codegen
 		for _, nat := range *dt {
 			makeProtoBufMessageR(nat.Attribute, tname, scope, seen...)
 		}
+	case *expr.Union:
+		for _, nat := range *dt.Values {
+			makeProtoBufMessageR(nat.Attribute, tname, scope, seen...)
+		}
 	}
 }
 
@@ -218,7 +222,7 @@ func protoBufGoFullTypeName(att *expr.AttributeExpr, pkg string, s *codegen.Name
 		return fmt.Sprintf("map[%s]%s",
 			protoBufGoFullTypeRef(actual.KeyType, pkg, s),
 			protoBufGoFullTypeRef(actual.ElemType, pkg, s))
-	case *expr.Object:
+	case *expr.Object, *expr.Union:
 		return s.GoTypeDef(att, false, false)
 	default:
 		panic(fmt.Sprintf("unknown data type %T", actual)) // bug
@@ -242,21 +246,11 @@ func protoBufMessageDef(att *expr.AttributeExpr, s *codegen.NameScope) string {
 		var ss []string
 		ss = append(ss, " {")
 		for _, nat := range *actual {
-			var (
-				fn   string
-				fnum uint64
-				typ  string
-				desc string
-			)
-			{
-				fn = codegen.SnakeCase(protoBufify(nat.Name, false))
-				fnum = rpcTag(nat.Attribute)
-				typ = protoBufMessageDef(nat.Attribute, s)
-				if nat.Attribute.Description != "" {
-					desc = codegen.Comment(nat.Attribute.Description) + "\n\t"
-				}
+			if u := expr.AsUnion(nat.Attribute.Type); u != nil {
+				ss = append(ss, protoBufOneOfDef(nat, u, s)...)
+				continue
 			}
-			ss = append(ss, fmt.Sprintf("\t%s%s %s = %d;", desc, typ, fn, fnum))
+			ss = append(ss, "\t"+protoBufFieldDef(nat, "\t", s))
 		}
 		ss = append(ss, "}")
 		return strings.Join(ss, "\n")
@@ -265,6 +259,43 @@ func protoBufMessageDef(att *expr.AttributeExpr, s *codegen.NameScope) string {
 	}
 }
 
+// protoBufFieldDef returns the protocol buffer code that defines the message
+// field corresponding to the given attribute. indent is used to indent the
+// field description if any.
+func protoBufFieldDef(nat *expr.NamedAttributeExpr, indent string, s *codegen.NameScope) string {
+	var (
+		fn   string
+		fnum uint64
+		typ  string
+		desc string
+	)
+	{
+		fn = codegen.SnakeCase(protoBufify(nat.Name, false))
+		fnum = rpcTag(nat.Attribute)
+		typ = protoBufMessageDef(nat.Attribute, s)
+		if nat.Attribute.Description != "" {
+			desc = codegen.Comment(nat.Attribute.Description) + "\n" + indent
+		}
+	}
+	return fmt.Sprintf("%s%s %s = %d;", desc, typ, fn, fnum)
+}
+
+// protoBufOneOfDef returns the protocol buffer code that defines the oneof
+// field corresponding to the given union attribute. The field numbers are
+// given by the union values.
+func protoBufOneOfDef(nat *expr.NamedAttributeExpr, u *expr.Union, s *codegen.NameScope) []string {
+	var ss []string
+	if nat.Attribute.Description != "" {
+		ss = append(ss, "\t"+strings.Replace(codegen.Comment(nat.Attribute.Description), "\n", "\n\t", -1))
+	}
+	ss = append(ss, fmt.Sprintf("\toneof %s {", codegen.SnakeCase(protoBufify(nat.Name, false))))
+	for _, v := range *u.Values {
+		ss = append(ss, "\t\t"+protoBufFieldDef(v, "\t\t", s))
+	}
+	ss = append(ss, "\t}")
+	return ss
+}
+
 // protoBufGoFullTypeRef returns the Go code qualified with package name that
 // refers to the Go type generated by compiling the protocol buffer
 // (in *.pb.go) for the given attribute.
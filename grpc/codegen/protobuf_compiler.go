@@ -0,0 +1,77 @@
+package codegen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/golang/protobuf/protoc-gen-go/generator"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+
+	// register the gRPC plugin with the protocol buffer Go generator.
+	_ "github.com/golang/protobuf/protoc-gen-go/grpc"
+)
+
+// protocGenGo generates the Go code (*.pb.go) for the protocol buffer
+// messages and gRPC service stubs of the .proto file written at the given
+// path. The file is parsed and validated in-process and the code is generated
+// using the same generator as the protoc-gen-go protoc plugin so that neither
+// the protocol buffer compiler nor the plugin are needed.
+func protocGenGo(path string) error {
+	fds, err := protoFileDescriptors(path)
+	if err != nil {
+		return err
+	}
+	g := generator.New()
+	g.Request.FileToGenerate = []string{filepath.Base(path)}
+	g.Request.ProtoFile = fds
+	g.CommandLineParameters("plugins=grpc")
+	g.WrapTypes()
+	g.SetPackageNames()
+	g.BuildTypeNameMap()
+	g.GenerateAllFiles()
+	if g.Response.Error != nil {
+		return fmt.Errorf("failed to generate protocol buffer code for %q: %s", path, g.Response.GetError())
+	}
+	for _, f := range g.Response.File {
+		dest := filepath.Join(filepath.Dir(path), filepath.Base(f.GetName()))
+		if err := ioutil.WriteFile(dest, []byte(f.GetContent()), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protoFileDescriptors parses the .proto file at the given path and returns
+// the descriptors of the file and of the files it imports, dependencies
+// first. Parsing links the file and performs the same checks as protoc
+// (field numbers, type references, name conflicts etc.).
+func protoFileDescriptors(path string) ([]*descriptor.FileDescriptorProto, error) {
+	p := protoparse.Parser{
+		ImportPaths:           []string{filepath.Dir(path)},
+		IncludeSourceCodeInfo: true,
+	}
+	fds, err := p.ParseFiles(filepath.Base(path))
+	if err != nil {
+		return nil, fmt.Errorf("invalid protocol buffer definition %q: %s", path, err)
+	}
+	var (
+		protos []*descriptor.FileDescriptorProto
+		seen   = make(map[string]struct{})
+		add    func(*desc.FileDescriptor)
+	)
+	add = func(fd *desc.FileDescriptor) {
+		if _, ok := seen[fd.GetName()]; ok {
+			return
+		}
+		seen[fd.GetName()] = struct{}{}
+		for _, dep := range fd.GetDependencies() {
+			add(dep)
+		}
+		protos = append(protos, fd.AsFileDescriptorProto())
+	}
+	add(fds[0])
+	return protos, nil
+}
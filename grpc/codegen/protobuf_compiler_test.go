@@ -0,0 +1,176 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestProtocGenGo(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Types []string
+	}{
+		{"unary-rpcs", testdata.UnaryRPCsDSL, []string{"MethodUnaryRPCARequest", "MethodUnaryRPCAResponse", "ServiceUnaryRPCsClient", "ServiceUnaryRPCsServer"}},
+		{"server-streaming-rpc", testdata.ServerStreamingRPCDSL, []string{"ServiceServerStreamingRPC_MethodServerStreamingRPCClient"}},
+		{"client-streaming-rpc", testdata.ClientStreamingRPCDSL, []string{"ServiceClientStreamingRPC_MethodClientStreamingRPCServer"}},
+		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL, []string{"ServiceBidirectionalStreamingRPC_MethodBidirectionalStreamingRPCServer"}},
+		{"result-type-collection", testdata.MessageResultTypeCollectionDSL, []string{"RTCollection"}},
+		{"map", testdata.MessageMapDSL, []string{"MethodMessageMapRequest"}},
+		{"one-of", testdata.MessageWithOneOfDSL, []string{"MethodMessageWithOneOfRequest_Circle", "MethodMessageWithOneOfResponse_Square"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			fs := ProtoFiles("", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected one", len(fs))
+			}
+			dir, err := ioutil.TempDir("", "goa")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path, err := fs[0].Render(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pbpath := strings.TrimSuffix(path, ".proto") + ".pb.go"
+			f, err := parser.ParseFile(token.NewFileSet(), pbpath, nil, 0)
+			if err != nil {
+				t.Fatalf("invalid generated code: %s", err)
+			}
+			for _, typ := range c.Types {
+				if f.Scope.Lookup(typ) == nil {
+					t.Errorf("missing type %q in generated code", typ)
+				}
+			}
+		})
+	}
+}
+
+func TestProtocGenGoInvalidFieldNumber(t *testing.T) {
+	// UserType attributes have no "rpc:tag" so the generated fields are
+	// numbered 0.
+	RunGRPCDSL(t, testdata.ServerStreamingMapDSL)
+	fs := ProtoFiles("", expr.Root)
+	dir, err := ioutil.TempDir("", "goa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	_, err = fs[0].Render(dir)
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if !strings.Contains(err.Error(), "tag number 0") {
+		t.Errorf("got error %q, expected invalid tag number", err)
+	}
+}
+
+func TestProtoFileDescriptors(t *testing.T) {
+	RunGRPCDSL(t, testdata.UnaryRPCsDSL)
+	fs := ProtoFiles("", expr.Root)
+	dir, err := ioutil.TempDir("", "goa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fs[0].FinalizeFunc = nil
+	path, err := fs[0].Render(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fds, err := protoFileDescriptors(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := fds[len(fds)-1]
+
+	if len(fd.Service) != 1 || len(fd.Service[0].Method) != 2 {
+		t.Fatalf("got %v, expected one service with two methods", fd.Service)
+	}
+	m := fd.Service[0].Method[0]
+	if m.GetInputType() != ".service_unary_rp_cs.MethodUnaryRPCARequest" {
+		t.Errorf("got input type %q", m.GetInputType())
+	}
+	if m.GetClientStreaming() || m.GetServerStreaming() {
+		t.Errorf("unexpected streaming method")
+	}
+	var res *descriptor.DescriptorProto
+	for _, msg := range fd.MessageType {
+		if msg.GetName() == "MethodUnaryRPCAResponse" {
+			res = msg
+		}
+	}
+	if res == nil {
+		t.Fatal("missing MethodUnaryRPCAResponse message")
+	}
+	if len(res.Field) != 2 {
+		t.Fatalf("got %d fields, expected 2", len(res.Field))
+	}
+	mf := res.Field[1]
+	if mf.GetName() != "map_field" || mf.GetJsonName() != "mapField" || mf.GetNumber() != 2 {
+		t.Errorf("got field %q (%q) = %d", mf.GetName(), mf.GetJsonName(), mf.GetNumber())
+	}
+	if mf.GetTypeName() != ".service_unary_rp_cs.MethodUnaryRPCAResponse.MapFieldEntry" {
+		t.Errorf("got map field type %q", mf.GetTypeName())
+	}
+	if len(res.NestedType) != 1 || !res.NestedType[0].GetOptions().GetMapEntry() {
+		t.Errorf("missing map entry nested type")
+	}
+}
+
+func TestProtocPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a shell script")
+	}
+	dir, err := ioutil.TempDir("", "goa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// fake protoc that records its arguments
+	protoc := filepath.Join(dir, "protoc")
+	script := "#!/bin/sh\necho \"$@\" > " + filepath.Join(dir, "args") + "\n"
+	if err := ioutil.WriteFile(protoc, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	RunGRPCDSL(t, func() {
+		dsl.API("test", func() {
+			dsl.Meta("protoc:path", protoc)
+		})
+		dsl.Service("Service", func() {
+			dsl.Method("Method", func() {
+				dsl.GRPC(func() {})
+			})
+		})
+	})
+	fs := ProtoFiles("", expr.Root)
+	path, err := fs[0].Render(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := ioutil.ReadFile(filepath.Join(dir, "args"))
+	if err != nil {
+		t.Fatalf("protoc was not run: %s", err)
+	}
+	if !strings.Contains(string(args), "--go_out=plugins=grpc:. "+path) {
+		t.Errorf("got protoc arguments %q", args)
+	}
+	if _, err := os.Stat(strings.TrimSuffix(path, ".proto") + ".pb.go"); err == nil {
+		t.Errorf("unexpected in-process generated code")
+	}
+}
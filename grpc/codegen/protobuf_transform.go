@@ -231,6 +231,8 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 			}
 			_, ok := srcc.Type.(expr.UserType)
 			switch {
+			case expr.IsUnion(srcc.Type):
+				code, err = transformUnion(source, target, srcc, tgtc, srcVar, tgtVar, ta)
 			case expr.IsArray(srcc.Type):
 				code, err = transformArray(expr.AsArray(srcc.Type), expr.AsArray(tgtc.Type), srcVar, tgtVar, false, ta)
 			case expr.IsMap(srcc.Type):
@@ -301,6 +303,66 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 	return buffer.String(), nil
 }
 
+// transformUnion returns the code to transform source attribute of union type
+// to target attribute of union type. Unions are generated as oneof fields in
+// protocol buffer types: the field holds a value of the type generated by the
+// protocol buffer compiler for the union value that is set (e.g. Message_Value
+// for value "value" of message "Message"). sourceParent and targetParent are
+// the messages that define the union attributes.
+func transformUnion(sourceParent, targetParent, source, target *expr.AttributeExpr, sourceVar, targetVar string, ta *transformAttrs) (string, error) {
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	if ta.proto {
+		msg := ta.TargetCtx.Scope.Name(targetParent, ta.TargetCtx.Pkg)
+		buf.WriteString("switch {\n")
+		walkMatches(source, target, func(srcMatt, tgtMatt *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
+			if _, ok := srcc.Type.(expr.UserType); !ok && !expr.IsPrimitive(srcc.Type) {
+				err = fmt.Errorf("unsupported type %s for union value %q, union values must be primitives or user types", srcc.Type.Name(), n)
+				return
+			}
+			var (
+				srcField = sourceVar + "." + ta.SourceCtx.Scope.Field(srcc, srcMatt.ElemName(n), true)
+				tgtField = ta.TargetCtx.Scope.Field(tgtc, tgtMatt.ElemName(n), true)
+				val      = srcField
+			)
+			if ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr) {
+				val = "*" + val
+			}
+			buf.WriteString(fmt.Sprintf("case %s != nil:\n\t%s = &%s_%s{%s: %s}\n", srcField, targetVar, msg, tgtField, tgtField, convertType(srcc, tgtc, val, ta)))
+		})
+		buf.WriteString("}\n")
+		return buf.String(), err
+	}
+
+	msg := ta.SourceCtx.Scope.Name(sourceParent, ta.SourceCtx.Pkg)
+	buf.WriteString(fmt.Sprintf("%s = &%s{}\n", targetVar, ta.TargetCtx.Scope.Name(target, ta.TargetCtx.Pkg)))
+	buf.WriteString(fmt.Sprintf("switch val := %s.(type) {\n", sourceVar))
+	walkMatches(source, target, func(srcMatt, tgtMatt *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
+		if _, ok := srcc.Type.(expr.UserType); !ok && !expr.IsPrimitive(srcc.Type) {
+			err = fmt.Errorf("unsupported type %s for union value %q, union values must be primitives or user types", srcc.Type.Name(), n)
+			return
+		}
+		var (
+			srcField = ta.SourceCtx.Scope.Field(srcc, srcMatt.ElemName(n), true)
+			tgtField = targetVar + "." + ta.TargetCtx.Scope.Field(tgtc, tgtMatt.ElemName(n), true)
+			val      = convertType(srcc, tgtc, "val."+srcField, ta)
+		)
+		buf.WriteString(fmt.Sprintf("case *%s_%s:\n", msg, srcField))
+		switch {
+		case !ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr):
+			buf.WriteString(fmt.Sprintf("\t%s = %s\n", tgtField, val))
+		case val != "val."+srcField:
+			buf.WriteString(fmt.Sprintf("\tptr := %s\n\t%s = &ptr\n", val, tgtField))
+		default:
+			buf.WriteString(fmt.Sprintf("\t%s = &%s\n", tgtField, val))
+		}
+	})
+	buf.WriteString("}\n")
+	return buf.String(), err
+}
+
 // transformArray returns the code to transform source attribute of array
 // type to target attribute of array type. It returns an error if source
 // and target are not compatible for transformation.
@@ -584,7 +646,9 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformA
 		}
 		data = append(data, helpers...)
 	case expr.IsObject(source.Type):
-		if ut, ok := source.Type.(expr.UserType); ok {
+		// unions are transformed inline as protocol buffer oneof fields are not
+		// messages.
+		if _, ok := source.Type.(expr.UserType); ok && !expr.IsUnion(source.Type) {
 			name := transformHelperName(source, target, ta)
 			var s map[string]*codegen.TransformFunctionData
 			if len(seen) > 0 {
@@ -596,7 +660,7 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformA
 			if _, ok := s[name]; ok {
 				return nil, nil
 			}
-			code, err := transformAttribute(ut.Attribute(), target, "v", "res", true, ta)
+			code, err := transformAttribute(source, target, "v", "res", true, ta)
 			if err != nil {
 				return nil, err
 			}
@@ -36,6 +36,7 @@ func TestProtoBufTransform(t *testing.T) {
 		customField = root.UserType("CompositeWithCustomField")
 		optional    = root.UserType("Optional")
 		defaults    = root.UserType("WithDefaults")
+		union       = root.UserType("WithUnion")
 
 		resultType = root.UserType("ResultType")
 		rtCol      = root.UserType("ResultTypeCollection")
@@ -84,6 +85,7 @@ func TestProtoBufTransform(t *testing.T) {
 			{"result-type-collection-to-result-type-collection", rtCol, rtCol, true, svcCtx, rtColSvcToRTColProtoCode},
 			{"optional-to-optional", optional, optional, true, svcCtx, optionalSvcToOptionalProtoCode},
 			{"defaults-to-defaults", defaults, defaults, true, svcCtx, defaultsSvcToDefaultsProtoCode},
+			{"union-to-union", union, union, true, svcCtx, unionSvcToUnionProtoCode},
 		},
 
 		// test cases to transform protocol buffer type to service type
@@ -116,6 +118,7 @@ func TestProtoBufTransform(t *testing.T) {
 			{"result-type-collection-to-result-type-collection", rtCol, rtCol, false, svcCtx, rtColProtoToRTColSvcCode},
 			{"optional-to-optional", optional, optional, false, svcCtx, optionalProtoToOptionalSvcCode},
 			{"defaults-to-defaults", defaults, defaults, false, svcCtx, defaultsProtoToDefaultsSvcCode},
+			{"union-to-union", union, union, false, svcCtx, unionProtoToUnionSvcCode},
 		},
 	}
 	for name, cases := range tc {
@@ -565,6 +568,24 @@ const (
 		}
 	}
 }
+`
+
+	unionSvcToUnionProtoCode = `func transform() {
+	target := &WithUnion{}
+	if source.Name != nil {
+		target.Name = *source.Name
+	}
+	if source.Value != nil {
+		switch {
+		case source.Value.String != nil:
+			target.Value = &WithUnion_String_{String_: *source.Value.String}
+		case source.Value.Int != nil:
+			target.Value = &WithUnion_Int{Int: int32(*source.Value.Int)}
+		case source.Value.Simple != nil:
+			target.Value = &WithUnion_Simple{Simple: svcSimpleToSimple(source.Value.Simple)}
+		}
+	}
+}
 `
 
 	primitiveProtoToPrimitiveSvcCode = `func transform() {
@@ -983,5 +1004,25 @@ const (
 		}
 	}
 }
+`
+
+	unionProtoToUnionSvcCode = `func transform() {
+	target := &WithUnion{}
+	if source.Name != "" {
+		target.Name = &source.Name
+	}
+	if source.Value != nil {
+		target.Value = &WithUnionValue{}
+		switch val := source.Value.(type) {
+		case *WithUnion_String_:
+			target.Value.String = &val.String_
+		case *WithUnion_Int:
+			ptr := int(val.Int)
+			target.Value.Int = &ptr
+		case *WithUnion_Simple:
+			target.Value.Simple = protobufSimpleToSimple(val.Simple)
+		}
+	}
+}
 `
 )
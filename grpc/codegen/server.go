@@ -43,6 +43,7 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 				codegen.GoaImport(""),
 				codegen.GoaNamedImport("grpc", "goagrpc"),
 				{Path: "google.golang.org/grpc/codes"},
+				{Path: "google.golang.org/grpc/metadata"},
 				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
 				{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
 				{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
@@ -240,6 +241,22 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 {{- end }}
 	ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 	ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+{{- if .FieldMask }}
+	if message.{{ .FieldMask.FieldName }} != nil {
+		mask := goa.FieldMask(message.{{ .FieldMask.FieldName }}.Paths)
+		if err := {{ .ServicePkgName }}.Validate{{ .Method.VarName }}FieldMask(mask); err != nil {
+			return nil, goagrpc.EncodeError(err)
+		}
+		ctx = context.WithValue(ctx, goa.FieldMaskKey, mask)
+	}
+{{- end }}
+{{- if .ViewSelector }}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get({{ printf "%q" .ViewSelector }}); len(vals) > 0 && vals[0] != "" {
+			ctx = context.WithValue(ctx, goa.ViewKey, vals[0])
+		}
+	}
+{{- end }}
 
 {{- if .ServerStream }}
 	p, err := s.{{ .Method.VarName }}H.Decode(ctx, {{ if .Method.StreamingPayload }}nil{{ else }}message{{ end }})
@@ -2,12 +2,18 @@ package codegen
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/golang/protobuf/protoc-gen-go/generator"
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 )
 
+// fieldMaskProto is the path to the protocol buffer definition of the
+// google.protobuf.FieldMask message.
+const fieldMaskProto = "google/protobuf/field_mask.proto"
+
 // GRPCServices holds the data computed from the design needed to generate the
 // transport code of the gRPC services.
 var GRPCServices = make(ServicesData)
@@ -89,6 +95,15 @@ type (
 		MessageSchemes service.SchemesData
 		// Errors describes the method gRPC errors.
 		Errors []*ErrorData
+		// PaginationDoc describes how to request the subsequent pages of
+		// paginated methods, empty otherwise.
+		PaginationDoc string
+		// FieldMask describes the request message field holding the
+		// result field mask if the method defines one, nil otherwise.
+		FieldMask *FieldMaskData
+		// ViewSelector is the name of the request metadata holding the
+		// view selected by the client if any.
+		ViewSelector string
 
 		// server side
 
@@ -187,6 +202,17 @@ type (
 		CLIArgs []*InitArgData
 	}
 
+	// FieldMaskData describes the google.protobuf.FieldMask field added to
+	// the request message of methods that define a field mask.
+	FieldMaskData struct {
+		// Name is the name of the protocol buffer field.
+		Name string
+		// FieldName is the name of the field in the generated Go struct.
+		FieldName string
+		// Tag is the protocol buffer field number.
+		Tag uint64
+	}
+
 	// ResponseData describes a gRPC success or error response.
 	ResponseData struct {
 		// StatusCode is the return code of the response.
@@ -409,6 +435,17 @@ func (sd *ServiceData) HasStreamingEndpoint() bool {
 	return false
 }
 
+// HasFieldMask returns true if at least one of the service endpoints defines a
+// field mask.
+func (sd *ServiceData) HasFieldMask() bool {
+	for _, e := range sd.Endpoints {
+		if e.FieldMask != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // analyze creates the data necessary to render the code of the given service.
 func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 	var (
@@ -603,6 +640,11 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			ServerInterface: sd.ServerInterface,
 			ClientStruct:    sd.ClientStruct,
 			ClientInterface: sd.ClientInterface,
+			PaginationDoc:   paginationDoc(e.MethodExpr.Pagination),
+			FieldMask:       buildFieldMaskData(e, request.Message),
+		}
+		if vs := e.MethodExpr.ViewSelector; vs != nil {
+			ed.ViewSelector = vs.Name
 		}
 		sd.Endpoints = append(sd.Endpoints, ed)
 		if e.MethodExpr.IsStreaming() {
@@ -626,13 +668,12 @@ func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]st
 		if _, ok := seen[dt.Name()]; ok {
 			return nil
 		}
-		att := dt.Attribute()
-		if rt, ok := dt.(*expr.ResultTypeExpr); ok {
-			if a := unwrapAttr(expr.DupAtt(rt.Attribute())); expr.IsArray(a.Type) {
-				// result type collection
-				att = &expr.AttributeExpr{Type: expr.AsObject(rt)}
-			}
+		if expr.IsUnion(dt) {
+			// unions are generated as oneof fields of the parent message
+			seen[dt.Name()] = struct{}{}
+			return collect(dt.Attribute())
 		}
+		att := messageAttribute(dt)
 		data = append(data, &service.UserTypeData{
 			Name:        dt.Name(),
 			VarName:     protoBufMessageName(at, sd.Scope),
@@ -647,6 +688,10 @@ func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]st
 		for _, nat := range *dt {
 			data = append(data, collect(nat.Attribute)...)
 		}
+	case *expr.Union:
+		for _, nat := range *dt.Values {
+			data = append(data, collect(nat.Attribute)...)
+		}
 	case *expr.Array:
 		data = append(data, collect(dt.ElemType)...)
 	case *expr.Map:
@@ -656,6 +701,18 @@ func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]st
 	return
 }
 
+// messageAttribute returns the attribute that defines the fields of the
+// protocol buffer message corresponding to the given user type.
+func messageAttribute(ut expr.UserType) *expr.AttributeExpr {
+	if rt, ok := ut.(*expr.ResultTypeExpr); ok {
+		if a := unwrapAttr(expr.DupAtt(rt.Attribute())); expr.IsArray(a.Type) {
+			// result type collection
+			return &expr.AttributeExpr{Type: expr.AsObject(rt)}
+		}
+	}
+	return ut.Attribute()
+}
+
 // addValidation adds a validation function (if any) for the given user type
 // and recurses through the user type adding other validation functions
 // (if any).
@@ -691,7 +748,7 @@ func addValidation(att *expr.AttributeExpr, sd *ServiceData, req bool) *Validati
 		}
 	}
 	ctx := protoBufTypeContext("", sd.Scope)
-	if def := codegen.RecursiveValidationCode(att, ctx, true, "message"); def != "" {
+	if def := protoBufValidationCode(att, ref, ctx, sd); def != "" {
 		v := &ValidationData{
 			Name:    "Validate" + name,
 			Def:     def,
@@ -707,6 +764,81 @@ func addValidation(att *expr.AttributeExpr, sd *ServiceData, req bool) *Validati
 	return nil
 }
 
+// protoBufValidationCode returns the code that validates the protocol buffer
+// message described by att. ref is the reference to the message Go type.
+// Union attributes are generated as oneof fields which guarantee that at most
+// one value is set so only their presence is validated by the message
+// validations, the value that is set is validated using a type switch on the
+// oneof field.
+func protoBufValidationCode(att *expr.AttributeExpr, ref string, ctx *codegen.AttributeContext, sd *ServiceData) string {
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return codegen.RecursiveValidationCode(att, ctx, true, "message")
+	}
+	var hasUnion bool
+	for _, nat := range *obj {
+		if expr.IsUnion(nat.Attribute.Type) {
+			hasUnion = true
+			break
+		}
+	}
+	if !hasUnion {
+		return codegen.RecursiveValidationCode(att, ctx, true, "message")
+	}
+	validation := att.Validation
+	if ut, ok := att.Type.(expr.UserType); ok && validation == nil {
+		validation = ut.Attribute().Validation
+	}
+	msg := &expr.Object{}
+	for _, nat := range *obj {
+		a := nat.Attribute
+		if expr.IsUnion(a.Type) {
+			// oneof fields are interfaces, validate presence only.
+			a = &expr.AttributeExpr{Type: expr.Any}
+		}
+		msg.Set(nat.Name, a)
+	}
+	code := codegen.RecursiveValidationCode(&expr.AttributeExpr{Type: msg, Validation: validation}, ctx, true, "message")
+	for _, nat := range *obj {
+		if u := expr.AsUnion(nat.Attribute.Type); u != nil {
+			if val := oneOfValidationCode(nat, u, strings.TrimPrefix(ref, "*"), ctx, sd); val != "" {
+				if code != "" {
+					code += "\n"
+				}
+				code += val
+			}
+		}
+	}
+	return code
+}
+
+// oneOfValidationCode returns the code that validates the value set in the
+// oneof field generated for the given union attribute. msg is the name of the
+// Go type of the message that defines the oneof field.
+func oneOfValidationCode(nat *expr.NamedAttributeExpr, u *expr.Union, msg string, ctx *codegen.AttributeContext, sd *ServiceData) string {
+	var cases []string
+	for _, v := range *u.Values {
+		var (
+			field   = ctx.Scope.Field(v.Attribute, v.Name, true)
+			context = fmt.Sprintf("message.%s.%s", nat.Name, v.Name)
+			code    string
+		)
+		if _, ok := v.Attribute.Type.(expr.UserType); ok {
+			code = fmt.Sprintf("if v.%s != nil {\n\tif err2 := Validate%s(v.%s); err2 != nil {\n\t\terr = goa.MergeErrors(err, goa.NestedError(err2, %q))\n\t}\n}",
+				field, protoBufMessageName(v.Attribute, sd.Scope), field, context)
+		} else {
+			code = codegen.ValidationCode(v.Attribute, ctx, true, "v."+field, context)
+		}
+		if code != "" {
+			cases = append(cases, fmt.Sprintf("case *%s_%s:\n%s", msg, field, code))
+		}
+	}
+	if len(cases) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("switch v := message.%s.(type) {\n%s\n}", ctx.Scope.Field(nat.Attribute, nat.Name, true), strings.Join(cases, "\n"))
+}
+
 // collectValidations recurses through the attribute and collects the
 // validation functions.
 //
@@ -715,7 +847,16 @@ func addValidation(att *expr.AttributeExpr, sd *ServiceData, req bool) *Validati
 func collectValidations(att *expr.AttributeExpr, ctx *codegen.AttributeContext, req bool, sd *ServiceData) {
 	switch dt := att.Type.(type) {
 	case expr.UserType:
+		if u := expr.AsUnion(dt); u != nil {
+			// oneof fields are validated by the parent message, collect the
+			// validations of the union values.
+			for _, nat := range *u.Values {
+				collectValidations(nat.Attribute, ctx, req, sd)
+			}
+			return
+		}
 		name := protoBufMessageName(att, sd.Scope)
+		ref := protoBufGoFullTypeRef(att, sd.PkgName, sd.Scope)
 		kind := validateClient
 		if req {
 			kind = validateServer
@@ -731,10 +872,10 @@ func collectValidations(att *expr.AttributeExpr, ctx *codegen.AttributeContext,
 		}
 		sd.validations = append(sd.validations, &ValidationData{
 			Name:    "Validate" + name,
-			Def:     codegen.RecursiveValidationCode(att, ctx, true, "message"),
+			Def:     protoBufValidationCode(att, ref, ctx, sd),
 			ArgName: "message",
 			SrcName: name,
-			SrcRef:  protoBufGoFullTypeRef(att, sd.PkgName, sd.Scope),
+			SrcRef:  ref,
 			Kind:    kind,
 		})
 	collect:
@@ -1058,6 +1199,58 @@ func buildErrorConvertData(ge *expr.GRPCErrorExpr, e *expr.GRPCEndpointExpr, sd
 	}
 }
 
+// paginationDoc returns the comment describing how to request the subsequent
+// pages of a paginated method in the protocol buffer definition, empty if pag
+// is nil.
+func paginationDoc(pag *expr.PaginationExpr) string {
+	if pag == nil {
+		return ""
+	}
+	field := func(att string) string { return codegen.SnakeCase(protoBufify(att, false)) }
+	var doc string
+	if pag.IsCursor() {
+		doc = fmt.Sprintf("The results are paginated: set %s to the value of %s in the response to request the next page, an empty %s indicates the last page.",
+			field(pag.CursorAttribute), field(pag.NextCursorAttribute), field(pag.NextCursorAttribute))
+	} else {
+		doc = fmt.Sprintf("The results are paginated: add the number of %s in the response to %s to request the next page, an empty page indicates the last page.",
+			field(pag.ItemsAttribute), field(pag.OffsetAttribute))
+	}
+	if pag.PageSizeAttribute != "" {
+		doc += fmt.Sprintf(" %s sets the maximum number of %s in a page.", field(pag.PageSizeAttribute), field(pag.ItemsAttribute))
+	}
+	return doc
+}
+
+// buildFieldMaskData returns the data describing the field mask field of the
+// endpoint request message and adds the field to the message definition. It
+// returns nil if the endpoint method does not define a field mask.
+func buildFieldMaskData(e *expr.GRPCEndpointExpr, msg *service.UserTypeData) *FieldMaskData {
+	fm := e.MethodExpr.FieldMask
+	if fm == nil || msg == nil {
+		return nil
+	}
+	var tag uint64
+	if obj := expr.AsObject(e.Request.Type); obj != nil {
+		for _, nat := range *obj {
+			if t := rpcTag(nat.Attribute); t > tag {
+				tag = t
+			}
+		}
+	}
+	name := codegen.SnakeCase(protoBufify(fm.Name, false))
+	data := &FieldMaskData{
+		Name:      name,
+		FieldName: generator.CamelCase(name),
+		Tag:       tag + 1,
+	}
+	field := fmt.Sprintf("\tgoogle.protobuf.FieldMask %s = %d;", data.Name, data.Tag)
+	if !strings.Contains(msg.Def, field) {
+		// The message may be shared with other methods.
+		msg.Def = strings.TrimSuffix(msg.Def, "}") + field + "\n}"
+	}
+	return data
+}
+
 // buildStreamData builds the StreamData for the server and client streams.
 //
 // svr param indicates that the stream data is built for the server.
@@ -92,7 +92,7 @@ func (c *Client) MethodUnaryRPCWithErrors() goa.Endpoint {
 			case *service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsCustomErrorError:
 				return nil, NewMethodUnaryRPCWithErrorsCustomErrorError(message)
 			case *goapb.ErrorResponse:
-				return nil, goagrpc.NewServiceError(message)
+				return nil, goagrpc.NewServiceError(message, goagrpc.DecodeViolations(err)...)
 			default:
 				return nil, goa.Fault(err.Error())
 			}
@@ -227,7 +227,7 @@ func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 			resp := goagrpc.DecodeError(err)
 			switch message := resp.(type) {
 			case *goapb.ErrorResponse:
-				return nil, goagrpc.NewServiceError(message)
+				return nil, goagrpc.NewServiceError(message, goagrpc.DecodeViolations(err)...)
 			default:
 				return nil, goa.Fault(err.Error())
 			}
@@ -115,7 +115,7 @@ var ServerStreamingRPCDSL = func() {
 
 var ServerStreamingUserTypeDSL = func() {
 	var UT = Type("UserType", func() {
-		Attribute("IntField", Int)
+		Field(1, "IntField", Int)
 	})
 	Service("ServiceServerStreamingUserTypeRPC", func() {
 		Method("MethodServerStreamingUserTypeRPC", func() {
@@ -150,8 +150,8 @@ var ServerStreamingResultWithViewsDSL = func() {
 	var RT = ResultType("application/vnd.result", func() {
 		TypeName("ResultType")
 		Attributes(func() {
-			Attribute("IntField", Int)
-			Attribute("DoubleField", Float64)
+			Field(1, "IntField", Int)
+			Field(2, "DoubleField", Float64)
 		})
 		View("default", func() {
 			Attribute("IntField")
@@ -483,6 +483,34 @@ var PayloadWithNestedTypesDSL = func() {
 	})
 }
 
+var MessageWithOneOfDSL = func() {
+	var Square = Type("Square", func() {
+		Field(1, "color", String, func() {
+			Pattern("^#[0-9a-f]{6}$")
+		})
+	})
+	var Figure = Type("Figure", func() {
+		Field(1, "name", String)
+		OneOf("shape", func() {
+			Field(2, "circle", String, func() {
+				MinLength(2)
+			})
+			Field(3, "radius", Int, func() {
+				Minimum(1)
+			})
+			Field(4, "square", Square)
+		})
+		Required("shape")
+	})
+	Service("ServiceMessageWithOneOf", func() {
+		Method("MethodMessageWithOneOf", func() {
+			Payload(Figure)
+			Result(Figure)
+			GRPC(func() {})
+		})
+	})
+}
+
 var MessageArrayDSL = func() {
 	var UT = Type("UT", func() {
 		Field(1, "ArrayOfPrimitives", ArrayOf(UInt))
@@ -676,3 +704,44 @@ var MultipleMethodsSameResultCollectionDSL = func() {
 		})
 	})
 }
+
+var HTTPAndGRPCDSL = func() {
+	var Person = Type("Person", func() {
+		Field(1, "firstName", String)
+		OneOf("contact", func() {
+			Field(2, "email", String)
+			Field(3, "phone", Int)
+		})
+		Required("firstName")
+	})
+	var RT = ResultType("application/vnd.result", func() {
+		TypeName("Result")
+		Attributes(func() {
+			Field(1, "firstName", String)
+			Field(2, "tags", ArrayOf(ArrayOf(String)))
+			Required("firstName")
+		})
+		View("default", func() {
+			Attribute("firstName")
+			Attribute("tags")
+		})
+		View("tiny", func() {
+			Attribute("firstName")
+		})
+	})
+	Service("ServiceHTTPAndGRPC", func() {
+		Method("MethodHTTPAndGRPC", func() {
+			Payload(func() {
+				Field(1, "firstName", String)
+				Field(2, "tags", ArrayOf(ArrayOf(String)))
+				Field(3, "friend", Person)
+				Required("firstName")
+			})
+			Result(RT)
+			HTTP(func() {
+				POST("/")
+			})
+			GRPC(func() {})
+		})
+	})
+}
@@ -0,0 +1,172 @@
+package testdata
+
+const HTTPAndGRPCServerProtoCode = `func init() {
+	goahttp.RegisterProtoMessage(&MethodHTTPAndGRPCRequestBody{}, &goahttp.ProtoConverter{
+		NewMessage: func() proto.Message { return &service_http_and_grpcpb.MethodHTTPAndGRPCRequest{} },
+		FromMessage: func(msg proto.Message) interface{} {
+			return NewMethodHTTPAndGRPCRequestBodyFromProto(msg.(*service_http_and_grpcpb.MethodHTTPAndGRPCRequest))
+		},
+	})
+	goahttp.RegisterProtoMessage(&MethodHTTPAndGRPCResponseBody{}, &goahttp.ProtoConverter{
+		NewMessage: func() proto.Message { return &service_http_and_grpcpb.MethodHTTPAndGRPCResponse{} },
+		ToMessage: func(body interface{}) proto.Message {
+			return NewProtoFromMethodHTTPAndGRPCResponseBody(body.(*MethodHTTPAndGRPCResponseBody))
+		},
+	})
+	goahttp.RegisterProtoMessage(&MethodHTTPAndGRPCResponseBodyTiny{}, &goahttp.ProtoConverter{
+		NewMessage: func() proto.Message { return &service_http_and_grpcpb.MethodHTTPAndGRPCResponse{} },
+		ToMessage: func(body interface{}) proto.Message {
+			return NewProtoFromMethodHTTPAndGRPCResponseBodyTiny(body.(*MethodHTTPAndGRPCResponseBodyTiny))
+		},
+	})
+}
+
+// NewMethodHTTPAndGRPCRequestBodyFromProto builds the HTTP request body
+// MethodHTTPAndGRPCRequestBody from the gRPC request message.
+func NewMethodHTTPAndGRPCRequestBodyFromProto(message *service_http_and_grpcpb.MethodHTTPAndGRPCRequest) *MethodHTTPAndGRPCRequestBody {
+	v := &MethodHTTPAndGRPCRequestBody{
+		FirstName: &message.FirstName,
+	}
+	if message.Tags != nil {
+		v.Tags = make([][]string, len(message.Tags))
+		for i, val := range message.Tags {
+			v.Tags[i] = make([]string, len(val.Field))
+			for j, val := range val.Field {
+				v.Tags[i][j] = val
+			}
+		}
+	}
+	if message.Friend != nil {
+		v.Friend = protobufServiceHTTPAndGrpcpbPersonToPersonRequestBody(message.Friend)
+	}
+	return v
+}
+
+// NewProtoFromMethodHTTPAndGRPCResponseBody builds the gRPC response message
+// from the HTTP response body MethodHTTPAndGRPCResponseBody.
+func NewProtoFromMethodHTTPAndGRPCResponseBody(body *MethodHTTPAndGRPCResponseBody) *service_http_and_grpcpb.MethodHTTPAndGRPCResponse {
+	v := &service_http_and_grpcpb.MethodHTTPAndGRPCResponse{
+		FirstName: body.FirstName,
+	}
+	if body.Tags != nil {
+		v.Tags = make([]*service_http_and_grpcpb.ArrayOfString, len(body.Tags))
+		for i, val := range body.Tags {
+			v.Tags[i] = &service_http_and_grpcpb.ArrayOfString{}
+			v.Tags[i].Field = make([]string, len(val))
+			for j, val := range val {
+				v.Tags[i].Field[j] = val
+			}
+		}
+	}
+	return v
+}
+
+// NewProtoFromMethodHTTPAndGRPCResponseBodyTiny builds the gRPC response
+// message from the HTTP response body MethodHTTPAndGRPCResponseBodyTiny.
+func NewProtoFromMethodHTTPAndGRPCResponseBodyTiny(body *MethodHTTPAndGRPCResponseBodyTiny) *service_http_and_grpcpb.MethodHTTPAndGRPCResponse {
+	v := &service_http_and_grpcpb.MethodHTTPAndGRPCResponse{
+		FirstName: body.FirstName,
+	}
+	return v
+}
+
+// protobufServiceHTTPAndGrpcpbPersonToPersonRequestBody builds a value of type
+// *PersonRequestBody from a value of type *service_http_and_grpcpb.Person.
+func protobufServiceHTTPAndGrpcpbPersonToPersonRequestBody(v *service_http_and_grpcpb.Person) *PersonRequestBody {
+	if v == nil {
+		return nil
+	}
+	res := &PersonRequestBody{
+		FirstName: &v.FirstName,
+	}
+	if v.Contact != nil {
+		res.Contact = &PersonContactRequestBody{}
+		switch val := v.Contact.(type) {
+		case *service_http_and_grpcpb.Person_Email:
+			res.Contact.Email = &val.Email
+		case *service_http_and_grpcpb.Person_Phone:
+			ptr := int(val.Phone)
+			res.Contact.Phone = &ptr
+		}
+	}
+
+	return res
+}
+`
+
+const HTTPAndGRPCClientProtoCode = `func init() {
+	goahttp.RegisterProtoMessage(&MethodHTTPAndGRPCRequestBody{}, &goahttp.ProtoConverter{
+		NewMessage: func() proto.Message { return &service_http_and_grpcpb.MethodHTTPAndGRPCRequest{} },
+		ToMessage: func(body interface{}) proto.Message {
+			return NewProtoFromMethodHTTPAndGRPCRequestBody(body.(*MethodHTTPAndGRPCRequestBody))
+		},
+	})
+	goahttp.RegisterProtoMessage(&MethodHTTPAndGRPCResponseBody{}, &goahttp.ProtoConverter{
+		NewMessage: func() proto.Message { return &service_http_and_grpcpb.MethodHTTPAndGRPCResponse{} },
+		FromMessage: func(msg proto.Message) interface{} {
+			return NewMethodHTTPAndGRPCResponseBodyFromProto(msg.(*service_http_and_grpcpb.MethodHTTPAndGRPCResponse))
+		},
+	})
+}
+
+// NewProtoFromMethodHTTPAndGRPCRequestBody builds the gRPC request message
+// from the HTTP request body MethodHTTPAndGRPCRequestBody.
+func NewProtoFromMethodHTTPAndGRPCRequestBody(body *MethodHTTPAndGRPCRequestBody) *service_http_and_grpcpb.MethodHTTPAndGRPCRequest {
+	v := &service_http_and_grpcpb.MethodHTTPAndGRPCRequest{
+		FirstName: body.FirstName,
+	}
+	if body.Tags != nil {
+		v.Tags = make([]*service_http_and_grpcpb.ArrayOfString, len(body.Tags))
+		for i, val := range body.Tags {
+			v.Tags[i] = &service_http_and_grpcpb.ArrayOfString{}
+			v.Tags[i].Field = make([]string, len(val))
+			for j, val := range val {
+				v.Tags[i].Field[j] = val
+			}
+		}
+	}
+	if body.Friend != nil {
+		v.Friend = svcPersonRequestBodyToServiceHTTPAndGrpcpbPerson(body.Friend)
+	}
+	return v
+}
+
+// NewMethodHTTPAndGRPCResponseBodyFromProto builds the HTTP response body
+// MethodHTTPAndGRPCResponseBody from the gRPC response message.
+func NewMethodHTTPAndGRPCResponseBodyFromProto(message *service_http_and_grpcpb.MethodHTTPAndGRPCResponse) *MethodHTTPAndGRPCResponseBody {
+	v := &MethodHTTPAndGRPCResponseBody{
+		FirstName: &message.FirstName,
+	}
+	if message.Tags != nil {
+		v.Tags = make([][]string, len(message.Tags))
+		for i, val := range message.Tags {
+			v.Tags[i] = make([]string, len(val.Field))
+			for j, val := range val.Field {
+				v.Tags[i][j] = val
+			}
+		}
+	}
+	return v
+}
+
+// svcPersonRequestBodyToServiceHTTPAndGrpcpbPerson builds a value of type
+// *service_http_and_grpcpb.Person from a value of type *PersonRequestBody.
+func svcPersonRequestBodyToServiceHTTPAndGrpcpbPerson(v *PersonRequestBody) *service_http_and_grpcpb.Person {
+	if v == nil {
+		return nil
+	}
+	res := &service_http_and_grpcpb.Person{
+		FirstName: v.FirstName,
+	}
+	if v.Contact != nil {
+		switch {
+		case v.Contact.Email != nil:
+			res.Contact = &service_http_and_grpcpb.Person_Email{Email: *v.Contact.Email}
+		case v.Contact.Phone != nil:
+			res.Contact = &service_http_and_grpcpb.Person_Phone{Phone: int32(*v.Contact.Phone)}
+		}
+	}
+
+	return res
+}
+`
@@ -391,3 +391,27 @@ message ResultT {
 message MethodBRequest {
 }
 `
+
+const MessageWithOneOfCode = `
+message MethodMessageWithOneOfRequest {
+	string name = 1;
+	oneof shape {
+		string circle = 2;
+		sint32 radius = 3;
+		Square square = 4;
+	}
+}
+
+message Square {
+	string color = 1;
+}
+
+message MethodMessageWithOneOfResponse {
+	string name = 1;
+	oneof shape {
+		string circle = 2;
+		sint32 radius = 3;
+		Square square = 4;
+	}
+}
+`
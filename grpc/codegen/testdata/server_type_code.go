@@ -27,7 +27,7 @@ func NewMethodPayloadWithNestedTypesResponse() *service_payload_with_nested_type
 func ValidateMethodPayloadWithNestedTypesRequest(message *service_payload_with_nested_typespb.MethodPayloadWithNestedTypesRequest) (err error) {
 	if message.AParams != nil {
 		if err2 := ValidateAParams(message.AParams); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "message.a_params"))
 		}
 	}
 	return
@@ -35,10 +35,10 @@ func ValidateMethodPayloadWithNestedTypesRequest(message *service_payload_with_n
 
 // ValidateAParams runs the validations defined on AParams.
 func ValidateAParams(message *service_payload_with_nested_typespb.AParams) (err error) {
-	for _, v := range message.A {
+	for k, v := range message.A {
 		if v != nil {
 			if err2 := ValidateArrayOfString(v); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestedError(err2, fmt.Sprintf("message.a[%v]", k)))
 			}
 		}
 	}
@@ -257,3 +257,109 @@ func NewMethodUnaryRPCWithErrorsCustomErrorError(er *serviceunaryrpcwitherrors.E
 	return message
 }
 `
+
+const MessageWithOneOfServerTypeCode = `// NewMethodMessageWithOneOfPayload builds the payload of the
+// "MethodMessageWithOneOf" endpoint of the "ServiceMessageWithOneOf" service
+// from the gRPC request type.
+func NewMethodMessageWithOneOfPayload(message *service_message_with_one_ofpb.MethodMessageWithOneOfRequest) *servicemessagewithoneof.Figure {
+	v := &servicemessagewithoneof.Figure{}
+	if message.Name != "" {
+		v.Name = &message.Name
+	}
+	if message.Shape != nil {
+		v.Shape = &servicemessagewithoneof.FigureShape{}
+		switch val := message.Shape.(type) {
+		case *service_message_with_one_ofpb.MethodMessageWithOneOfRequest_Circle:
+			v.Shape.Circle = &val.Circle
+		case *service_message_with_one_ofpb.MethodMessageWithOneOfRequest_Radius:
+			ptr := int(val.Radius)
+			v.Shape.Radius = &ptr
+		case *service_message_with_one_ofpb.MethodMessageWithOneOfRequest_Square:
+			v.Shape.Square = protobufServiceMessageWithOneOfpbSquareToServicemessagewithoneofSquare(val.Square)
+		}
+	}
+	return v
+}
+
+// NewMethodMessageWithOneOfResponse builds the gRPC response type from the
+// result of the "MethodMessageWithOneOf" endpoint of the
+// "ServiceMessageWithOneOf" service.
+func NewMethodMessageWithOneOfResponse(result *servicemessagewithoneof.Figure) *service_message_with_one_ofpb.MethodMessageWithOneOfResponse {
+	message := &service_message_with_one_ofpb.MethodMessageWithOneOfResponse{}
+	if result.Name != nil {
+		message.Name = *result.Name
+	}
+	if result.Shape != nil {
+		switch {
+		case result.Shape.Circle != nil:
+			message.Shape = &service_message_with_one_ofpb.MethodMessageWithOneOfResponse_Circle{Circle: *result.Shape.Circle}
+		case result.Shape.Radius != nil:
+			message.Shape = &service_message_with_one_ofpb.MethodMessageWithOneOfResponse_Radius{Radius: int32(*result.Shape.Radius)}
+		case result.Shape.Square != nil:
+			message.Shape = &service_message_with_one_ofpb.MethodMessageWithOneOfResponse_Square{Square: svcServicemessagewithoneofSquareToServiceMessageWithOneOfpbSquare(result.Shape.Square)}
+		}
+	}
+	return message
+}
+
+// ValidateMethodMessageWithOneOfRequest runs the validations defined on
+// MethodMessageWithOneOfRequest.
+func ValidateMethodMessageWithOneOfRequest(message *service_message_with_one_ofpb.MethodMessageWithOneOfRequest) (err error) {
+	if message.Shape == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("shape", "message"))
+	}
+	switch v := message.Shape.(type) {
+	case *service_message_with_one_ofpb.MethodMessageWithOneOfRequest_Circle:
+		if utf8.RuneCountInString(v.Circle) < 2 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("message.shape.circle", v.Circle, utf8.RuneCountInString(v.Circle), 2, true))
+		}
+	case *service_message_with_one_ofpb.MethodMessageWithOneOfRequest_Radius:
+		if v.Radius < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("message.shape.radius", v.Radius, 1, true))
+		}
+	case *service_message_with_one_ofpb.MethodMessageWithOneOfRequest_Square:
+		if v.Square != nil {
+			if err2 := ValidateSquare(v.Square); err2 != nil {
+				err = goa.MergeErrors(err, goa.NestedError(err2, "message.shape.square"))
+			}
+		}
+	}
+	return
+}
+
+// ValidateSquare runs the validations defined on Square.
+func ValidateSquare(message *service_message_with_one_ofpb.Square) (err error) {
+	err = goa.MergeErrors(err, goa.ValidatePattern("message.color", message.Color, "^#[0-9a-f]{6}$"))
+	return
+}
+
+// protobufServiceMessageWithOneOfpbSquareToServicemessagewithoneofSquare
+// builds a value of type *servicemessagewithoneof.Square from a value of type
+// *service_message_with_one_ofpb.Square.
+func protobufServiceMessageWithOneOfpbSquareToServicemessagewithoneofSquare(v *service_message_with_one_ofpb.Square) *servicemessagewithoneof.Square {
+	if v == nil {
+		return nil
+	}
+	res := &servicemessagewithoneof.Square{}
+	if v.Color != "" {
+		res.Color = &v.Color
+	}
+
+	return res
+}
+
+// svcServicemessagewithoneofSquareToServiceMessageWithOneOfpbSquare builds a
+// value of type *service_message_with_one_ofpb.Square from a value of type
+// *servicemessagewithoneof.Square.
+func svcServicemessagewithoneofSquareToServiceMessageWithOneOfpbSquare(v *servicemessagewithoneof.Square) *service_message_with_one_ofpb.Square {
+	if v == nil {
+		return nil
+	}
+	res := &service_message_with_one_ofpb.Square{}
+	if v.Color != nil {
+		res.Color = *v.Color
+	}
+
+	return res
+}
+`
@@ -7,6 +7,7 @@ import (
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
 )
 
 // RunGRPCDSL returns the GRPC DSL root resulting from running the given DSL.
@@ -15,6 +16,7 @@ func RunGRPCDSL(t *testing.T, dsl func()) *expr.RootExpr {
 	// reset all roots and codegen data structures
 	service.Services = make(service.ServicesData)
 	GRPCServices = make(ServicesData)
+	httpcodegen.HTTPServices = make(httpcodegen.ServicesData)
 	return expr.RunDSL(t, dsl)
 }
 
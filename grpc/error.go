@@ -1,11 +1,13 @@
 package grpc
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
 	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -50,18 +52,99 @@ func NewErrorResponse(err error) *goapb.ErrorResponse {
 }
 
 // NewServiceError returns a goa ServiceError type for the given ErrorResponse
-// message.
-func NewServiceError(resp *goapb.ErrorResponse) *goa.ServiceError {
+// message and validation violations if any.
+func NewServiceError(resp *goapb.ErrorResponse, violations ...*goa.Violation) *goa.ServiceError {
 	return &goa.ServiceError{
-		Name:      resp.Name,
-		ID:        resp.Id,
-		Message:   resp.Msg,
-		Timeout:   resp.Timeout,
-		Temporary: resp.Temporary,
-		Fault:     resp.Fault,
+		Name:       resp.Name,
+		ID:         resp.Id,
+		Message:    resp.Msg,
+		Timeout:    resp.Timeout,
+		Temporary:  resp.Temporary,
+		Fault:      resp.Fault,
+		Violations: violations,
 	}
 }
 
+// NewBadRequest creates a google.rpc.BadRequest protocol buffer message that
+// lists the validation violations of the given error. The field violations
+// use the JSON pointers of the goa violations as field paths. NewBadRequest
+// returns nil if the error is not a goa ServiceError or has no violations.
+func NewBadRequest(err error) *errdetails.BadRequest {
+	gerr, ok := err.(*goa.ServiceError)
+	if !ok || len(gerr.Violations) == 0 {
+		return nil
+	}
+	fvs := make([]*errdetails.BadRequest_FieldViolation, len(gerr.Violations))
+	for i, v := range gerr.Violations {
+		fvs[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Message,
+		}
+	}
+	return &errdetails.BadRequest{FieldViolations: fvs}
+}
+
+// NewViolations creates a Violations protocol buffer message that lists the
+// validation violations of the given error including the rules that failed
+// and the expected and actual values encoded in JSON. NewViolations returns nil
+// if the error is not a goa ServiceError or has no violations.
+func NewViolations(err error) *goapb.Violations {
+	gerr, ok := err.(*goa.ServiceError)
+	if !ok || len(gerr.Violations) == 0 {
+		return nil
+	}
+	vs := make([]*goapb.Violation, len(gerr.Violations))
+	for i, v := range gerr.Violations {
+		vs[i] = &goapb.Violation{
+			Field:    v.Field,
+			Rule:     v.Rule,
+			Expected: encodeViolationValue(v.Expected),
+			Actual:   encodeViolationValue(v.Actual),
+			Message:  v.Message,
+		}
+	}
+	return &goapb.Violations{Violations: vs}
+}
+
+// DecodeViolations returns the validation violations encoded in the status
+// details if error is a gRPC status error. The violations are read from the
+// Violations message if present and from the google.rpc.BadRequest message
+// otherwise in which case only the field and message of the violations are
+// available.
+func DecodeViolations(err error) []*goa.Violation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	var br *errdetails.BadRequest
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *goapb.Violations:
+			vs := make([]*goa.Violation, len(d.Violations))
+			for i, v := range d.Violations {
+				vs[i] = &goa.Violation{
+					Field:    v.Field,
+					Rule:     v.Rule,
+					Expected: decodeViolationValue(v.Expected),
+					Actual:   decodeViolationValue(v.Actual),
+					Message:  v.Message,
+				}
+			}
+			return vs
+		case *errdetails.BadRequest:
+			br = d
+		}
+	}
+	if br == nil {
+		return nil
+	}
+	vs := make([]*goa.Violation, len(br.FieldViolations))
+	for i, fv := range br.FieldViolations {
+		vs[i] = &goa.Violation{Field: fv.Field, Message: fv.Description}
+	}
+	return vs
+}
+
 // NewStatusError creates a gRPC status error with the error response
 // messages added to its details.
 func NewStatusError(code codes.Code, err error, details ...proto.Message) error {
@@ -75,8 +158,11 @@ func NewStatusError(code codes.Code, err error, details ...proto.Message) error
 // EncodeError returns a gRPC status error from the given error with the error
 // response encoded in the status details. If error is a goa ServiceError type
 // it implements a heuristic to compute the status code from the Timeout,
-// Fault, and Temporary characteristics of the ServiceError. If error is not a
-// ServiceError or a gRPC status error it returns a gRPC status error with
+// Fault, and Temporary characteristics of the ServiceError. Service errors
+// with validation violations use the InvalidArgument code unless they are
+// timeouts, temporary or faults and list the violations in the
+// google.rpc.BadRequest and Violations messages added to the status details. If error is not
+// a ServiceError or a gRPC status error it returns a gRPC status error with
 // Unknown code and Fault characteristic set.
 func EncodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
@@ -91,6 +177,9 @@ func EncodeError(err error) error {
 		var code codes.Code
 		{
 			code = codes.Unknown
+			if len(gerr.Violations) > 0 {
+				code = codes.InvalidArgument
+			}
 			if gerr.Fault {
 				code = codes.Internal
 			}
@@ -100,8 +189,11 @@ func EncodeError(err error) error {
 			if gerr.Temporary {
 				code = codes.Unavailable
 			}
+			if gerr.Name == goa.PreconditionFailed {
+				code = codes.FailedPrecondition
+			}
 		}
-		return NewStatusError(code, err, NewErrorResponse(err))
+		return NewStatusError(code, err, errorDetails(err)...)
 	}
 	// Return an unknown gRPC status error with fault characteristic set.
 	return NewStatusError(codes.Unknown, err, NewErrorResponse(err))
@@ -123,6 +215,44 @@ func DecodeError(err error) proto.Message {
 	return details[0].(proto.Message)
 }
 
+// errorDetails returns the status details for the given error: the error
+// response message followed by the bad request and violations messages if the
+// error has validation violations.
+func errorDetails(err error) []proto.Message {
+	details := []proto.Message{NewErrorResponse(err)}
+	if br := NewBadRequest(err); br != nil {
+		details = append(details, br, NewViolations(err))
+	}
+	return details
+}
+
+// encodeViolationValue returns the JSON encoding of the given violation
+// expected or actual value, the empty string if the value is nil or cannot be
+// encoded.
+func encodeViolationValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// decodeViolationValue decodes the JSON encoded violation expected or actual
+// value.
+func decodeViolationValue(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil
+	}
+	return v
+}
+
 // ErrInvalidType is the error returned when the wrong type is given to a
 // encoder or decoder.
 func ErrInvalidType(svc, m, expected string, actual interface{}) error {
@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"reflect"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestDecodeViolations(t *testing.T) {
+	var err error
+	err = goa.MergeErrors(err, goa.InvalidLengthError("message.items[1].name", "a", 1, 2, true))
+	err = goa.MergeErrors(err, goa.InvalidEnumValueError("message.map[foo]", "x", []interface{}{"y", "z"}))
+	expected := []*goa.Violation{
+		{Field: "/items/1/name", Rule: "min_length", Expected: 2.0, Actual: 1.0},
+		{Field: "/map/foo", Rule: "enum", Expected: []interface{}{"y", "z"}, Actual: "x"},
+	}
+	for i, v := range err.(*goa.ServiceError).Violations {
+		expected[i].Message = v.Message
+	}
+
+	vs := DecodeViolations(EncodeError(err))
+
+	if len(vs) != len(expected) {
+		t.Fatalf("got %d violations, expected %d", len(vs), len(expected))
+	}
+	for i, v := range vs {
+		if !reflect.DeepEqual(v, expected[i]) {
+			t.Errorf("violation %d: got %#v, expected %#v", i, v, expected[i])
+		}
+	}
+}
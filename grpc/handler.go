@@ -78,7 +78,7 @@ func (h *unaryHandler) Handle(ctx context.Context, reqpb interface{}) (interface
 			// Decode gRPC request message and incoming metadata
 			md, _ := metadata.FromIncomingContext(ctx)
 			if req, err = h.decoder(ctx, reqpb, md); err != nil {
-				return nil, status.Error(codes.InvalidArgument, err.Error())
+				return nil, decodeError(err)
 			}
 		}
 	}
@@ -135,7 +135,7 @@ func (h *streamHandler) Decode(ctx context.Context, reqpb interface{}) (interfac
 		if h.decoder != nil {
 			md, _ := metadata.FromIncomingContext(ctx)
 			if req, err = h.decoder(ctx, reqpb, md); err != nil {
-				return nil, status.Error(codes.InvalidArgument, err.Error())
+				return nil, decodeError(err)
 			}
 		}
 	}
@@ -147,3 +147,13 @@ func (h *streamHandler) Handle(ctx context.Context, stream interface{}) error {
 	_, err := h.endpoint(ctx, stream)
 	return err
 }
+
+// decodeError returns the gRPC status error returned when the request message
+// cannot be decoded or is invalid. The status details contain the error
+// response and the validation violations if err is a goa ServiceError.
+func decodeError(err error) error {
+	if _, ok := err.(*goa.ServiceError); ok {
+		return NewStatusError(codes.InvalidArgument, err, errorDetails(err)...)
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
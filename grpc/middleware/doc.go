@@ -9,6 +9,8 @@
 //   * Tracing middleware for unary and streaming server and client.
 //   * AWS X-Ray middleware for producing X-Ray segments for unary and streaming
 //     client and server.
+//   * Recover server middleware for unary and streaming endpoints that converts
+//     panics into internal errors.
 //
 // Example to use the server middleware:
 //
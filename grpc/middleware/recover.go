@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+
+	goagrpc "goa.design/goa/v3/grpc"
+	"goa.design/goa/v3/middleware"
+	"goa.design/goa/v3/middleware/xray"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerRecover returns a middleware that recovers from panics raised by
+// unary RPC handlers and converts them into goa.Fault errors encoded as gRPC
+// status errors with the Internal code. The middleware logs the panic value
+// and stack trace with l if not nil and records the panic in the X-Ray
+// segment stored in the request context if any. The error message only
+// includes the request ID so that the panic value does not leak to clients.
+//
+// UnaryServerRecover must be the last of the chained interceptors so that it
+// runs after the request ID and X-Ray interceptors and recovers from the
+// panics raised by the handler only.
+func UnaryServerRecover(l middleware.Logger) grpc.UnaryServerInterceptor {
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp = nil
+				err = recovered(ctx, l, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	})
+}
+
+// StreamServerRecover returns a middleware that recovers from panics raised
+// by streaming RPC handlers. See UnaryServerRecover for details.
+func StreamServerRecover(l middleware.Logger) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), l, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	})
+}
+
+// recovered logs and traces the value p recovered from a panic raised while
+// handling a request made to method and returns the corresponding gRPC
+// status error.
+func recovered(ctx context.Context, l middleware.Logger, method string, p interface{}) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	reqID := MetadataValue(md, RequestIDMetadataKey)
+	if reqID == "" {
+		reqID = shortID()
+	}
+	if l != nil {
+		l.Log("id", reqID,
+			"method", method,
+			"panic", p,
+			"stack", string(debug.Stack()))
+	}
+	if s, ok := ctx.Value(xray.SegKey).(*xray.Segment); ok {
+		s.RecordPanic(p)
+	}
+	return goagrpc.EncodeError(goa.Fault("internal error, request ID: %v", reqID))
+}
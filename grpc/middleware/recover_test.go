@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"strings"
+	"testing"
+
+	goagrpc "goa.design/goa/v3/grpc"
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	goapb "goa.design/goa/v3/grpc/pb"
+	"goa.design/goa/v3/middleware"
+	"goa.design/goa/v3/middleware/xray"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerRecover(t *testing.T) {
+	var buf bytes.Buffer
+	logger := middleware.NewLogger(log.New(&buf, "", 0))
+	info := &grpc.UnaryServerInfo{FullMethod: "Test.Test"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcm.RequestIDMetadataKey, "reqid"))
+	resp, err := grpcm.UnaryServerRecover(logger)(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	if resp != nil {
+		t.Errorf("got response %v, expected nil", resp)
+	}
+	assertPanicStatus(t, err, "internal error, request ID: reqid")
+	logs := buf.String()
+	for _, s := range []string{"id=reqid", "method=Test.Test", "panic=boom", "recover_test.go"} {
+		if !strings.Contains(logs, s) {
+			t.Errorf("logs %q do not contain %q", logs, s)
+		}
+	}
+}
+
+func TestUnaryServerRecoverNoPanic(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "Test.Test"}
+	resp, err := grpcm.UnaryServerRecover(nil)(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	if err != nil {
+		t.Errorf("got error %v, expected none", err)
+	}
+	if resp != "ok" {
+		t.Errorf("got response %v, expected %q", resp, "ok")
+	}
+}
+
+func TestStreamServerRecover(t *testing.T) {
+	conn, err := net.Dial("udp", "127.0.0.1:62111")
+	if err != nil {
+		t.Fatalf("failed to connect to daemon - %s", err)
+	}
+	seg := xray.NewSegment("test", xray.NewTraceID(), xray.NewID(), conn)
+	ctx := context.WithValue(context.Background(), xray.SegKey, seg)
+	info := &grpc.StreamServerInfo{FullMethod: "Test.Test"}
+	err = grpcm.StreamServerRecover(nil)(nil, grpcm.NewWrappedServerStream(ctx, &testServerStream{}), info, func(interface{}, grpc.ServerStream) error {
+		panic("boom")
+	})
+	assertPanicStatus(t, err, "")
+	if !seg.Fault {
+		t.Errorf("segment fault not set")
+	}
+	if seg.Cause == nil || len(seg.Cause.Exceptions) != 1 || seg.Cause.Exceptions[0].Message != "panic: boom" {
+		t.Errorf("got segment cause %+v, expected panic exception", seg.Cause)
+	}
+}
+
+func assertPanicStatus(t *testing.T, err error, msg string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("got error %#v, expected a gRPC status error", err)
+	}
+	if st.Code() != codes.Internal {
+		t.Errorf("got code %s, expected %s", st.Code(), codes.Internal)
+	}
+	resp, ok := goagrpc.DecodeError(err).(*goapb.ErrorResponse)
+	if !ok || !resp.Fault {
+		t.Errorf("got error details %v, expected a fault error response", goagrpc.DecodeError(err))
+	}
+	if strings.Contains(st.Message(), "boom") {
+		t.Errorf("got message %q, expected panic value to be omitted", st.Message())
+	}
+	if msg != "" && st.Message() != msg {
+		t.Errorf("got message %q, expected %q", st.Message(), msg)
+	}
+}
@@ -4,10 +4,9 @@
 package goapb
 
 import (
-	"fmt"
-	"math"
-
-	"github.com/golang/protobuf/proto"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -19,7 +18,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 // ErrorResponse message defines the error encoded in the gRPC response that
 // correspond to the errors created by the generated code. This is mainly
@@ -46,16 +45,17 @@ func (m *ErrorResponse) Reset()         { *m = ErrorResponse{} }
 func (m *ErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*ErrorResponse) ProtoMessage()    {}
 func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_error_a11b9d65941b6837, []int{0}
+	return fileDescriptor_0579b252106fcf4a, []int{0}
 }
+
 func (m *ErrorResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ErrorResponse.Unmarshal(m, b)
 }
 func (m *ErrorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_ErrorResponse.Marshal(b, m, deterministic)
 }
-func (dst *ErrorResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ErrorResponse.Merge(dst, src)
+func (m *ErrorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorResponse.Merge(m, src)
 }
 func (m *ErrorResponse) XXX_Size() int {
 	return xxx_messageInfo_ErrorResponse.Size(m)
@@ -108,22 +108,149 @@ func (m *ErrorResponse) GetFault() bool {
 	return false
 }
 
+// Violations message lists the validation failures of an error. It is added to
+// the status details of errors with validation violations.
+type Violations struct {
+	// violations lists the validation failures.
+	Violations           []*Violation `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Violations) Reset()         { *m = Violations{} }
+func (m *Violations) String() string { return proto.CompactTextString(m) }
+func (*Violations) ProtoMessage()    {}
+func (*Violations) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0579b252106fcf4a, []int{1}
+}
+
+func (m *Violations) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Violations.Unmarshal(m, b)
+}
+func (m *Violations) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Violations.Marshal(b, m, deterministic)
+}
+func (m *Violations) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Violations.Merge(m, src)
+}
+func (m *Violations) XXX_Size() int {
+	return xxx_messageInfo_Violations.Size(m)
+}
+func (m *Violations) XXX_DiscardUnknown() {
+	xxx_messageInfo_Violations.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Violations proto.InternalMessageInfo
+
+func (m *Violations) GetViolations() []*Violation {
+	if m != nil {
+		return m.Violations
+	}
+	return nil
+}
+
+// Violation message describes a single validation failure.
+type Violation struct {
+	// field is the JSON pointer to the invalid field.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// rule is the name of the validation rule that failed.
+	Rule string `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	// expected is the JSON encoding of the value expected by the rule.
+	Expected string `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	// actual is the JSON encoding of the invalid value.
+	Actual string `protobuf:"bytes,4,opt,name=actual,proto3" json:"actual,omitempty"`
+	// message describes the validation failure.
+	Message              string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Violation) Reset()         { *m = Violation{} }
+func (m *Violation) String() string { return proto.CompactTextString(m) }
+func (*Violation) ProtoMessage()    {}
+func (*Violation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0579b252106fcf4a, []int{2}
+}
+
+func (m *Violation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Violation.Unmarshal(m, b)
+}
+func (m *Violation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Violation.Marshal(b, m, deterministic)
+}
+func (m *Violation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Violation.Merge(m, src)
+}
+func (m *Violation) XXX_Size() int {
+	return xxx_messageInfo_Violation.Size(m)
+}
+func (m *Violation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Violation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Violation proto.InternalMessageInfo
+
+func (m *Violation) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *Violation) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *Violation) GetExpected() string {
+	if m != nil {
+		return m.Expected
+	}
+	return ""
+}
+
+func (m *Violation) GetActual() string {
+	if m != nil {
+		return m.Actual
+	}
+	return ""
+}
+
+func (m *Violation) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ErrorResponse)(nil), "goapb.ErrorResponse")
+	proto.RegisterType((*Violations)(nil), "goapb.Violations")
+	proto.RegisterType((*Violation)(nil), "goapb.Violation")
 }
 
-func init() { proto.RegisterFile("error.proto", fileDescriptor_error_a11b9d65941b6837) }
-
-var fileDescriptor_error_a11b9d65941b6837 = []byte{
-	// 160 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0xce, 0x3d, 0x0e, 0xc2, 0x30,
-	0x0c, 0x86, 0x61, 0xa5, 0x7f, 0x50, 0x23, 0x10, 0xb2, 0x18, 0x3c, 0x30, 0x54, 0x4c, 0x9d, 0x58,
-	0x38, 0x03, 0x17, 0xe8, 0x0d, 0x52, 0x35, 0x54, 0x95, 0x48, 0x1d, 0x39, 0xe9, 0xc0, 0x39, 0xb8,
-	0x30, 0xaa, 0x11, 0x62, 0xfb, 0xde, 0xc7, 0x8b, 0x61, 0xe7, 0x44, 0x58, 0xae, 0x41, 0x38, 0x31,
-	0x96, 0x23, 0xdb, 0xd0, 0x5f, 0xde, 0x06, 0xf6, 0xf7, 0x95, 0x3b, 0x17, 0x03, 0xcf, 0xd1, 0x21,
-	0x42, 0x31, 0x5b, 0xef, 0xc8, 0x34, 0xa6, 0xad, 0x3b, 0xdd, 0x78, 0x80, 0x6c, 0x1a, 0x28, 0x53,
-	0xc9, 0xa6, 0x01, 0x8f, 0x90, 0xfb, 0x38, 0x52, 0xae, 0xb0, 0x4e, 0x3c, 0x43, 0x9d, 0x9c, 0x0f,
-	0x2c, 0x56, 0x5e, 0x54, 0x34, 0xa6, 0xdd, 0x76, 0x7f, 0x40, 0x82, 0x4d, 0x9a, 0xbc, 0xe3, 0x25,
-	0x51, 0xa9, 0xb7, 0x5f, 0xe2, 0x09, 0xca, 0x87, 0x5d, 0x9e, 0x89, 0x2a, 0xf5, 0x6f, 0xf4, 0x95,
-	0xfe, 0x78, 0xfb, 0x04, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x9b, 0xa7, 0xd8, 0xb2, 0x00, 0x00, 0x00,
+func init() { proto.RegisterFile("error.proto", fileDescriptor_0579b252106fcf4a) }
+
+var fileDescriptor_0579b252106fcf4a = []byte{
+	// 249 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xc1, 0x4a, 0xc4, 0x30,
+	0x10, 0x86, 0xc9, 0x76, 0x5b, 0xb7, 0xb3, 0x28, 0xcb, 0x20, 0x12, 0xc4, 0x43, 0xe9, 0xa9, 0xa7,
+	0x22, 0x7a, 0xf7, 0xe6, 0x0b, 0xe4, 0xe0, 0x3d, 0xbb, 0x1d, 0x4b, 0xa0, 0x69, 0x42, 0x92, 0x8a,
+	0x9e, 0x7d, 0x04, 0x5f, 0x58, 0x3a, 0xdb, 0xad, 0xde, 0xfe, 0xef, 0x9f, 0x81, 0xe4, 0x1b, 0xd8,
+	0x53, 0x08, 0x2e, 0xb4, 0x3e, 0xb8, 0xe4, 0x30, 0xef, 0x9d, 0xf6, 0xc7, 0xfa, 0x47, 0xc0, 0xf5,
+	0xeb, 0x5c, 0x2b, 0x8a, 0xde, 0x8d, 0x91, 0x10, 0x61, 0x3b, 0x6a, 0x4b, 0x52, 0x54, 0xa2, 0x29,
+	0x15, 0x67, 0xbc, 0x81, 0x8d, 0xe9, 0xe4, 0x86, 0x9b, 0x8d, 0xe9, 0xf0, 0x00, 0x99, 0x8d, 0xbd,
+	0xcc, 0xb8, 0x98, 0x23, 0x3e, 0x40, 0x99, 0xc8, 0x7a, 0x17, 0x74, 0xf8, 0x92, 0xdb, 0x4a, 0x34,
+	0x3b, 0xf5, 0x57, 0xa0, 0x84, 0xab, 0x64, 0x2c, 0xb9, 0x29, 0xc9, 0x9c, 0x67, 0x17, 0xc4, 0x5b,
+	0xc8, 0xdf, 0xf5, 0x34, 0x24, 0x59, 0x70, 0x7f, 0x86, 0xfa, 0x05, 0xe0, 0xcd, 0xb8, 0x41, 0x27,
+	0xe3, 0xc6, 0x88, 0x8f, 0x00, 0x1f, 0x2b, 0x49, 0x51, 0x65, 0xcd, 0xfe, 0xe9, 0xd0, 0xf2, 0xff,
+	0xdb, 0x75, 0x4d, 0xfd, 0xdb, 0xa9, 0xbf, 0x05, 0x94, 0xeb, 0x84, 0xdf, 0x30, 0x34, 0x74, 0x8b,
+	0xd2, 0x19, 0x66, 0xcf, 0x30, 0x0d, 0xb4, 0x58, 0x71, 0xc6, 0x7b, 0xd8, 0xd1, 0xa7, 0xa7, 0x53,
+	0xa2, 0x6e, 0x91, 0x5b, 0x19, 0xef, 0xa0, 0xd0, 0xa7, 0x34, 0xe9, 0x81, 0xf5, 0x4a, 0xb5, 0xd0,
+	0xec, 0x66, 0x29, 0x46, 0xdd, 0x13, 0xbb, 0x95, 0xea, 0x82, 0xc7, 0x82, 0x2f, 0xfd, 0xfc, 0x3b,
+	0x00, 0x22, 0x58, 0xd1, 0x3c, 0x78, 0x01, 0x00, 0x00,
 }
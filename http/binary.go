@@ -0,0 +1,358 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// binaryWriter is implemented by the encoders of binary formats that share
+// the JSON data model such as MessagePack and CBOR.
+type binaryWriter interface {
+	writeNil()
+	writeBool(b bool)
+	writeInt(i int64)
+	writeUint(u uint64)
+	writeFloat(f float64, bits int)
+	writeString(s string)
+	writeBytes(b []byte)
+	writeArrayHeader(n int)
+	writeMapHeader(n int)
+}
+
+// encodeBinary writes the value v using bw. Struct fields are named after
+// their tag with the given key, falling back to the json tag.
+func encodeBinary(bw binaryWriter, v reflect.Value, key string) error {
+	switch v.Kind() {
+	case reflect.Invalid:
+		bw.writeNil()
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			bw.writeNil()
+			return nil
+		}
+		return encodeBinary(bw, v.Elem(), key)
+	case reflect.Bool:
+		bw.writeBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bw.writeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		bw.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		bw.writeFloat(v.Float(), v.Type().Bits())
+	case reflect.String:
+		bw.writeString(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			bw.writeNil()
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			bw.writeBytes(b)
+			return nil
+		}
+		bw.writeArrayHeader(v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeBinary(bw, v.Index(i), key); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			bw.writeNil()
+			return nil
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		bw.writeMapHeader(len(keys))
+		for _, k := range keys {
+			if err := encodeBinary(bw, k, key); err != nil {
+				return err
+			}
+			if err := encodeBinary(bw, v.MapIndex(k), key); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		var (
+			names  []string
+			fields []reflect.Value
+		)
+		for i := 0; i < t.NumField(); i++ {
+			name, omitempty, ok := taggedFieldName(t.Field(i), key)
+			if !ok {
+				continue
+			}
+			fv := v.Field(i)
+			if omitempty && isEmptyFormValue(fv) {
+				continue
+			}
+			names = append(names, name)
+			fields = append(fields, fv)
+		}
+		bw.writeMapHeader(len(names))
+		for i, name := range names {
+			bw.writeString(name)
+			if err := encodeBinary(bw, fields[i], key); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// binaryMap is the generic representation of decoded maps. It preserves the
+// order of the keys and supports keys of any type.
+type binaryMap []binaryMapItem
+
+// binaryMapItem is a decoded map key and value.
+type binaryMapItem struct {
+	key, value interface{}
+}
+
+// decodeBinary sets v from the generic value g produced by the decoders of
+// binary formats. g is one of nil, bool, int64, uint64, float64, string,
+// []byte, []interface{} or binaryMap. Struct fields are matched using their
+// tag with the given key, falling back to the json tag. Map entries that do
+// not match a field are skipped.
+func decodeBinary(v reflect.Value, g interface{}, key string) error {
+	if g == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeBinary(v.Elem(), g, key)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			break
+		}
+		v.Set(reflect.ValueOf(genericValue(g)))
+		return nil
+	case reflect.Bool:
+		if b, ok := g.(bool); ok {
+			v.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch n := g.(type) {
+		case int64:
+			i = n
+		case uint64:
+			if n > math.MaxInt64 {
+				return fmt.Errorf("value %d overflows %s", n, v.Type())
+			}
+			i = int64(n)
+		default:
+			return invalidBinaryValue(v, g)
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %s", i, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch n := g.(type) {
+		case uint64:
+			u = n
+		case int64:
+			if n < 0 {
+				return fmt.Errorf("value %d overflows %s", n, v.Type())
+			}
+			u = uint64(n)
+		default:
+			return invalidBinaryValue(v, g)
+		}
+		if v.OverflowUint(u) {
+			return fmt.Errorf("value %d overflows %s", u, v.Type())
+		}
+		v.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		switch n := g.(type) {
+		case float64:
+			v.SetFloat(n)
+		case int64:
+			v.SetFloat(float64(n))
+		case uint64:
+			v.SetFloat(float64(n))
+		default:
+			return invalidBinaryValue(v, g)
+		}
+		return nil
+	case reflect.String:
+		switch s := g.(type) {
+		case string:
+			v.SetString(s)
+			return nil
+		case []byte:
+			v.SetString(string(s))
+			return nil
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			switch b := g.(type) {
+			case []byte:
+				v.SetBytes(b)
+				return nil
+			case string:
+				v.SetBytes([]byte(b))
+				return nil
+			}
+		}
+		elems, ok := g.([]interface{})
+		if !ok {
+			break
+		}
+		s := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, e := range elems {
+			if err := decodeBinary(s.Index(i), e, key); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		elems, ok := g.([]interface{})
+		if !ok {
+			if b, isBytes := g.([]byte); isBytes && v.Type().Elem().Kind() == reflect.Uint8 {
+				elems = make([]interface{}, len(b))
+				for i, c := range b {
+					elems[i] = uint64(c)
+				}
+			} else {
+				break
+			}
+		}
+		if len(elems) != v.Len() {
+			return fmt.Errorf("got %d elements, expected %d for %s", len(elems), v.Len(), v.Type())
+		}
+		for i, e := range elems {
+			if err := decodeBinary(v.Index(i), e, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		items, ok := g.(binaryMap)
+		if !ok {
+			break
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(items))
+		for _, item := range items {
+			k := reflect.New(v.Type().Key()).Elem()
+			if err := decodeBinary(k, item.key, key); err != nil {
+				return err
+			}
+			e := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeBinary(e, item.value, key); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Struct:
+		items, ok := g.(binaryMap)
+		if !ok {
+			break
+		}
+		fields := make(map[string]int)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if name, _, ok := taggedFieldName(t.Field(i), key); ok {
+				fields[name] = i
+			}
+		}
+		for _, item := range items {
+			name, ok := item.key.(string)
+			if !ok {
+				continue
+			}
+			i, ok := fields[name]
+			if !ok {
+				continue
+			}
+			if err := decodeBinary(v.Field(i), item.value, key); err != nil {
+				return fmt.Errorf("invalid value for %q: %s", name, err)
+			}
+		}
+		return nil
+	}
+	return invalidBinaryValue(v, g)
+}
+
+// genericValue converts the decoded value g so that it can be assigned to an
+// empty interface. Maps with string keys are converted to
+// map[string]interface{} and other maps to map[interface{}]interface{}.
+func genericValue(g interface{}) interface{} {
+	switch v := g.(type) {
+	case []interface{}:
+		for i, e := range v {
+			v[i] = genericValue(e)
+		}
+		return v
+	case binaryMap:
+		strs := make(map[string]interface{}, len(v))
+		for _, item := range v {
+			k, ok := item.key.(string)
+			if !ok {
+				break
+			}
+			strs[k] = genericValue(item.value)
+		}
+		if len(strs) == len(v) {
+			return strs
+		}
+		m := make(map[interface{}]interface{}, len(v))
+		for _, item := range v {
+			k := genericValue(item.key)
+			if b, ok := k.([]byte); ok {
+				k = string(b)
+			}
+			if k != nil && !reflect.TypeOf(k).Comparable() {
+				k = fmt.Sprint(k)
+			}
+			m[k] = genericValue(item.value)
+		}
+		return m
+	}
+	return g
+}
+
+// invalidBinaryValue returns the error reported when the decoded value g
+// cannot be assigned to v.
+func invalidBinaryValue(v reflect.Value, g interface{}) error {
+	return fmt.Errorf("cannot decode %T into %s", g, v.Type())
+}
+
+// readBinary reads n bytes from r. The buffer grows as the data is read so
+// that invalid lengths do not cause large allocations.
+func readBinary(r io.Reader, n uint64) ([]byte, error) {
+	if n > math.MaxInt32 {
+		return nil, fmt.Errorf("length %d is too large", n)
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,320 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// CBORContentType is the media type of CBOR encoded content.
+const CBORContentType = "application/cbor"
+
+// cborBreak is the "break" stop code terminating indefinite-length items.
+const cborBreak = 0xff
+
+// NewCBOREncoder returns an encoder that writes values to w using the Concise
+// Binary Object Representation defined in RFC 7049. Struct fields are written
+// as map entries named after their "cbor" tag, falling back to the "json"
+// tag, following the same rules as encoding/json for omitempty. Byte slices
+// are written as byte strings.
+func NewCBOREncoder(w io.Writer) Encoder {
+	return EncodingFunc(func(v interface{}) error {
+		var e cborWriter
+		if err := encodeBinary(&e, reflect.ValueOf(v), "cbor"); err != nil {
+			return fmt.Errorf("can't encode %T as %s: %s", v, CBORContentType, err)
+		}
+		_, err := w.Write(e.Bytes())
+		return err
+	})
+}
+
+// NewCBORDecoder returns a decoder that reads CBOR values from r. See
+// NewCBOREncoder for how values map to Go types. Indefinite-length items are
+// supported, tags are ignored and their content decoded as is.
+func NewCBORDecoder(r io.Reader) Decoder {
+	br := bufio.NewReader(r)
+	return EncodingFunc(func(v interface{}) error {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return fmt.Errorf("can't decode %s content to %T", CBORContentType, v)
+		}
+		g, err := readCBOR(br)
+		if err != nil {
+			return err
+		}
+		if g == cborBreakValue {
+			return fmt.Errorf("unexpected CBOR break code")
+		}
+		return decodeBinary(rv.Elem(), g, "cbor")
+	})
+}
+
+// cborWriter implements binaryWriter for the CBOR format.
+type cborWriter struct {
+	bytes.Buffer
+}
+
+func (e *cborWriter) writeNil() { e.WriteByte(0xf6) }
+
+func (e *cborWriter) writeBool(b bool) {
+	if b {
+		e.WriteByte(0xf5)
+		return
+	}
+	e.WriteByte(0xf4)
+}
+
+func (e *cborWriter) writeInt(i int64) {
+	if i >= 0 {
+		e.writeHead(0, uint64(i))
+		return
+	}
+	e.writeHead(1, uint64(-1-i))
+}
+
+func (e *cborWriter) writeUint(u uint64) { e.writeHead(0, u) }
+
+func (e *cborWriter) writeFloat(f float64, bits int) {
+	var b [9]byte
+	if bits == 32 {
+		b[0] = 0xfa
+		binary.BigEndian.PutUint32(b[1:], math.Float32bits(float32(f)))
+		e.Write(b[:5])
+		return
+	}
+	b[0] = 0xfb
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(f))
+	e.Write(b[:])
+}
+
+func (e *cborWriter) writeString(s string) {
+	e.writeHead(3, uint64(len(s)))
+	e.WriteString(s)
+}
+
+func (e *cborWriter) writeBytes(b []byte) {
+	e.writeHead(2, uint64(len(b)))
+	e.Write(b)
+}
+
+func (e *cborWriter) writeArrayHeader(n int) { e.writeHead(4, uint64(n)) }
+
+func (e *cborWriter) writeMapHeader(n int) { e.writeHead(5, uint64(n)) }
+
+// writeHead writes the initial byte and argument of a data item of the given
+// major type.
+func (e *cborWriter) writeHead(major byte, n uint64) {
+	var b [9]byte
+	major <<= 5
+	switch {
+	case n < 24:
+		e.WriteByte(major | byte(n))
+	case n <= math.MaxUint8:
+		e.Write([]byte{major | 24, byte(n)})
+	case n <= math.MaxUint16:
+		b[0] = major | 25
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		e.Write(b[:3])
+	case n <= math.MaxUint32:
+		b[0] = major | 26
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		e.Write(b[:5])
+	default:
+		b[0] = major | 27
+		binary.BigEndian.PutUint64(b[1:], n)
+		e.Write(b[:])
+	}
+}
+
+// cborBreakCode is the type of the value returned by readCBOR when it reads
+// the break stop code.
+type cborBreakCode struct{}
+
+// cborBreakValue is returned by readCBOR when it reads the break stop code.
+var cborBreakValue = cborBreakCode{}
+
+// readCBOR reads the next CBOR data item from r and returns its generic
+// representation, see decodeBinary.
+func readCBOR(r *bufio.Reader) (interface{}, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if c == cborBreak {
+		return cborBreakValue, nil
+	}
+	major, info := c>>5, c&0x1f
+	if major == 7 {
+		return readCBORSimple(r, info)
+	}
+	indefinite := info == 31
+	var n uint64
+	if !indefinite {
+		if n, err = readCBORArgument(r, info); err != nil {
+			return nil, err
+		}
+	}
+	switch major {
+	case 0:
+		if n <= math.MaxInt64 {
+			return int64(n), nil
+		}
+		return n, nil
+	case 1:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("CBOR negative integer -1-%d overflows int64", n)
+		}
+		return -1 - int64(n), nil
+	case 2, 3:
+		var b []byte
+		if indefinite {
+			b, err = readCBORChunks(r, major)
+		} else {
+			b, err = readBinary(r, n)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if major == 3 {
+			return string(b), nil
+		}
+		return b, nil
+	case 4:
+		elems := []interface{}{}
+		for i := uint64(0); indefinite || i < n; i++ {
+			e, err := readCBOR(r)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if e == cborBreakValue {
+				if !indefinite {
+					return nil, fmt.Errorf("unexpected CBOR break code")
+				}
+				break
+			}
+			elems = append(elems, e)
+		}
+		return elems, nil
+	case 5:
+		m := binaryMap{}
+		for i := uint64(0); indefinite || i < n; i++ {
+			k, err := readCBOR(r)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if k == cborBreakValue {
+				if !indefinite {
+					return nil, fmt.Errorf("unexpected CBOR break code")
+				}
+				break
+			}
+			v, err := readCBOR(r)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if v == cborBreakValue {
+				return nil, fmt.Errorf("unexpected CBOR break code")
+			}
+			m = append(m, binaryMapItem{k, v})
+		}
+		return m, nil
+	default: // 6: tag
+		if indefinite {
+			return nil, fmt.Errorf("invalid CBOR tag")
+		}
+		v, err := readCBOR(r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if v == cborBreakValue {
+			return nil, fmt.Errorf("unexpected CBOR break code")
+		}
+		return v, nil
+	}
+}
+
+// readCBORArgument reads the argument of a data item given the additional
+// information encoded in its initial byte.
+func readCBORArgument(r io.Reader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		return readBE(r, 1<<(info-24))
+	}
+	return 0, fmt.Errorf("invalid CBOR additional information %d", info)
+}
+
+// readCBORChunks reads the definite-length chunks of an indefinite-length
+// byte or text string of the given major type.
+func readCBORChunks(r *bufio.Reader, major byte) ([]byte, error) {
+	var buf bytes.Buffer
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if c == cborBreak {
+			return buf.Bytes(), nil
+		}
+		if c>>5 != major || c&0x1f == 31 {
+			return nil, fmt.Errorf("invalid CBOR indefinite-length string chunk")
+		}
+		n, err := readCBORArgument(r, c&0x1f)
+		if err != nil {
+			return nil, err
+		}
+		b, err := readBinary(r, n)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+}
+
+// readCBORSimple reads a simple value or floating-point number given the
+// additional information encoded in its initial byte.
+func readCBORSimple(r io.Reader, info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23: // null, undefined
+		return nil, nil
+	case 25:
+		u, err := readBE(r, 2)
+		return halfToFloat64(uint16(u)), err
+	case 26:
+		u, err := readBE(r, 4)
+		return float64(math.Float32frombits(uint32(u))), err
+	case 27:
+		u, err := readBE(r, 8)
+		return math.Float64frombits(u), err
+	}
+	return nil, fmt.Errorf("unsupported CBOR simple value %d", info)
+}
+
+// halfToFloat64 converts the IEEE 754 half-precision number h.
+func halfToFloat64(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(mant+1024, exp-25)
+}
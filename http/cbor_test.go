@@ -0,0 +1,96 @@
+package http
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCBOREncoder(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected string
+	}{
+		{"nil", nil, "f6"},
+		{"bool", false, "f4"},
+		{"small", 10, "0a"},
+		{"uint8", 100, "1864"},
+		{"uint16", 1000, "1903e8"},
+		{"uint64", uint64(math.MaxUint64), "1bffffffffffffffff"},
+		{"negative", -1000, "3903e7"},
+		{"float32", float32(100000), "fa47c35000"},
+		{"float64", 1.1, "fb3ff199999999999a"},
+		{"string", "IETF", "6449455446"},
+		{"bytes", []byte{1, 2, 3, 4}, "4401020304"},
+		{"array", []int{1, 2, 3}, "83010203"},
+		{"map", map[int]int{3: 4, 1: 2}, "a201020304"},
+		{"struct", &formAddress{City: strPtr("x")}, "a1646369747961 78"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewCBOREncoder(&buf).Encode(c.Value); err != nil {
+				t.Fatal(err)
+			}
+			expected := strings.Replace(c.Expected, " ", "", -1)
+			if actual := hex.EncodeToString(buf.Bytes()); actual != expected {
+				t.Errorf("got %s, expected %s", actual, expected)
+			}
+		})
+	}
+}
+
+func TestCBORDecoder(t *testing.T) {
+	city := "Paris"
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected interface{}
+		Err      string
+	}{
+		{"struct", "a2646369747965506172697363666f6ff6", &formAddress{City: &city}, ""},
+		{"indefinite-map", "bf64636974796550617269736374616780ff", &formAddress{City: &city}, ""},
+		{"indefinite-array", "a164746167739f61616162ff", &multipartBody{Tags: []string{"a", "b"}}, ""},
+		{"indefinite-string", "a1657469746c65 7f625061637269 73ff", &multipartBody{Title: &city}, ""},
+		{"tagged", "a16466696c65 d8c9 4101", &multipartBody{File: []byte{1}}, ""},
+		{"half-float", "a165726174696ff93e00", &multipartBody{Ratio: 1.5}, ""},
+		{"interface", "a2616101616282f4f7", &map[string]interface{}{"a": int64(1), "b": []interface{}{false, nil}}, ""},
+		{"negative-uint", "a165636f756e7420", nil, `invalid value for "count": value -1 overflows uint`},
+		{"truncated", "a2646369747965", nil, "unexpected EOF"},
+		{"unexpected-break", "ff", nil, "unexpected CBOR break code"},
+		{"invalid-info", "1c", nil, "invalid CBOR additional information 28"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := hex.DecodeString(strings.Replace(c.Input, " ", "", -1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var v interface{}
+			if c.Expected != nil {
+				v = reflect.New(reflect.TypeOf(c.Expected).Elem()).Interface()
+			} else {
+				v = &struct {
+					Count *uint `json:"count"`
+				}{}
+			}
+			err = NewCBORDecoder(bytes.NewReader(b)).Decode(v)
+			if c.Err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.Err) {
+					t.Fatalf("got error %v, expected %q", err, c.Err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(v, c.Expected) {
+				t.Errorf("got %#v, expected %#v", v, c.Expected)
+			}
+		})
+	}
+}
@@ -207,6 +207,13 @@ func ErrInvalidResponse(svc, m string, code int, body string) error {
 		Temporary: temporary, Timeout: timeout, Fault: fault}
 }
 
+// ErrNotModified is the error returned when the service responded to a
+// conditional request with 304 Not Modified indicating that the resource
+// cached by the client is still valid.
+func ErrNotModified(svc, m string) error {
+	return &ClientError{Name: "not_modified", Message: "resource not modified", Service: svc, Method: m}
+}
+
 // ErrRequestError is the error returned when the request fails to be sent.
 func ErrRequestError(svc, m string, err error) error {
 	temporary := false
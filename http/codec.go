@@ -0,0 +1,198 @@
+package http
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"strings"
+	"sync"
+)
+
+type (
+	// Codec describes how to encode and decode HTTP bodies of a given media
+	// type.
+	Codec struct {
+		// MediaType is the media type handled by the codec, e.g.
+		// "application/json".
+		MediaType string
+		// Suffixes lists the structured syntax suffixes (RFC 6839)
+		// handled by the codec, e.g. "json" for media types such as
+		// "application/vnd.goa+json".
+		Suffixes []string
+		// NewEncoder creates an encoder that writes to w. NewEncoder may
+		// be nil if the codec only decodes.
+		NewEncoder func(w io.Writer) Encoder
+		// NewDecoder creates a decoder that reads from r. NewDecoder may
+		// be nil if the codec only encodes.
+		NewDecoder func(r io.Reader) Decoder
+	}
+
+	// CodecRegistry maps media types to codecs. The RequestDecoder,
+	// ResponseEncoder, RequestEncoder and ResponseDecoder functions use
+	// the codecs registered in Codecs to encode and decode HTTP bodies. A
+	// CodecRegistry is safe for concurrent use.
+	CodecRegistry struct {
+		mu       sync.RWMutex
+		codecs   map[string]*Codec
+		suffixes map[string]*Codec
+		order    []string
+	}
+)
+
+// Codecs is the registry of the codecs used by the default encoders and
+// decoders. It contains the codecs for the following media types:
+//
+//     * application/json using package encoding/json
+//     * application/xml using package encoding/xml
+//     * application/gob using package encoding/gob
+//     * application/x-www-form-urlencoded using NewFormEncoder and NewFormDecoder
+//     * application/msgpack using NewMsgPackEncoder and NewMsgPackDecoder
+//     * application/cbor using NewCBOREncoder and NewCBORDecoder
+//     * application/x-protobuf using NewProtobufEncoder and NewProtobufDecoder
+//     * text/html and text/plain for strings
+//
+var Codecs = NewCodecRegistry()
+
+// RegisterCodec registers the codec c in Codecs so that the default encoders
+// and decoders handle its media type. RegisterCodec replaces the codec
+// previously registered for the same media type or suffixes if any.
+//
+// Example:
+//
+//    goahttp.RegisterCodec(&goahttp.Codec{
+//        MediaType:  "text/csv",
+//        NewEncoder: NewCSVEncoder,
+//        NewDecoder: NewCSVDecoder,
+//    })
+//
+func RegisterCodec(c *Codec) {
+	Codecs.Register(c)
+}
+
+// NewCodecRegistry returns a registry initialized with the codecs listed in
+// the documentation of Codecs.
+func NewCodecRegistry() *CodecRegistry {
+	r := &CodecRegistry{
+		codecs:   make(map[string]*Codec),
+		suffixes: make(map[string]*Codec),
+	}
+	r.Register(&Codec{
+		MediaType:  "application/json",
+		Suffixes:   []string{"json"},
+		NewEncoder: func(w io.Writer) Encoder { return json.NewEncoder(w) },
+		NewDecoder: func(r io.Reader) Decoder { return json.NewDecoder(r) },
+	})
+	r.Register(&Codec{
+		MediaType:  "application/xml",
+		Suffixes:   []string{"xml"},
+		NewEncoder: func(w io.Writer) Encoder { return xml.NewEncoder(w) },
+		NewDecoder: func(r io.Reader) Decoder { return xml.NewDecoder(r) },
+	})
+	r.Register(&Codec{
+		MediaType:  "application/gob",
+		Suffixes:   []string{"gob"},
+		NewEncoder: func(w io.Writer) Encoder { return gob.NewEncoder(w) },
+		NewDecoder: func(r io.Reader) Decoder { return gob.NewDecoder(r) },
+	})
+	r.Register(&Codec{
+		MediaType:  FormContentType,
+		NewEncoder: NewFormEncoder,
+		NewDecoder: NewFormDecoder,
+	})
+	r.Register(&Codec{
+		MediaType:  MsgPackContentType,
+		Suffixes:   []string{"msgpack"},
+		NewEncoder: NewMsgPackEncoder,
+		NewDecoder: NewMsgPackDecoder,
+	})
+	r.Register(&Codec{
+		MediaType:  CBORContentType,
+		Suffixes:   []string{"cbor"},
+		NewEncoder: NewCBOREncoder,
+		NewDecoder: NewCBORDecoder,
+	})
+	r.Register(&Codec{
+		MediaType:  ProtobufContentType,
+		Suffixes:   []string{"proto"},
+		NewEncoder: NewProtobufEncoder,
+		NewDecoder: NewProtobufDecoder,
+	})
+	r.Register(&Codec{
+		MediaType:  "text/html",
+		Suffixes:   []string{"html"},
+		NewEncoder: func(w io.Writer) Encoder { return newTextEncoder(w, "text/html") },
+		NewDecoder: func(r io.Reader) Decoder { return newTextDecoder(r, "text/html") },
+	})
+	r.Register(&Codec{
+		MediaType:  "text/plain",
+		Suffixes:   []string{"txt"},
+		NewEncoder: func(w io.Writer) Encoder { return newTextEncoder(w, "text/plain") },
+		NewDecoder: func(r io.Reader) Decoder { return newTextDecoder(r, "text/plain") },
+	})
+	return r
+}
+
+// Register adds the codec c to the registry. Register replaces the codec
+// previously registered for the same media type or suffixes if any.
+func (r *CodecRegistry) Register(c *Codec) {
+	mt := strings.ToLower(c.MediaType)
+	if m, _, err := mime.ParseMediaType(mt); err == nil {
+		mt = m
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.codecs[mt]; !ok {
+		r.order = append(r.order, mt)
+	}
+	r.codecs[mt] = c
+	for _, s := range c.Suffixes {
+		r.suffixes[strings.ToLower(strings.TrimPrefix(s, "+"))] = c
+	}
+}
+
+// Lookup returns the codec registered for the media type mt or for its
+// structured syntax suffix if there is none, nil if no codec matches. Media
+// type parameters are ignored.
+func (r *CodecRegistry) Lookup(mt string) *Codec {
+	mt = strings.ToLower(mt)
+	if m, _, err := mime.ParseMediaType(mt); err == nil {
+		mt = m
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if c, ok := r.codecs[mt]; ok {
+		return c
+	}
+	if i := strings.LastIndex(mt, "+"); i >= 0 {
+		return r.suffixes[mt[i+1:]]
+	}
+	return nil
+}
+
+// MediaTypes returns the media types of the registered codecs in order of
+// registration.
+func (r *CodecRegistry) MediaTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string{}, r.order...)
+}
+
+// NewEncoder returns an encoder that writes content of media type mt to w
+// using the matching codec, nil if there is none.
+func (r *CodecRegistry) NewEncoder(mt string, w io.Writer) Encoder {
+	if c := r.Lookup(mt); c != nil && c.NewEncoder != nil {
+		return c.NewEncoder(w)
+	}
+	return nil
+}
+
+// NewDecoder returns a decoder that reads content of media type mt from rd
+// using the matching codec, nil if there is none.
+func (r *CodecRegistry) NewDecoder(mt string, rd io.Reader) Decoder {
+	if c := r.Lookup(mt); c != nil && c.NewDecoder != nil {
+		return c.NewDecoder(rd)
+	}
+	return nil
+}
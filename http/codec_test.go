@@ -0,0 +1,124 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodecRegistryLookup(t *testing.T) {
+	r := NewCodecRegistry()
+	cases := []struct {
+		MediaType string
+		Expected  string
+	}{
+		{"application/json", "application/json"},
+		{"Application/JSON; charset=utf-8", "application/json"},
+		{"application/vnd.goa+json", "application/json"},
+		{"+xml", "application/xml"},
+		{"application/msgpack", MsgPackContentType},
+		{"application/cbor", CBORContentType},
+		{"application/vnd.goa+cbor", CBORContentType},
+		{"application/x-protobuf", ProtobufContentType},
+		{FormContentType, FormContentType},
+		{"text/plain", "text/plain"},
+		{"text/csv", ""},
+		{"application/vnd.goa+csv", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.MediaType, func(t *testing.T) {
+			var actual string
+			if codec := r.Lookup(c.MediaType); codec != nil {
+				actual = codec.MediaType
+			}
+			if actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCodecRegistryRegister(t *testing.T) {
+	r := NewCodecRegistry()
+	r.Register(&Codec{
+		MediaType: "text/csv",
+		Suffixes:  []string{"+csv"},
+		NewEncoder: func(w io.Writer) Encoder {
+			return EncodingFunc(func(v interface{}) error {
+				_, err := fmt.Fprintf(w, "%v", v)
+				return err
+			})
+		},
+	})
+	var buf bytes.Buffer
+	if err := r.NewEncoder("application/vnd.goa+csv", &buf).Encode("a,b"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "a,b" {
+		t.Errorf("got %q, expected %q", buf.String(), "a,b")
+	}
+	if dec := r.NewDecoder("text/csv", &buf); dec != nil {
+		t.Errorf("got decoder %T, expected none", dec)
+	}
+	mts := r.MediaTypes()
+	if mts[0] != "application/json" || mts[len(mts)-1] != "text/csv" {
+		t.Errorf("got media types %v, expected JSON first and CSV last", mts)
+	}
+	r.Register(&Codec{MediaType: "application/json"})
+	if len(r.MediaTypes()) != len(mts) {
+		t.Errorf("got %d media types after replacing JSON codec, expected %d", len(r.MediaTypes()), len(mts))
+	}
+}
+
+func TestCodecsRoundTrip(t *testing.T) {
+	var (
+		name  = "joe"
+		count = 3
+	)
+	body := &multipartBody{Title: &name, Count: &count, Ratio: 0.5, Tags: []string{"a"}, File: []byte{0, 1}}
+	for _, ct := range []string{"application/json", MsgPackContentType, CBORContentType} {
+		t.Run(ct, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", nil)
+			req.Header.Set("Content-Type", ct)
+			if err := RequestEncoder(req).Encode(body); err != nil {
+				t.Fatal(err)
+			}
+			var decoded multipartBody
+			if err := RequestDecoder(req).Decode(&decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(&decoded, body) {
+				t.Errorf("got %+v, expected %+v", decoded, *body)
+			}
+		})
+	}
+}
+
+func TestResponseCodecs(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, MsgPackContentType)
+	if err := ResponseEncoder(ctx, w).Encode(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != MsgPackContentType {
+		t.Errorf("got Content-Type %q, expected %q", ct, MsgPackContentType)
+	}
+	resp := &http.Response{
+		Header: http.Header{"Content-Type": {w.Header().Get("Content-Type")}},
+		Body:   ioutil.NopCloser(strings.NewReader(w.Body.String())),
+	}
+	var decoded map[string]int
+	if err := ResponseDecoder(resp).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["a"] != 1 {
+		t.Errorf("got %v, expected map[a:1]", decoded)
+	}
+}
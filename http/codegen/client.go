@@ -109,6 +109,52 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			Source: endpointInitT,
 			Data:   e,
 		})
+		if e.ClientStream != nil && e.SSE {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-sse-recv",
+				Source: responseStreamRecvT,
+				Data:   e.ClientStream,
+			})
+			if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-stream-set-view",
+					Source: streamSetViewT,
+					Data:   e.ClientStream,
+				})
+			}
+			continue
+		}
+		if e.ClientStream != nil && e.NDJSON {
+			if e.ClientStream.RecvTypeRef != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-ndjson-recv",
+					Source: responseStreamRecvT,
+					Data:   e.ClientStream,
+				})
+			}
+			if e.ClientStream.SendTypeRef != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-ndjson-send",
+					Source: requestStreamSendT,
+					Data:   e.ClientStream,
+				})
+			}
+			if e.ClientStream.MustClose {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-ndjson-close",
+					Source: requestStreamCloseT,
+					Data:   e.ClientStream,
+				})
+			}
+			if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-stream-set-view",
+					Source: streamSetViewT,
+					Data:   e.ClientStream,
+				})
+			}
+			continue
+		}
 		if e.ClientStream != nil {
 			if e.ClientStream.RecvTypeRef != "" {
 				sections = append(sections, &codegen.SectionTemplate{
@@ -209,6 +255,13 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 				Source: multipartRequestEncoderT,
 				Data:   e.MultipartRequestEncoder,
 			})
+			if e.MultipartRequestEncoder.DefaultName != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "multipart-request-default-encoder",
+					Source: multipartRequestDefaultEncoderT,
+					Data:   e.MultipartRequestEncoder,
+				})
+			}
 		}
 		if e.Result != nil || len(e.Errors) > 0 {
 			sections = append(sections, &codegen.SectionTemplate{
@@ -350,7 +403,48 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		}
 	{{- end }}
 
-	{{- if .ClientStream }}
+	{{- if .SSE }}
+		req.Header.Set("Accept", goahttp.EventStreamContentType)
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if !goahttp.IsEventStream(resp) {
+			return decodeResponse(resp)
+		}
+		stream := &{{ .ClientStream.VarName }}{events: goahttp.NewEventReader(resp.Body)}
+		{{- if .Method.ViewedResult }}
+			{{- if not .Method.ViewedResult.ViewName }}
+		view := resp.Header.Get("goa-view")
+		stream.SetView(view)
+			{{- end }}
+		{{- end }}
+		return stream, nil
+	{{- else if .NDJSON }}
+		{{- if .ClientStream.SendTypeRef }}
+		stream := &{{ .ClientStream.VarName }}{
+			req:    goahttp.NewNDJSONRequestStream("{{ .ServiceName }}", "{{ .Method.Name }}", c.{{ .Method.VarName }}Doer, req),
+			decode: decodeResponse,
+		}
+		{{- else }}
+		req.Header.Set("Accept", goahttp.NDJSONContentType)
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if !goahttp.IsNDJSON(resp) {
+			return decodeResponse(resp)
+		}
+		stream := &{{ .ClientStream.VarName }}{r: goahttp.NewNDJSONResponseReader(resp)}
+			{{- if .Method.ViewedResult }}
+				{{- if not .Method.ViewedResult.ViewName }}
+		view := resp.Header.Get("goa-view")
+		stream.SetView(view)
+				{{- end }}
+			{{- end }}
+		{{- end }}
+		return stream, nil
+	{{- else if .ClientStream }}
 		var cancel context.CancelFunc
 		{
 			ctx, cancel = context.WithCancel(ctx)
@@ -430,6 +524,22 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- end }}
 		{{- end }}
 	{{- end }}
+	{{- range .Payload.Request.Cookies }}
+		{{- if .FieldPointer }}
+		if p.{{ .FieldName }} != nil {
+		{{- end }}
+		req.AddCookie(&http.Cookie{
+			Name: {{ printf "%q" .Name }},
+			Value:
+			{{- if eq .Type.Name "string" }} {{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
+			{{- else if eq .Type.Name "bytes" }} string(p.{{ .FieldName }})
+			{{- else }} fmt.Sprintf("%v", {{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }})
+			{{- end }},
+		})
+		{{- if .FieldPointer }}
+		}
+		{{- end }}
+	{{- end }}
 	{{- if or .Payload.Request.QueryParams }}
 		values := req.URL.Query()
 	{{- end }}
@@ -487,6 +597,9 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 		{{- else }}
 		body := p
 		{{- end }}
+		{{- if .Payload.Request.Form }}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		{{- end }}
 		if err := encoder(req).Encode(&body); err != nil {
 			return goahttp.ErrEncodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
 		}
@@ -667,6 +780,10 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 				{{- end }}
 			{{- end }}
 		{{- end }}
+	{{- end }}
+	{{- if .Conditional }}
+		case http.StatusNotModified:
+			return nil, goahttp.ErrNotModified({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }})
 	{{- end }}
 		default:
 			body, _ := ioutil.ReadAll(resp.Body)
@@ -682,7 +799,7 @@ const singleResponseT = ` {{- if .ClientBody }}
 				body {{ .ClientBody.VarName }}
 				err error
 			)
-			err = decoder(resp).Decode(&body)
+			err = {{ if .ProblemDetails }}goahttp.ProblemDecoder(decoder){{ else }}decoder{{ end }}(resp).Decode(&body)
 			if err != nil {
 				return nil, goahttp.ErrDecodingError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
 			}
@@ -787,6 +904,74 @@ const singleResponseT = ` {{- if .ClientBody }}
 		{{- end }}{{/* range .Headers */}}
 	{{- end }}
 
+	{{- if .Cookies }}
+			var (
+		{{- range .Cookies }}
+				{{ .VarName }}    {{ .TypeRef }}
+				{{ .VarName }}Raw string
+		{{- end }}
+		{{- if and (not .ClientBody) (not .Headers) }}
+			{{- if .MustValidate }}
+				err error
+			{{- end }}
+		{{- end }}
+
+				cookies = resp.Cookies()
+			)
+			for _, c := range cookies {
+				switch c.Name {
+		{{- range .Cookies }}
+				case {{ printf "%q" .Name }}:
+					{{ .VarName }}Raw = c.Value
+		{{- end }}
+				}
+			}
+		{{- range .Cookies }}
+
+		{{- if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
+			{{- if .Required }}
+			if {{ .VarName }}Raw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
+			}
+			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+			{{- else }}
+			if {{ .VarName }}Raw != "" {
+				{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+			}
+				{{- if .DefaultValue }} else {
+				{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
+			}
+				{{- end }}
+			{{- end }}
+
+		{{- else }}{{/* not string and not any */}}
+		{
+			{{- if .Required }}
+			if {{ .VarName }}Raw == "" {
+				return nil, goahttp.ErrValidationError("{{ $.ServiceName }}", "{{ $.Method.Name }}", goa.MissingFieldError("{{ .Name }}", "cookie"))
+			}
+			{{- else if .DefaultValue }}
+			if {{ .VarName }}Raw == "" {
+				{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
+			}
+			{{- end }}
+
+			{{- if .DefaultValue }}else {
+			{{- else if not .Required }}
+			if {{ .VarName }}Raw != "" {
+			{{- end }}
+				{{- template "type_conversion" . }}
+			{{- if or .DefaultValue (not .Required) }}
+			}
+			{{- end }}
+		}
+		{{- end }}
+		{{- if .Validate }}
+			{{ .Validate }}
+		{{- end }}
+		{{- end }}{{/* range .Cookies */}}
+	{{- end }}
+
 	{{- if .MustValidate }}
 			if err != nil {
 				return nil, goahttp.ErrValidationError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
@@ -802,6 +987,11 @@ type {{ .FuncName }} func(*multipart.Writer, {{ .Payload.Ref }}) error
 // input: multipartData
 const multipartRequestEncoderT = `{{ printf "%s returns an encoder to encode the multipart request for the %q service %q endpoint." .InitName .ServiceName .MethodName | comment }}
 func {{ .InitName }}(encoderFn {{ .FuncName }}) func(r *http.Request) goahttp.Encoder {
+	{{- if .DefaultName }}
+	if encoderFn == nil {
+		encoderFn = {{ .DefaultName }}
+	}
+	{{- end }}
 	return func(r *http.Request) goahttp.Encoder {
 		body := &bytes.Buffer{}
 		mw := multipart.NewWriter(body)
@@ -817,3 +1007,15 @@ func {{ .InitName }}(encoderFn {{ .FuncName }}) func(r *http.Request) goahttp.En
 	}
 }
 `
+
+// input: multipartData
+const multipartRequestDefaultEncoderT = `{{ printf "%s encodes the multipart request for the %q service %q endpoint. It writes the request body attributes as form fields and file parts with the same names and is used when no encoder function is given to %s." .DefaultName .ServiceName .MethodName .InitName | comment }}
+func {{ .DefaultName }}(mw *multipart.Writer, p {{ .Payload.Ref }}) error {
+	{{- if .Payload.Request.ClientBody.Init }}
+	body := {{ .Payload.Request.ClientBody.Init.Name }}({{ range .Payload.Request.ClientBody.Init.ClientArgs }}{{ if .FieldPointer }}&{{ end }}{{ .Name }}, {{ end }})
+	{{- else }}
+	body := p
+	{{- end }}
+	return goahttp.EncodeMultipart(mw, body)
+}
+`
@@ -29,6 +29,9 @@ func TestClientDecode(t *testing.T) {
 		{"with-headers-dsl", testdata.WithHeadersBlockDSL, testdata.WithHeadersBlockResponseDecodeCode},
 		{"with-headers-dsl-viewed-result", testdata.WithHeadersBlockViewedResultDSL, testdata.WithHeadersBlockViewedResultResponseDecodeCode},
 		{"validate-error-response-type", testdata.ValidateErrorResponseTypeDSL, testdata.ValidateErrorResponseTypeDecodeCode},
+		{"problem-details-error-response", testdata.ProblemDetailsErrorResponseDSL, testdata.ProblemDetailsErrorResponseDecodeCode},
+		{"conditional", testdata.ConditionalResultDSL, testdata.ConditionalDecodeCode},
+		{"cookie", testdata.ResultCookieDSL, testdata.ResultCookieDecodeCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -106,6 +106,8 @@ func TestClientEncode(t *testing.T) {
 		{"header-primitive-array-bool-validate", testdata.PayloadHeaderPrimitiveArrayBoolValidateDSL, testdata.PayloadHeaderPrimitiveArrayBoolValidateEncodeCode},
 
 		{"header-string-default", testdata.PayloadHeaderStringDefaultDSL, testdata.PayloadHeaderStringDefaultEncodeCode},
+		{"cookie", testdata.PayloadCookieDSL, testdata.PayloadCookieEncodeCode},
+		{"form-body", testdata.PayloadFormBodyDSL, testdata.PayloadFormBodyEncodeCode},
 		{"header-primitive-string-default", testdata.PayloadHeaderPrimitiveStringDefaultDSL, testdata.PayloadHeaderPrimitiveStringDefaultEncodeCode},
 
 		{"body-string", testdata.PayloadBodyStringDSL, testdata.PayloadBodyStringEncodeCode},
@@ -192,6 +194,9 @@ func TestClientBuildRequest(t *testing.T) {
 		{"path-string", testdata.PayloadPathStringDSL, testdata.PathStringRequestBuildCode},
 		{"path-string-required", testdata.PayloadPathStringValidateDSL, testdata.PathStringRequiredRequestBuildCode},
 		{"path-string-default", testdata.PayloadPathStringDefaultDSL, testdata.PathStringDefaultRequestBuildCode},
+		{"path-string-field-mask", testdata.PayloadPathStringFieldMaskDSL, testdata.PathStringFieldMaskRequestBuildCode},
+		{"conditional", testdata.ConditionalResultDSL, testdata.ConditionalRequestBuildCode},
+		{"idempotent", testdata.ServerIdempotentDSL, testdata.IdempotentRequestBuildCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
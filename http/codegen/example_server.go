@@ -100,7 +100,10 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				"Services": svcdata,
 				"APIPkg":   apiPkg,
 			},
-			FuncMap: map[string]interface{}{"needStream": needStream},
+			FuncMap: map[string]interface{}{
+				"needStream":               needStream,
+				"idempotentEndpointExists": idempotentEndpointExists,
+			},
 		},
 		&codegen.SectionTemplate{Name: "server-http-middleware", Source: httpSvrMiddlewareT},
 		&codegen.SectionTemplate{
@@ -139,6 +142,24 @@ func dummyMultipartFile(genpkg string, root *expr.RootExpr, svc *expr.HTTPServic
 			Name: scope.Unique(data.Service.PkgName, "svc"),
 		})
 
+		var (
+			svcName = codegen.SnakeCase(data.Service.VarName)
+			svrPkg  = scope.Unique(data.Service.PkgName+"svr", "svr")
+			cliPkg  = scope.Unique(data.Service.PkgName+"c", "c")
+			hasDef  bool
+		)
+		for _, e := range data.Endpoints {
+			if e.MultipartRequestDecoder != nil && e.MultipartRequestDecoder.DefaultName != "" {
+				hasDef = true
+			}
+		}
+		if hasDef {
+			specs = append(specs,
+				&codegen.ImportSpec{Path: path.Join(genpkg, "http", svcName, "server"), Name: svrPkg},
+				&codegen.ImportSpec{Path: path.Join(genpkg, "http", svcName, "client"), Name: cliPkg},
+			)
+		}
+
 		apiPkg := scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 		sections = []*codegen.SectionTemplate{codegen.Header("", apiPkg, specs)}
 		for _, e := range data.Endpoints {
@@ -147,7 +168,10 @@ func dummyMultipartFile(genpkg string, root *expr.RootExpr, svc *expr.HTTPServic
 				sections = append(sections, &codegen.SectionTemplate{
 					Name:   "dummy-multipart-request-decoder",
 					Source: dummyMultipartRequestDecoderImplT,
-					Data:   e.MultipartRequestDecoder,
+					Data: map[string]interface{}{
+						"Multipart": e.MultipartRequestDecoder,
+						"Pkg":       svrPkg,
+					},
 				})
 			}
 			if e.MultipartRequestEncoder != nil {
@@ -155,7 +179,10 @@ func dummyMultipartFile(genpkg string, root *expr.RootExpr, svc *expr.HTTPServic
 				sections = append(sections, &codegen.SectionTemplate{
 					Name:   "dummy-multipart-request-encoder",
 					Source: dummyMultipartRequestEncoderImplT,
-					Data:   e.MultipartRequestEncoder,
+					Data: map[string]interface{}{
+						"Multipart": e.MultipartRequestEncoder,
+						"Pkg":       cliPkg,
+					},
 				})
 			}
 		}
@@ -171,21 +198,31 @@ func dummyMultipartFile(genpkg string, root *expr.RootExpr, svc *expr.HTTPServic
 }
 
 const (
-	// input: MultipartData
-	dummyMultipartRequestDecoderImplT = `{{ printf "%s implements the multipart decoder for service %q endpoint %q. The decoder must populate the argument p after encoding." .FuncName .ServiceName .MethodName | comment }}
+	// input: map[string]interface{}{"Multipart":*MultipartData, "Pkg":string}
+	dummyMultipartRequestDecoderImplT = `{{ with .Multipart }}{{ printf "%s implements the multipart decoder for service %q endpoint %q. The decoder must populate the argument p after encoding." .FuncName .ServiceName .MethodName | comment }}
 func {{ .FuncName }}(mr *multipart.Reader, p *{{ .Payload.Ref }}) error {
+	{{- if .DefaultName }}
+	// Replace with custom multipart request decoder logic if needed
+	return {{ $.Pkg }}.{{ .DefaultName }}(mr, p)
+	{{- else }}
 	// Add multipart request decoder logic here
 	return nil
+	{{- end }}
 }
-`
+{{ end }}`
 
-	// input: MultipartData
-	dummyMultipartRequestEncoderImplT = `{{ printf "%s implements the multipart encoder for service %q endpoint %q." .FuncName .ServiceName .MethodName | comment }}
+	// input: map[string]interface{}{"Multipart":*MultipartData, "Pkg":string}
+	dummyMultipartRequestEncoderImplT = `{{ with .Multipart }}{{ printf "%s implements the multipart encoder for service %q endpoint %q." .FuncName .ServiceName .MethodName | comment }}
 func {{ .FuncName }}(mw *multipart.Writer, p {{ .Payload.Ref }}) error {
+	{{- if .DefaultName }}
+	// Replace with custom multipart request encoder logic if needed
+	return {{ $.Pkg }}.{{ .DefaultName }}(mw, p)
+	{{- else }}
 	// Add multipart request encoder logic here
 	return nil
+	{{- end }}
 }
-`
+{{ end }}`
 
 	// input: map[string]interface{}{"Services":[]*ServiceData}
 	httpSvrStartT = `{{ comment "handleHTTPServer starts configures and starts a HTTP server on the given URL. It shuts down the server if any error is received in the error channel." }}
@@ -240,7 +277,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- end }}
 	{{- range .Services }}
 		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if needStream $.Services }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }})
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if needStream $.Services }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ if idempotentEndpointExists . }}, nil{{ end }})
 		{{-  else }}
 		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh)
 		{{-  end }}
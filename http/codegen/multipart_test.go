@@ -135,3 +135,61 @@ func TestClientMultipartNewFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestServerMultipartDefaultFunc(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"multipart-body-user-type", testdata.PayloadMultipartUserTypeDSL, testdata.MultipartUserTypeDefaultDecoderFuncCode},
+		{"multipart-with-param", testdata.PayloadMultipartWithParamDSL, testdata.MultipartWithParamDefaultDecoderFuncCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[1].SectionTemplates
+			if len(sections) < 5 {
+				t.Fatalf("got %d sections, expected at least 5", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[4])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestClientMultipartDefaultFunc(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"multipart-body-user-type", testdata.PayloadMultipartUserTypeDSL, testdata.MultipartUserTypeDefaultEncoderFuncCode},
+		{"multipart-with-param", testdata.PayloadMultipartWithParamDSL, testdata.MultipartWithParamDefaultEncoderFuncCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ClientFiles(genpkg, expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[1].SectionTemplates
+			if len(sections) < 5 {
+				t.Fatalf("got %d sections, expected at least 5", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[4])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
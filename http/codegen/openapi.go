@@ -13,23 +13,39 @@ import (
 )
 
 // OpenAPIFiles returns the files for the OpenAPIFile spec of the given HTTP API.
+// It produces both the OpenAPI v2 (openapi.json, openapi.yaml) and the OpenAPI
+// v3 (openapi3.json, openapi3.yaml) specifications.
 func OpenAPIFiles(root *expr.RootExpr) ([]*codegen.File, error) {
 	// Only create a OpenAPI specification if there are HTTP services.
 	if len(root.API.HTTP.Services) == 0 {
 		return nil, nil
 	}
 
-	jsonPath := filepath.Join(codegen.Gendir, "http", "openapi.json")
-	yamlPath := filepath.Join(codegen.Gendir, "http", "openapi.yaml")
+	v2, err := openapi.NewV2(root, root.API.Servers[0].Hosts[0])
+	if err != nil {
+		return nil, err
+	}
+	v3, err := openapi.NewV3(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*codegen.File
+	files = append(files, openAPIFiles("openapi", v2)...)
+	files = append(files, openAPIFiles("openapi3", v3)...)
+	return files, nil
+}
+
+// openAPIFiles returns the JSON and YAML files rendering the given OpenAPI
+// specification.
+func openAPIFiles(name string, spec interface{}) []*codegen.File {
+	jsonPath := filepath.Join(codegen.Gendir, "http", name+".json")
+	yamlPath := filepath.Join(codegen.Gendir, "http", name+".yaml")
 	var (
 		jsonSection *codegen.SectionTemplate
 		yamlSection *codegen.SectionTemplate
 	)
 	{
-		spec, err := openapi.NewV2(root, root.API.Servers[0].Hosts[0])
-		if err != nil {
-			return nil, err
-		}
 		jsonSection = &codegen.SectionTemplate{
 			Name:    "openapi",
 			FuncMap: template.FuncMap{"toJSON": toJSON},
@@ -53,7 +69,7 @@ func OpenAPIFiles(root *expr.RootExpr) ([]*codegen.File, error) {
 			Path:             yamlPath,
 			SectionTemplates: []*codegen.SectionTemplate{yamlSection},
 		},
-	}, nil
+	}
 }
 
 func toJSON(d interface{}) string {
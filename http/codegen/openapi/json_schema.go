@@ -39,15 +39,23 @@ type (
 		Pattern              string        `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 		Minimum              *float64      `json:"minimum,omitempty" yaml:"minimum,omitempty"`
 		Maximum              *float64      `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+		ExclusiveMinimum     bool          `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum     bool          `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+		MultipleOf           *float64      `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 		MinLength            *int          `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 		MaxLength            *int          `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
 		MinItems             *int          `json:"minItems,omitempty" yaml:"minItems,omitempty"`
 		MaxItems             *int          `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+		UniqueItems          bool          `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
 		Required             []string      `json:"required,omitempty" yaml:"required,omitempty"`
 		AdditionalProperties bool          `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 
 		// Union
 		AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+		OneOf []*Schema `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+
+		// Composition
+		AllOf []*Schema `json:"allOf,omitempty" yaml:"allOf,omitempty"`
 
 		// Extensions defines the swagger extensions.
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
@@ -334,6 +342,15 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 			buildAttributeSchema(api, prop, nat.Attribute)
 			s.Properties[nat.Name] = prop
 		}
+	case *expr.Union:
+		// Unions are objects with exactly one of the union values set.
+		s.Type = Object
+		for _, nat := range *actual.Values {
+			prop := NewSchema()
+			buildAttributeSchema(api, prop, nat.Attribute)
+			s.Properties[nat.Name] = prop
+			s.OneOf = append(s.OneOf, &Schema{Required: []string{nat.Name}})
+		}
 	case *expr.Map:
 		s.Type = Object
 		s.AdditionalProperties = true
@@ -372,11 +389,17 @@ func (s *Schema) createMergeItems(other *Schema) mergeItems {
 		{&s.Pattern, other.Pattern, s.Pattern == ""},
 		{&s.AdditionalProperties, other.AdditionalProperties, !s.AdditionalProperties},
 		{&s.Minimum, other.Minimum, minFloat64(s.Minimum, other.Minimum)},
+		{&s.ExclusiveMinimum, other.ExclusiveMinimum, minFloat64(s.Minimum, other.Minimum)},
 		{&s.Maximum, other.Maximum, maxFloat64(s.Maximum, other.Maximum)},
+		{&s.ExclusiveMaximum, other.ExclusiveMaximum, maxFloat64(s.Maximum, other.Maximum)},
+		{&s.MultipleOf, other.MultipleOf, s.MultipleOf == nil},
 		{&s.MinLength, other.MinLength, minInt(s.MinLength, other.MinLength)},
 		{&s.MaxLength, other.MaxLength, maxInt(s.MaxLength, other.MaxLength)},
 		{&s.MinItems, other.MinItems, minInt(s.MinItems, other.MinItems)},
 		{&s.MaxItems, other.MaxItems, maxInt(s.MaxItems, other.MaxItems)},
+		{&s.UniqueItems, other.UniqueItems, !s.UniqueItems},
+		{&s.OneOf, other.OneOf, s.OneOf == nil},
+		{&s.AllOf, other.AllOf, s.AllOf == nil},
 	}
 }
 
@@ -427,12 +450,18 @@ func (s *Schema) Dup() *Schema {
 		Pattern:              s.Pattern,
 		Minimum:              s.Minimum,
 		Maximum:              s.Maximum,
+		ExclusiveMinimum:     s.ExclusiveMinimum,
+		ExclusiveMaximum:     s.ExclusiveMaximum,
+		MultipleOf:           s.MultipleOf,
 		MinLength:            s.MinLength,
 		MaxLength:            s.MaxLength,
 		MinItems:             s.MinItems,
 		MaxItems:             s.MaxItems,
+		UniqueItems:          s.UniqueItems,
 		Required:             s.Required,
 		AdditionalProperties: s.AdditionalProperties,
+		OneOf:                s.OneOf,
+		AllOf:                s.AllOf,
 	}
 	for n, p := range s.Properties {
 		js.Properties[n] = p.Dup()
@@ -478,6 +507,17 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 	if val.Maximum != nil {
 		s.Maximum = val.Maximum
 	}
+	if min := val.ExclusiveMinimum; min != nil && (val.Minimum == nil || *min >= *val.Minimum) {
+		s.Minimum = min
+		s.ExclusiveMinimum = true
+	}
+	if max := val.ExclusiveMaximum; max != nil && (val.Maximum == nil || *max <= *val.Maximum) {
+		s.Maximum = max
+		s.ExclusiveMaximum = true
+	}
+	if val.MultipleOf != nil {
+		s.MultipleOf = val.MultipleOf
+	}
 	if val.MinLength != nil {
 		if _, ok := at.Type.(*expr.Array); ok {
 			s.MinItems = val.MinLength
@@ -492,6 +532,7 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 			s.MaxLength = val.MaxLength
 		}
 	}
+	s.UniqueItems = val.UniqueItems
 	s.Required = val.Required
 }
 
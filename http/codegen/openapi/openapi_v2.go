@@ -1,4 +1,5 @@
 // Package openapi produces OpenAPI Specification 2.0 (https://github.com/OAI/OpenAPI-Specification/blob/master/versions/2.0.md)
+// and OpenAPI Specification 3.0 (https://github.com/OAI/OpenAPI-Specification/blob/master/versions/3.0.2.md)
 // for the HTTP endpoints.
 package openapi
 
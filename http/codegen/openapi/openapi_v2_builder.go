@@ -97,16 +97,48 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 			// sad but swagger doesn't support these
 			d.Media = nil
 			d.Links = nil
-			s.Definitions[n] = d
+			s.Definitions[n] = v2Schema(d)
 		}
 	}
 	return s, nil
 }
 
+// v2Schema returns a copy of s without the "oneOf" keyword which swagger does
+// not support. Union schemas still list the union values as properties.
+func v2Schema(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	res := *s
+	res.OneOf = nil
+	res.Items = v2Schema(s.Items)
+	if len(s.AllOf) > 0 {
+		res.AllOf = make([]*Schema, len(s.AllOf))
+		for i, a := range s.AllOf {
+			res.AllOf[i] = v2Schema(a)
+		}
+	}
+	if len(s.Properties) > 0 {
+		res.Properties = make(map[string]*Schema, len(s.Properties))
+		for n, p := range s.Properties {
+			res.Properties[n] = v2Schema(p)
+		}
+	}
+	return &res
+}
+
 // ExtensionsFromExpr generates swagger extensions from the given meta
-// expression.
+// expression. Both the "swagger:extension:" and "openapi:extension:" key
+// prefixes are supported.
 func ExtensionsFromExpr(mdata expr.MetaExpr) map[string]interface{} {
-	return extensionsFromExprWithPrefix(mdata, "swagger:extension:")
+	extensions := extensionsFromExprWithPrefix(mdata, "swagger:extension:")
+	for k, v := range extensionsFromExprWithPrefix(mdata, "openapi:extension:") {
+		if extensions == nil {
+			extensions = make(map[string]interface{})
+		}
+		extensions[k] = v
+	}
+	return extensions
 }
 
 // extensionsFromExprWithPrefix generates swagger extensions from
@@ -170,9 +202,11 @@ func defaultURI(h *expr.HostExpr) string {
 // mustGenerate returns true if the meta indicates that a OpenAPI specification should be
 // generated, false otherwise.
 func mustGenerate(meta expr.MetaExpr) bool {
-	if m, ok := meta["swagger:generate"]; ok {
-		if len(m) > 0 && m[0] == "false" {
-			return false
+	for _, key := range []string{"swagger:generate", "openapi:generate"} {
+		if m, ok := meta[key]; ok {
+			if len(m) > 0 && m[0] == "false" {
+				return false
+			}
 		}
 	}
 	return true
@@ -304,7 +338,7 @@ func tagsFromExpr(mdata expr.MetaExpr) (tags []*Tag) {
 		if len(chunks) != 3 {
 			continue
 		}
-		if chunks[0] != "swagger" || chunks[1] != "tag" {
+		if (chunks[0] != "swagger" && chunks[0] != "openapi") || chunks[1] != "tag" {
 			continue
 		}
 
@@ -354,22 +388,12 @@ func tagNamesFromExpr(mdatas ...expr.MetaExpr) (tagNames []string) {
 }
 
 func summaryFromExpr(name string, e *expr.HTTPEndpointExpr) string {
-	for n, mdata := range e.Meta {
-		if n == "swagger:summary" && len(mdata) > 0 {
-			return mdata[0]
-		}
-	}
-	for n, mdata := range e.MethodExpr.Meta {
-		if n == "swagger:summary" && len(mdata) > 0 {
-			return mdata[0]
-		}
-	}
-	return name
+	return summaryFromMeta(summaryFromMeta(name, e.MethodExpr.Meta), e.Meta)
 }
 
 func summaryFromMeta(name string, meta expr.MetaExpr) string {
-	for n, mdata := range meta {
-		if n == "swagger:summary" && len(mdata) > 0 {
+	for _, key := range []string{"swagger:summary", "openapi:summary"} {
+		if mdata, ok := meta[key]; ok && len(mdata) > 0 {
 			return mdata[0]
 		}
 	}
@@ -402,6 +426,45 @@ func paramsFromExpr(params *expr.MappedAttributeExpr, path string) []*Parameter
 	return res
 }
 
+// paramsFromFormBody returns the formData parameters describing the fields
+// of a form-urlencoded request body. Nested objects are flattened using
+// bracketed names, other composite types cannot be described by form
+// parameters and are documented as strings.
+func paramsFromFormBody(body *expr.AttributeExpr, prefix string, required bool) []*Parameter {
+	obj := expr.AsObject(body.Type)
+	if obj == nil {
+		return nil
+	}
+	var params []*Parameter
+	for _, nat := range *obj {
+		var (
+			at   = nat.Attribute
+			name = nat.Name
+			req  = required && body.IsRequired(nat.Name)
+		)
+		if prefix != "" {
+			name = prefix + "[" + name + "]"
+		}
+		if expr.IsObject(at.Type) {
+			params = append(params, paramsFromFormBody(at, name, req)...)
+			continue
+		}
+		p := paramFor(at, name, "formData", req)
+		elem := at
+		if arr := expr.AsArray(at.Type); arr != nil {
+			elem = arr.ElemType
+		}
+		if !expr.IsPrimitive(elem.Type) || elem.Type == expr.Any {
+			p.Type = "string"
+			p.Format = ""
+			p.Items = nil
+			p.CollectionFormat = ""
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
 func paramsFromHeaders(endpoint *expr.HTTPEndpointExpr) []*Parameter {
 	params := []*Parameter{}
 	var (
@@ -499,10 +562,19 @@ func responseSpecFromExpr(s *V2, root *expr.RootExpr, r *expr.HTTPResponseExpr,
 	} else if r.Body.Type != expr.Empty {
 		schema = AttributeTypeSchemaWithPrefix(root.API, r.Body, typeNamePrefix)
 	}
+	if r.ProblemDetails {
+		schema = problemDetailsSchema(schema)
+	}
 	if schema != nil {
 		schema.Extensions = ExtensionsFromExpr(r.Meta)
 	}
 	headers := headersFromExpr(r.Headers)
+	if desc := cookiesDescription(r.Cookies); desc != "" {
+		if headers == nil {
+			headers = make(map[string]*Header)
+		}
+		headers["Set-Cookie"] = &Header{Type: "string", Description: desc}
+	}
 	desc := r.Description
 	if desc == "" {
 		desc = fmt.Sprintf("%s response.", http.StatusText(r.StatusCode))
@@ -515,6 +587,167 @@ func responseSpecFromExpr(s *V2, root *expr.RootExpr, r *expr.HTTPResponseExpr,
 	}
 }
 
+// problemContentType is the content type of RFC 7807 problem details
+// documents.
+const problemContentType = "application/problem+json"
+
+// fieldMaskDescription is the description of the query string parameter
+// holding the field mask of endpoints that use field masks.
+const fieldMaskDescription = "Comma separated list of the paths of the result fields to return, e.g. \"id,author.name\". All the fields are returned if empty."
+
+// viewSelectorDescription is the description of the query string parameter
+// holding the view selected by the client of endpoints that use view
+// selectors.
+const viewSelectorDescription = "Name of the view used to render the result, the default view is used if empty. The view may also be set with the parameter of the same name of the Accept header media type."
+
+// viewSelectorValues returns the names of the views that may be selected with
+// the given view selector.
+func viewSelectorValues(vs *expr.ViewSelectorExpr) []interface{} {
+	var views []interface{}
+	for _, v := range vs.Views() {
+		views = append(views, v.Name)
+	}
+	return views
+}
+
+// conditionalHeaders returns the names and descriptions of the conditional
+// request headers evaluated by the generated server code of the given route.
+func conditionalHeaders(route *expr.RouteExpr) [][2]string {
+	e := route.Endpoint
+	if route.Method != "GET" && route.Method != "HEAD" {
+		return nil
+	}
+	var headers [][2]string
+	if e.ETag != "" {
+		headers = append(headers, [2]string{"If-None-Match", "Entity tags of the cached representations, the response is 304 Not Modified if one of them matches."})
+	}
+	if e.LastModified != "" {
+		headers = append(headers, [2]string{"If-Modified-Since", "Date of the cached representation, the response is 304 Not Modified if the resource was not modified since."})
+	}
+	return headers
+}
+
+// implicitResponses returns the descriptions of the responses written by the
+// generated code when evaluating conditional requests and idempotency keys of
+// requests made to the given route indexed by status code. The errors returned
+// when evaluating If-Match and Accept headers are described by the endpoint
+// HTTP errors.
+func implicitResponses(route *expr.RouteExpr) map[int]string {
+	e := route.Endpoint
+	resps := make(map[int]string)
+	if (route.Method == "GET" || route.Method == "HEAD") && e.HasValidators() {
+		resps[expr.StatusNotModified] = "Not Modified response."
+	}
+	if e.Idempotent {
+		resps[expr.StatusConflict] = "Conflict response, a request with the same idempotency key is being processed."
+		resps[expr.StatusUnprocessableEntity] = "Unprocessable Entity response, the idempotency key was used with a different request."
+	}
+	return resps
+}
+
+// idempotencyKeyDescription is the description of the Idempotency-Key header
+// of requests made to idempotent endpoints.
+const idempotencyKeyDescription = "Unique key identifying the request, retries made with the same key replay the response of the first request."
+
+// cookiesDescription returns the description of the Cookie or Set-Cookie
+// header holding the given cookies, the empty string if there is none.
+func cookiesDescription(cookies *expr.MappedAttributeExpr) string {
+	if cookies == nil || cookies.IsEmpty() {
+		return ""
+	}
+	var names []string
+	codegen.WalkMappedAttr(cookies, func(_, n string, _ bool, _ *expr.AttributeExpr) error {
+		names = append(names, n)
+		return nil
+	})
+	return fmt.Sprintf("Cookies %s.", strings.Join(names, ", "))
+}
+
+// linkHeaderDescription is the description of the Link header set in the
+// responses of paginated endpoints.
+const linkHeaderDescription = "Link to the next page of results if any, e.g. <...>; rel=\"next\"."
+
+// operationExtensions returns the extensions of the operation describing the
+// given endpoint. The "x-pagination" extension describes how paginated
+// endpoints map the pagination attributes to the request and response.
+func operationExtensions(endpoint *expr.HTTPEndpointExpr) map[string]interface{} {
+	exts := ExtensionsFromExpr(endpoint.MethodExpr.Meta)
+	pag := endpoint.MethodExpr.Pagination
+	if pag == nil {
+		return exts
+	}
+	wireName := func(att string) string {
+		if endpoint.Params.Find(att) != nil {
+			return endpoint.Params.ElemName(att)
+		}
+		if endpoint.Headers.Find(att) != nil {
+			return endpoint.Headers.ElemName(att)
+		}
+		return att
+	}
+	ext := map[string]interface{}{"items": pag.ItemsAttribute}
+	if pag.IsCursor() {
+		ext["type"] = "cursor"
+		ext["cursor"] = wireName(pag.CursorAttribute)
+		ext["next"] = pag.NextCursorAttribute
+	} else {
+		ext["type"] = "offset"
+		ext["offset"] = wireName(pag.OffsetAttribute)
+	}
+	if pag.PageSizeAttribute != "" {
+		ext["page_size"] = wireName(pag.PageSizeAttribute)
+	}
+	if exts == nil {
+		exts = make(map[string]interface{})
+	}
+	exts["x-pagination"] = ext
+	return exts
+}
+
+// problemResponse returns the response used to document the given error in
+// the OpenAPI specifications. The body of the response returned for errors
+// that use the default error type and the RFC 7807 problem details format
+// omits the "id" and "message" attributes which are encoded in the standard
+// "instance" and "detail" members.
+func problemResponse(er *expr.HTTPErrorExpr) *expr.HTTPResponseExpr {
+	r := er.Response
+	if !r.ProblemDetails || er.ErrorExpr == nil || er.ErrorExpr.Type != expr.ErrorResult {
+		return r
+	}
+	obj := expr.AsObject(r.Body.Type)
+	if obj == nil {
+		return r
+	}
+	var ext expr.Object
+	for _, nat := range *obj {
+		if nat.Name == "id" || nat.Name == "message" {
+			continue
+		}
+		ext = append(ext, nat)
+	}
+	r = r.Dup()
+	r.Body = &expr.AttributeExpr{Type: &ext}
+	return r
+}
+
+// problemDetailsSchema returns the schema of RFC 7807 problem details
+// documents. ext describes the extension members if not nil.
+func problemDetailsSchema(ext *Schema) *Schema {
+	s := NewSchema()
+	s.Type = Object
+	s.Description = "RFC 7807 problem details"
+	s.Properties["type"] = &Schema{Type: String, Format: "uri", Description: "URI reference that identifies the problem type."}
+	s.Properties["title"] = &Schema{Type: String, Description: "Short, human-readable summary of the problem type."}
+	s.Properties["status"] = &Schema{Type: Integer, Description: "HTTP status code."}
+	s.Properties["detail"] = &Schema{Type: String, Description: "Human-readable explanation specific to this occurrence of the problem."}
+	s.Properties["instance"] = &Schema{Type: String, Description: "URI reference that identifies the specific occurrence of the problem."}
+	s.Required = []string{"type", "title", "status"}
+	if ext == nil {
+		return s
+	}
+	return &Schema{AllOf: []*Schema{s, ext}}
+}
+
 func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 	if headers == nil {
 		return nil
@@ -608,6 +841,48 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 	for _, key := range route.FullPaths() {
 		params := paramsFromExpr(endpoint.Params, key)
 		params = append(params, paramsFromHeaders(endpoint)...)
+		if fm := endpoint.MethodExpr.FieldMask; fm != nil {
+			params = append(params, &Parameter{
+				In:          "query",
+				Name:        fm.Name,
+				Description: fieldMaskDescription,
+				Type:        "string",
+			})
+		}
+		if vs := endpoint.MethodExpr.ViewSelector; vs != nil {
+			params = append(params, &Parameter{
+				In:          "query",
+				Name:        vs.Name,
+				Description: viewSelectorDescription,
+				Type:        "string",
+				Enum:        viewSelectorValues(vs),
+			})
+		}
+		for _, h := range conditionalHeaders(route) {
+			params = append(params, &Parameter{
+				In:          "header",
+				Name:        h[0],
+				Description: h[1],
+				Type:        "string",
+			})
+		}
+		if endpoint.Idempotent {
+			params = append(params, &Parameter{
+				In:          "header",
+				Name:        "Idempotency-Key",
+				Description: idempotencyKeyDescription,
+				Type:        "string",
+			})
+		}
+		if desc := cookiesDescription(endpoint.Cookies); desc != "" {
+			// OpenAPI 2.0 does not support cookie parameters.
+			params = append(params, &Parameter{
+				In:          "header",
+				Name:        "Cookie",
+				Description: desc,
+				Type:        "string",
+			})
+		}
 		produces := []string{}
 		responses := make(map[string]*Response, len(endpoint.Responses))
 		for _, r := range endpoint.Responses {
@@ -621,34 +896,56 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 				}
 			}
 			resp := responseSpecFromExpr(s, root, r, endpoint.Service.Name())
+			if endpoint.MethodExpr.Pagination != nil {
+				if resp.Headers == nil {
+					resp.Headers = make(map[string]*Header)
+				}
+				resp.Headers["Link"] = &Header{Type: "string", Description: linkHeaderDescription}
+			}
 			responses[strconv.Itoa(r.StatusCode)] = resp
+			cts := endpoint.Produces
 			if r.ContentType != "" {
+				cts = []string{r.ContentType}
+			}
+			for _, ct := range cts {
 				foundCT := false
-				for _, ct := range produces {
-					if ct == r.ContentType {
+				for _, p := range produces {
+					if p == ct {
 						foundCT = true
 						break
 					}
 				}
 				if !foundCT {
-					produces = append(produces, r.ContentType)
+					produces = append(produces, ct)
 				}
 			}
 		}
 		for _, er := range endpoint.HTTPErrors {
-			resp := responseSpecFromExpr(s, root, er.Response, endpoint.Service.Name())
+			resp := responseSpecFromExpr(s, root, problemResponse(er), endpoint.Service.Name())
 			responses[strconv.Itoa(er.Response.StatusCode)] = resp
 		}
+		for code, desc := range implicitResponses(route) {
+			if _, ok := responses[strconv.Itoa(code)]; !ok {
+				responses[strconv.Itoa(code)] = &Response{Description: desc}
+			}
+		}
 
+		var consumes []string
 		if endpoint.Body.Type != expr.Empty {
-			pp := &Parameter{
-				Name:        endpoint.Body.Type.Name(),
-				In:          "body",
-				Description: endpoint.Body.Description,
-				Required:    true,
-				Schema:      AttributeTypeSchemaWithPrefix(root.API, endpoint.Body, codegen.Goify(endpoint.Service.Name(), true)),
+			if endpoint.IsFormBody() {
+				consumes = []string{"application/x-www-form-urlencoded"}
+				params = append(params, paramsFromFormBody(endpoint.Body, "", true)...)
+			} else {
+				pp := &Parameter{
+					Name:        endpoint.Body.Type.Name(),
+					In:          "body",
+					Description: endpoint.Body.Description,
+					Required:    true,
+					Schema:      AttributeTypeSchemaWithPrefix(root.API, endpoint.Body, codegen.Goify(endpoint.Service.Name(), true)),
+				}
+				params = append(params, pp)
+				consumes = endpoint.Consumes
 			}
-			params = append(params, pp)
 		}
 
 		operationID := fmt.Sprintf("%s#%s", endpoint.Service.Name(), endpoint.Name())
@@ -720,11 +1017,12 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			ExternalDocs: docsFromExpr(endpoint.MethodExpr.Docs),
 			OperationID:  operationID,
 			Parameters:   params,
+			Consumes:     consumes,
 			Produces:     produces,
 			Responses:    responses,
 			Schemes:      schemes,
 			Deprecated:   false,
-			Extensions:   ExtensionsFromExpr(endpoint.MethodExpr.Meta),
+			Extensions:   operationExtensions(endpoint),
 			Security:     requirements,
 		}
 
@@ -838,6 +1136,56 @@ func initMaximumValidation(def interface{}, max *float64) {
 	}
 }
 
+func initExclusiveMinimumValidation(def interface{}, min *float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	case *Header:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	case *Items:
+		actual.Minimum = min
+		actual.ExclusiveMinimum = true
+	}
+}
+
+func initExclusiveMaximumValidation(def interface{}, max *float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	case *Header:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	case *Items:
+		actual.Maximum = max
+		actual.ExclusiveMaximum = true
+	}
+}
+
+func initMultipleOfValidation(def interface{}, multipleOf float64) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.MultipleOf = multipleOf
+	case *Header:
+		actual.MultipleOf = multipleOf
+	case *Items:
+		actual.MultipleOf = multipleOf
+	}
+}
+
+func initUniqueItemsValidation(def interface{}) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.UniqueItems = true
+	case *Header:
+		actual.UniqueItems = true
+	case *Items:
+		actual.UniqueItems = true
+	}
+}
+
 func initMinLengthValidation(def interface{}, isArray bool, min *int) {
 	switch actual := def.(type) {
 	case *Parameter:
@@ -882,10 +1230,22 @@ func initValidations(attr *expr.AttributeExpr, def interface{}) {
 	if val.Maximum != nil {
 		initMaximumValidation(def, val.Maximum)
 	}
+	if min := val.ExclusiveMinimum; min != nil && (val.Minimum == nil || *min >= *val.Minimum) {
+		initExclusiveMinimumValidation(def, min)
+	}
+	if max := val.ExclusiveMaximum; max != nil && (val.Maximum == nil || *max <= *val.Maximum) {
+		initExclusiveMaximumValidation(def, max)
+	}
+	if val.MultipleOf != nil {
+		initMultipleOfValidation(def, *val.MultipleOf)
+	}
 	if val.MinLength != nil {
 		initMinLengthValidation(def, expr.IsArray(attr.Type), val.MinLength)
 	}
 	if val.MaxLength != nil {
 		initMaxLengthValidation(def, expr.IsArray(attr.Type), val.MaxLength)
 	}
+	if val.UniqueItems {
+		initUniqueItemsValidation(def)
+	}
 }
@@ -0,0 +1,302 @@
+package openapi
+
+type (
+	// V3 represents an instance of an OpenAPI 3.0 document.
+	// See https://github.com/OAI/OpenAPI-Specification/blob/master/versions/3.0.2.md
+	V3 struct {
+		OpenAPI      string                 `json:"openapi" yaml:"openapi"`
+		Info         *Info                  `json:"info" yaml:"info"`
+		Servers      []*Server              `json:"servers,omitempty" yaml:"servers,omitempty"`
+		Paths        map[string]interface{} `json:"paths" yaml:"paths"`
+		Components   *Components            `json:"components,omitempty" yaml:"components,omitempty"`
+		Security     []map[string][]string  `json:"security,omitempty" yaml:"security,omitempty"`
+		Tags         []*Tag                 `json:"tags,omitempty" yaml:"tags,omitempty"`
+		ExternalDocs *ExternalDocs          `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	}
+
+	// Server represents a server hosting the API.
+	Server struct {
+		// URL to the target host. The URL may contain variables in curly
+		// braces that are substituted using the values in Variables.
+		URL string `json:"url" yaml:"url"`
+		// Description of the host designated by the URL.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Variables maps variable names to their value used for
+		// substitution in the server URL template.
+		Variables map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+	}
+
+	// ServerVariable describes a variable used for server URL template
+	// substitution.
+	ServerVariable struct {
+		// Enum is the list of possible values if the substitution options
+		// are from a limited set.
+		Enum []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+		// Default is the value used for substitution if none is supplied.
+		Default string `json:"default" yaml:"default"`
+		// Description of the server variable.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	// PathItem describes the operations available on a single path.
+	PathItem struct {
+		// Ref allows for an external definition of this path item.
+		Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+		// Summary is an optional string summary intended to apply to all
+		// operations in this path.
+		Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+		// Description is an optional string description intended to apply
+		// to all operations in this path.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Get defines a GET operation on this path.
+		Get *V3Operation `json:"get,omitempty" yaml:"get,omitempty"`
+		// Put defines a PUT operation on this path.
+		Put *V3Operation `json:"put,omitempty" yaml:"put,omitempty"`
+		// Post defines a POST operation on this path.
+		Post *V3Operation `json:"post,omitempty" yaml:"post,omitempty"`
+		// Delete defines a DELETE operation on this path.
+		Delete *V3Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+		// Options defines a OPTIONS operation on this path.
+		Options *V3Operation `json:"options,omitempty" yaml:"options,omitempty"`
+		// Head defines a HEAD operation on this path.
+		Head *V3Operation `json:"head,omitempty" yaml:"head,omitempty"`
+		// Patch defines a PATCH operation on this path.
+		Patch *V3Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+		// Trace defines a TRACE operation on this path.
+		Trace *V3Operation `json:"trace,omitempty" yaml:"trace,omitempty"`
+		// Servers is an alternative server array to service all operations
+		// in this path.
+		Servers []*Server `json:"servers,omitempty" yaml:"servers,omitempty"`
+		// Parameters is the list of parameters that are applicable for all
+		// the operations described under this path.
+		Parameters []*V3Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// V3Operation describes a single API operation on a path.
+	V3Operation struct {
+		// Tags is a list of tags for API documentation control. Tags
+		// can be used for logical grouping of operations by services or
+		// any other qualifier.
+		Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+		// Summary is a short summary of what the operation does.
+		Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+		// Description is a verbose explanation of the operation behavior.
+		// CommonMark syntax can be used for rich text representation.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// ExternalDocs points to additional external documentation for this operation.
+		ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+		// OperationID is a unique string used to identify the operation.
+		OperationID string `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+		// Parameters is a list of parameters that are applicable for this operation.
+		Parameters []*V3Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		// RequestBody is the request body applicable for this operation.
+		RequestBody *RequestBody `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+		// Responses is the list of possible responses as they are returned
+		// from executing this operation.
+		Responses map[string]*V3Response `json:"responses" yaml:"responses"`
+		// Deprecated declares this operation to be deprecated.
+		Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+		// Security is a declaration of which security mechanisms can be
+		// used for this operation.
+		Security []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+		// Servers is an alternative server array to service this operation.
+		Servers []*Server `json:"servers,omitempty" yaml:"servers,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// V3Parameter describes a single operation parameter.
+	V3Parameter struct {
+		// Name of the parameter. Parameter names are case sensitive.
+		Name string `json:"name" yaml:"name"`
+		// In is the location of the parameter.
+		// Possible values are "query", "header", "path" or "cookie".
+		In string `json:"in" yaml:"in"`
+		// Description is a brief description of the parameter.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Required determines whether this parameter is mandatory.
+		Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+		// AllowEmptyValue sets the ability to pass empty-valued
+		// parameters. Only valid for query parameters.
+		AllowEmptyValue bool `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
+		// Schema defining the type used for the parameter.
+		Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+		// Example of the parameter value.
+		Example interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// RequestBody describes a single request body.
+	RequestBody struct {
+		// Description is a brief description of the request body.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Content maps media types to the corresponding request body
+		// description.
+		Content map[string]*MediaType `json:"content" yaml:"content"`
+		// Required determines if the request body is required in the
+		// request.
+		Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	}
+
+	// MediaType provides the schema and examples for the media type
+	// identified by its key.
+	MediaType struct {
+		// Schema defining the type used for the request or response body.
+		Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+		// Example of the media type.
+		Example interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+	}
+
+	// V3Response describes a single response from an API operation.
+	V3Response struct {
+		// Ref references a response defined in the components section.
+		// This field is exclusive with the other fields of V3Response.
+		Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+		// Description of the response.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Headers maps header names to their definition.
+		Headers map[string]*V3Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+		// Content maps media types to the corresponding response body
+		// description.
+		Content map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// V3Header describes a single response header.
+	V3Header struct {
+		// Description is a brief description of the header.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Required determines whether this header is mandatory.
+		Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+		// Schema defining the type used for the header.
+		Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+	}
+
+	// Components holds a set of reusable objects for different aspects of
+	// the API.
+	Components struct {
+		// Schemas holds the reusable data type definitions.
+		Schemas map[string]*Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+		// Responses holds the reusable responses.
+		Responses map[string]*V3Response `json:"responses,omitempty" yaml:"responses,omitempty"`
+		// Parameters holds the reusable parameters.
+		Parameters map[string]*V3Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		// SecuritySchemes holds the reusable security schemes.
+		SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+	}
+
+	// SecurityScheme defines a security scheme that can be used by the
+	// operations. Supported schemes are HTTP authentication, an API key
+	// (either as a header, a cookie or as a query parameter) and OAuth2's
+	// common flows (implicit, password, client credentials and
+	// authorization code).
+	SecurityScheme struct {
+		// Type of the security scheme. Valid values are "apiKey", "http",
+		// "oauth2" or "openIdConnect".
+		Type string `json:"type" yaml:"type"`
+		// Description for security scheme.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Name of the header, query or cookie parameter to be used when
+		// type is "apiKey".
+		Name string `json:"name,omitempty" yaml:"name,omitempty"`
+		// In is the location of the API key when type is "apiKey".
+		// Valid values are "query", "header" or "cookie".
+		In string `json:"in,omitempty" yaml:"in,omitempty"`
+		// Scheme is the name of the HTTP Authorization scheme to be used
+		// when type is "http", e.g. "basic" or "bearer".
+		Scheme string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+		// BearerFormat is a hint to the client to identify how the bearer
+		// token is formatted.
+		BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+		// Flows contains configuration information for the flow types
+		// supported when type is "oauth2".
+		Flows *OAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
+		// Extensions defines the OpenAPI extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+	}
+
+	// OAuthFlows allows configuration of the supported OAuth2 flows.
+	OAuthFlows struct {
+		// Implicit configures the OAuth2 implicit flow.
+		Implicit *OAuthFlow `json:"implicit,omitempty" yaml:"implicit,omitempty"`
+		// Password configures the OAuth2 resource owner password flow.
+		Password *OAuthFlow `json:"password,omitempty" yaml:"password,omitempty"`
+		// ClientCredentials configures the OAuth2 client credentials flow.
+		ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
+		// AuthorizationCode configures the OAuth2 authorization code flow.
+		AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
+	}
+
+	// OAuthFlow describes the configuration of a single OAuth2 flow.
+	OAuthFlow struct {
+		// AuthorizationURL is the authorization URL to be used for this flow.
+		AuthorizationURL string `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+		// TokenURL is the token URL to be used for this flow.
+		TokenURL string `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+		// RefreshURL is the URL to be used for obtaining refresh tokens.
+		RefreshURL string `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
+		// Scopes lists the available scopes for the OAuth2 security scheme.
+		Scopes map[string]string `json:"scopes" yaml:"scopes"`
+	}
+
+	// These types are used in marshalJSON() to avoid recursive call of json.Marshal().
+	_PathItem       PathItem
+	_V3Operation    V3Operation
+	_V3Parameter    V3Parameter
+	_V3Response     V3Response
+	_SecurityScheme SecurityScheme
+)
+
+// MarshalJSON returns the JSON encoding of p.
+func (p PathItem) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_PathItem(p), p.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of o.
+func (o V3Operation) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_V3Operation(o), o.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of p.
+func (p V3Parameter) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_V3Parameter(p), p.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of r.
+func (r V3Response) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_V3Response(r), r.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of s.
+func (s SecurityScheme) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_SecurityScheme(s), s.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (p PathItem) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_PathItem(p), p.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (o V3Operation) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_V3Operation(o), o.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (p V3Parameter) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_V3Parameter(p), p.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (r V3Response) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_V3Response(r), r.Extensions)
+}
+
+// MarshalYAML returns value which marshaled in place of the original value
+func (s SecurityScheme) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_SecurityScheme(s), s.Extensions)
+}
@@ -0,0 +1,766 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// NewV3 returns the OpenAPI v3 specification for the given API.
+func NewV3(root *expr.RootExpr) (*V3, error) {
+	if root == nil {
+		return nil, nil
+	}
+	s := &V3{
+		OpenAPI: "3.0.3",
+		Info: &Info{
+			Title:          root.API.Title,
+			Description:    root.API.Description,
+			TermsOfService: root.API.TermsOfService,
+			Contact:        root.API.Contact,
+			License:        root.API.License,
+			Version:        root.API.Version,
+			Extensions:     ExtensionsFromExpr(root.API.Meta),
+		},
+		Servers:      serversFromExpr(root),
+		Paths:        make(map[string]interface{}),
+		Tags:         tagsFromExpr(root.API.Meta),
+		ExternalDocs: docsFromExpr(root.API.Docs),
+	}
+	components := &Components{
+		Parameters:      v3ParamsMapFromExpr(root, root.API.HTTP.Params),
+		SecuritySchemes: securitySchemesFromExpr(root),
+	}
+
+	for _, he := range root.API.HTTP.Errors {
+		if components.Responses == nil {
+			components.Responses = make(map[string]*V3Response)
+		}
+		components.Responses[he.Name] = v3ResponseFromExpr(root, []*expr.HTTPResponseExpr{he.Response}, "")
+	}
+
+	for _, res := range root.API.HTTP.Services {
+		if !mustGenerate(res.Meta) || !mustGenerate(res.ServiceExpr.Meta) {
+			continue
+		}
+		for k, v := range ExtensionsFromExpr(res.Meta) {
+			s.Paths[k] = v
+		}
+		for _, fs := range res.FileServers {
+			if !mustGenerate(fs.Meta) || !mustGenerate(fs.Service.Meta) {
+				continue
+			}
+			buildV3PathFromFileServer(s, root, fs)
+		}
+		for _, a := range res.HTTPEndpoints {
+			if !mustGenerate(a.Meta) || !mustGenerate(a.MethodExpr.Meta) {
+				continue
+			}
+			for _, route := range a.Routes {
+				buildV3PathFromExpr(s, root, route)
+			}
+		}
+	}
+
+	if len(Definitions) > 0 {
+		components.Schemas = make(map[string]*Schema, len(Definitions))
+		for n, d := range Definitions {
+			components.Schemas[n] = v3Schema(d)
+		}
+	}
+	if len(components.Schemas) > 0 || len(components.Responses) > 0 ||
+		len(components.Parameters) > 0 || len(components.SecuritySchemes) > 0 {
+		s.Components = components
+	}
+	return s, nil
+}
+
+// serversFromExpr builds the OpenAPI server objects from the HTTP URIs of all
+// the hosts defined in the design. The URI variables are described by the
+// server variables.
+func serversFromExpr(root *expr.RootExpr) []*Server {
+	var (
+		servers []*Server
+		seen    = make(map[string]struct{})
+	)
+	for _, svr := range root.API.Servers {
+		for _, h := range svr.Hosts {
+			for _, u := range h.URIs {
+				ustr := string(u)
+				if !strings.HasPrefix(ustr, "http") {
+					continue
+				}
+				// Service and API paths are already part of the OpenAPI
+				// paths so only keep the scheme and host of the URI.
+				if idx := strings.Index(ustr, "://"); idx >= 0 {
+					if i := strings.Index(ustr[idx+3:], "/"); i >= 0 {
+						ustr = ustr[:idx+3+i]
+					}
+				}
+				if _, ok := seen[ustr]; ok {
+					continue
+				}
+				seen[ustr] = struct{}{}
+				desc := h.Description
+				if desc == "" {
+					desc = svr.Description
+				}
+				server := &Server{URL: ustr, Description: desc}
+				if params := u.Params(); len(params) > 0 {
+					server.Variables = make(map[string]*ServerVariable, len(params))
+					vars := expr.AsObject(h.Attribute().Type)
+					for _, p := range params {
+						att := vars.Attribute(p)
+						if att == nil {
+							continue
+						}
+						sv := &ServerVariable{Description: att.Description}
+						if att.Validation != nil {
+							for _, v := range att.Validation.Values {
+								sv.Enum = append(sv.Enum, fmt.Sprintf("%v", v))
+							}
+						}
+						if att.DefaultValue != nil {
+							sv.Default = fmt.Sprintf("%v", att.DefaultValue)
+						} else if len(sv.Enum) > 0 {
+							sv.Default = sv.Enum[0]
+						}
+						server.Variables[p] = sv
+					}
+				}
+				servers = append(servers, server)
+			}
+		}
+	}
+	return servers
+}
+
+// securitySchemesFromExpr generates the OpenAPI v3 security schemes from the
+// security design.
+func securitySchemesFromExpr(root *expr.RootExpr) map[string]*SecurityScheme {
+	schemes := make(map[string]*SecurityScheme)
+	for _, svc := range root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			for _, req := range e.Requirements {
+				for _, s := range req.Schemes {
+					ss := &SecurityScheme{
+						Description: s.Description,
+						Extensions:  ExtensionsFromExpr(s.Meta),
+					}
+					switch s.Kind {
+					case expr.BasicAuthKind:
+						ss.Type = "http"
+						ss.Scheme = "basic"
+						ss.Description = scopesDescription(s.Scopes, ss.Description)
+					case expr.APIKeyKind:
+						ss.Type = "apiKey"
+						ss.In = s.In
+						ss.Name = s.Name
+						ss.Description = scopesDescription(s.Scopes, ss.Description)
+					case expr.JWTKind:
+						// Tokens carried in the Authorization header
+						// use the bearer scheme, tokens carried in
+						// other headers or in query strings are
+						// described as API keys.
+						if s.In == "header" && s.Name == "Authorization" {
+							ss.Type = "http"
+							ss.Scheme = "bearer"
+							ss.BearerFormat = "JWT"
+						} else {
+							ss.Type = "apiKey"
+							ss.In = s.In
+							ss.Name = s.Name
+						}
+						ss.Description = scopesDescription(s.Scopes, ss.Description)
+					case expr.OAuth2Kind:
+						ss.Type = "oauth2"
+						ss.Flows = &OAuthFlows{}
+						scopes := make(map[string]string, len(s.Scopes))
+						for _, scope := range s.Scopes {
+							scopes[scope.Name] = scope.Description
+						}
+						for _, f := range s.Flows {
+							flow := &OAuthFlow{
+								AuthorizationURL: f.AuthorizationURL,
+								TokenURL:         f.TokenURL,
+								RefreshURL:       f.RefreshURL,
+								Scopes:           scopes,
+							}
+							switch f.Kind {
+							case expr.AuthorizationCodeFlowKind:
+								ss.Flows.AuthorizationCode = flow
+							case expr.ImplicitFlowKind:
+								ss.Flows.Implicit = flow
+							case expr.PasswordFlowKind:
+								ss.Flows.Password = flow
+							case expr.ClientCredentialsFlowKind:
+								ss.Flows.ClientCredentials = flow
+							}
+						}
+					}
+					schemes[s.Hash()] = ss
+				}
+			}
+		}
+	}
+	if len(schemes) == 0 {
+		return nil
+	}
+	return schemes
+}
+
+// scopesDescription appends the description of the given scopes to desc.
+func scopesDescription(scopes []*expr.ScopeExpr, desc string) string {
+	sd := &SecurityDefinition{Description: desc}
+	addScopeDescription(scopes, sd)
+	return sd.Description
+}
+
+func v3ParamsMapFromExpr(root *expr.RootExpr, params *expr.MappedAttributeExpr) map[string]*V3Parameter {
+	ps := v3ParamsFromExpr(root, params, root.API.HTTP.Path)
+	if len(ps) == 0 {
+		return nil
+	}
+	res := make(map[string]*V3Parameter, len(ps))
+	for _, p := range ps {
+		res[p.Name] = p
+	}
+	return res
+}
+
+func v3ParamsFromExpr(root *expr.RootExpr, params *expr.MappedAttributeExpr, path string) []*V3Parameter {
+	if params == nil {
+		return nil
+	}
+	var (
+		res       []*V3Parameter
+		wildcards = expr.ExtractHTTPWildcards(path)
+	)
+	codegen.WalkMappedAttr(params, func(n, pn string, required bool, at *expr.AttributeExpr) error {
+		in := "query"
+		for _, w := range wildcards {
+			if n == w {
+				in = "path"
+				required = true
+				break
+			}
+		}
+		res = append(res, v3ParamFor(root, at, pn, in, required))
+		return nil
+	})
+	return res
+}
+
+func v3ParamsFromHeaders(root *expr.RootExpr, endpoint *expr.HTTPEndpointExpr) []*V3Parameter {
+	var (
+		rma = endpoint.Service.Params
+		ma  = endpoint.Headers
+
+		merged *expr.MappedAttributeExpr
+	)
+	{
+		if rma == nil {
+			merged = ma
+		} else if ma == nil {
+			merged = rma
+		} else {
+			merged = expr.DupMappedAtt(rma)
+			merged.Merge(ma)
+		}
+	}
+
+	var params []*V3Parameter
+	for _, n := range *expr.AsObject(merged.Type) {
+		required := merged.IsRequiredNoDefault(n.Name)
+		params = append(params, v3ParamFor(root, n.Attribute, merged.ElemName(n.Name), "header", required))
+	}
+	return params
+}
+
+func v3ParamsFromCookies(root *expr.RootExpr, endpoint *expr.HTTPEndpointExpr) []*V3Parameter {
+	ma := endpoint.Cookies
+	if ma == nil {
+		return nil
+	}
+	var params []*V3Parameter
+	for _, n := range *expr.AsObject(ma.Type) {
+		required := ma.IsRequiredNoDefault(n.Name)
+		params = append(params, v3ParamFor(root, n.Attribute, ma.ElemName(n.Name), "cookie", required))
+	}
+	return params
+}
+
+func v3ParamFor(root *expr.RootExpr, at *expr.AttributeExpr, name, in string, required bool) *V3Parameter {
+	schema := v3Schema(AttributeTypeSchema(root.API, at))
+	schema.DefaultValue = toStringMap(at.DefaultValue)
+	return &V3Parameter{
+		Name:        name,
+		In:          in,
+		Description: at.Description,
+		Required:    required,
+		Schema:      schema,
+		Extensions:  ExtensionsFromExpr(at.Meta),
+	}
+}
+
+// v3ResponseFromExpr builds the OpenAPI response object for the given HTTP
+// responses which must all share the same status code. The response body
+// schema is a oneOf of the response body schemas when the responses define
+// different bodies.
+func v3ResponseFromExpr(root *expr.RootExpr, rs []*expr.HTTPResponseExpr, typeNamePrefix string) *V3Response {
+	var (
+		descs   []string
+		schemas = make(map[string][]*Schema)
+		cts     []string
+		headers map[string]*V3Header
+		exts    map[string]interface{}
+	)
+	for _, r := range rs {
+		if r.Description != "" {
+			descs = append(descs, r.Description)
+		}
+		for n, h := range v3HeadersFromExpr(root, r.Headers) {
+			if headers == nil {
+				headers = make(map[string]*V3Header)
+			}
+			headers[n] = h
+		}
+		if desc := cookiesDescription(r.Cookies); desc != "" {
+			if headers == nil {
+				headers = make(map[string]*V3Header)
+			}
+			headers["Set-Cookie"] = &V3Header{
+				Description: desc,
+				Schema:      &Schema{Type: String},
+			}
+		}
+		for k, v := range ExtensionsFromExpr(r.Meta) {
+			if exts == nil {
+				exts = make(map[string]interface{})
+			}
+			exts[k] = v
+		}
+		var schema *Schema
+		if mt, ok := r.Body.Type.(*expr.ResultTypeExpr); ok {
+			view := expr.DefaultView
+			if v, ok := r.Body.Meta["view"]; ok {
+				view = v[0]
+			}
+			schema = NewSchema()
+			schema.Ref = ResultTypeRefWithPrefix(root.API, mt, view, typeNamePrefix)
+		} else if r.Body.Type != expr.Empty {
+			schema = AttributeTypeSchemaWithPrefix(root.API, r.Body, typeNamePrefix)
+		}
+		if r.ProblemDetails {
+			schema = problemDetailsSchema(schema)
+		}
+		if schema == nil {
+			continue
+		}
+		for _, ct := range responseContentTypes(root, r) {
+			if _, ok := schemas[ct]; !ok {
+				cts = append(cts, ct)
+			}
+			schemas[ct] = appendSchema(schemas[ct], v3Schema(schema))
+		}
+	}
+	desc := strings.Join(descs, "\n")
+	if desc == "" {
+		desc = fmt.Sprintf("%s response.", http.StatusText(rs[0].StatusCode))
+	}
+	resp := &V3Response{
+		Description: desc,
+		Headers:     headers,
+		Extensions:  exts,
+	}
+	if len(cts) > 0 {
+		resp.Content = make(map[string]*MediaType, len(cts))
+		for _, ct := range cts {
+			ss := schemas[ct]
+			schema := ss[0]
+			if len(ss) > 1 {
+				schema = &Schema{OneOf: ss}
+			}
+			resp.Content[ct] = &MediaType{Schema: schema}
+		}
+	}
+	return resp
+}
+
+// appendSchema appends s to schemas unless schemas already contains a schema
+// referencing the same definition.
+func appendSchema(schemas []*Schema, s *Schema) []*Schema {
+	if s.Ref != "" {
+		for _, sc := range schemas {
+			if sc.Ref == s.Ref {
+				return schemas
+			}
+		}
+	}
+	return append(schemas, s)
+}
+
+func v3HeadersFromExpr(root *expr.RootExpr, headers *expr.MappedAttributeExpr) map[string]*V3Header {
+	if headers == nil {
+		return nil
+	}
+	res := make(map[string]*V3Header)
+	codegen.WalkMappedAttr(headers, func(_, n string, required bool, at *expr.AttributeExpr) error {
+		schema := v3Schema(AttributeTypeSchema(root.API, at))
+		schema.DefaultValue = toStringMap(at.DefaultValue)
+		res[n] = &V3Header{
+			Description: at.Description,
+			Required:    required,
+			Schema:      schema,
+		}
+		return nil
+	})
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// responseContentTypes returns the content types of the given response body.
+func responseContentTypes(root *expr.RootExpr, r *expr.HTTPResponseExpr) []string {
+	if r.ProblemDetails {
+		return []string{problemContentType}
+	}
+	if r.ContentType != "" {
+		return []string{r.ContentType}
+	}
+	if e, ok := r.Parent.(*expr.HTTPEndpointExpr); ok && len(e.Produces) > 0 {
+		return e.Produces
+	}
+	if len(root.API.HTTP.Produces) > 0 {
+		return root.API.HTTP.Produces
+	}
+	return []string{"application/json"}
+}
+
+// requestContentTypes returns the content types of the given endpoint request
+// body.
+func requestContentTypes(root *expr.RootExpr, e *expr.HTTPEndpointExpr) []string {
+	if e.MultipartRequest {
+		return []string{"multipart/form-data"}
+	}
+	if e.FormBody {
+		return []string{"application/x-www-form-urlencoded"}
+	}
+	if len(e.Consumes) > 0 {
+		return e.Consumes
+	}
+	if len(root.API.HTTP.Consumes) > 0 {
+		return root.API.HTTP.Consumes
+	}
+	return []string{"application/json"}
+}
+
+func buildV3PathFromFileServer(s *V3, root *expr.RootExpr, fs *expr.HTTPFileServerExpr) {
+	for _, path := range fs.RequestPaths {
+		wcs := expr.ExtractHTTPWildcards(path)
+		var param []*V3Parameter
+		if len(wcs) > 0 {
+			param = []*V3Parameter{{
+				In:          "path",
+				Name:        wcs[0],
+				Description: "Relative file path",
+				Required:    true,
+				Schema:      &Schema{Type: String},
+			}}
+		}
+
+		responses := map[string]*V3Response{
+			"200": {
+				Description: "File downloaded",
+				Content: map[string]*MediaType{
+					"application/octet-stream": {Schema: &Schema{Type: String, Format: "binary"}},
+				},
+			},
+		}
+		if len(wcs) > 0 {
+			schema := v3Schema(TypeSchema(root.API, expr.ErrorResult))
+			responses["404"] = &V3Response{
+				Description: "File not found",
+				Content:     map[string]*MediaType{"application/json": {Schema: schema}},
+			}
+		}
+
+		operation := &V3Operation{
+			Description:  fs.Description,
+			Summary:      summaryFromMeta(fmt.Sprintf("Download %s", fs.FilePath), fs.Meta),
+			ExternalDocs: docsFromExpr(fs.Docs),
+			OperationID:  fmt.Sprintf("%s#%s", fs.Service.Name(), path),
+			Parameters:   param,
+			Responses:    responses,
+		}
+
+		key := expr.HTTPWildcardRegex.ReplaceAllString(path, "/{$1}")
+		if key == "" {
+			key = "/"
+		}
+		p := v3PathItem(s, key)
+		p.Get = operation
+		p.Extensions = ExtensionsFromExpr(fs.Meta)
+	}
+}
+
+func buildV3PathFromExpr(s *V3, root *expr.RootExpr, route *expr.RouteExpr) {
+	endpoint := route.Endpoint
+	prefix := endpoint.Service.Name()
+
+	tagNames := tagNamesFromExpr(endpoint.Service.Meta, endpoint.Meta)
+	if len(tagNames) == 0 {
+		// By default tag with service name
+		tagNames = []string{endpoint.Service.Name()}
+	}
+	for _, key := range route.FullPaths() {
+		params := v3ParamsFromExpr(root, endpoint.Params, key)
+		params = append(params, v3ParamsFromHeaders(root, endpoint)...)
+		params = append(params, v3ParamsFromCookies(root, endpoint)...)
+		if fm := endpoint.MethodExpr.FieldMask; fm != nil {
+			params = append(params, &V3Parameter{
+				Name:        fm.Name,
+				In:          "query",
+				Description: fieldMaskDescription,
+				Schema:      &Schema{Type: String},
+			})
+		}
+		if vs := endpoint.MethodExpr.ViewSelector; vs != nil {
+			params = append(params, &V3Parameter{
+				Name:        vs.Name,
+				In:          "query",
+				Description: viewSelectorDescription,
+				Schema:      &Schema{Type: String, Enum: viewSelectorValues(vs)},
+			})
+		}
+		for _, h := range conditionalHeaders(route) {
+			params = append(params, &V3Parameter{
+				Name:        h[0],
+				In:          "header",
+				Description: h[1],
+				Schema:      &Schema{Type: String},
+			})
+		}
+		if endpoint.Idempotent {
+			params = append(params, &V3Parameter{
+				Name:        "Idempotency-Key",
+				In:          "header",
+				Description: idempotencyKeyDescription,
+				Schema:      &Schema{Type: String},
+			})
+		}
+
+		var (
+			codes     []int
+			responses = make(map[int][]*expr.HTTPResponseExpr)
+		)
+		addResponse := func(r *expr.HTTPResponseExpr) {
+			if _, ok := responses[r.StatusCode]; !ok {
+				codes = append(codes, r.StatusCode)
+			}
+			responses[r.StatusCode] = append(responses[r.StatusCode], r)
+		}
+		for _, r := range endpoint.Responses {
+			if endpoint.MethodExpr.IsStreaming() {
+				// A streaming endpoint allows at most one successful
+				// response definition. So it is okay to change the
+				// first successful response to a HTTP 101 response.
+				r = r.Dup()
+				r.StatusCode = expr.StatusSwitchingProtocols
+			}
+			addResponse(r)
+		}
+		for _, er := range endpoint.HTTPErrors {
+			addResponse(problemResponse(er))
+		}
+		sort.Ints(codes)
+		resps := make(map[string]*V3Response, len(codes))
+		for _, code := range codes {
+			resps[strconv.Itoa(code)] = v3ResponseFromExpr(root, responses[code], prefix)
+		}
+		if endpoint.MethodExpr.Pagination != nil {
+			for _, r := range endpoint.Responses {
+				resp := resps[strconv.Itoa(r.StatusCode)]
+				if resp.Headers == nil {
+					resp.Headers = make(map[string]*V3Header)
+				}
+				resp.Headers["Link"] = &V3Header{
+					Description: linkHeaderDescription,
+					Schema:      &Schema{Type: String},
+				}
+			}
+		}
+		for code, desc := range implicitResponses(route) {
+			if _, ok := resps[strconv.Itoa(code)]; !ok {
+				resps[strconv.Itoa(code)] = &V3Response{Description: desc}
+			}
+		}
+
+		var body *RequestBody
+		if endpoint.Body.Type != expr.Empty {
+			schema := v3Schema(AttributeTypeSchemaWithPrefix(root.API, endpoint.Body, codegen.Goify(prefix, true)))
+			body = &RequestBody{
+				Description: endpoint.Body.Description,
+				Required:    true,
+				Content:     make(map[string]*MediaType),
+			}
+			for _, ct := range requestContentTypes(root, endpoint) {
+				body.Content[ct] = &MediaType{Schema: schema}
+			}
+		}
+
+		operationID := fmt.Sprintf("%s#%s", endpoint.Service.Name(), endpoint.Name())
+		for i, rt := range endpoint.Routes {
+			if rt == route && i > 0 {
+				operationID = fmt.Sprintf("%s#%d", operationID, i)
+				break
+			}
+		}
+
+		description := endpoint.Description()
+		requirements := make([]map[string][]string, len(endpoint.Requirements))
+		for i, req := range endpoint.Requirements {
+			requirement := make(map[string][]string)
+			for _, s := range req.Schemes {
+				requirement[s.Hash()] = []string{}
+				switch s.Kind {
+				case expr.OAuth2Kind:
+					requirement[s.Hash()] = append(requirement[s.Hash()], req.Scopes...)
+				case expr.BasicAuthKind, expr.APIKeyKind, expr.JWTKind:
+					lines := make([]string, 0, len(req.Scopes))
+					for _, scope := range req.Scopes {
+						lines = append(lines, fmt.Sprintf("  * `%s`", scope))
+					}
+					// List scopes only if they are defined
+					if len(lines) > 0 {
+						if description != "" {
+							description += "\n"
+						}
+						description += fmt.Sprintf("\n**Required security scopes for %s**:\n%s", s.SchemeName, strings.Join(lines, "\n"))
+					}
+				}
+			}
+			requirements[i] = requirement
+		}
+
+		operation := &V3Operation{
+			Tags:         tagNames,
+			Description:  description,
+			Summary:      summaryFromExpr(endpoint.Name()+" "+endpoint.Service.Name(), endpoint),
+			ExternalDocs: docsFromExpr(endpoint.MethodExpr.Docs),
+			OperationID:  operationID,
+			Parameters:   params,
+			RequestBody:  body,
+			Responses:    resps,
+			Extensions:   operationExtensions(endpoint),
+			Security:     requirements,
+		}
+
+		key = expr.HTTPWildcardRegex.ReplaceAllString(key, "/{$1}")
+		if key == "" {
+			key = "/"
+		}
+		p := v3PathItem(s, key)
+		switch route.Method {
+		case "GET":
+			p.Get = operation
+		case "PUT":
+			p.Put = operation
+		case "POST":
+			p.Post = operation
+		case "DELETE":
+			p.Delete = operation
+		case "OPTIONS":
+			p.Options = operation
+		case "HEAD":
+			p.Head = operation
+		case "PATCH":
+			p.Patch = operation
+		case "TRACE":
+			p.Trace = operation
+		}
+		p.Extensions = ExtensionsFromExpr(route.Endpoint.Meta)
+	}
+}
+
+// v3PathItem returns the path item for the given key, creating it if needed.
+func v3PathItem(s *V3, key string) *PathItem {
+	if p, ok := s.Paths[key].(*PathItem); ok {
+		return p
+	}
+	p := new(PathItem)
+	s.Paths[key] = p
+	return p
+}
+
+// v3Schema returns a copy of the given JSON schema suitable for use in an
+// OpenAPI v3 document: references to definitions point to the components
+// section and the JSON hyper-schema specific fields are removed. OpenAPI v3
+// ignores the properties of reference objects other than $ref so references
+// that come with other keywords are wrapped in allOf.
+func v3Schema(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	res := &Schema{
+		Title:                s.Title,
+		Type:                 s.Type,
+		Description:          s.Description,
+		DefaultValue:         s.DefaultValue,
+		Example:              s.Example,
+		ReadOnly:             s.ReadOnly,
+		Ref:                  strings.Replace(s.Ref, "#/definitions/", "#/components/schemas/", 1),
+		Enum:                 s.Enum,
+		Format:               s.Format,
+		Pattern:              s.Pattern,
+		Minimum:              s.Minimum,
+		Maximum:              s.Maximum,
+		ExclusiveMinimum:     s.ExclusiveMinimum,
+		ExclusiveMaximum:     s.ExclusiveMaximum,
+		MultipleOf:           s.MultipleOf,
+		MinLength:            s.MinLength,
+		MaxLength:            s.MaxLength,
+		MinItems:             s.MinItems,
+		MaxItems:             s.MaxItems,
+		UniqueItems:          s.UniqueItems,
+		Required:             s.Required,
+		AdditionalProperties: s.AdditionalProperties,
+		Items:                v3Schema(s.Items),
+		Extensions:           s.Extensions,
+	}
+	if s.Type == File {
+		res.Type = String
+		res.Format = "binary"
+	}
+	if len(s.Properties) > 0 {
+		res.Properties = make(map[string]*Schema, len(s.Properties))
+		for n, p := range s.Properties {
+			res.Properties[n] = v3Schema(p)
+		}
+	}
+	for _, a := range s.AnyOf {
+		res.AnyOf = append(res.AnyOf, v3Schema(a))
+	}
+	for _, o := range s.OneOf {
+		res.OneOf = append(res.OneOf, v3Schema(o))
+	}
+	for _, a := range s.AllOf {
+		res.AllOf = append(res.AllOf, v3Schema(a))
+	}
+	if res.Ref != "" {
+		ref := &Schema{Ref: res.Ref}
+		res.Ref = ""
+		if reflect.DeepEqual(res, &Schema{}) {
+			return ref
+		}
+		res.AllOf = append([]*Schema{ref}, res.AllOf...)
+	}
+	return res
+}
@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 	"text/template"
 
 	"github.com/go-openapi/loads"
+	"github.com/xeipuuv/gojsonschema"
+	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/http/codegen/openapi"
 	"goa.design/goa/v3/http/codegen/testdata"
 )
@@ -51,7 +54,7 @@ func TestOutputPath(t *testing.T) {
 	if err != nil {
 		t.Fatalf("OpenAPI failed with %s", err)
 	}
-	c := 2 // number of files we expect
+	c := 4 // number of files we expect
 	if len(o) != c {
 		t.Fatalf("unexpected number of OpenAPI files %d instead of %d", len(o), c)
 	}
@@ -61,6 +64,12 @@ func TestOutputPath(t *testing.T) {
 	if o[1].Path != filepath.Join("gen", "http", "openapi.yaml") {
 		t.Errorf("invalid output path %#v", o[1].Path)
 	}
+	if o[2].Path != filepath.Join("gen", "http", "openapi3.json") {
+		t.Errorf("invalid output path %#v", o[2].Path)
+	}
+	if o[3].Path != filepath.Join("gen", "http", "openapi3.yaml") {
+		t.Errorf("invalid output path %#v", o[3].Path)
+	}
 }
 
 func TestSections(t *testing.T) {
@@ -80,6 +89,11 @@ func TestSections(t *testing.T) {
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
+		{"with-validations", testdata.WithValidationsDSL},
+		{"problem-details", testdata.ProblemDetailsDSL},
+		{"form-body", testdata.FormBodyDSL},
+		{"produces", testdata.ProducesDSL},
+		{"consumes", testdata.ConsumesDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -90,7 +104,7 @@ func TestSections(t *testing.T) {
 			if err != nil {
 				t.Fatalf("OpenAPI failed with %s", err)
 			}
-			for i, o := range oFiles {
+			for i, o := range v2Files(oFiles) {
 				tname := fmt.Sprintf("file%d", i)
 				s := o.SectionTemplates
 				t.Run(tname, func(t *testing.T) {
@@ -156,7 +170,7 @@ func TestValidations(t *testing.T) {
 			if len(oFiles) == 0 {
 				t.Fatalf("No swagger files")
 			}
-			for i, o := range oFiles {
+			for i, o := range v2Files(oFiles) {
 				tname := fmt.Sprintf("file%d", i)
 				s := o.SectionTemplates
 				t.Run(tname, func(t *testing.T) {
@@ -220,7 +234,7 @@ func TestExtensions(t *testing.T) {
 			if len(oFiles) == 0 {
 				t.Fatalf("No swagger files")
 			}
-			for i, o := range oFiles {
+			for i, o := range v2Files(oFiles) {
 				tname := fmt.Sprintf("file%d", i)
 				s := o.SectionTemplates
 				t.Run(tname, func(t *testing.T) {
@@ -262,6 +276,151 @@ func TestExtensions(t *testing.T) {
 	}
 }
 
+func TestSectionsV3(t *testing.T) {
+	var (
+		goldenPath = filepath.Join("testdata", "openapi_v3", t.Name())
+	)
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"valid", testdata.SimpleDSL},
+		{"multiple-services", testdata.MultipleServicesDSL},
+		{"multiple-views", testdata.MultipleViewsDSL},
+		{"security", testdata.SecurityDSL},
+		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
+		{"with-map", testdata.WithMapDSL},
+		{"error-one-of", testdata.ErrorOneOfDSL},
+		{"with-validations", testdata.WithValidationsDSL},
+		{"problem-details", testdata.ProblemDetailsDSL},
+		{"form-body", testdata.FormBodyDSL},
+		{"produces", testdata.ProducesDSL},
+		{"consumes", testdata.ConsumesDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// Reset global variables
+			openapi.Definitions = make(map[string]*openapi.Schema)
+			root := RunHTTPDSL(t, c.DSL)
+			oFiles, err := OpenAPIFiles(root)
+			if err != nil {
+				t.Fatalf("OpenAPI failed with %s", err)
+			}
+			for i, o := range v3Files(oFiles) {
+				tname := fmt.Sprintf("file%d", i)
+				s := o.SectionTemplates
+				t.Run(tname, func(t *testing.T) {
+					if len(s) != 1 {
+						t.Fatalf("expected 1 section, got %d", len(s))
+					}
+					var buf bytes.Buffer
+					tmpl := template.Must(template.New("openapi").Funcs(s[0].FuncMap).Parse(s[0].Source))
+					if err := tmpl.Execute(&buf, s[0].Data); err != nil {
+						t.Fatalf("failed to render template: %s", err)
+					}
+					if i == 0 {
+						if err := validateOpenAPIV3(buf.Bytes()); err != nil {
+							t.Errorf("invalid OpenAPI v3 specification: %s", err)
+						}
+					}
+
+					golden := filepath.Join(goldenPath, fmt.Sprintf("%s_%s.golden", c.Name, tname))
+					if *update {
+						if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+							t.Fatalf("failed to update golden file: %s", err)
+						}
+					}
+
+					want, err := ioutil.ReadFile(golden)
+					if err != nil {
+						t.Fatalf("failed to read golden file: %s", err)
+					}
+					if !bytes.Equal(buf.Bytes(), want) {
+						t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+					}
+				})
+			}
+		})
+	}
+}
+
+// v2Files returns the OpenAPI v2 specification files.
+func v2Files(files []*codegen.File) []*codegen.File {
+	var res []*codegen.File
+	for _, f := range files {
+		if !strings.Contains(f.Path, "openapi3") {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// v3Files returns the OpenAPI v3 specification files.
+func v3Files(files []*codegen.File) []*codegen.File {
+	var res []*codegen.File
+	for _, f := range files {
+		if strings.Contains(f.Path, "openapi3") {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// validateOpenAPIV3 asserts that the given bytes contain a JSON OpenAPI v3
+// spec that validates against the OpenAPI 3.0 JSON schema and that does not
+// define reference objects with properties other than $ref.
+func validateOpenAPIV3(b []byte) error {
+	schema := gojsonschema.NewReferenceLoader("file://" + filepath.ToSlash(mustAbs(filepath.Join("testdata", "openapi_v3", "schema.json"))))
+	res, err := gojsonschema.Validate(schema, gojsonschema.NewBytesLoader(b))
+	if err != nil {
+		return err
+	}
+	if !res.Valid() {
+		msgs := make([]string, len(res.Errors()))
+		for i, e := range res.Errors() {
+			msgs[i] = e.String()
+		}
+		return errors.New(strings.Join(msgs, "\n"))
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	return validateRefs(doc, "#")
+}
+
+// validateRefs returns an error if v contains an object with a $ref property
+// and other properties, these are ignored by OpenAPI v3.
+func validateRefs(v interface{}, path string) error {
+	switch actual := v.(type) {
+	case map[string]interface{}:
+		if _, ok := actual["$ref"]; ok && len(actual) > 1 {
+			return fmt.Errorf("%s: reference object with sibling properties", path)
+		}
+		for k, e := range actual {
+			if err := validateRefs(e, path+"/"+k); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, e := range actual {
+			if err := validateRefs(e, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mustAbs returns the absolute path of path.
+func mustAbs(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		panic(err)
+	}
+	return abs
+}
+
 // validateSwagger asserts that the given bytes contain a valid Swagger spec.
 func validateSwagger(b []byte) error {
 	doc, err := loads.Analyzed(json.RawMessage(b), "")
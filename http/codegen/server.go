@@ -30,10 +30,11 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	path := filepath.Join(codegen.Gendir, "http", svcName, "server", "server.go")
 	title := fmt.Sprintf("%s HTTP server", svc.Name())
 	funcs := map[string]interface{}{
-		"join":                    func(ss []string, s string) string { return strings.Join(ss, s) },
-		"streamingEndpointExists": streamingEndpointExists,
-		"upgradeParams":           upgradeParams,
-		"viewedServerBody":        viewedServerBody,
+		"join":                     func(ss []string, s string) string { return strings.Join(ss, s) },
+		"streamingEndpointExists":  streamingEndpointExists,
+		"idempotentEndpointExists": idempotentEndpointExists,
+		"upgradeParams":            upgradeParams,
+		"viewedServerBody":         viewedServerBody,
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", []*codegen.ImportSpec{
@@ -43,6 +44,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: "mime/multipart"},
 			{Path: "net/http"},
 			{Path: "path"},
+			{Path: "regexp"},
 			{Path: "strings"},
 			{Path: "sync"},
 			{Path: "time"},
@@ -111,7 +113,33 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	for _, s := range data.FileServers {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-files", Source: fileServerT, FuncMap: funcs, Data: s})
 	}
+	if len(data.Preflights) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-cors", Source: serverCORST, Data: data})
+	}
 	for _, e := range data.Endpoints {
+		if e.ServerStream != nil && e.SSE {
+			sections = append(sections, &codegen.SectionTemplate{Name: "server-sse-send", Source: responseStreamSendT, Data: e.ServerStream, FuncMap: funcs})
+			sections = append(sections, &codegen.SectionTemplate{Name: "server-sse-close", Source: responseStreamCloseT, Data: e.ServerStream})
+			if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+				sections = append(sections, &codegen.SectionTemplate{Name: "server-stream-set-view", Source: streamSetViewT, Data: e.ServerStream})
+			}
+			continue
+		}
+		if e.ServerStream != nil && e.NDJSON {
+			if e.ServerStream.SendTypeRef != "" {
+				sections = append(sections, &codegen.SectionTemplate{Name: "server-ndjson-send", Source: responseStreamSendT, Data: e.ServerStream, FuncMap: funcs})
+			}
+			if e.ServerStream.RecvTypeRef != "" {
+				sections = append(sections, &codegen.SectionTemplate{Name: "server-ndjson-recv", Source: requestStreamRecvT, Data: e.ServerStream})
+			}
+			if e.ServerStream.MustClose {
+				sections = append(sections, &codegen.SectionTemplate{Name: "server-ndjson-close", Source: responseStreamCloseT, Data: e.ServerStream})
+			}
+			if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+				sections = append(sections, &codegen.SectionTemplate{Name: "server-stream-set-view", Source: streamSetViewT, Data: e.ServerStream})
+			}
+			continue
+		}
 		if e.ServerStream != nil {
 			if e.ServerStream.SendTypeRef != "" {
 				sections = append(sections, &codegen.SectionTemplate{Name: "server-stream-send", Source: streamSendT, Data: e.ServerStream, FuncMap: funcs})
@@ -185,6 +213,13 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 				FuncMap: fm,
 				Data:    e.MultipartRequestDecoder,
 			})
+			if e.MultipartRequestDecoder.DefaultName != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "multipart-request-default-decoder",
+					Source: multipartRequestDefaultDecoderT,
+					Data:   e.MultipartRequestDecoder,
+				})
+			}
 		}
 
 		if len(e.Errors) > 0 {
@@ -324,7 +359,15 @@ func {{ .ServerInit }}(
 	{{ .MultipartRequestDecoder.VarName }} {{ .MultipartRequestDecoder.FuncName }},
 		{{- end }}
 	{{- end }}
+	{{- if idempotentEndpointExists . }}
+	is goahttp.IdempotencyStore,
+	{{- end }}
 ) *{{ .ServerStruct }} {
+{{- if idempotentEndpointExists . }}
+	if is == nil {
+		is = goahttp.NewMemoryIdempotencyStore(goahttp.DefaultIdempotencyTTL)
+	}
+{{- end }}
 {{- if streamingEndpointExists . }}
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
@@ -345,7 +388,7 @@ func {{ .ServerInit }}(
 			{{- end }}
 		},
 		{{- range .Endpoints }}
-		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}dec{{ end }}, enc, eh{{ if .ServerStream }}, up, cfn.{{ .Method.VarName }}Fn{{ end }}),
+		{{ .Method.VarName }}: {{ if .Idempotent }}goahttp.IdempotentHandler(is, enc, {{ end }}{{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}dec{{ end }}, enc, eh{{ if and .ServerStream (not .SSE) (not .NDJSON) }}, up, cfn.{{ .Method.VarName }}Fn{{ end }}){{ if .Idempotent }}){{ end }},
 		{{- end }}
 	}
 }
@@ -389,12 +432,18 @@ func {{ .MountServer }}(mux goahttp.Muxer{{ if .Endpoints }}, h *{{ .ServerStruc
 		}))
 		{{- end }}
 	{{- end }}
+	{{- if .Preflights }}
+	{{ .MountCORSHandler }}(mux)
+	{{- end }}
 }
 `
 
 // input: EndpointData
 const serverHandlerT = `{{ printf "%s configures the mux to serve the %q service %q endpoint." .MountHandler .ServiceName .Method.Name | comment }}
 func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	{{- if .Origins }}
+	h = goahttp.CORSHandler(h, {{ template "cors_policies" .Origins }})
+	{{- end }}
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
@@ -405,7 +454,45 @@ func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
 	{{- end }}
 }
-`
+` + corsPoliciesT
+
+// input: ServiceData
+const serverCORST = `{{ printf "%s configures the mux to serve the CORS preflight requests made to the %s endpoints." .MountCORSHandler .Service.Name | comment }}
+func {{ .MountCORSHandler }}(mux goahttp.Muxer) {
+	{{- range .Preflights }}
+	mux.Handle("OPTIONS", "{{ .Path }}", goahttp.CORSPreflightHandler({{ template "cors_policies" .Origins }}).ServeHTTP)
+	{{- end }}
+}
+` + corsPoliciesT
+
+// input: []*OriginData
+const corsPoliciesT = `{{ define "cors_policies" }}[]*goahttp.CORSPolicy{
+	{{- range . }}
+	{
+		{{- if .Regexp }}
+		OriginRegexp: regexp.MustCompile({{ printf "%q" .Regexp }}),
+		{{- else }}
+		Origin: {{ printf "%q" .Origin }},
+		{{- end }}
+		{{- if .Methods }}
+		Methods: {{ printf "%#v" .Methods }},
+		{{- end }}
+		{{- if .Headers }}
+		Headers: {{ printf "%#v" .Headers }},
+		{{- end }}
+		{{- if .ExposedHeaders }}
+		ExposedHeaders: {{ printf "%#v" .ExposedHeaders }},
+		{{- end }}
+		{{- if .MaxAge }}
+		MaxAge: {{ .MaxAge }},
+		{{- end }}
+		{{- if .Credentials }}
+		Credentials: true,
+		{{- end }}
+	},
+	{{- end }}
+}
+{{- end }}`
 
 // input: FileServerData
 const fileServerT = `{{ printf "%s configures the mux to serve GET request made to %q." .MountHandler (join .RequestPaths ", ") | comment }}
@@ -431,7 +518,7 @@ func {{ .HandlerInit }}(
 	dec func(*http.Request) goahttp.Decoder,
 	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	eh func(context.Context, http.ResponseWriter, error),
-	{{- if .ServerStream }}
+	{{- if and .ServerStream (not .SSE) (not .NDJSON) }}
 	up goahttp.Upgrader,
 	connConfigFn goahttp.ConnConfigureFunc,
 	{{- end }}
@@ -447,12 +534,50 @@ func {{ .HandlerInit }}(
 			{{- end }}
 		encodeResponse = {{ .ResponseEncoder }}(enc)
 		{{- end }}
-		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else }}goahttp.ErrorEncoder{{ end }}(enc)
+		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else if .ProblemDetails }}goahttp.ProblemErrorEncoder{{ else }}goahttp.ErrorEncoder{{ end }}(enc)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .Paginated }}
+		ctx = context.WithValue(ctx, goahttp.RequestURLKey, r.URL)
+	{{- end }}
+	{{- if .Conditional }}
+		ctx = context.WithValue(ctx, goahttp.ConditionalRequestKey, r)
+	{{- end }}
+	{{- if and .NDJSON (eq .Method.StreamKind 4) }}
+		if !goahttp.CheckNDJSONProtocol(w, r) {
+			return
+		}
+	{{- end }}
+	{{- if .Produces }}
+		produces := {{ printf "%#v" .Produces }}
+		if err := goahttp.CheckAcceptable(w, r, produces); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		ctx = context.WithValue(ctx, goahttp.ProducesKey, produces)
+	{{- end }}
+	{{- if .FieldMaskParam }}
+		if fields := r.URL.Query().Get({{ printf "%q" .FieldMaskParam }}); fields != "" {
+			mask := goa.ParseFieldMask(fields)
+			if err := {{ .ServicePkgName }}.Validate{{ .Method.VarName }}FieldMask(mask); err != nil {
+				if err := encodeError(ctx, w, err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
+			ctx = context.WithValue(ctx, goa.FieldMaskKey, mask)
+		}
+	{{- end }}
+	{{- if .ViewSelectorParam }}
+		if view := goahttp.RequestedView(r, {{ printf "%q" .ViewSelectorParam }}); view != "" {
+			ctx = context.WithValue(ctx, goa.ViewKey, view)
+		}
+	{{- end }}
 
 	{{- if .Payload.Ref }}
 		payload, err := decodeRequest(r)
@@ -466,7 +591,30 @@ func {{ .HandlerInit }}(
 		var err error
 	{{- end }}
 
-	{{ if .ServerStream }}
+	{{ if .SSE }}
+		events := goahttp.NewEventStream(w)
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: &{{ .ServerStream.VarName }}{events: events},
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err = endpoint(ctx, v)
+	{{- else if .NDJSON }}
+		nw := goahttp.NewNDJSONWriter(w)
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: &{{ .ServerStream.VarName }}{
+				w: nw,
+			{{- if .ServerStream.RecvTypeRef }}
+				r: goahttp.NewNDJSONReader(r.Body),
+			{{- end }}
+			},
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err = endpoint(ctx, v)
+	{{- else if .ServerStream }}
 		var cancel context.CancelFunc
 		{
 			ctx, cancel = context.WithCancel(ctx)
@@ -489,7 +637,22 @@ func {{ .HandlerInit }}(
 	{{- end }}
 
 		if err != nil {
-			{{- if .ServerStream }}
+			{{- if .SSE }}
+			if events.Started() {
+				// The error is reported in an error event once events
+				// have been sent.
+				events.SendError(err)
+				eh(ctx, w, err)
+				return
+			}
+			{{- else if .NDJSON }}
+			if nw.Started() {
+				// The error is reported in the response trailer once
+				// values have been sent.
+				nw.SetError(err)
+				return
+			}
+			{{- else if .ServerStream }}
 			if _, ok := err.(websocket.HandshakeError); ok {
 				return
 			}
@@ -499,7 +662,9 @@ func {{ .HandlerInit }}(
 			}
 			return
 		}
-	{{- if not .ServerStream }}
+	{{- if .NDJSON }}
+		nw.Start()
+	{{- else if not .ServerStream }}
 		if err := encodeResponse(ctx, w, res); err != nil {
 			eh(ctx, w, err)
 		}
@@ -523,6 +688,9 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 {{- if .MultipartRequestDecoder }}
 		var payload {{ .Payload.Ref }}
 		if err := decoder(r).Decode(&payload); err != nil {
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 {{- else if .Payload.Request.ServerBody }}
@@ -590,7 +758,7 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 
 // input: RequestData
 const requestParamsHeadersT = `{{- define "request_params_headers" }}
-{{- if or .PathParams .QueryParams .Headers }}
+{{- if or .PathParams .QueryParams .Headers .Cookies }}
 {{- if .ServerBody }}{{/* we want a newline only if there was code before */}}
 {{ end }}
 		var (
@@ -603,9 +771,15 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- range .Headers }}
 			{{ .VarName }} {{ .TypeRef }}
 		{{- end }}
+		{{- range .Cookies }}
+			{{ .VarName }} {{ .TypeRef }}
+		{{- end }}
 		{{- if and .MustValidate (or (not .ServerBody) .Multipart) }}
 			err error
 		{{- end }}
+		{{- if .Cookies }}
+			c   *http.Cookie
+		{{- end }}
 		{{- if .PathParams }}
 
 			params = mux.Vars(r)
@@ -841,6 +1015,52 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{ .Validate }}
 	{{- end }}
 {{- end }}
+
+{{- range .Cookies }}
+		c, _ = r.Cookie("{{ .Name }}")
+		var {{ .VarName }}Raw string
+		if c != nil {
+			{{ .VarName }}Raw = c.Value
+		}
+	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) .Required }}
+		if {{ .VarName }}Raw == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
+		}
+		{{ .VarName }} = {{ .VarName }}Raw
+
+	{{- else if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
+		if {{ .VarName }}Raw != "" {
+			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+		}
+		{{- if .DefaultValue }} else {
+			{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
+		}
+		{{- end }}
+
+	{{- else }}{{/* not string and not any */}}
+		{{- if .Required }}
+		if {{ .VarName }}Raw == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
+		}
+		{{- else if .DefaultValue }}
+		if {{ .VarName }}Raw == "" {
+			{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
+		}
+		{{- end }}
+
+		{{- if .DefaultValue }}else {
+		{{- else if not .Required }}
+		if {{ .VarName }}Raw != "" {
+		{{- else }}
+		{
+		{{- end }}
+		{{- template "type_conversion" . }}
+		}
+	{{- end }}
+	{{- if .Validate }}
+		{{ .Validate }}
+	{{- end }}
+{{- end }}
 {{- end }}
 {{- end }}
 
@@ -1105,7 +1325,7 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 // input: EndpointData
 const errorEncoderT = `{{ printf "%s returns an encoder for errors returned by the %s %s endpoint." .ErrorEncoder .Method.Name .ServiceName | comment }}
 func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
-	encodeError := goahttp.ErrorEncoder(encoder)
+	encodeError := goahttp.{{ if .ProblemDetails }}ProblemErrorEncoder{{ else }}ErrorEncoder{{ end }}(encoder)
 	return func(ctx context.Context, w http.ResponseWriter, v error) error {
 		en, ok := v.(ErrorNamer)
 		if !ok {
@@ -1118,7 +1338,9 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 			res := v.({{ $err.Ref }})
 			{{- with .Response}}
 				{{- template "response" . }}
-				{{- if .ServerBody }}
+				{{- if .ProblemDetails }}
+				return goahttp.EncodeProblem(w, {{ .StatusCode }}, {{ printf "%q" .ProblemType }}, res, {{ if .ServerBody }}body{{ else }}nil{{ end }})
+				{{- else if .ServerBody }}
 				return enc.Encode(body)
 				{{- end }}
 			{{- end }}
@@ -1134,7 +1356,7 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 // input: ResponseData
 const responseT = `{{ define "response" -}}
 	{{- $servBodyLen := len .ServerBody }}
-	{{- if gt $servBodyLen 0 }}
+	{{- if and (gt $servBodyLen 0) (not .ProblemDetails) }}
 	enc := encoder(ctx, w)
 	{{- end }}
 	{{- if gt $servBodyLen 0 }}
@@ -1179,9 +1401,69 @@ const responseT = `{{ define "response" -}}
 
 	{{- end }}
 
+	{{- range .Cookies }}
+		{{- $deref := or .FieldPointer $.ViewedResult }}
+		{{- $checkNil := or $deref (eq .Type.Name "bytes") (eq .Type.Name "any") }}
+		{{- if $checkNil }}
+	if res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .FieldName }} != nil {
+		{{- else }}
+	{
+		{{- end }}
+		{{- if eq .Type.Name "string" }}
+		{{ .VarName }} := {{ if $deref }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .FieldName }}
+		{{- else }}
+		{{ .VarName }}Raw := res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .FieldName }}
+		{{ template "header_conversion" (headerConversionData .Type .VarName (not $deref) (printf "%sRaw" .VarName)) }}
+		{{- end }}
+		http.SetCookie(w, &http.Cookie{
+			Name:  {{ printf "%q" .Name }},
+			Value: {{ .VarName }},
+		{{- if .MaxAge }}
+			MaxAge: {{ .MaxAge }},
+		{{- end }}
+		{{- if .Path }}
+			Path: {{ printf "%q" .Path }},
+		{{- end }}
+		{{- if .Domain }}
+			Domain: {{ printf "%q" .Domain }},
+		{{- end }}
+		{{- if .Secure }}
+			Secure: true,
+		{{- end }}
+		{{- if .HTTPOnly }}
+			HttpOnly: true,
+		{{- end }}
+		{{- if .SameSite }}
+			SameSite: http.SameSite{{ .SameSite }}Mode,
+		{{- end }}
+		})
+	}
+	{{- end }}
+
 	{{- if .ErrorHeader }}
 	w.Header().Set("goa-error", {{ printf "%q" .ErrorHeader }})
 	{{- end }}
+	{{- if .ProblemDetails }}
+	w.Header().Set("Content-Type", goahttp.ProblemContentType)
+	{{- end }}
+	{{- with .Pagination }}
+		{{- if .CursorParam }}
+			{{- if or .NextCursorPointer $.ViewedResult }}
+	if res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .NextCursorField }} != nil {
+		goahttp.SetNextCursorLink(ctx, w, {{ printf "%q" .CursorParam }}, *res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .NextCursorField }})
+	}
+			{{- else }}
+	goahttp.SetNextCursorLink(ctx, w, {{ printf "%q" .CursorParam }}, res.{{ .NextCursorField }})
+			{{- end }}
+		{{- else }}
+	goahttp.SetNextOffsetLink(ctx, w, {{ printf "%q" .OffsetParam }}, {{ printf "%q" .PageSizeParam }}, {{ .PageSizeDefault }}, len(res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .ItemsField }}))
+		{{- end }}
+	{{- end }}
+	{{- if .NotModified }}
+	if goahttp.WriteNotModified(ctx, w) {
+		return nil
+	}
+	{{- end }}
 	w.WriteHeader({{ .StatusCode }})
 {{- end }}
 
@@ -1232,9 +1514,40 @@ const multipartRequestDecoderTypeT = `{{ printf "%s is the type to decode multip
 type {{ .FuncName }} func(*multipart.Reader, *{{ .Payload.Ref }}) error
 `
 
+// input: multipartData
+const multipartRequestDefaultDecoderT = `{{ printf "%s decodes the multipart request for the %q service %q endpoint. It maps the form fields and file parts to the request body attributes with the same names and is used when no decoder function is given to %s." .DefaultName .ServiceName .MethodName .InitName | comment }}
+func {{ .DefaultName }}(mr *multipart.Reader, p *{{ .Payload.Ref }}) error {
+	var (
+		body {{ .Payload.Request.ServerBody.VarName }}
+		err  error
+	)
+	if err = goahttp.DecodeMultipart(mr, &body); err != nil {
+		return err
+	}
+	{{- if .Payload.Request.ServerBody.ValidateRef }}
+	{{ .Payload.Request.ServerBody.ValidateRef }}
+	if err != nil {
+		return err
+	}
+	{{- end }}
+	{{- range .Payload.Request.PayloadInit.ServerArgs }}
+		{{- if ne .Name "body" }}
+	var {{ .Name }} {{ .TypeRef }}
+		{{- end }}
+	{{- end }}
+	*p = {{ .Payload.Request.PayloadInit.Name }}({{ range .Payload.Request.PayloadInit.ServerArgs }}{{ .Ref }}, {{ end }})
+	return nil
+}
+`
+
 // input: multipartData
 const multipartRequestDecoderT = `{{ printf "%s returns a decoder to decode the multipart request for the %q service %q endpoint." .InitName .ServiceName .MethodName | comment }}
 func {{ .InitName }}(mux goahttp.Muxer, {{ .VarName }} {{ .FuncName }}) func(r *http.Request) goahttp.Decoder {
+	{{- if .DefaultName }}
+	if {{ .VarName }} == nil {
+		{{ .VarName }} = {{ .DefaultName }}
+	}
+	{{- end }}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, merr := r.MultipartReader()
@@ -78,6 +78,11 @@ func TestEncode(t *testing.T) {
 
 		{"empty-server-response", testdata.EmptyServerResponseDSL, testdata.EmptyServerResponseEncodeCode},
 		{"empty-server-response-with-tags", testdata.EmptyServerResponseWithTagsDSL, testdata.EmptyServerResponseWithTagsEncodeCode},
+
+		{"cursor-pagination", testdata.CursorPaginationResultDSL, testdata.CursorPaginationEncodeCode},
+		{"offset-pagination", testdata.OffsetPaginationResultDSL, testdata.OffsetPaginationEncodeCode},
+		{"conditional", testdata.ConditionalResultDSL, testdata.ConditionalEncodeCode},
+		{"cookie", testdata.ResultCookieDSL, testdata.ResultCookieEncodeCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -17,6 +17,8 @@ func TestEncodeError(t *testing.T) {
 		{"primitive-error-response", testdata.PrimitiveErrorResponseDSL, testdata.PrimitiveErrorResponseEncoderCode},
 		{"default-error-response", testdata.DefaultErrorResponseDSL, testdata.DefaultErrorResponseEncoderCode},
 		{"service-error-response", testdata.ServiceErrorResponseDSL, testdata.ServiceErrorResponseEncoderCode},
+		{"problem-details-error-response", testdata.ProblemDetailsErrorResponseDSL, testdata.ProblemDetailsErrorResponseEncoderCode},
+		{"api-problem-details-error-response", testdata.APIProblemDetailsErrorResponseDSL, testdata.APIProblemDetailsErrorResponseEncoderCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
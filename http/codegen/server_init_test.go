@@ -22,6 +22,7 @@ func TestServerInit(t *testing.T) {
 		{"mixed", testdata.ServerMixedDSL, testdata.ServerMixedConstructorCode, 3},
 		{"multipart", testdata.ServerMultipartDSL, testdata.ServerMultipartConstructorCode, 4},
 		{"streaming", testdata.StreamingResultDSL, testdata.ServerStreamingConstructorCode, 5},
+		{"idempotent", testdata.ServerIdempotentDSL, testdata.ServerIdempotentConstructorCode, 3},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -41,3 +42,64 @@ func TestServerInit(t *testing.T) {
 		})
 	}
 }
+
+func TestServerCORS(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Code    string
+		Section string
+	}{
+		{"mount", testdata.ServerCORSDSL, testdata.ServerCORSMountCode, "server-mount"},
+		{"mount-handler", testdata.ServerCORSDSL, testdata.ServerCORSMountHandlerCode, "server-handler"},
+		{"preflight", testdata.ServerCORSDSL, testdata.ServerCORSPreflightCode, "server-cors"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			var section *codegen.SectionTemplate
+			for _, s := range fs[0].SectionTemplates {
+				if s.Name == c.Section {
+					section = s
+					break
+				}
+			}
+			if section == nil {
+				t.Fatalf("section %q not found", c.Section)
+			}
+			code := codegen.SectionCode(t, section)
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServerCORSMultipleServices(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerCORSMultipleServicesDSL)
+	fs := ServerFiles("gen", expr.Root)
+	if len(fs) != 4 {
+		t.Fatalf("got %d files, expected four", len(fs))
+	}
+	for i, expected := range []string{testdata.ServerCORSMultipleServicesFirstCode, testdata.ServerCORSMultipleServicesSecondCode} {
+		var section *codegen.SectionTemplate
+		for _, s := range fs[i].SectionTemplates {
+			if s.Name == "server-cors" {
+				section = s
+				break
+			}
+		}
+		if section == nil {
+			t.Fatalf("section %q not found in %s", "server-cors", fs[i].Path)
+		}
+		code := codegen.SectionCode(t, section)
+		if code != expected {
+			t.Errorf("invalid code for %s, got:\n%s\ngot vs. expected:\n%s", fs[i].Path, code, codegen.Diff(t, code, expected))
+		}
+	}
+}
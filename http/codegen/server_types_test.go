@@ -89,12 +89,12 @@ func ValidateMethodARequestBody(body *MethodARequestBody) (err error) {
 	}
 	if body.Object != nil {
 		if err2 := ValidateBPayloadRequestBody(body.Object); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "body.object"))
 		}
 	}
 	if body.DupObj != nil {
 		if err2 := ValidateBPayloadRequestBody(body.DupObj); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "body.dup_obj"))
 		}
 	}
 	return
@@ -173,7 +173,7 @@ func ValidateMethodBRequestBody(body *MethodBRequestBody) (err error) {
 	}
 	if body.C != nil {
 		if err2 := ValidateAPayloadRequestBody(body.C); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestedError(err2, "body.c"))
 		}
 	}
 	return
@@ -47,6 +47,12 @@ type (
 		ServerInit string
 		// MountServer is the name of the mount function.
 		MountServer string
+		// MountCORSHandler is the name of the function that mounts the
+		// CORS preflight request handlers.
+		MountCORSHandler string
+		// Preflights lists the paths of the service endpoint routes
+		// that serve CORS preflight requests.
+		Preflights []*PreflightData
 		// ServerService is the name of service function.
 		ServerService string
 		// ClientStruct is the name of the HTTP client struct.
@@ -133,6 +139,39 @@ type (
 		// ServerStream holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerStream *StreamData
+		// SSE is true if the endpoint streams results using server-sent
+		// events instead of websockets.
+		SSE bool
+		// NDJSON is true if the endpoint streams payloads and/or results
+		// using newline-delimited JSON instead of websockets.
+		NDJSON bool
+		// ProblemDetails is true if the errors not described in the
+		// design are encoded as RFC 7807 problem details documents.
+		ProblemDetails bool
+		// Paginated is true if the endpoint sets the Link header to the
+		// URL of the next page.
+		Paginated bool
+		// FieldMaskParam is the name of the query string parameter
+		// holding the field mask that selects the result fields if any.
+		FieldMaskParam string
+		// ViewSelectorParam is the name of the query string parameter and
+		// of the Accept header media type parameter holding the view
+		// selected by the client if any.
+		ViewSelectorParam string
+		// Conditional is true if the endpoint defines ETag or
+		// Last-Modified validators. The server evaluates the conditional
+		// request headers and the client sets them from the context.
+		Conditional bool
+		// Idempotent is true if the server replays the responses of
+		// requests retried with the same Idempotency-Key header and the
+		// client sets the header.
+		Idempotent bool
+		// Produces lists the media types the server negotiates the
+		// response content type from if any.
+		Produces []string
+		// Origins lists the CORS policies applied to the endpoint
+		// responses if any.
+		Origins []*OriginData
 
 		// client
 
@@ -242,6 +281,9 @@ type (
 		// Headers contains the HTTP request headers used to build the
 		// method payload.
 		Headers []*HeaderData
+		// Cookies contains the HTTP request cookies used to build the
+		// method payload.
+		Cookies []*CookieData
 		// ServerBody describes the request body type used by server
 		// code. The type is generated using pointers for all fields so
 		// that it can be validated.
@@ -259,6 +301,9 @@ type (
 		// Multipart if true indicates the request is a multipart
 		// request.
 		Multipart bool
+		// Form if true indicates the request body is encoded using the
+		// application/x-www-form-urlencoded content type.
+		Form bool
 	}
 
 	// ResponseData describes a response.
@@ -270,12 +315,21 @@ type (
 		// Headers provides information about the headers in the
 		// response.
 		Headers []*HeaderData
+		// Cookies provides information about the cookies in the
+		// response.
+		Cookies []*CookieData
 		// ContentType contains the value of the response
 		// "Content-Type" header.
 		ContentType string
 		// ErrorHeader contains the value of the response "goa-error"
 		// header if any.
 		ErrorHeader string
+		// ProblemDetails is true if the error response body is encoded
+		// as a RFC 7807 problem details document.
+		ProblemDetails bool
+		// ProblemType is the problem type URI of the problem details
+		// document.
+		ProblemType string
 		// ServerBody is the type of the response body used by server
 		// code, nil if body should be empty. The type does NOT use
 		// pointers for all fields. If the method result is a result
@@ -314,6 +368,40 @@ type (
 		// ViewedResult indicates whether the response body type is a
 		// result type.
 		ViewedResult *service.ViewedResultTypeData
+		// Pagination contains the data needed to set the Link header of
+		// paginated success responses, nil otherwise.
+		Pagination *PaginationData
+		// NotModified is true if the response is a success response of
+		// an endpoint that defines validators in which case the server
+		// writes a 304 Not Modified response instead when the
+		// conditional request validators match.
+		NotModified bool
+	}
+
+	// PaginationData contains the data needed to render the code that sets
+	// the Link header to the URL of the next page.
+	PaginationData struct {
+		// CursorParam is the name of the query string parameter holding
+		// the cursor if the method uses cursor pagination.
+		CursorParam string
+		// NextCursorField is the name of the result field holding the
+		// cursor of the next page.
+		NextCursorField string
+		// NextCursorPointer is true if the next cursor result field is a
+		// pointer.
+		NextCursorPointer bool
+		// OffsetParam is the name of the query string parameter holding
+		// the offset if the method uses offset pagination.
+		OffsetParam string
+		// PageSizeParam is the name of the query string parameter holding
+		// the page size if any.
+		PageSizeParam string
+		// PageSizeDefault is the default value of the page size, 0 if
+		// there is none.
+		PageSizeDefault int
+		// ItemsField is the name of the result field holding the page
+		// items.
+		ItemsField string
 	}
 
 	// InitData contains the data required to render a constructor.
@@ -388,6 +476,38 @@ type (
 		Example interface{}
 	}
 
+	// OriginData describes a CORS policy.
+	OriginData struct {
+		// Origin is the allowed origin, "*" allows any origin.
+		Origin string
+		// Regexp is the regular expression matching the allowed
+		// origins if any.
+		Regexp string
+		// Methods lists the HTTP methods allowed in preflight requests.
+		Methods []string
+		// Headers lists the request headers allowed in preflight
+		// requests.
+		Headers []string
+		// ExposedHeaders lists the response headers exposed to the
+		// client.
+		ExposedHeaders []string
+		// MaxAge is the number of seconds the result of preflight
+		// requests may be cached.
+		MaxAge int
+		// Credentials indicates that requests may include credentials.
+		Credentials bool
+	}
+
+	// PreflightData describes the handler of the CORS preflight requests
+	// made to a path.
+	PreflightData struct {
+		// Path is the request path including wildcards.
+		Path string
+		// Origins lists the CORS policies of the endpoints served by
+		// the path.
+		Origins []*OriginData
+	}
+
 	// RouteData describes a route.
 	RouteData struct {
 		// Verb is the HTTP method.
@@ -492,6 +612,55 @@ type (
 		Example interface{}
 	}
 
+	// CookieData describes a HTTP request or response cookie.
+	CookieData struct {
+		// Name is the name of the cookie.
+		Name string
+		// AttributeName is the name of the corresponding attribute.
+		AttributeName string
+		// Description is the cookie description.
+		Description string
+		// FieldName is the name of the struct field that holds the
+		// cookie value if any, empty string otherwise.
+		FieldName string
+		// FieldPointer if true indicates that the struct field that holds the
+		// cookie value is a pointer.
+		FieldPointer bool
+		// VarName is the name of the Go variable used to read or
+		// convert the cookie value.
+		VarName string
+		// TypeName is the name of the type.
+		TypeName string
+		// TypeRef is the reference to the type.
+		TypeRef string
+		// Required is true if the cookie is required.
+		Required bool
+		// Pointer is true if and only the cookie variable is a pointer.
+		Pointer bool
+		// Type describes the datatype of the variable value. Mainly
+		// used for conversion.
+		Type expr.DataType
+		// Validate contains the validation code if any.
+		Validate string
+		// DefaultValue contains the default value if any.
+		DefaultValue interface{}
+		// Example is an example value.
+		Example interface{}
+		// MaxAge is the cookie "Max-Age" attribute if any.
+		MaxAge string
+		// Path is the cookie "Path" attribute if any.
+		Path string
+		// Domain is the cookie "Domain" attribute if any.
+		Domain string
+		// Secure is true if the cookie "Secure" attribute is set.
+		Secure bool
+		// HTTPOnly is true if the cookie "HttpOnly" attribute is set.
+		HTTPOnly bool
+		// SameSite is the cookie "SameSite" attribute if any, one of
+		// "Lax", "Strict" or "None".
+		SameSite string
+	}
+
 	// TypeData contains the data needed to render a type definition.
 	TypeData struct {
 		// Name is the type name.
@@ -526,6 +695,10 @@ type (
 		InitName string
 		// VarName is the name of the variable referring to the function.
 		VarName string
+		// DefaultName is the name of the generated function used when
+		// the user does not provide one, empty if the payload body is
+		// not an object.
+		DefaultName string
 		// ServiceName is the name of the service.
 		ServiceName string
 		// MethodName is the name of the method.
@@ -581,6 +754,12 @@ type (
 		// Kind is the kind of the stream (payload, result or
 		// bidirectional).
 		Kind expr.StreamKind
+		// SSE is true if the stream uses server-sent events instead of
+		// websockets.
+		SSE bool
+		// NDJSON is true if the stream uses newline-delimited JSON
+		// instead of websockets.
+		NDJSON bool
 	}
 )
 
@@ -623,6 +802,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		MountPointStruct: "MountPoint",
 		ServerInit:       "New",
 		MountServer:      "Mount",
+		MountCORSHandler: "MountCORSHandler",
 		ServerService:    "Service",
 		ClientStruct:     "Client",
 		ServerTypeNames:  make(map[string]bool),
@@ -757,7 +937,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 
 		var requestEncoder string
 		{
-			if payload.Request.ClientBody != nil || len(payload.Request.Headers) > 0 || len(payload.Request.QueryParams) > 0 || basch != nil {
+			if payload.Request.ClientBody != nil || len(payload.Request.Headers) > 0 || len(payload.Request.Cookies) > 0 || len(payload.Request.QueryParams) > 0 || basch != nil {
 				requestEncoder = fmt.Sprintf("Encode%sRequest", ep.VarName)
 			}
 		}
@@ -791,7 +971,11 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				"Args":         args,
 				"PathInit":     routes[0].PathInit,
 				"Verb":         routes[0].Verb,
-				"IsStreaming":  a.MethodExpr.IsStreaming(),
+				"IsStreaming":  a.MethodExpr.IsStreaming() && !a.SSE && !a.NDJSON,
+				"FieldMask":    a.MethodExpr.FieldMask,
+				"ViewSelector": a.MethodExpr.ViewSelector,
+				"Conditional":  a.HasValidators(),
+				"Idempotent":   a.Idempotent,
 			}
 			var buf bytes.Buffer
 			if err := requestInitTmpl.Execute(&buf, data); err != nil {
@@ -832,14 +1016,41 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			RequestInit:     requestInit,
 			RequestEncoder:  requestEncoder,
 			ResponseDecoder: fmt.Sprintf("Decode%sResponse", ep.VarName),
+			SSE:             a.SSE,
+			NDJSON:          a.NDJSON,
+			ProblemDetails:  expr.Root.API.HTTP.ProblemDetails,
 		}
 		buildStreamData(ad, a, rd)
+		if fm := a.MethodExpr.FieldMask; fm != nil {
+			ad.FieldMaskParam = fm.Name
+		}
+		if vs := a.MethodExpr.ViewSelector; vs != nil {
+			ad.ViewSelectorParam = vs.Name
+		}
+		ad.Conditional = a.HasValidators()
+		ad.Idempotent = a.Idempotent
+		if a.NegotiatesContentType() {
+			ad.Produces = a.Produces
+		}
+		ad.Origins = buildOriginsData(a)
+		if pd := buildPaginationData(a); pd != nil {
+			ad.Paginated = true
+			for _, r := range ad.Result.Responses {
+				r.Pagination = pd
+			}
+		}
 
 		if a.MultipartRequest {
+			var decoderName, encoderName string
+			if req := ad.Payload.Request; req.ServerBody != nil && req.ClientBody != nil && req.PayloadInit != nil && expr.IsObject(a.Body.Type) {
+				decoderName = fmt.Sprintf("Decode%s%sMultipart", svc.StructName, ep.VarName)
+				encoderName = fmt.Sprintf("Encode%s%sMultipart", svc.StructName, ep.VarName)
+			}
 			ad.MultipartRequestDecoder = &MultipartData{
 				FuncName:    fmt.Sprintf("%s%sDecoderFunc", svc.StructName, ep.VarName),
 				InitName:    fmt.Sprintf("New%s%sDecoder", svc.StructName, ep.VarName),
 				VarName:     fmt.Sprintf("%s%sDecoderFn", svc.VarName, ep.VarName),
+				DefaultName: decoderName,
 				ServiceName: svc.Name,
 				MethodName:  ep.Name,
 				Payload:     ad.Payload,
@@ -848,6 +1059,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				FuncName:    fmt.Sprintf("%s%sEncoderFunc", svc.StructName, ep.VarName),
 				InitName:    fmt.Sprintf("New%s%sEncoder", svc.StructName, ep.VarName),
 				VarName:     fmt.Sprintf("%s%sEncoderFn", svc.VarName, ep.VarName),
+				DefaultName: encoderName,
 				ServiceName: svc.Name,
 				MethodName:  ep.Name,
 				Payload:     ad.Payload,
@@ -902,10 +1114,128 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			})
 		}
 	}
+	rd.Preflights = buildPreflightsData(hs)
 
 	return rd
 }
 
+// buildOriginsData returns the data structures used to render the CORS
+// policies of the endpoint.
+func buildOriginsData(e *expr.HTTPEndpointExpr) []*OriginData {
+	var origins []*OriginData
+	for _, o := range e.Origins {
+		od := &OriginData{
+			Origin:         o.Origin,
+			Methods:        o.Methods,
+			Headers:        o.Headers,
+			ExposedHeaders: o.ExposedHeaders,
+			MaxAge:         o.MaxAge,
+			Credentials:    o.Credentials,
+		}
+		if o.IsRegexp() {
+			od.Regexp = o.Regexp()
+		}
+		origins = append(origins, od)
+	}
+	return origins
+}
+
+// buildPreflightsData groups the CORS policies of the endpoints of all the
+// services by route path and returns the paths owned by the service hs. A path
+// is owned by the first service in the design that defines CORS policies for
+// one of its routes so that the preflight handler is registered only once when
+// all the services are mounted on the same muxer, the handler applies the
+// policies of all the services. Paths that are also served by an endpoint
+// using the OPTIONS method are skipped as the endpoint answers the preflight
+// requests.
+func buildPreflightsData(hs *expr.HTTPServiceExpr) []*PreflightData {
+	options := make(map[string]bool)
+	for _, s := range expr.Root.API.HTTP.Services {
+		for _, e := range s.HTTPEndpoints {
+			for _, r := range e.Routes {
+				if strings.ToUpper(r.Method) != "OPTIONS" {
+					continue
+				}
+				for _, p := range r.FullPaths() {
+					options[preflightKey(p)] = true
+				}
+			}
+		}
+	}
+	var preflights []*PreflightData
+	index := make(map[string]*PreflightData)
+	for _, s := range expr.Root.API.HTTP.Services {
+		for _, e := range s.HTTPEndpoints {
+			origins := buildOriginsData(e)
+			if len(origins) == 0 {
+				continue
+			}
+			seen := make(map[string]bool)
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					key := preflightKey(p)
+					if options[key] || seen[key] {
+						continue
+					}
+					seen[key] = true
+					pd, ok := index[key]
+					if !ok {
+						pd = &PreflightData{Path: p}
+						index[key] = pd
+						if s.Name() == hs.Name() {
+							preflights = append(preflights, pd)
+						}
+					}
+					for _, o := range origins {
+						pd.Origins = mergeOrigin(pd.Origins, o)
+					}
+				}
+			}
+		}
+	}
+	return preflights
+}
+
+// preflightKey returns the key used to group the routes by path regardless
+// of the names of the path wildcards.
+func preflightKey(p string) string {
+	return expr.HTTPWildcardRegex.ReplaceAllStringFunc(p, func(w string) string {
+		if strings.HasPrefix(w, "/{*") {
+			return "/{*}"
+		}
+		return "/{}"
+	})
+}
+
+// mergeOrigin adds the methods of o to the policy in origins that only
+// differs from o by its methods if any, it appends a copy of o to origins
+// otherwise.
+func mergeOrigin(origins []*OriginData, o *OriginData) []*OriginData {
+	for _, eo := range origins {
+		if eo.Origin != o.Origin || eo.MaxAge != o.MaxAge || eo.Credentials != o.Credentials ||
+			strings.Join(eo.Headers, ",") != strings.Join(o.Headers, ",") ||
+			strings.Join(eo.ExposedHeaders, ",") != strings.Join(o.ExposedHeaders, ",") {
+			continue
+		}
+		for _, m := range o.Methods {
+			found := false
+			for _, em := range eo.Methods {
+				if em == m {
+					found = true
+					break
+				}
+			}
+			if !found {
+				eo.Methods = append(eo.Methods, m)
+			}
+		}
+		return origins
+	}
+	dup := *o
+	dup.Methods = append([]string{}, o.Methods...)
+	return append(origins, &dup)
+}
+
 // buildPayloadData returns the data structure used to describe the endpoint
 // payload including the HTTP request details. It also returns the user types
 // used by the request body type recursively if any.
@@ -929,6 +1259,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 			paramsData     = extractPathParams(e.PathParams(), payload, sd.Scope)
 			queryData      = extractQueryParams(e.QueryParams(), payload, sd.Scope)
 			headersData    = extractHeaders(e.Headers, payload, svcctx, sd.Scope)
+			cookiesData    = extractCookies(e.Cookies, payload, svcctx, sd.Scope)
 
 			mustValidate bool
 		)
@@ -989,15 +1320,25 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					}
 				}
 			}
+			if !mustValidate {
+				for _, c := range cookiesData {
+					if c.Validate != "" || c.Required || needConversion(c.Type) {
+						mustValidate = true
+						break
+					}
+				}
+			}
 		}
 		request = &RequestData{
 			PathParams:   paramsData,
 			QueryParams:  queryData,
 			Headers:      headersData,
+			Cookies:      cookiesData,
 			ServerBody:   serverBodyData,
 			ClientBody:   clientBodyData,
 			MustValidate: mustValidate,
 			Multipart:    e.MultipartRequest,
+			Form:         e.IsFormBody(),
 		}
 	}
 
@@ -1101,6 +1442,21 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				Example:      h.Example,
 			})
 		}
+		for _, c := range request.Cookies {
+			args = append(args, &InitArgData{
+				Name:         c.VarName,
+				Ref:          c.VarName,
+				FieldName:    c.FieldName,
+				FieldPointer: c.FieldPointer,
+				TypeName:     c.TypeName,
+				TypeRef:      c.TypeRef,
+				Pointer:      c.Pointer,
+				Required:     c.Required,
+				DefaultValue: c.DefaultValue,
+				Validate:     c.Validate,
+				Example:      c.Example,
+			})
+		}
 		serverArgs = append(serverArgs, args...)
 		clientArgs = append(clientArgs, args...)
 
@@ -1285,8 +1641,8 @@ func buildResultData(e *expr.HTTPEndpointExpr, sd *ServiceData) *ResultData {
 		}
 		responses = buildResponses(e, result, viewed, sd)
 		for _, r := range responses {
-			// response has a body or headers or tag
-			if len(r.ServerBody) > 0 || len(r.Headers) > 0 || r.TagName != "" {
+			// response has a body, headers, cookies or tag
+			if len(r.ServerBody) > 0 || len(r.Headers) > 0 || len(r.Cookies) > 0 || r.TagName != "" {
 				mustInit = true
 			}
 		}
@@ -1301,6 +1657,44 @@ func buildResultData(e *expr.HTTPEndpointExpr, sd *ServiceData) *ResultData {
 	}
 }
 
+// buildPaginationData returns the data needed to render the code that sets
+// the Link header of the responses of paginated endpoints. It returns nil if
+// the endpoint is not paginated or if the cursor or offset is not mapped to a
+// query string parameter.
+func buildPaginationData(e *expr.HTTPEndpointExpr) *PaginationData {
+	pag := e.MethodExpr.Pagination
+	if pag == nil || e.MethodExpr.Result.Type == expr.Empty {
+		return nil
+	}
+	query := expr.AsObject(e.QueryParams().Type)
+	param := func(att string) string {
+		if att == "" || query.Attribute(att) == nil {
+			return ""
+		}
+		return e.Params.ElemName(att)
+	}
+	data := &PaginationData{ItemsField: codegen.Goify(pag.ItemsAttribute, true)}
+	if pag.IsCursor() {
+		data.CursorParam = param(pag.CursorAttribute)
+		if data.CursorParam == "" {
+			return nil
+		}
+		data.NextCursorField = codegen.Goify(pag.NextCursorAttribute, true)
+		data.NextCursorPointer = e.MethodExpr.Result.IsPrimitivePointer(pag.NextCursorAttribute, true)
+	} else {
+		data.OffsetParam = param(pag.OffsetAttribute)
+		if data.OffsetParam == "" {
+			return nil
+		}
+	}
+	if data.PageSizeParam = param(pag.PageSizeAttribute); data.PageSizeParam != "" {
+		if def, ok := e.MethodExpr.Payload.Find(pag.PageSizeAttribute).DefaultValue.(int); ok {
+			data.PageSizeDefault = def
+		}
+	}
+	return data
+}
+
 // buildResponses builds the response data for all the responses in the
 // endpoint expression. The response headers and body for each response
 // are inferred from the method's result expression if not specified
@@ -1333,6 +1727,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 			}
 			var (
 				headersData    []*HeaderData
+				cookiesData    []*CookieData
 				serverBodyData []*TypeData
 				clientBodyData *TypeData
 				init           *InitData
@@ -1343,6 +1738,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 			)
 			{
 				headersData = extractHeaders(resp.Headers, result, svcctx, scope)
+				cookiesData = extractCookies(resp.Cookies, result, svcctx, scope)
 				if resp.Body.Type != expr.Empty {
 					// If design uses Body("name") syntax we need to use the
 					// corresponding attribute in the result type for body
@@ -1398,6 +1794,12 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 						break
 					}
 				}
+				for _, c := range cookiesData {
+					if c.Validate != "" || c.Required || needConversion(c.Type) {
+						mustValidate = true
+						break
+					}
+				}
 				if needInit(result.Type) {
 					// generate constructor function to transform response body
 					// and headers into the method result type
@@ -1489,6 +1891,19 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 								Example:      h.Example,
 							})
 						}
+						for _, c := range cookiesData {
+							clientArgs = append(clientArgs, &InitArgData{
+								Name:         c.VarName,
+								Ref:          c.VarName,
+								FieldName:    c.FieldName,
+								FieldPointer: c.FieldPointer,
+								Required:     c.Required,
+								Pointer:      c.Pointer,
+								TypeRef:      c.TypeRef,
+								Validate:     c.Validate,
+								Example:      c.Example,
+							})
+						}
 					}
 					init = &InitData{
 						Name:                     name,
@@ -1519,6 +1934,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 					StatusCode:   statusCodeToHTTPConst(resp.StatusCode),
 					Description:  resp.Description,
 					Headers:      headersData,
+					Cookies:      cookiesData,
 					ContentType:  resp.ContentType,
 					ServerBody:   serverBodyData,
 					ClientBody:   clientBodyData,
@@ -1529,6 +1945,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 					MustValidate: mustValidate,
 					ResultAttr:   codegen.Goify(origin, true),
 					ViewedResult: md.ViewedResult,
+					NotModified:  e.HasValidators() && resp.StatusCode < 300,
 				})
 			}
 		}
@@ -1674,13 +2091,15 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 				}
 			}
 			responseData = &ResponseData{
-				StatusCode:   statusCodeToHTTPConst(v.Response.StatusCode),
-				Headers:      headers,
-				ErrorHeader:  v.Name,
-				ServerBody:   serverBodyData,
-				ClientBody:   clientBodyData,
-				ResultInit:   init,
-				MustValidate: mustValidate,
+				StatusCode:     statusCodeToHTTPConst(v.Response.StatusCode),
+				Headers:        headers,
+				ErrorHeader:    v.Name,
+				ProblemDetails: v.Response.ProblemDetails,
+				ProblemType:    v.Response.ProblemType,
+				ServerBody:     serverBodyData,
+				ClientBody:     clientBodyData,
+				ResultInit:     init,
+				MustValidate:   mustValidate,
 			}
 		}
 
@@ -1745,8 +2164,15 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 	{
 		svrSendTypeName = ed.Result.Name
 		svrSendTypeRef = ed.Result.Ref
-		svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint websocket connection.", md.ServerStream.SendName, svrSendTypeName, md.Name)
-		cliRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint websocket connection.", md.ClientStream.RecvName, svrSendTypeName, md.Name)
+		conn, short := "websocket connection", "connection"
+		switch {
+		case e.SSE:
+			conn, short = "event stream", "stream"
+		case e.NDJSON:
+			conn, short = "NDJSON stream", "stream"
+		}
+		svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint %s.", md.ServerStream.SendName, svrSendTypeName, md.Name, conn)
+		cliRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint %s.", md.ClientStream.RecvName, svrSendTypeName, md.Name, conn)
 		if e.MethodExpr.Stream == expr.ClientStreamKind || e.MethodExpr.Stream == expr.BidirectionalStreamKind {
 			svrRecvTypeName = sd.Scope.GoFullTypeName(e.MethodExpr.StreamingPayload, svc.PkgName)
 			svrRecvTypeRef = sd.Scope.GoFullTypeRef(e.MethodExpr.StreamingPayload, svc.PkgName)
@@ -1830,11 +2256,11 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 				sd.ServerTypeNames[cliPayload.Name] = false
 			}
 			if e.MethodExpr.Stream == expr.ClientStreamKind {
-				svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint websocket connection and closes the connection.", md.ServerStream.SendName, svrSendTypeName, md.Name)
-				cliRecvDesc = fmt.Sprintf("%s stops sending messages to the %q endpoint websocket connection and reads instances of %q from the connection.", md.ClientStream.RecvName, md.Name, svrSendTypeName)
+				svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint %s and closes the %s.", md.ServerStream.SendName, svrSendTypeName, md.Name, conn, short)
+				cliRecvDesc = fmt.Sprintf("%s stops sending messages to the %q endpoint %s and reads instances of %q from the %s.", md.ClientStream.RecvName, md.Name, conn, svrSendTypeName, short)
 			}
-			svrRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint websocket connection.", md.ServerStream.RecvName, svrRecvTypeName, md.Name)
-			cliSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint websocket connection.", md.ClientStream.SendName, svrRecvTypeName, md.Name)
+			svrRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint %s.", md.ServerStream.RecvName, svrRecvTypeName, md.Name, conn)
+			cliSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint %s.", md.ClientStream.SendName, svrRecvTypeName, md.Name, conn)
 		}
 	}
 	ed.ServerStream = &StreamData{
@@ -1855,6 +2281,8 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 		RecvTypeName: svrRecvTypeName,
 		RecvTypeRef:  svrRecvTypeRef,
 		MustClose:    md.ServerStream.MustClose,
+		SSE:          e.SSE,
+		NDJSON:       e.NDJSON,
 	}
 	ed.ClientStream = &StreamData{
 		VarName:      md.ClientStream.VarName,
@@ -1874,6 +2302,8 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 		RecvTypeName: svrSendTypeName,
 		RecvTypeRef:  svrSendTypeRef,
 		MustClose:    md.ClientStream.MustClose,
+		SSE:          e.SSE,
+		NDJSON:       e.NDJSON,
 	}
 }
 
@@ -2319,6 +2749,68 @@ func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 	return headers
 }
 
+// extractCookies returns the cookie data for the given mapped attribute. The
+// cookie properties are read from the metadata of the mapped attribute.
+func extractCookies(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svcCtx *codegen.AttributeContext, scope *codegen.NameScope) []*CookieData {
+	var cookies []*CookieData
+	prop := func(name string) string {
+		if v, ok := a.Meta["cookie:"+name]; ok && len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	codegen.WalkMappedAttr(a, func(name, elem string, required bool, _ *expr.AttributeExpr) error {
+		var (
+			cattr *expr.AttributeExpr
+		)
+		{
+			if cattr = svcAtt.Find(name); cattr == nil {
+				cattr = svcAtt
+			}
+		}
+		var (
+			varn    = scope.Name(codegen.Goify(name, false))
+			typeRef = scope.GoTypeRef(cattr)
+
+			fieldName string
+			pointer   bool
+		)
+		{
+			pointer = a.IsPrimitivePointer(name, true)
+			if expr.IsObject(svcAtt.Type) {
+				fieldName = codegen.Goify(name, true)
+			}
+			if pointer {
+				typeRef = "*" + typeRef
+			}
+		}
+		cookies = append(cookies, &CookieData{
+			Name:          elem,
+			AttributeName: name,
+			Description:   cattr.Description,
+			FieldName:     fieldName,
+			FieldPointer:  expr.IsObject(svcAtt.Type) && svcCtx.IsPrimitivePointer(name, svcAtt),
+			VarName:       varn,
+			TypeName:      scope.GoTypeName(cattr),
+			TypeRef:       typeRef,
+			Required:      required,
+			Pointer:       pointer,
+			Type:          cattr.Type,
+			Validate:      codegen.RecursiveValidationCode(cattr, svcCtx, required, varn),
+			DefaultValue:  cattr.DefaultValue,
+			Example:       cattr.Example(expr.Root.API.Random()),
+			MaxAge:        prop("maxage"),
+			Path:          prop("path"),
+			Domain:        prop("domain"),
+			Secure:        prop("secure") != "",
+			HTTPOnly:      prop("httponly") != "",
+			SameSite:      prop("samesite"),
+		})
+		return nil
+	})
+	return cookies
+}
+
 // collectUserTypes traverses the given data type recursively and calls back the
 // given function for each attribute using a user type.
 func collectUserTypes(dt expr.DataType, cb func(expr.UserType), seen ...map[string]struct{}) {
@@ -2336,6 +2828,10 @@ func collectUserTypes(dt expr.DataType, cb func(expr.UserType), seen ...map[stri
 		for _, nat := range *actual {
 			collectUserTypes(nat.Attribute.Type, cb, seen...)
 		}
+	case *expr.Union:
+		for _, nat := range *actual.Values {
+			collectUserTypes(nat.Attribute.Type, cb, seen...)
+		}
 	case *expr.Array:
 		collectUserTypes(actual.ElemType.Type, cb, seen...)
 	case *expr.Map:
@@ -2504,6 +3000,8 @@ func needInit(dt expr.DataType) bool {
 			}
 		}
 		return false
+	case *expr.Union:
+		return needInit(actual.Values)
 	case expr.UserType:
 		return true
 	default:
@@ -2532,7 +3030,7 @@ func needStream(data []*ServiceData) bool {
 }
 
 // streamingEndpointExists returns true if at least one of the endpoints in
-// the service defines a streaming payload or result.
+// the service defines a streaming payload or result sent over websockets.
 func streamingEndpointExists(sd *ServiceData) bool {
 	for _, e := range sd.Endpoints {
 		if isStreamingEndpoint(e) {
@@ -2542,10 +3040,23 @@ func streamingEndpointExists(sd *ServiceData) bool {
 	return false
 }
 
+// idempotentEndpointExists returns true if at least one of the service
+// endpoints is idempotent.
+func idempotentEndpointExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.Idempotent {
+			return true
+		}
+	}
+	return false
+}
+
 // isStreamingEndpoint returns true if the endpoint defines a streaming payload
-// or result.
+// or result sent over websockets. Endpoints that use server-sent events or
+// NDJSON do not require the websocket upgrader, dialer and connection
+// configurers.
 func isStreamingEndpoint(ed *EndpointData) bool {
-	return ed.ServerStream != nil || ed.ClientStream != nil
+	return (ed.ServerStream != nil || ed.ClientStream != nil) && !ed.SSE && !ed.NDJSON
 }
 
 const (
@@ -2626,7 +3137,27 @@ const (
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
+	{{- if .FieldMask }}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok && len(mask) > 0 {
+			values := req.URL.Query()
+			values.Set({{ printf "%q" .FieldMask.Name }}, mask.String())
+			req.URL.RawQuery = values.Encode()
+		}
+	{{- end }}
+	{{- if .ViewSelector }}
+		if view, ok := ctx.Value(goa.ViewKey).(string); ok && view != "" {
+			values := req.URL.Query()
+			values.Set({{ printf "%q" .ViewSelector.Name }}, view)
+			req.URL.RawQuery = values.Encode()
+		}
+	{{- end }}
+	{{- if .Conditional }}
+		goahttp.SetConditionalHeaders(ctx, req)
+	{{- end }}
 	}
+{{- if .Idempotent }}
+	goahttp.SetIdempotencyKey(ctx, req)
+{{- end }}
 
 	return req, nil`
 
@@ -2635,6 +3166,33 @@ const (
 	// input: StreamData
 	streamStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
 type {{ .VarName }} struct {
+{{- if .SSE }}
+	{{- if eq .Type "server" }}
+	{{ comment "events is the server-sent events stream." }}
+	events *goahttp.EventStream
+	{{- else }}
+	{{ comment "events reads the server-sent events from the HTTP response." }}
+	events *goahttp.EventReader
+	{{- end }}
+{{- else if .NDJSON }}
+	{{- if eq .Type "server" }}
+	{{ comment "w writes the streamed values to the HTTP response body." }}
+	w *goahttp.NDJSONWriter
+		{{- if .RecvTypeRef }}
+	{{ comment "r reads the streamed values from the HTTP request body." }}
+	r *goahttp.NDJSONReader
+		{{- end }}
+	{{- else }}
+		{{- if .SendTypeRef }}
+	{{ comment "req streams the values in the HTTP request body." }}
+	req *goahttp.NDJSONRequestStream
+	{{ comment "decode decodes the HTTP response if it does not contain a NDJSON stream." }}
+	decode func(*http.Response) (interface{}, error)
+		{{- end }}
+	{{ comment "r reads the streamed values from the HTTP response body." }}
+	r *goahttp.NDJSONReader
+	{{- end }}
+{{- else }}
 {{- if eq .Type "server" }}
 	once sync.Once
 	{{ comment "upgrader is the websocket connection upgrader." }}
@@ -2650,9 +3208,10 @@ type {{ .VarName }} struct {
 {{- end }}
 	{{ comment "conn is the underlying websocket connection." }}
 	conn *websocket.Conn
+{{- end }}
 	{{- if .Endpoint.Method.ViewedResult }}
 		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
-	{{ printf "view is the view to render %s result type before sending to the websocket connection." .SendTypeName | comment }}
+	{{ printf "view is the view to render %s result type before sending to the %s." .SendTypeName (or (and .SSE "event stream") (and .NDJSON "NDJSON stream") "websocket connection") | comment }}
 	view string
 		{{- end }}
 	{{- end }}
@@ -2892,9 +3451,230 @@ func (s *{{ .VarName }}) Close() error {
 	// streamSetViewT renders the function implementing the SetView method in
 	// server stream interface.
 	// input: StreamData
-	streamSetViewT = `{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint websocket connection." .SendTypeName .Endpoint.Method.Name | comment }}
+	streamSetViewT = `{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint %s." .SendTypeName .Endpoint.Method.Name (or (and .SSE "event stream") (and .NDJSON "NDJSON stream") "websocket connection") | comment }}
 func (s *{{ .VarName }}) SetView(view string) {
 	s.view = view
 }
+`
+
+	// responseStreamSendT renders the function implementing the Send method
+	// in the server stream interface for endpoints that stream results in
+	// the HTTP response body using server-sent events or NDJSON.
+	// input: StreamData
+	responseStreamSendT = `{{ comment .SendDesc }}
+func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+	{{- $w := "s.w" }}
+	{{- if .SSE }}
+		{{- $w = "s.events" }}
+	{{- end }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if .Endpoint.Method.ViewedResult.ViewName }}
+			res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, {{ printf "%q" .Endpoint.Method.ViewedResult.ViewName }})
+		{{- else }}
+			if !{{ $w }}.Started() {
+				{{ $w }}.Header().Set("goa-view", s.view)
+			}
+			res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, s.view)
+		{{- end }}
+	{{- else }}
+	res := v
+	{{- end }}
+	{{- $servBodyLen := len .Response.ServerBody }}
+	{{- $servBodyInit := false }}
+	{{- if gt $servBodyLen 0 }}
+		{{- $servBodyInit = (index .Response.ServerBody 0).Init }}
+	{{- end }}
+	{{- if $servBodyInit }}
+		{{- if .Endpoint.Method.ViewedResult }}
+			{{- if .Endpoint.Method.ViewedResult.ViewName }}
+				{{- $vsb := (viewedServerBody $.Response.ServerBody .Endpoint.Method.ViewedResult.ViewName) }}
+				body := {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+			{{- else }}
+				var body interface{}
+				switch s.view {
+				{{- range .Endpoint.Method.ViewedResult.Views }}
+					case {{ printf "%q" .Name }}{{ if eq .Name "default" }}, ""{{ end }}:
+					{{- $vsb := (viewedServerBody $.Response.ServerBody .Name) }}
+						body = {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+				{{- end }}
+				}
+			{{- end }}
+		{{- else }}
+			body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- end }}
+		return {{ $w }}.Send(body)
+	{{- else }}
+		return {{ $w }}.Send(res)
+	{{- end }}
+}
+`
+
+	// responseStreamCloseT renders the function implementing the Close
+	// method in the server stream interface for endpoints that stream
+	// results in the HTTP response body using server-sent events or NDJSON.
+	// input: StreamData
+	responseStreamCloseT = `{{ printf "Close ends the %q endpoint %s. The stream is closed once the handler returns." .Endpoint.Method.Name (or (and .SSE "event stream") (and .NDJSON "NDJSON stream") "websocket connection") | comment }}
+func (s *{{ .VarName }}) Close() error {
+	{{- if .SSE }}
+	s.events.Start()
+	{{- else }}
+	s.w.Start()
+	{{- end }}
+	return nil
+}
+`
+
+	// responseStreamRecvT renders the function implementing the Recv method
+	// in the client stream interface for endpoints that stream results in
+	// the HTTP response body using server-sent events or NDJSON.
+	// input: StreamData
+	responseStreamRecvT = `{{ comment .RecvDesc }}
+func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	var (
+		rv   {{ .RecvTypeRef }}
+		body {{ .Response.ClientBody.VarName }}
+		err  error
+	)
+{{- if .SSE }}
+	err = s.events.Decode(&body)
+	if err == io.EOF {
+		s.events.Close()
+		return rv, io.EOF
+	}
+{{- else if eq .RecvName "CloseAndRecv" }}
+	if err = s.req.Close(); err != nil {
+		return rv, err
+	}
+	resp, err := s.req.Response(s.decode)
+	if err != nil {
+		return rv, err
+	}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+	s.view = resp.Header.Get("goa-view")
+		{{- end }}
+	{{- end }}
+	s.r = goahttp.NewNDJSONResponseReader(resp)
+	defer s.r.Close()
+	err = s.r.Decode(&body)
+{{- else }}
+	{{- if .SendTypeRef }}
+	if s.r == nil {
+		resp, err := s.req.Response(s.decode)
+		if err != nil {
+			return rv, err
+		}
+		{{- if .Endpoint.Method.ViewedResult }}
+			{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+		s.view = resp.Header.Get("goa-view")
+			{{- end }}
+		{{- end }}
+		s.r = goahttp.NewNDJSONResponseReader(resp)
+	}
+	{{- end }}
+	err = s.r.Decode(&body)
+	if err == io.EOF {
+		s.r.Close()
+		return rv, io.EOF
+	}
+{{- end }}
+	if err != nil {
+		if serr, ok := err.(*goa.ServiceError); ok {
+			return rv, serr
+		}
+		return rv, goahttp.ErrDecodingError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
+	{{ .Response.ClientBody.ValidateRef }}
+	if err != nil {
+		return rv, goahttp.ErrValidationError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	{{- end }}
+	{{- if .Response.ResultInit }}
+		res := {{ .Response.ResultInit.Name }}({{ range .Response.ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
+		{{- if .Endpoint.Method.ViewedResult }}{{ with .Endpoint.Method.ViewedResult }}
+			vres := {{ if not .IsCollection }}&{{ end }}{{ .ViewsPkg }}.{{ .VarName }}{res, {{ if .ViewName }}{{ printf "%q" .ViewName }}{{ else }}s.view{{ end }} }
+			if err := {{ .ViewsPkg }}.Validate{{ $.Endpoint.Method.Result }}(vres); err != nil {
+				return rv, goahttp.ErrValidationError("{{ $.Endpoint.ServiceName }}", "{{ $.Endpoint.Method.Name }}", err)
+			}
+			return {{ $.PkgName }}.{{ .ResultInit.Name }}(vres){{ end }}, nil
+		{{- else }}
+			return res, nil
+		{{- end }}
+	{{- else }}
+		return body, nil
+	{{- end }}
+}
+`
+
+	// requestStreamRecvT renders the function implementing the Recv method
+	// in the server stream interface for endpoints that stream payloads in
+	// the HTTP request body using NDJSON.
+	// input: StreamData
+	requestStreamRecvT = `{{ comment .RecvDesc }}
+func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	var (
+		rv   {{ .RecvTypeRef }}
+		body {{ .Payload.VarName }}
+		err  error
+	)
+	err = s.r.Decode(&body)
+	if err == io.EOF {
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, goa.DecodePayloadError(err.Error())
+	}
+	{{- if .Payload.ValidateRef }}
+	{{ .Payload.ValidateRef }}
+	if err != nil {
+		return rv, err
+	}
+	{{- end }}
+	{{- if .Payload.Init }}
+	return {{ .Payload.Init.Name }}({{ range .Payload.Init.ServerArgs }}{{ .Ref }}{{ end }}), nil
+	{{- else }}
+	return body, nil
+	{{- end }}
+}
+`
+
+	// requestStreamSendT renders the function implementing the Send method
+	// in the client stream interface for endpoints that stream payloads in
+	// the HTTP request body using NDJSON.
+	// input: StreamData
+	requestStreamSendT = `{{ comment .SendDesc }}
+func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+	{{- if .Payload.Init }}
+	body := {{ .Payload.Init.Name }}(v)
+	return s.req.Send(body)
+	{{- else }}
+	return s.req.Send(v)
+	{{- end }}
+}
+`
+
+	// requestStreamCloseT renders the function implementing the Close method
+	// in the client stream interface for endpoints that stream payloads in
+	// the HTTP request body using NDJSON.
+	// input: StreamData
+	requestStreamCloseT = `{{ printf "Close stops sending messages to the %q endpoint NDJSON stream." .Endpoint.Method.Name | comment }}
+{{- if not .RecvTypeRef }}
+{{ comment "Close waits for the server response and returns the error it describes if any." }}
+{{- end }}
+func (s *{{ .VarName }}) Close() error {
+	{{- if .RecvTypeRef }}
+	return s.req.Close()
+	{{- else }}
+	if err := s.req.Close(); err != nil {
+		return err
+	}
+	resp, err := s.req.Response(s.decode)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+	{{- end }}
+}
 `
 )
@@ -196,6 +196,26 @@ func TestServerStreaming(t *testing.T) {
 			{"server-stream-send", &testdata.BidirectionalStreamingUserTypeMapServerStreamSendCode},
 			{"server-stream-recv", &testdata.BidirectionalStreamingUserTypeMapServerStreamRecvCode},
 		}},
+		{"streaming-result-sse", testdata.StreamingResultSSEDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.StreamingResultSSEServerHandlerInitCode},
+			{"server-sse-send", &testdata.StreamingResultSSEServerSendCode},
+			{"server-sse-close", &testdata.StreamingResultSSEServerCloseCode},
+			{"server-stream-send", nil},
+			{"server-stream-conn-configurer-struct", nil},
+		}},
+		{"streaming-payload-ndjson", testdata.StreamingPayloadNDJSONDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.StreamingPayloadNDJSONServerHandlerInitCode},
+			{"server-ndjson-send", &testdata.StreamingPayloadNDJSONServerSendCode},
+			{"server-ndjson-recv", &testdata.StreamingPayloadNDJSONServerRecvCode},
+			{"server-ndjson-close", nil},
+			{"server-stream-recv", nil},
+		}},
+		{"bidirectional-streaming-ndjson", testdata.BidirectionalStreamingNDJSONDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.BidirectionalStreamingNDJSONServerHandlerInitCode},
+			{"server-ndjson-send", &testdata.BidirectionalStreamingNDJSONServerSendCode},
+			{"server-ndjson-close", &testdata.BidirectionalStreamingNDJSONServerCloseCode},
+			{"server-stream-conn-configurer-struct", nil},
+		}},
 	}
 
 	filesFn := func() []*codegen.File { return ServerFiles("", expr.Root) }
@@ -377,6 +397,24 @@ func TestClientStreaming(t *testing.T) {
 			{"client-stream-send", &testdata.BidirectionalStreamingUserTypeMapClientStreamSendCode},
 			{"client-stream-recv", &testdata.BidirectionalStreamingUserTypeMapClientStreamRecvCode},
 		}},
+		{"streaming-result-sse", testdata.StreamingResultSSEDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.StreamingResultSSEClientEndpointCode},
+			{"client-sse-recv", &testdata.StreamingResultSSEClientRecvCode},
+			{"client-stream-recv", nil},
+			{"client-stream-conn-configurer-struct", nil},
+		}},
+		{"streaming-payload-ndjson", testdata.StreamingPayloadNDJSONDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.StreamingPayloadNDJSONClientEndpointCode},
+			{"client-ndjson-send", &testdata.StreamingPayloadNDJSONClientSendCode},
+			{"client-ndjson-recv", &testdata.StreamingPayloadNDJSONClientRecvCode},
+			{"client-ndjson-close", nil},
+		}},
+		{"bidirectional-streaming-ndjson", testdata.BidirectionalStreamingNDJSONDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.BidirectionalStreamingNDJSONClientEndpointCode},
+			{"client-ndjson-recv", &testdata.BidirectionalStreamingNDJSONClientRecvCode},
+			{"client-ndjson-close", &testdata.BidirectionalStreamingNDJSONClientCloseCode},
+			{"client-stream-send", nil},
+		}},
 	}
 	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
 	runTests(t, cases, filesFn)
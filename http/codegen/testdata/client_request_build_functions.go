@@ -82,3 +82,73 @@ func (c *Client) BuildMethodPathStringDefaultRequest(ctx context.Context, v inte
 	return req, nil
 }
 `
+
+const PathStringFieldMaskRequestBuildCode = `// BuildMethodPathStringFieldMaskRequest instantiates a HTTP request object
+// with method and path set to call the "ServicePathStringFieldMask" service
+// "MethodPathStringFieldMask" endpoint
+func (c *Client) BuildMethodPathStringFieldMaskRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	var (
+		p string
+	)
+	{
+		p, ok := v.(*servicepathstringfieldmask.MethodPathStringFieldMaskPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("ServicePathStringFieldMask", "MethodPathStringFieldMask", "*servicepathstringfieldmask.MethodPathStringFieldMaskPayload", v)
+		}
+		if p.P != nil {
+			p = *p.P
+		}
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodPathStringFieldMaskServicePathStringFieldMaskPath(p)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServicePathStringFieldMask", "MethodPathStringFieldMask", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok && len(mask) > 0 {
+			values := req.URL.Query()
+			values.Set("fields", mask.String())
+			req.URL.RawQuery = values.Encode()
+		}
+	}
+
+	return req, nil
+}
+`
+
+const ConditionalRequestBuildCode = `// BuildMethodConditionalRequest instantiates a HTTP request object with method
+// and path set to call the "ServiceConditional" service "MethodConditional"
+// endpoint
+func (c *Client) BuildMethodConditionalRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodConditionalServiceConditionalPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceConditional", "MethodConditional", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+		goahttp.SetConditionalHeaders(ctx, req)
+	}
+
+	return req, nil
+}
+`
+
+const IdempotentRequestBuildCode = `// BuildMethodIdempotentRequest instantiates a HTTP request object with method
+// and path set to call the "ServiceIdempotent" service "MethodIdempotent"
+// endpoint
+func (c *Client) BuildMethodIdempotentRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodIdempotentServiceIdempotentPath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceIdempotent", "MethodIdempotent", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	goahttp.SetIdempotencyKey(ctx, req)
+
+	return req, nil
+}
+`
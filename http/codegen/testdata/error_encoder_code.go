@@ -86,3 +86,60 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 	}
 }
 `
+
+var ProblemDetailsErrorResponseEncoderCode = `// EncodeMethodProblemDetailsErrorResponseError returns an encoder for errors
+// returned by the MethodProblemDetailsErrorResponse
+// ServiceProblemDetailsErrorResponse endpoint.
+func EncodeMethodProblemDetailsErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "bad_request":
+			res := v.(*goa.ServiceError)
+			body := NewMethodProblemDetailsErrorResponseBadRequestResponseBody(res)
+			w.Header().Set("goa-error", "bad_request")
+			w.Header().Set("Content-Type", goahttp.ProblemContentType)
+			w.WriteHeader(http.StatusBadRequest)
+			return goahttp.EncodeProblem(w, http.StatusBadRequest, "https://goa.design/problems/bad-request", res, body)
+		case "invalid":
+			res := v.(*serviceproblemdetailserrorresponse.Invalid)
+			body := NewMethodProblemDetailsErrorResponseInvalidResponseBody(res)
+			w.Header().Set("goa-error", "invalid")
+			w.Header().Set("Content-Type", goahttp.ProblemContentType)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return goahttp.EncodeProblem(w, http.StatusUnprocessableEntity, "", res, body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
+
+var APIProblemDetailsErrorResponseEncoderCode = `// EncodeMethodAPIProblemDetailsErrorResponseError returns an encoder for
+// errors returned by the MethodAPIProblemDetailsErrorResponse
+// ServiceAPIProblemDetailsErrorResponse endpoint.
+func EncodeMethodAPIProblemDetailsErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ProblemErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "bad_request":
+			res := v.(*goa.ServiceError)
+			body := NewMethodAPIProblemDetailsErrorResponseBadRequestResponseBody(res)
+			w.Header().Set("goa-error", "bad_request")
+			w.Header().Set("Content-Type", goahttp.ProblemContentType)
+			w.WriteHeader(http.StatusBadRequest)
+			return goahttp.EncodeProblem(w, http.StatusBadRequest, "https://goa.design/problems", res, body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
@@ -45,3 +45,42 @@ var ServiceErrorResponseDSL = func() {
 		})
 	})
 }
+
+var ProblemDetailsErrorResponseDSL = func() {
+	var Invalid = Type("Invalid", func() {
+		Attribute("reason", String)
+		Required("reason")
+	})
+	Service("ServiceProblemDetailsErrorResponse", func() {
+		Method("MethodProblemDetailsErrorResponse", func() {
+			Error("bad_request")
+			Error("invalid", Invalid)
+			HTTP(func() {
+				GET("/one/two")
+				Response("bad_request", StatusBadRequest, func() {
+					ProblemDetails("https://goa.design/problems/bad-request")
+				})
+				Response("invalid", StatusUnprocessableEntity, func() {
+					ProblemDetails()
+				})
+			})
+		})
+	})
+}
+
+var APIProblemDetailsErrorResponseDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			ProblemDetails("https://goa.design/problems")
+		})
+	})
+	Service("ServiceAPIProblemDetailsErrorResponse", func() {
+		Method("MethodAPIProblemDetailsErrorResponse", func() {
+			Error("bad_request")
+			HTTP(func() {
+				GET("/one/two")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
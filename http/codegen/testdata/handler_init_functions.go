@@ -195,3 +195,45 @@ func NewMethodPayloadResultErrorHandler(
 	})
 }
 `
+
+var ServerProducesHandlerConstructorCode = `// NewMethodProducesHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceProduces" service "MethodProduces" endpoint.
+func NewMethodProducesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodProducesResponse(enc)
+		encodeError    = EncodeMethodProducesError(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodProduces")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceProduces")
+		produces := []string{"application/xml", "application/json"}
+		if err := goahttp.CheckAcceptable(w, r, produces); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		ctx = context.WithValue(ctx, goahttp.ProducesKey, produces)
+		var err error
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
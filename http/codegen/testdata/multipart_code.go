@@ -72,6 +72,9 @@ var MultipartUserTypeDecoderFuncCode = `// NewServiceMultipartUserTypeMethodMult
 // to decode the multipart request for the "ServiceMultipartUserType" service
 // "MethodMultipartUserType" endpoint.
 func NewServiceMultipartUserTypeMethodMultipartUserTypeDecoder(mux goahttp.Muxer, serviceMultipartUserTypeMethodMultipartUserTypeDecoderFn ServiceMultipartUserTypeMethodMultipartUserTypeDecoderFunc) func(r *http.Request) goahttp.Decoder {
+	if serviceMultipartUserTypeMethodMultipartUserTypeDecoderFn == nil {
+		serviceMultipartUserTypeMethodMultipartUserTypeDecoderFn = DecodeServiceMultipartUserTypeMethodMultipartUserTypeMultipart
+	}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, merr := r.MultipartReader()
@@ -132,6 +135,9 @@ var MultipartWithParamDecoderFuncCode = `// NewServiceMultipartWithParamMethodMu
 // decoder to decode the multipart request for the "ServiceMultipartWithParam"
 // service "MethodMultipartWithParam" endpoint.
 func NewServiceMultipartWithParamMethodMultipartWithParamDecoder(mux goahttp.Muxer, serviceMultipartWithParamMethodMultipartWithParamDecoderFn ServiceMultipartWithParamMethodMultipartWithParamDecoderFunc) func(r *http.Request) goahttp.Decoder {
+	if serviceMultipartWithParamMethodMultipartWithParamDecoderFn == nil {
+		serviceMultipartWithParamMethodMultipartWithParamDecoderFn = DecodeServiceMultipartWithParamMethodMultipartWithParamMultipart
+	}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, merr := r.MultipartReader()
@@ -275,6 +281,9 @@ var MultipartUserTypeEncoderFuncCode = `// NewServiceMultipartUserTypeMethodMult
 // to encode the multipart request for the "ServiceMultipartUserType" service
 // "MethodMultipartUserType" endpoint.
 func NewServiceMultipartUserTypeMethodMultipartUserTypeEncoder(encoderFn ServiceMultipartUserTypeMethodMultipartUserTypeEncoderFunc) func(r *http.Request) goahttp.Encoder {
+	if encoderFn == nil {
+		encoderFn = EncodeServiceMultipartUserTypeMethodMultipartUserTypeMultipart
+	}
 	return func(r *http.Request) goahttp.Encoder {
 		body := &bytes.Buffer{}
 		mw := multipart.NewWriter(body)
@@ -334,6 +343,9 @@ var MultipartWithParamEncoderFuncCode = `// NewServiceMultipartWithParamMethodMu
 // encoder to encode the multipart request for the "ServiceMultipartWithParam"
 // service "MethodMultipartWithParam" endpoint.
 func NewServiceMultipartWithParamMethodMultipartWithParamEncoder(encoderFn ServiceMultipartWithParamMethodMultipartWithParamEncoderFunc) func(r *http.Request) goahttp.Encoder {
+	if encoderFn == nil {
+		encoderFn = EncodeServiceMultipartWithParamMethodMultipartWithParamMultipart
+	}
 	return func(r *http.Request) goahttp.Encoder {
 		body := &bytes.Buffer{}
 		mw := multipart.NewWriter(body)
@@ -370,3 +382,74 @@ func NewServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersE
 	}
 }
 `
+
+var MultipartUserTypeDefaultDecoderFuncCode = `// DecodeServiceMultipartUserTypeMethodMultipartUserTypeMultipart decodes the
+// multipart request for the "ServiceMultipartUserType" service
+// "MethodMultipartUserType" endpoint. It maps the form fields and file parts
+// to the request body attributes with the same names and is used when no
+// decoder function is given to
+// NewServiceMultipartUserTypeMethodMultipartUserTypeDecoder.
+func DecodeServiceMultipartUserTypeMethodMultipartUserTypeMultipart(mr *multipart.Reader, p **servicemultipartusertype.MethodMultipartUserTypePayload) error {
+	var (
+		body MethodMultipartUserTypeRequestBody
+		err  error
+	)
+	if err = goahttp.DecodeMultipart(mr, &body); err != nil {
+		return err
+	}
+	err = ValidateMethodMultipartUserTypeRequestBody(&body)
+	if err != nil {
+		return err
+	}
+	*p = NewMethodMultipartUserTypePayload(&body)
+	return nil
+}
+`
+
+var MultipartWithParamDefaultDecoderFuncCode = `// DecodeServiceMultipartWithParamMethodMultipartWithParamMultipart decodes the
+// multipart request for the "ServiceMultipartWithParam" service
+// "MethodMultipartWithParam" endpoint. It maps the form fields and file parts
+// to the request body attributes with the same names and is used when no
+// decoder function is given to
+// NewServiceMultipartWithParamMethodMultipartWithParamDecoder.
+func DecodeServiceMultipartWithParamMethodMultipartWithParamMultipart(mr *multipart.Reader, p **servicemultipartwithparam.PayloadType) error {
+	var (
+		body MethodMultipartWithParamRequestBody
+		err  error
+	)
+	if err = goahttp.DecodeMultipart(mr, &body); err != nil {
+		return err
+	}
+	err = ValidateMethodMultipartWithParamRequestBody(&body)
+	if err != nil {
+		return err
+	}
+	var c2 map[int][]string
+	*p = NewMethodMultipartWithParamPayloadType(&body, c2)
+	return nil
+}
+`
+
+var MultipartUserTypeDefaultEncoderFuncCode = `// EncodeServiceMultipartUserTypeMethodMultipartUserTypeMultipart encodes the
+// multipart request for the "ServiceMultipartUserType" service
+// "MethodMultipartUserType" endpoint. It writes the request body attributes as
+// form fields and file parts with the same names and is used when no encoder
+// function is given to
+// NewServiceMultipartUserTypeMethodMultipartUserTypeEncoder.
+func EncodeServiceMultipartUserTypeMethodMultipartUserTypeMultipart(mw *multipart.Writer, p *servicemultipartusertype.MethodMultipartUserTypePayload) error {
+	body := NewMethodMultipartUserTypeRequestBody(p)
+	return goahttp.EncodeMultipart(mw, body)
+}
+`
+
+var MultipartWithParamDefaultEncoderFuncCode = `// EncodeServiceMultipartWithParamMethodMultipartWithParamMultipart encodes the
+// multipart request for the "ServiceMultipartWithParam" service
+// "MethodMultipartWithParam" endpoint. It writes the request body attributes
+// as form fields and file parts with the same names and is used when no
+// encoder function is given to
+// NewServiceMultipartWithParamMethodMultipartWithParamEncoder.
+func EncodeServiceMultipartWithParamMethodMultipartWithParamMultipart(mw *multipart.Writer, p *servicemultipartwithparam.PayloadType) error {
+	body := NewMethodMultipartWithParamRequestBody(p)
+	return goahttp.EncodeMultipart(mw, body)
+}
+`
@@ -432,3 +432,192 @@ var WithMapDSL = func() {
 		})
 	})
 }
+
+var WithValidationsDSL = func() {
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("page", Int, func() {
+					ExclusiveMinimum(0)
+					Example(1)
+				})
+				Attribute("ratio", Float64, func() {
+					ExclusiveMaximum(1)
+					MultipleOf(0.25)
+					Example(0.5)
+				})
+				Attribute("tags", ArrayOf(String), func() {
+					UniqueItems()
+					Example([]string{"a", "b"})
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Param("page")
+			})
+		})
+	})
+}
+
+var ErrorOneOfDSL = func() {
+	var NotFound = Type("NotFound", func() {
+		Attribute("id", String, func() {
+			Example("abc")
+		})
+	})
+	var Conflict = Type("Conflict", func() {
+		Attribute("reason", String, func() {
+			Example("exists")
+		})
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("production", func() {
+				Description("Production host.")
+				URI("https://{version}.goa.design/api")
+				Variable("version", String, "API Version", func() {
+					Enum("v1", "v2")
+				})
+			})
+			Host("development", func() {
+				Description("Development host.")
+				URI("http://localhost:8000")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Error("not_found", NotFound)
+			Error("conflict", Conflict)
+			HTTP(func() {
+				PUT("/{id}")
+				Response(StatusNoContent)
+				Response("not_found", StatusBadRequest)
+				Response("conflict", StatusBadRequest)
+			})
+		})
+	})
+}
+
+var ProblemDetailsDSL = func() {
+	var Conflict = Type("Conflict", func() {
+		Attribute("reason", String, func() {
+			Example("exists")
+		})
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	Service("testService", func() {
+		Error("not_found")
+		HTTP(func() {
+			Response("not_found", StatusNotFound, func() {
+				ProblemDetails("https://goa.design/problems/not-found")
+			})
+		})
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Error("conflict", Conflict)
+			HTTP(func() {
+				PUT("/{id}")
+				Response(StatusNoContent)
+				Response("conflict", StatusConflict, func() {
+					ProblemDetails()
+				})
+			})
+		})
+	})
+}
+
+var FormBodyDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("street", String)
+		Attribute("city", String)
+		Required("city")
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("age", Int)
+				Attribute("tags", ArrayOf(String))
+				Attribute("address", Address)
+				Required("name", "address")
+			})
+			HTTP(func() {
+				POST("/")
+				FormBody()
+			})
+		})
+	})
+}
+
+var ProducesDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Result(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Produces("application/xml", "application/json")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ConsumesDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	Service("testService", func() {
+		HTTP(func() {
+			Consumes("application/xml")
+		})
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("testEndpoint2", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/2")
+				Consumes("application/msgpack", "application/json")
+			})
+		})
+	})
+}
@@ -764,9 +764,9 @@ func DecodeMethodQueryArrayBoolValidateRequest(mux goahttp.Muxer, decoder func(*
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", i), e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -835,9 +835,9 @@ func DecodeMethodQueryArrayIntValidateRequest(mux goahttp.Muxer, decoder func(*h
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("q[%v]", i), e, 1, true))
 			}
 		}
 		if err != nil {
@@ -907,9 +907,9 @@ func DecodeMethodQueryArrayInt32ValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("q[%v]", i), e, 1, true))
 			}
 		}
 		if err != nil {
@@ -979,9 +979,9 @@ func DecodeMethodQueryArrayInt64ValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("q[%v]", i), e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1051,9 +1051,9 @@ func DecodeMethodQueryArrayUIntValidateRequest(mux goahttp.Muxer, decoder func(*
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("q[%v]", i), e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1123,9 +1123,9 @@ func DecodeMethodQueryArrayUInt32ValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("q[%v]", i), e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1195,9 +1195,9 @@ func DecodeMethodQueryArrayUInt64ValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("q[%v]", i), e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1267,9 +1267,9 @@ func DecodeMethodQueryArrayFloat32ValidateRequest(mux goahttp.Muxer, decoder fun
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("q[%v]", i), e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1339,9 +1339,9 @@ func DecodeMethodQueryArrayFloat64ValidateRequest(mux goahttp.Muxer, decoder fun
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("q[%v]", i), e, 1, true))
 			}
 		}
 		if err != nil {
@@ -1385,9 +1385,9 @@ func DecodeMethodQueryArrayStringValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", i), e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -1445,9 +1445,9 @@ func DecodeMethodQueryArrayBytesValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if len(e) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[*]", e, len(e), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError(fmt.Sprintf("q[%v]", i), e, len(e), 2, true))
 			}
 		}
 		if err != nil {
@@ -1504,9 +1504,9 @@ func DecodeMethodQueryArrayAnyValidateRequest(mux goahttp.Muxer, decoder func(*h
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == "val" || e == 1) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val", 1}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", i), e, []interface{}{"val", 1}))
 			}
 		}
 		if err != nil {
@@ -1589,7 +1589,7 @@ func DecodeMethodQueryMapStringStringValidateRequest(mux goahttp.Muxer, decoder
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
 			}
 			if !(v == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", k), v, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -1692,7 +1692,7 @@ func DecodeMethodQueryMapStringBoolValidateRequest(mux goahttp.Muxer, decoder fu
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
 			}
 			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", k), v, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -1789,7 +1789,7 @@ func DecodeMethodQueryMapBoolStringValidateRequest(mux goahttp.Muxer, decoder fu
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
 			}
 			if !(v == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", k), v, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -1902,7 +1902,7 @@ func DecodeMethodQueryMapBoolBoolValidateRequest(mux goahttp.Muxer, decoder func
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{false}))
 			}
 			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", k), v, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -1986,7 +1986,7 @@ func DecodeMethodQueryMapStringArrayStringValidateRequest(mux goahttp.Muxer, dec
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError(fmt.Sprintf("q[%v]", k), v, len(v), 2, true))
 			}
 		}
 		if err != nil {
@@ -2096,7 +2096,7 @@ func DecodeMethodQueryMapStringArrayBoolValidateRequest(mux goahttp.Muxer, decod
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError(fmt.Sprintf("q[%v]", k), v, len(v), 2, true))
 			}
 		}
 		if err != nil {
@@ -2193,7 +2193,7 @@ func DecodeMethodQueryMapBoolArrayStringValidateRequest(mux goahttp.Muxer, decod
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError(fmt.Sprintf("q[%v]", k), v, len(v), 2, true))
 			}
 		}
 		if err != nil {
@@ -2312,7 +2312,7 @@ func DecodeMethodQueryMapBoolArrayBoolValidateRequest(mux goahttp.Muxer, decoder
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError(fmt.Sprintf("q[%v]", k), v, len(v), 2, true))
 			}
 		}
 		if err != nil {
@@ -2400,9 +2400,9 @@ func DecodeMethodQueryPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, dec
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", i), e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -2441,9 +2441,9 @@ func DecodeMethodQueryPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decod
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
+		for i, e := range q {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", i), e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -2492,10 +2492,10 @@ func DecodeMethodQueryPrimitiveMapStringArrayStringValidateRequest(mux goahttp.M
 		for k, v := range q {
 			err = goa.MergeErrors(err, goa.ValidatePattern("q.key", k, "key"))
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError(fmt.Sprintf("q[%v]", k), v, len(v), 2, true))
 			}
-			for _, e := range v {
-				err = goa.MergeErrors(err, goa.ValidatePattern("q[key][*]", e, "val"))
+			for i1, e := range v {
+				err = goa.MergeErrors(err, goa.ValidatePattern(fmt.Sprintf("q[%v][%v]", k, i1), e, "val"))
 			}
 		}
 		if err != nil {
@@ -2551,7 +2551,7 @@ func DecodeMethodQueryPrimitiveMapStringBoolValidateRequest(mux goahttp.Muxer, d
 		for k, v := range q {
 			err = goa.MergeErrors(err, goa.ValidatePattern("q.key", k, "key"))
 			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v]", k), v, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -2617,11 +2617,11 @@ func DecodeMethodQueryPrimitiveMapBoolArrayBoolValidateRequest(mux goahttp.Muxer
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
 			}
 			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				err = goa.MergeErrors(err, goa.InvalidLengthError(fmt.Sprintf("q[%v]", k), v, len(v), 2, true))
 			}
-			for _, e := range v {
+			for i1, e := range v {
 				if !(e == false) {
-					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key][*]", e, []interface{}{false}))
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("q[%v][%v]", k, i1), e, []interface{}{false}))
 				}
 			}
 		}
@@ -3048,9 +3048,9 @@ func DecodeMethodPathPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, deco
 		if len(p) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("p", p, len(p), 1, true))
 		}
-		for _, e := range p {
+		for i, e := range p {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("p[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("p[%v]", i), e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -3089,9 +3089,9 @@ func DecodeMethodPathPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decode
 		if len(p) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("p", p, len(p), 1, true))
 		}
-		for _, e := range p {
+		for i, e := range p {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("p[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("p[%v]", i), e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -3172,9 +3172,9 @@ func DecodeMethodHeaderArrayStringValidateRequest(mux goahttp.Muxer, decoder fun
 			err error
 		)
 		h = r.Header["H"]
-		for _, e := range h {
+		for i, e := range h {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("h[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("h[%v]", i), e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -3262,8 +3262,8 @@ func DecodeMethodHeaderPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, de
 		if len(h) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("h", h, len(h), 1, true))
 		}
-		for _, e := range h {
-			err = goa.MergeErrors(err, goa.ValidatePattern("h[*]", e, "val"))
+		for i, e := range h {
+			err = goa.MergeErrors(err, goa.ValidatePattern(fmt.Sprintf("h[%v]", i), e, "val"))
 		}
 		if err != nil {
 			return nil, err
@@ -3301,9 +3301,9 @@ func DecodeMethodHeaderPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, deco
 		if len(h) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("h", h, len(h), 1, true))
 		}
-		for _, e := range h {
+		for i, e := range h {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("h[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("h[%v]", i), e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -3817,9 +3817,9 @@ func DecodeMethodBodyPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, deco
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
+		for i, e := range body {
 			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body[*]", e, []interface{}{"val"}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("body[%v]", i), e, []interface{}{"val"}))
 			}
 		}
 		if err != nil {
@@ -3851,9 +3851,9 @@ func DecodeMethodBodyPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decode
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
+		for i, e := range body {
 			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body[*]", e, []interface{}{true}))
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(fmt.Sprintf("body[%v]", i), e, []interface{}{true}))
 			}
 		}
 		if err != nil {
@@ -3885,10 +3885,10 @@ func DecodeMethodBodyPrimitiveArrayUserValidateRequest(mux goahttp.Muxer, decode
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
+		for i, e := range body {
 			if e != nil {
 				if err2 := ValidatePayloadTypeRequestBody(e); err2 != nil {
-					err = goa.MergeErrors(err, err2)
+					err = goa.MergeErrors(err, goa.NestedError(err2, fmt.Sprintf("body[%v]", i)))
 				}
 			}
 		}
@@ -3967,8 +3967,8 @@ func DecodeMethodBodyPrimitiveArrayUserValidateRequest(mux goahttp.Muxer, decode
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
-			err = goa.MergeErrors(err, goa.ValidatePattern("body[*]", e, "pattern"))
+		for i, e := range body {
+			err = goa.MergeErrors(err, goa.ValidatePattern(fmt.Sprintf("body[%v]", i), e, "pattern"))
 		}
 		if err != nil {
 			return nil, err
@@ -4562,6 +4562,9 @@ func DecodeMethodMultipartPrimitiveRequest(mux goahttp.Muxer, decoder func(*http
 	return func(r *http.Request) (interface{}, error) {
 		var payload string
 		if err := decoder(r).Decode(&payload); err != nil {
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 
@@ -4576,6 +4579,9 @@ func DecodeMethodMultipartUserTypeRequest(mux goahttp.Muxer, decoder func(*http.
 	return func(r *http.Request) (interface{}, error) {
 		var payload *servicemultipartusertype.MethodMultipartUserTypePayload
 		if err := decoder(r).Decode(&payload); err != nil {
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 
@@ -4590,6 +4596,9 @@ func DecodeMethodMultipartArrayTypeRequest(mux goahttp.Muxer, decoder func(*http
 	return func(r *http.Request) (interface{}, error) {
 		var payload []*servicemultipartarraytype.PayloadType
 		if err := decoder(r).Decode(&payload); err != nil {
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 
@@ -4604,6 +4613,9 @@ func DecodeMethodMultipartMapTypeRequest(mux goahttp.Muxer, decoder func(*http.R
 	return func(r *http.Request) (interface{}, error) {
 		var payload map[string]int
 		if err := decoder(r).Decode(&payload); err != nil {
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 
@@ -4691,3 +4703,45 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 	}
 }
 `
+
+const PayloadCookieDecodeCode = `// DecodeMethodCookieRequest returns a decoder for requests sent to the
+// ServiceCookie MethodCookie endpoint.
+func DecodeMethodCookieRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			token  string
+			visits *int
+			err    error
+			c      *http.Cookie
+		)
+		c, _ = r.Cookie("SID")
+		var tokenRaw string
+		if c != nil {
+			tokenRaw = c.Value
+		}
+		if tokenRaw == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("SID", "cookie"))
+		}
+		token = tokenRaw
+		c, _ = r.Cookie("visits")
+		var visitsRaw string
+		if c != nil {
+			visitsRaw = c.Value
+		}
+		if visitsRaw != "" {
+			v, err2 := strconv.ParseInt(visitsRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("visits", visitsRaw, "integer"))
+			}
+			pv := int(v)
+			visits = &pv
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodCookiePayload(token, visits)
+
+		return payload, nil
+	}
+}
+`
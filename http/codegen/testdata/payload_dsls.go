@@ -1405,6 +1405,24 @@ var PayloadPathStringDefaultDSL = func() {
 	})
 }
 
+var PayloadPathStringFieldMaskDSL = func() {
+	Service("ServicePathStringFieldMask", func() {
+		Method("MethodPathStringFieldMask", func() {
+			Payload(func() {
+				Attribute("p", String)
+			})
+			Result(func() {
+				Attribute("a", String)
+				Attribute("b", String)
+			})
+			FieldMask()
+			HTTP(func() {
+				GET("/{p}")
+			})
+		})
+	})
+}
+
 var PayloadPathArrayStringDSL = func() {
 	Service("ServicePathArrayString", func() {
 		Method("MethodPathArrayString", func() {
@@ -2646,3 +2664,36 @@ var WithParamsAndHeadersBlockDSL = func() {
 		})
 	})
 }
+
+var PayloadCookieDSL = func() {
+	Service("ServiceCookie", func() {
+		Method("MethodCookie", func() {
+			Payload(func() {
+				Attribute("token", String)
+				Attribute("visits", Int)
+				Required("token")
+			})
+			HTTP(func() {
+				GET("/")
+				Cookie("token:SID")
+				Cookie("visits")
+			})
+		})
+	})
+}
+
+var PayloadFormBodyDSL = func() {
+	Service("ServiceFormBody", func() {
+		Method("MethodFormBody", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("tags", ArrayOf(String))
+				Required("name")
+			})
+			HTTP(func() {
+				POST("/")
+				FormBody()
+			})
+		})
+	})
+}
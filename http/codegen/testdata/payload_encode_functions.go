@@ -2292,3 +2292,44 @@ func EncodeMethodMultipartMapTypeRequest(encoder func(*http.Request) goahttp.Enc
 	}
 }
 `
+
+const PayloadCookieEncodeCode = `// EncodeMethodCookieRequest returns an encoder for requests sent to the
+// ServiceCookie MethodCookie server.
+func EncodeMethodCookieRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicecookie.MethodCookiePayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceCookie", "MethodCookie", "*servicecookie.MethodCookiePayload", v)
+		}
+		req.AddCookie(&http.Cookie{
+			Name:  "SID",
+			Value: p.Token,
+		})
+		if p.Visits != nil {
+			req.AddCookie(&http.Cookie{
+				Name:  "visits",
+				Value: fmt.Sprintf("%v", *p.Visits),
+			})
+		}
+		return nil
+	}
+}
+`
+
+const PayloadFormBodyEncodeCode = `// EncodeMethodFormBodyRequest returns an encoder for requests sent to the
+// ServiceFormBody MethodFormBody server.
+func EncodeMethodFormBodyRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*serviceformbody.MethodFormBodyPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceFormBody", "MethodFormBody", "*serviceformbody.MethodFormBodyPayload", v)
+		}
+		body := NewMethodFormBodyRequestBody(p)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("ServiceFormBody", "MethodFormBody", err)
+		}
+		return nil
+	}
+}
+`
@@ -538,9 +538,9 @@ func DecodeMethodAResponse(decoder func(*http.Response) goahttp.Decoder, restore
 					}
 				}
 			}
-			for _, e := range array {
+			for i, e := range array {
 				if e < 5 {
-					err = goa.MergeErrors(err, goa.InvalidRangeError("array[*]", e, 5, true))
+					err = goa.MergeErrors(err, goa.InvalidRangeError(fmt.Sprintf("array[%v]", i), e, 5, true))
 				}
 			}
 			if err != nil {
@@ -784,3 +784,179 @@ func DecodeMethodAResponse(decoder func(*http.Response) goahttp.Decoder, restore
 	}
 }
 `
+
+var ProblemDetailsErrorResponseDecodeCode = `// DecodeMethodProblemDetailsErrorResponseResponse returns a decoder for
+// responses returned by the ServiceProblemDetailsErrorResponse
+// MethodProblemDetailsErrorResponse endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeMethodProblemDetailsErrorResponseResponse may return the following
+// errors:
+//   - "bad_request" (type *goa.ServiceError): http.StatusBadRequest
+//   - "invalid" (type *serviceproblemdetailserrorresponse.Invalid): http.StatusUnprocessableEntity
+//   - error: internal error
+func DecodeMethodProblemDetailsErrorResponseResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			return nil, nil
+		case http.StatusBadRequest:
+			var (
+				body MethodProblemDetailsErrorResponseBadRequestResponseBody
+				err  error
+			)
+			err = goahttp.ProblemDecoder(decoder)(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceProblemDetailsErrorResponse", "MethodProblemDetailsErrorResponse", err)
+			}
+			err = ValidateMethodProblemDetailsErrorResponseBadRequestResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceProblemDetailsErrorResponse", "MethodProblemDetailsErrorResponse", err)
+			}
+			return nil, NewMethodProblemDetailsErrorResponseBadRequest(&body)
+		case http.StatusUnprocessableEntity:
+			var (
+				body MethodProblemDetailsErrorResponseInvalidResponseBody
+				err  error
+			)
+			err = goahttp.ProblemDecoder(decoder)(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceProblemDetailsErrorResponse", "MethodProblemDetailsErrorResponse", err)
+			}
+			err = ValidateMethodProblemDetailsErrorResponseInvalidResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceProblemDetailsErrorResponse", "MethodProblemDetailsErrorResponse", err)
+			}
+			return nil, NewMethodProblemDetailsErrorResponseInvalid(&body)
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceProblemDetailsErrorResponse", "MethodProblemDetailsErrorResponse", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+const ConditionalDecodeCode = `// DecodeMethodConditionalResponse returns a decoder for responses returned by
+// the ServiceConditional MethodConditional endpoint. restoreBody controls
+// whether the response body should be restored after having been read.
+func DecodeMethodConditionalResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body MethodConditionalResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceConditional", "MethodConditional", err)
+			}
+			var (
+				etag      *string
+				updatedAt *string
+			)
+			etagRaw := resp.Header.Get("Etag")
+			if etagRaw != "" {
+				etag = &etagRaw
+			}
+			updatedAtRaw := resp.Header.Get("Last-Modified")
+			if updatedAtRaw != "" {
+				updatedAt = &updatedAtRaw
+			}
+			res := NewMethodConditionalResultOK(&body, etag, updatedAt)
+			return res, nil
+		case http.StatusNotModified:
+			return nil, goahttp.ErrNotModified("ServiceConditional", "MethodConditional")
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceConditional", "MethodConditional", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+const ResultCookieDecodeCode = `// DecodeMethodCookieResponse returns a decoder for responses returned by the
+// ServiceCookie MethodCookie endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeMethodCookieResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				token     string
+				tokenRaw  string
+				visits    *int
+				visitsRaw string
+				err       error
+
+				cookies = resp.Cookies()
+			)
+			for _, c := range cookies {
+				switch c.Name {
+				case "SID":
+					tokenRaw = c.Value
+				case "visits":
+					visitsRaw = c.Value
+				}
+			}
+			if tokenRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("SID", "cookie"))
+			}
+			token = tokenRaw
+			{
+				if visitsRaw != "" {
+					v, err2 := strconv.ParseInt(visitsRaw, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("visits", visitsRaw, "integer"))
+					}
+					pv := int(v)
+					visits = &pv
+				}
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceCookie", "MethodCookie", err)
+			}
+			res := NewMethodCookieResultOK(token, visits)
+			return res, nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceCookie", "MethodCookie", resp.StatusCode, string(body))
+		}
+	}
+}
+`
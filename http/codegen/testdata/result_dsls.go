@@ -1234,3 +1234,96 @@ var ValidateErrorResponseTypeDSL = func() {
 		})
 	})
 }
+
+var CursorPaginationResultDSL = func() {
+	Service("ServiceCursorPagination", func() {
+		Method("MethodCursorPagination", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+				Attribute("limit", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next", String)
+			})
+			Paginated(func() {
+				Cursor("cursor", "next")
+				PageSize("limit")
+				Items("items")
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var OffsetPaginationResultDSL = func() {
+	Service("ServiceOffsetPagination", func() {
+		Method("MethodOffsetPagination", func() {
+			Payload(func() {
+				Attribute("offset", Int)
+				Attribute("limit", Int, func() {
+					Default(10)
+				})
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(Int))
+			})
+			Paginated(func() {
+				Offset("offset")
+				PageSize("limit")
+				Items("items")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("limit:size")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ConditionalResultDSL = func() {
+	Service("ServiceConditional", func() {
+		Method("MethodConditional", func() {
+			Result(func() {
+				Attribute("name", String)
+				Attribute("etag", String)
+				Attribute("updated_at", String)
+			})
+			HTTP(func() {
+				GET("/")
+				ETag("etag")
+				LastModified("updated_at")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ResultCookieDSL = func() {
+	Service("ServiceCookie", func() {
+		Method("MethodCookie", func() {
+			Result(func() {
+				Attribute("token", String)
+				Attribute("visits", Int)
+				Required("token")
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Cookie("token:SID")
+					Cookie("visits")
+					CookieMaxAge(3600)
+					CookieSecure()
+					CookieHTTPOnly()
+					CookieSameSite(CookieSameSiteLax)
+					CookiePath("/")
+					CookieDomain("goa.design")
+				})
+			})
+		})
+	})
+}
@@ -993,3 +993,93 @@ func EncodeMethodEmptyServerResponseWithTagsResponse(encoder func(context.Contex
 	}
 }
 `
+
+var CursorPaginationEncodeCode = `// EncodeMethodCursorPaginationResponse returns an encoder for responses
+// returned by the ServiceCursorPagination MethodCursorPagination endpoint.
+func EncodeMethodCursorPaginationResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicecursorpagination.MethodCursorPaginationResult)
+		enc := encoder(ctx, w)
+		body := NewMethodCursorPaginationResponseBody(res)
+		if res.Next != nil {
+			goahttp.SetNextCursorLink(ctx, w, "cursor", *res.Next)
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var OffsetPaginationEncodeCode = `// EncodeMethodOffsetPaginationResponse returns an encoder for responses
+// returned by the ServiceOffsetPagination MethodOffsetPagination endpoint.
+func EncodeMethodOffsetPaginationResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*serviceoffsetpagination.MethodOffsetPaginationResult)
+		enc := encoder(ctx, w)
+		body := NewMethodOffsetPaginationResponseBody(res)
+		goahttp.SetNextOffsetLink(ctx, w, "offset", "size", 10, len(res.Items))
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+const ConditionalEncodeCode = `// EncodeMethodConditionalResponse returns an encoder for responses returned by
+// the ServiceConditional MethodConditional endpoint.
+func EncodeMethodConditionalResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*serviceconditional.MethodConditionalResult)
+		enc := encoder(ctx, w)
+		body := NewMethodConditionalResponseBody(res)
+		if res.Etag != nil {
+			w.Header().Set("Etag", *res.Etag)
+		}
+		if res.UpdatedAt != nil {
+			w.Header().Set("Last-Modified", *res.UpdatedAt)
+		}
+		if goahttp.WriteNotModified(ctx, w) {
+			return nil
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+const ResultCookieEncodeCode = `// EncodeMethodCookieResponse returns an encoder for responses returned by the
+// ServiceCookie MethodCookie endpoint.
+func EncodeMethodCookieResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicecookie.MethodCookieResult)
+		{
+			token := res.Token
+			http.SetCookie(w, &http.Cookie{
+				Name:     "SID",
+				Value:    token,
+				MaxAge:   3600,
+				Path:     "/",
+				Domain:   "goa.design",
+				Secure:   true,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+		if res.Visits != nil {
+			visitsRaw := res.Visits
+			visits := strconv.Itoa(*visitsRaw)
+			http.SetCookie(w, &http.Cookie{
+				Name:     "visits",
+				Value:    visits,
+				MaxAge:   3600,
+				Path:     "/",
+				Domain:   "goa.design",
+				Secure:   true,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+`
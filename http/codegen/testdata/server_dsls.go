@@ -156,3 +156,111 @@ var ServerMultipartDSL = func() {
 		})
 	})
 }
+
+var ServerIdempotentDSL = func() {
+	Service("ServiceIdempotent", func() {
+		Method("MethodIdempotent", func() {
+			Payload(func() {
+				Attribute("amount", Int)
+			})
+			HTTP(func() {
+				POST("/")
+				Idempotent()
+			})
+		})
+		Method("MethodNotIdempotent", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServerProducesDSL = func() {
+	Service("ServiceProduces", func() {
+		HTTP(func() {
+			Produces("application/json")
+		})
+		Method("MethodProduces", func() {
+			Result(func() {
+				Attribute("b", Boolean)
+			})
+			HTTP(func() {
+				GET("/")
+				Produces("application/xml", "application/json")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ServerCORSDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Origin("https://goa.design")
+		})
+	})
+	Service("ServiceCORS", func() {
+		HTTP(func() {
+			Path("/cors")
+			Origin("/.*\\.goa\\.design/", func() {
+				AllowHeaders("X-Shared-Secret")
+				ExposeHeaders("X-Time")
+				MaxAge(600)
+				AllowCredentials()
+			})
+		})
+		Method("MethodList", func() {
+			HTTP(func() {
+				GET("/")
+				Origin("https://goa.design", func() {
+					AllowMethods("GET", "HEAD")
+				})
+			})
+		})
+		Method("MethodCreate", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodOptions", func() {
+			HTTP(func() {
+				OPTIONS("/options")
+			})
+		})
+	})
+}
+
+var ServerCORSMultipleServicesDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Origin("https://goa.design")
+		})
+	})
+	Service("ServiceCORSFirst", func() {
+		Method("MethodList", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/shared/{id}")
+			})
+		})
+	})
+	Service("ServiceCORSSecond", func() {
+		Method("MethodUpdate", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				PUT("/shared/{name}")
+				Origin("https://other.goa.design")
+			})
+		})
+		Method("MethodOwn", func() {
+			HTTP(func() {
+				GET("/own")
+			})
+		})
+	})
+}
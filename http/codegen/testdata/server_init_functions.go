@@ -118,3 +118,111 @@ func New(
 	}
 }
 `
+
+const ServerIdempotentConstructorCode = `// New instantiates HTTP handlers for all the ServiceIdempotent service
+// endpoints.
+func New(
+	e *serviceidempotent.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	is goahttp.IdempotencyStore,
+) *Server {
+	if is == nil {
+		is = goahttp.NewMemoryIdempotencyStore(goahttp.DefaultIdempotencyTTL)
+	}
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodIdempotent", "POST", "/"},
+			{"MethodNotIdempotent", "GET", "/"},
+		},
+		MethodIdempotent:    goahttp.IdempotentHandler(is, enc, NewMethodIdempotentHandler(e.MethodIdempotent, mux, dec, enc, eh)),
+		MethodNotIdempotent: NewMethodNotIdempotentHandler(e.MethodNotIdempotent, mux, dec, enc, eh),
+	}
+}
+`
+
+const ServerCORSMountCode = `// Mount configures the mux to serve the ServiceCORS endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodListHandler(mux, h.MethodList)
+	MountMethodCreateHandler(mux, h.MethodCreate)
+	MountMethodOptionsHandler(mux, h.MethodOptions)
+	MountCORSHandler(mux)
+}
+`
+
+const ServerCORSMountHandlerCode = `// MountMethodListHandler configures the mux to serve the "ServiceCORS" service
+// "MethodList" endpoint.
+func MountMethodListHandler(mux goahttp.Muxer, h http.Handler) {
+	h = goahttp.CORSHandler(h, []*goahttp.CORSPolicy{
+		{
+			Origin:  "https://goa.design",
+			Methods: []string{"GET", "HEAD"},
+		},
+		{
+			OriginRegexp:   regexp.MustCompile("^(?:.*\\.goa\\.design)$"),
+			Methods:        []string{"GET"},
+			Headers:        []string{"X-Shared-Secret"},
+			ExposedHeaders: []string{"X-Time"},
+			MaxAge:         600,
+			Credentials:    true,
+		},
+	})
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/cors", f)
+}
+`
+
+const ServerCORSPreflightCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the ServiceCORS endpoints.
+func MountCORSHandler(mux goahttp.Muxer) {
+	mux.Handle("OPTIONS", "/cors", goahttp.CORSPreflightHandler([]*goahttp.CORSPolicy{
+		{
+			Origin:  "https://goa.design",
+			Methods: []string{"GET", "HEAD", "POST"},
+		},
+		{
+			OriginRegexp:   regexp.MustCompile("^(?:.*\\.goa\\.design)$"),
+			Methods:        []string{"GET", "POST"},
+			Headers:        []string{"X-Shared-Secret"},
+			ExposedHeaders: []string{"X-Time"},
+			MaxAge:         600,
+			Credentials:    true,
+		},
+	}).ServeHTTP)
+}
+`
+
+const ServerCORSMultipleServicesFirstCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the ServiceCORSFirst endpoints.
+func MountCORSHandler(mux goahttp.Muxer) {
+	mux.Handle("OPTIONS", "/shared/{id}", goahttp.CORSPreflightHandler([]*goahttp.CORSPolicy{
+		{
+			Origin:  "https://goa.design",
+			Methods: []string{"GET", "PUT"},
+		},
+		{
+			Origin:  "https://other.goa.design",
+			Methods: []string{"PUT"},
+		},
+	}).ServeHTTP)
+}
+`
+
+const ServerCORSMultipleServicesSecondCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the ServiceCORSSecond endpoints.
+func MountCORSHandler(mux goahttp.Muxer) {
+	mux.Handle("OPTIONS", "/own", goahttp.CORSPreflightHandler([]*goahttp.CORSPolicy{
+		{
+			Origin:  "https://goa.design",
+			Methods: []string{"GET"},
+		},
+	}).ServeHTTP)
+}
+`
@@ -3389,3 +3389,423 @@ func (s *BidirectionalStreamingUserTypeMapMethodClientStream) Recv() (map[string
 	return res, nil
 }
 `
+
+var StreamingResultSSEServerHandlerInitCode = `// NewStreamingResultSSEMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "StreamingResultSSEService" service
+// "StreamingResultSSEMethod" endpoint.
+func NewStreamingResultSSEMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest = DecodeStreamingResultSSEMethodRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultSSEMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultSSEService")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		events := goahttp.NewEventStream(w)
+		v := &streamingresultsseservice.StreamingResultSSEMethodEndpointInput{
+			Stream:  &StreamingResultSSEMethodServerStream{events: events},
+			Payload: payload.(*streamingresultsseservice.Request),
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if events.Started() {
+				// The error is reported in an error event once events
+				// have been sent.
+				events.SendError(err)
+				eh(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var StreamingResultSSEServerSendCode = `// Send streams instances of "streamingresultsseservice.Usertype" to the
+// "StreamingResultSSEMethod" endpoint event stream.
+func (s *StreamingResultSSEMethodServerStream) Send(v *streamingresultsseservice.Usertype) error {
+	if !s.events.Started() {
+		s.events.Header().Set("goa-view", s.view)
+	}
+	res := streamingresultsseservice.NewViewedUsertype(v, s.view)
+	var body interface{}
+	switch s.view {
+	case "tiny":
+		body = NewStreamingResultSSEMethodResponseBodyTiny(res.Projected)
+	case "default", "":
+		body = NewStreamingResultSSEMethodResponseBody(res.Projected)
+	}
+	return s.events.Send(body)
+}
+`
+
+var StreamingResultSSEServerCloseCode = `// Close ends the "StreamingResultSSEMethod" endpoint event stream. The stream
+// is closed once the handler returns.
+func (s *StreamingResultSSEMethodServerStream) Close() error {
+	s.events.Start()
+	return nil
+}
+`
+
+var StreamingResultSSEClientEndpointCode = `// StreamingResultSSEMethod returns an endpoint that makes HTTP requests to the
+// StreamingResultSSEService service StreamingResultSSEMethod server.
+func (c *Client) StreamingResultSSEMethod() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeStreamingResultSSEMethodRequest(c.encoder)
+		decodeResponse = DecodeStreamingResultSSEMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingResultSSEMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", goahttp.EventStreamContentType)
+		resp, err := c.StreamingResultSSEMethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("StreamingResultSSEService", "StreamingResultSSEMethod", err)
+		}
+		if !goahttp.IsEventStream(resp) {
+			return decodeResponse(resp)
+		}
+		stream := &StreamingResultSSEMethodClientStream{events: goahttp.NewEventReader(resp.Body)}
+		view := resp.Header.Get("goa-view")
+		stream.SetView(view)
+		return stream, nil
+	}
+}
+`
+
+var StreamingResultSSEClientRecvCode = `// Recv reads instances of "streamingresultsseservice.Usertype" from the
+// "StreamingResultSSEMethod" endpoint event stream.
+func (s *StreamingResultSSEMethodClientStream) Recv() (*streamingresultsseservice.Usertype, error) {
+	var (
+		rv   *streamingresultsseservice.Usertype
+		body StreamingResultSSEMethodResponseBody
+		err  error
+	)
+	err = s.events.Decode(&body)
+	if err == io.EOF {
+		s.events.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		if serr, ok := err.(*goa.ServiceError); ok {
+			return rv, serr
+		}
+		return rv, goahttp.ErrDecodingError("StreamingResultSSEService", "StreamingResultSSEMethod", err)
+	}
+	res := NewStreamingResultSSEMethodUsertypeOK(&body)
+	vres := &streamingresultsseserviceviews.Usertype{res, s.view}
+	if err := streamingresultsseserviceviews.ValidateUsertype(vres); err != nil {
+		return rv, goahttp.ErrValidationError("StreamingResultSSEService", "StreamingResultSSEMethod", err)
+	}
+	return streamingresultsseservice.NewUsertype(vres), nil
+}
+`
+
+var StreamingPayloadNDJSONServerHandlerInitCode = `// NewStreamingPayloadNDJSONMethodHandler creates a HTTP handler which loads
+// the HTTP request and calls the "StreamingPayloadNDJSONService" service
+// "StreamingPayloadNDJSONMethod" endpoint.
+func NewStreamingPayloadNDJSONMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeError = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingPayloadNDJSONMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingPayloadNDJSONService")
+		var err error
+
+		nw := goahttp.NewNDJSONWriter(w)
+		v := &streamingpayloadndjsonservice.StreamingPayloadNDJSONMethodEndpointInput{
+			Stream: &StreamingPayloadNDJSONMethodServerStream{
+				w: nw,
+				r: goahttp.NewNDJSONReader(r.Body),
+			},
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if nw.Started() {
+				// The error is reported in the response trailer once
+				// values have been sent.
+				nw.SetError(err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		nw.Start()
+	})
+}
+`
+
+var StreamingPayloadNDJSONServerSendCode = `// SendAndClose streams instances of "streamingpayloadndjsonservice.Summary" to
+// the "StreamingPayloadNDJSONMethod" endpoint NDJSON stream and closes the
+// stream.
+func (s *StreamingPayloadNDJSONMethodServerStream) SendAndClose(v *streamingpayloadndjsonservice.Summary) error {
+	res := v
+	body := NewStreamingPayloadNDJSONMethodResponseBody(res)
+	return s.w.Send(body)
+}
+`
+
+var StreamingPayloadNDJSONServerRecvCode = `// Recv reads instances of "streamingpayloadndjsonservice.Request" from the
+// "StreamingPayloadNDJSONMethod" endpoint NDJSON stream.
+func (s *StreamingPayloadNDJSONMethodServerStream) Recv() (*streamingpayloadndjsonservice.Request, error) {
+	var (
+		rv   *streamingpayloadndjsonservice.Request
+		body StreamingPayloadNDJSONMethodStreamingBody
+		err  error
+	)
+	err = s.r.Decode(&body)
+	if err == io.EOF {
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, goa.DecodePayloadError(err.Error())
+	}
+	return NewStreamingPayloadNDJSONMethodStreamingBody(&body), nil
+}
+`
+
+var StreamingPayloadNDJSONClientEndpointCode = `// StreamingPayloadNDJSONMethod returns an endpoint that makes HTTP requests to
+// the StreamingPayloadNDJSONService service StreamingPayloadNDJSONMethod
+// server.
+func (c *Client) StreamingPayloadNDJSONMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeStreamingPayloadNDJSONMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingPayloadNDJSONMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		stream := &StreamingPayloadNDJSONMethodClientStream{
+			req:    goahttp.NewNDJSONRequestStream("StreamingPayloadNDJSONService", "StreamingPayloadNDJSONMethod", c.StreamingPayloadNDJSONMethodDoer, req),
+			decode: decodeResponse,
+		}
+		return stream, nil
+	}
+}
+`
+
+var StreamingPayloadNDJSONClientSendCode = `// Send streams instances of "streamingpayloadndjsonservice.Request" to the
+// "StreamingPayloadNDJSONMethod" endpoint NDJSON stream.
+func (s *StreamingPayloadNDJSONMethodClientStream) Send(v *streamingpayloadndjsonservice.Request) error {
+	body := NewStreamingPayloadNDJSONMethodStreamingBody(v)
+	return s.req.Send(body)
+}
+`
+
+var StreamingPayloadNDJSONClientRecvCode = `// CloseAndRecv stops sending messages to the "StreamingPayloadNDJSONMethod"
+// endpoint NDJSON stream and reads instances of
+// "streamingpayloadndjsonservice.Summary" from the stream.
+func (s *StreamingPayloadNDJSONMethodClientStream) CloseAndRecv() (*streamingpayloadndjsonservice.Summary, error) {
+	var (
+		rv   *streamingpayloadndjsonservice.Summary
+		body StreamingPayloadNDJSONMethodResponseBody
+		err  error
+	)
+	if err = s.req.Close(); err != nil {
+		return rv, err
+	}
+	resp, err := s.req.Response(s.decode)
+	if err != nil {
+		return rv, err
+	}
+	s.r = goahttp.NewNDJSONResponseReader(resp)
+	defer s.r.Close()
+	err = s.r.Decode(&body)
+	if err != nil {
+		if serr, ok := err.(*goa.ServiceError); ok {
+			return rv, serr
+		}
+		return rv, goahttp.ErrDecodingError("StreamingPayloadNDJSONService", "StreamingPayloadNDJSONMethod", err)
+	}
+	res := NewStreamingPayloadNDJSONMethodSummaryNoContent(&body)
+	return res, nil
+}
+`
+
+var BidirectionalStreamingNDJSONServerSendCode = `// Send streams instances of "bidirectionalstreamingndjsonservice.Usertype" to
+// the "BidirectionalStreamingNDJSONMethod" endpoint NDJSON stream.
+func (s *BidirectionalStreamingNDJSONMethodServerStream) Send(v *bidirectionalstreamingndjsonservice.Usertype) error {
+	if !s.w.Started() {
+		s.w.Header().Set("goa-view", s.view)
+	}
+	res := bidirectionalstreamingndjsonservice.NewViewedUsertype(v, s.view)
+	var body interface{}
+	switch s.view {
+	case "tiny":
+		body = NewBidirectionalStreamingNDJSONMethodResponseBodyTiny(res.Projected)
+	case "default", "":
+		body = NewBidirectionalStreamingNDJSONMethodResponseBody(res.Projected)
+	}
+	return s.w.Send(body)
+}
+`
+
+var BidirectionalStreamingNDJSONServerCloseCode = `// Close ends the "BidirectionalStreamingNDJSONMethod" endpoint NDJSON stream.
+// The stream is closed once the handler returns.
+func (s *BidirectionalStreamingNDJSONMethodServerStream) Close() error {
+	s.w.Start()
+	return nil
+}
+`
+
+var BidirectionalStreamingNDJSONClientEndpointCode = `// BidirectionalStreamingNDJSONMethod returns an endpoint that makes HTTP
+// requests to the BidirectionalStreamingNDJSONService service
+// BidirectionalStreamingNDJSONMethod server.
+func (c *Client) BidirectionalStreamingNDJSONMethod() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeBidirectionalStreamingNDJSONMethodRequest(c.encoder)
+		decodeResponse = DecodeBidirectionalStreamingNDJSONMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildBidirectionalStreamingNDJSONMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		stream := &BidirectionalStreamingNDJSONMethodClientStream{
+			req:    goahttp.NewNDJSONRequestStream("BidirectionalStreamingNDJSONService", "BidirectionalStreamingNDJSONMethod", c.BidirectionalStreamingNDJSONMethodDoer, req),
+			decode: decodeResponse,
+		}
+		return stream, nil
+	}
+}
+`
+
+var BidirectionalStreamingNDJSONClientRecvCode = `// Recv reads instances of "bidirectionalstreamingndjsonservice.Usertype" from
+// the "BidirectionalStreamingNDJSONMethod" endpoint NDJSON stream.
+func (s *BidirectionalStreamingNDJSONMethodClientStream) Recv() (*bidirectionalstreamingndjsonservice.Usertype, error) {
+	var (
+		rv   *bidirectionalstreamingndjsonservice.Usertype
+		body BidirectionalStreamingNDJSONMethodResponseBody
+		err  error
+	)
+	if s.r == nil {
+		resp, err := s.req.Response(s.decode)
+		if err != nil {
+			return rv, err
+		}
+		s.view = resp.Header.Get("goa-view")
+		s.r = goahttp.NewNDJSONResponseReader(resp)
+	}
+	err = s.r.Decode(&body)
+	if err == io.EOF {
+		s.r.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		if serr, ok := err.(*goa.ServiceError); ok {
+			return rv, serr
+		}
+		return rv, goahttp.ErrDecodingError("BidirectionalStreamingNDJSONService", "BidirectionalStreamingNDJSONMethod", err)
+	}
+	res := NewBidirectionalStreamingNDJSONMethodUsertypeOK(&body)
+	vres := &bidirectionalstreamingndjsonserviceviews.Usertype{res, s.view}
+	if err := bidirectionalstreamingndjsonserviceviews.ValidateUsertype(vres); err != nil {
+		return rv, goahttp.ErrValidationError("BidirectionalStreamingNDJSONService", "BidirectionalStreamingNDJSONMethod", err)
+	}
+	return bidirectionalstreamingndjsonservice.NewUsertype(vres), nil
+}
+`
+
+var BidirectionalStreamingNDJSONClientCloseCode = `// Close stops sending messages to the "BidirectionalStreamingNDJSONMethod"
+// endpoint NDJSON stream.
+func (s *BidirectionalStreamingNDJSONMethodClientStream) Close() error {
+	return s.req.Close()
+}
+`
+
+var BidirectionalStreamingNDJSONServerHandlerInitCode = `// NewBidirectionalStreamingNDJSONMethodHandler creates a HTTP handler which
+// loads the HTTP request and calls the "BidirectionalStreamingNDJSONService"
+// service "BidirectionalStreamingNDJSONMethod" endpoint.
+func NewBidirectionalStreamingNDJSONMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest = DecodeBidirectionalStreamingNDJSONMethodRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "BidirectionalStreamingNDJSONMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "BidirectionalStreamingNDJSONService")
+		if !goahttp.CheckNDJSONProtocol(w, r) {
+			return
+		}
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		nw := goahttp.NewNDJSONWriter(w)
+		v := &bidirectionalstreamingndjsonservice.BidirectionalStreamingNDJSONMethodEndpointInput{
+			Stream: &BidirectionalStreamingNDJSONMethodServerStream{
+				w: nw,
+				r: goahttp.NewNDJSONReader(r.Body),
+			},
+			Payload: payload.(*bidirectionalstreamingndjsonservice.BidirectionalStreamingNDJSONMethodPayload),
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if nw.Started() {
+				// The error is reported in the response trailer once
+				// values have been sent.
+				nw.SetError(err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		nw.Start()
+	})
+}
+`
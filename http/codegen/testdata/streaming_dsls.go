@@ -754,3 +754,78 @@ var BidirectionalStreamingResultCollectionWithExplicitViewDSL = func() {
 		})
 	})
 }
+
+var StreamingResultSSEDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("x", String)
+	})
+	var Result = ResultType("UserType", func() {
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", Int)
+		})
+		View("tiny", func() {
+			Attribute("a", String)
+		})
+	})
+	Service("StreamingResultSSEService", func() {
+		Method("StreamingResultSSEMethod", func() {
+			Payload(Request)
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/")
+				Param("x")
+				ServerSentEvents()
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var StreamingPayloadNDJSONDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("x", String)
+	})
+	var Summary = Type("Summary", func() {
+		Attribute("total", Int)
+	})
+	Service("StreamingPayloadNDJSONService", func() {
+		Method("StreamingPayloadNDJSONMethod", func() {
+			StreamingPayload(Request)
+			Result(Summary)
+			HTTP(func() {
+				POST("/")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var BidirectionalStreamingNDJSONDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("x", String)
+	})
+	var Result = ResultType("UserType", func() {
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", Int)
+		})
+		View("tiny", func() {
+			Attribute("a", String)
+		})
+	})
+	Service("BidirectionalStreamingNDJSONService", func() {
+		Method("BidirectionalStreamingNDJSONMethod", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			StreamingPayload(Request)
+			StreamingResult(Result)
+			HTTP(func() {
+				POST("/")
+				Header("name")
+				NDJSON()
+			})
+		})
+	})
+}
@@ -75,6 +75,10 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 		})
 		ss = append(ss, "}")
 		return strings.Join(ss, "\n")
+	case *expr.Union:
+		// Unions are encoded as objects with a single key named after the
+		// union value that is set.
+		return goTypeDef(scope, &expr.AttributeExpr{Type: actual.Values}, ptr, useDefault)
 	case expr.UserType:
 		return scope.GoTypeName(att)
 	default:
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// WriteNotModified writes a 304 Not Modified response and returns true if the
+// conditional GET or HEAD request stored in ctx under ConditionalRequestKey
+// matches the validators set in the ETag and Last-Modified headers of w. The
+// If-None-Match header takes precedence over the If-Modified-Since header as
+// described in RFC 7232 section 6. WriteNotModified returns false and does
+// not write anything if the request is not in ctx, is not conditional or if
+// the resource was modified.
+//
+// The view and field mask selected by the client and stored in ctx under the
+// goa.ViewKey and goa.FieldMaskKey keys are appended to the entity tag set in
+// the ETag header so that each representation of the resource has its own
+// entity tag. WriteNotModified also adds Accept to the Vary header when a view
+// is selected as clients may select views with the Accept header.
+func WriteNotModified(ctx context.Context, w http.ResponseWriter) bool {
+	r, ok := ctx.Value(ConditionalRequestKey).(*http.Request)
+	if !ok || r == nil {
+		return false
+	}
+	h := w.Header()
+	view, _ := ctx.Value(goa.ViewKey).(string)
+	mask, _ := ctx.Value(goa.FieldMaskKey).(goa.FieldMask)
+	if etag := h.Get("ETag"); etag != "" {
+		h.Set("ETag", variantETag(etag, view, mask))
+	}
+	if view != "" {
+		AddVary(h, "Accept")
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if !goa.MatchETag(inm, h.Get("ETag"), true) {
+			return false
+		}
+	} else if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		modified, err := http.ParseTime(h.Get("Last-Modified"))
+		if err != nil || modified.Truncate(time.Second).After(since) {
+			return false
+		}
+	} else {
+		return false
+	}
+	h.Del("Content-Type")
+	h.Del("Content-Length")
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// variantETag returns the entity tag of the representation of the resource
+// rendered with the given view and field mask, e.g. the entity tag "v1"
+// becomes "v1;view=tiny;fields=id,name". The entity tag is returned unchanged
+// if neither the view nor the field mask are set.
+func variantETag(etag, view string, mask goa.FieldMask) string {
+	var suffix string
+	if view != "" {
+		suffix += ";view=" + view
+	}
+	if len(mask) > 0 {
+		suffix += ";fields=" + mask.String()
+	}
+	if suffix == "" {
+		return etag
+	}
+	var prefix string
+	if strings.HasPrefix(etag, "W/") {
+		prefix, etag = "W/", etag[2:]
+	}
+	return prefix + `"` + strings.Trim(etag, `"`) + suffix + `"`
+}
+
+// SetConditionalHeaders sets the If-None-Match and If-Modified-Since headers
+// of req to the values stored in ctx under IfNoneMatchKey and
+// IfModifiedSinceKey respectively if any.
+func SetConditionalHeaders(ctx context.Context, req *http.Request) {
+	if etag, ok := ctx.Value(IfNoneMatchKey).(string); ok && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	switch since := ctx.Value(IfModifiedSinceKey).(type) {
+	case string:
+		if since != "" {
+			req.Header.Set("If-Modified-Since", since)
+		}
+	case time.Time:
+		if !since.IsZero() {
+			req.Header.Set("If-Modified-Since", since.UTC().Format(http.TimeFormat))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestWriteNotModified(t *testing.T) {
+	const (
+		etag     = `"v1"`
+		modified = "Mon, 02 Jan 2006 15:04:05 GMT"
+	)
+	cases := []struct {
+		Name     string
+		Method   string
+		Headers  map[string]string
+		Expected bool
+	}{
+		{"unconditional", "GET", nil, false},
+		{"if-none-match", "GET", map[string]string{"If-None-Match": etag}, true},
+		{"if-none-match-weak", "HEAD", map[string]string{"If-None-Match": `W/"v1"`}, true},
+		{"if-none-match-any", "GET", map[string]string{"If-None-Match": "*"}, true},
+		{"if-none-match-mismatch", "GET", map[string]string{"If-None-Match": `"v0"`}, false},
+		{"if-modified-since", "GET", map[string]string{"If-Modified-Since": modified}, true},
+		{"if-modified-since-later", "GET", map[string]string{"If-Modified-Since": "Tue, 03 Jan 2006 15:04:05 GMT"}, true},
+		{"if-modified-since-earlier", "GET", map[string]string{"If-Modified-Since": "Sun, 01 Jan 2006 15:04:05 GMT"}, false},
+		{"if-modified-since-invalid", "GET", map[string]string{"If-Modified-Since": "yesterday"}, false},
+		{"if-none-match-precedence", "GET", map[string]string{"If-None-Match": `"v0"`, "If-Modified-Since": modified}, false},
+		{"not-get", "PUT", map[string]string{"If-None-Match": etag}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest(c.Method, "/", nil)
+			for k, v := range c.Headers {
+				r.Header.Set(k, v)
+			}
+			ctx := context.WithValue(context.Background(), ConditionalRequestKey, r)
+			w := httptest.NewRecorder()
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Last-Modified", modified)
+			w.Header().Set("Content-Type", "application/json")
+			actual := WriteNotModified(ctx, w)
+			if actual != c.Expected {
+				t.Fatalf("got %v, expected %v", actual, c.Expected)
+			}
+			if !actual {
+				return
+			}
+			if w.Code != http.StatusNotModified {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusNotModified)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "" {
+				t.Errorf("got Content-Type %q, expected none", ct)
+			}
+		})
+	}
+}
+
+func TestWriteNotModifiedVariant(t *testing.T) {
+	cases := []struct {
+		Name         string
+		View         string
+		Mask         goa.FieldMask
+		IfNoneMatch  string
+		ExpectedETag string
+		ExpectedVary string
+		Expected     bool
+	}{
+		{"default", "", nil, `"v1"`, `"v1"`, "", true},
+		{"view", "tiny", nil, `"v1;view=tiny"`, `"v1;view=tiny"`, "Accept", true},
+		{"other-view", "default", nil, `"v1;view=tiny"`, `"v1;view=default"`, "Accept", false},
+		{"cached-default", "tiny", nil, `"v1"`, `"v1;view=tiny"`, "Accept", false},
+		{"mask", "", goa.FieldMask{"id", "name"}, `"v1;fields=id,name"`, `"v1;fields=id,name"`, "", true},
+		{"other-mask", "", goa.FieldMask{"id"}, `"v1;fields=id,name"`, `"v1;fields=id"`, "", false},
+		{"view-and-mask", "tiny", goa.FieldMask{"id"}, `W/"v1;view=tiny;fields=id"`, `"v1;view=tiny;fields=id"`, "Accept", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("If-None-Match", c.IfNoneMatch)
+			ctx := context.WithValue(context.Background(), ConditionalRequestKey, r)
+			if c.View != "" {
+				ctx = context.WithValue(ctx, goa.ViewKey, c.View)
+			}
+			if c.Mask != nil {
+				ctx = context.WithValue(ctx, goa.FieldMaskKey, c.Mask)
+			}
+			w := httptest.NewRecorder()
+			w.Header().Set("ETag", `"v1"`)
+			actual := WriteNotModified(ctx, w)
+			if actual != c.Expected {
+				t.Errorf("got %v, expected %v", actual, c.Expected)
+			}
+			if etag := w.Header().Get("ETag"); etag != c.ExpectedETag {
+				t.Errorf("got ETag %q, expected %q", etag, c.ExpectedETag)
+			}
+			if vary := w.Header().Get("Vary"); vary != c.ExpectedVary {
+				t.Errorf("got Vary %q, expected %q", vary, c.ExpectedVary)
+			}
+		})
+	}
+}
+
+func TestWriteNotModifiedNoRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	if WriteNotModified(context.Background(), w) {
+		t.Errorf("got true, expected false")
+	}
+}
+
+func TestSetConditionalHeaders(t *testing.T) {
+	since := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	cases := []struct {
+		Name            string
+		IfNoneMatch     interface{}
+		IfModifiedSince interface{}
+		ExpectedETag    string
+		ExpectedSince   string
+	}{
+		{"none", nil, nil, "", ""},
+		{"etag", `"v1"`, nil, `"v1"`, ""},
+		{"since-string", nil, "Mon, 02 Jan 2006 15:04:05 GMT", "", "Mon, 02 Jan 2006 15:04:05 GMT"},
+		{"since-time", nil, since, "", "Mon, 02 Jan 2006 15:04:05 GMT"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.IfNoneMatch != nil {
+				ctx = context.WithValue(ctx, IfNoneMatchKey, c.IfNoneMatch)
+			}
+			if c.IfModifiedSince != nil {
+				ctx = context.WithValue(ctx, IfModifiedSinceKey, c.IfModifiedSince)
+			}
+			req := httptest.NewRequest("GET", "/", nil)
+			SetConditionalHeaders(ctx, req)
+			if actual := req.Header.Get("If-None-Match"); actual != c.ExpectedETag {
+				t.Errorf("got If-None-Match %q, expected %q", actual, c.ExpectedETag)
+			}
+			if actual := req.Header.Get("If-Modified-Since"); actual != c.ExpectedSince {
+				t.Errorf("got If-Modified-Since %q, expected %q", actual, c.ExpectedSince)
+			}
+		})
+	}
+}
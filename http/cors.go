@@ -0,0 +1,160 @@
+package http
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CORSPolicy describes the cross-origin requests accepted by a HTTP endpoint
+// from a given origin.
+type CORSPolicy struct {
+	// Origin is the allowed origin, "*" allows any origin.
+	Origin string
+	// OriginRegexp is the regular expression matching the allowed
+	// origins if any. Origin is ignored when OriginRegexp is set.
+	OriginRegexp *regexp.Regexp
+	// Methods lists the HTTP methods allowed in preflight requests.
+	Methods []string
+	// Headers lists the request headers allowed in preflight requests,
+	// "*" allows any header.
+	Headers []string
+	// ExposedHeaders lists the response headers exposed to the client.
+	ExposedHeaders []string
+	// MaxAge is the number of seconds the result of preflight requests
+	// may be cached, the Access-Control-Max-Age header is not set if 0.
+	MaxAge int
+	// Credentials indicates that requests may include credentials. It is
+	// ignored if the policy allows any origin.
+	Credentials bool
+}
+
+// MatchOrigin returns true if the policy allows requests made by origin.
+func (p *CORSPolicy) MatchOrigin(origin string) bool {
+	if p.OriginRegexp != nil {
+		return p.OriginRegexp.MatchString(origin)
+	}
+	return p.Origin == "*" || strings.EqualFold(p.Origin, origin)
+}
+
+// CORSHandler returns a handler that adds the CORS headers to the responses
+// of requests made by an origin allowed by one of the policies before calling
+// h. The first policy matching the request Origin header applies.
+func CORSHandler(h http.Handler, policies []*CORSPolicy) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		AddVary(w.Header(), "Origin")
+		if origin := r.Header.Get("Origin"); origin != "" {
+			for _, p := range policies {
+				if p.MatchOrigin(origin) {
+					p.setAllowOrigin(w.Header(), origin)
+					if len(p.ExposedHeaders) > 0 {
+						w.Header().Set("Access-Control-Expose-Headers", strings.Join(p.ExposedHeaders, ", "))
+					}
+					break
+				}
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// CORSPreflightHandler returns a handler that answers the preflight requests
+// made to a route using the first policy that matches the request origin and
+// allows the requested method and headers. The response does not contain
+// any CORS header if no policy applies so that the client rejects the
+// cross-origin request.
+func CORSPreflightHandler(policies []*CORSPolicy) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hdr := w.Header()
+		AddVary(hdr, "Origin")
+		AddVary(hdr, "Access-Control-Request-Method")
+		AddVary(hdr, "Access-Control-Request-Headers")
+		origin := r.Header.Get("Origin")
+		method := r.Header.Get("Access-Control-Request-Method")
+		if origin == "" || method == "" {
+			// Not a preflight request, list the allowed methods.
+			var allow []string
+			for _, p := range policies {
+				for _, m := range p.Methods {
+					if !contains(allow, m) {
+						allow = append(allow, m)
+					}
+				}
+			}
+			hdr.Set("Allow", strings.Join(append(allow, "OPTIONS"), ", "))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		headers := parseHeaderList(r.Header.Get("Access-Control-Request-Headers"))
+		for _, p := range policies {
+			if !p.MatchOrigin(origin) || !contains(p.Methods, method) || !p.allowHeaders(headers) {
+				continue
+			}
+			p.setAllowOrigin(hdr, origin)
+			hdr.Set("Access-Control-Allow-Methods", strings.Join(p.Methods, ", "))
+			if len(headers) > 0 {
+				hdr.Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+			}
+			if p.MaxAge > 0 {
+				hdr.Set("Access-Control-Max-Age", strconv.Itoa(p.MaxAge))
+			}
+			break
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// setAllowOrigin sets the Access-Control-Allow-Origin and
+// Access-Control-Allow-Credentials headers. Policies that allow any origin
+// never allow credentials as required by the CORS specification.
+func (p *CORSPolicy) setAllowOrigin(h http.Header, origin string) {
+	if p.Origin == "*" && p.OriginRegexp == nil {
+		h.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	h.Set("Access-Control-Allow-Origin", origin)
+	if p.Credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// allowHeaders returns true if the policy allows all the given request
+// headers.
+func (p *CORSPolicy) allowHeaders(headers []string) bool {
+	for _, h := range headers {
+		allowed := false
+		for _, ph := range p.Headers {
+			if ph == "*" || strings.EqualFold(ph, h) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// parseHeaderList returns the header names listed in the comma separated
+// value v.
+func parseHeaderList(v string) []string {
+	var headers []string
+	for _, h := range strings.Split(v, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
+
+// contains returns true if vals contains v.
+func contains(vals []string, v string) bool {
+	for _, val := range vals {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
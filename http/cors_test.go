@@ -0,0 +1,134 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+var testCORSPolicies = []*CORSPolicy{
+	{
+		OriginRegexp:   regexp.MustCompile(`^https://.*\.goa\.design$`),
+		Methods:        []string{"GET", "POST"},
+		Headers:        []string{"X-Shared-Secret"},
+		ExposedHeaders: []string{"X-Time", "X-Request-Id"},
+		MaxAge:         600,
+		Credentials:    true,
+	},
+	{
+		Origin:  "https://example.com",
+		Methods: []string{"GET"},
+		Headers: []string{"*"},
+	},
+	{
+		Origin:  "*",
+		Methods: []string{"GET"},
+	},
+}
+
+func TestCORSHandler(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Origin      string
+		AllowOrigin string
+		Credentials string
+		Expose      string
+	}{
+		{"no-origin", "", "", "", ""},
+		{"regexp", "https://api.goa.design", "https://api.goa.design", "true", "X-Time, X-Request-Id"},
+		{"exact", "https://example.com", "https://example.com", "", ""},
+		{"exact-case", "https://Example.com", "https://Example.com", "", ""},
+		{"wildcard", "https://other.com", "*", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var called bool
+			h := CORSHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}), testCORSPolicies)
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.Origin != "" {
+				r.Header.Set("Origin", c.Origin)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if !called {
+				t.Error("handler not called")
+			}
+			if v := w.Header().Get("Vary"); v != "Origin" {
+				t.Errorf("got Vary %q, expected Origin", v)
+			}
+			if v := w.Header().Get("Access-Control-Allow-Origin"); v != c.AllowOrigin {
+				t.Errorf("got Access-Control-Allow-Origin %q, expected %q", v, c.AllowOrigin)
+			}
+			if v := w.Header().Get("Access-Control-Allow-Credentials"); v != c.Credentials {
+				t.Errorf("got Access-Control-Allow-Credentials %q, expected %q", v, c.Credentials)
+			}
+			if v := w.Header().Get("Access-Control-Expose-Headers"); v != c.Expose {
+				t.Errorf("got Access-Control-Expose-Headers %q, expected %q", v, c.Expose)
+			}
+		})
+	}
+}
+
+func TestCORSPreflightHandler(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Origin       string
+		Method       string
+		Headers      string
+		AllowOrigin  string
+		AllowMethods string
+		AllowHeaders string
+		MaxAge       string
+	}{
+		{"regexp", "https://api.goa.design", "POST", "x-shared-secret", "https://api.goa.design", "GET, POST", "x-shared-secret", "600"},
+		{"header-not-allowed", "https://api.goa.design", "POST", "X-Other", "", "", "", ""},
+		{"method-not-allowed", "https://api.goa.design", "DELETE", "", "", "", "", ""},
+		{"any-header", "https://example.com", "GET", "X-Other, X-Shared-Secret", "https://example.com", "GET", "X-Other, X-Shared-Secret", ""},
+		{"wildcard", "https://other.com", "GET", "", "*", "GET", "", ""},
+		{"wildcard-method-not-allowed", "https://other.com", "POST", "", "", "", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("OPTIONS", "/", nil)
+			r.Header.Set("Origin", c.Origin)
+			r.Header.Set("Access-Control-Request-Method", c.Method)
+			if c.Headers != "" {
+				r.Header.Set("Access-Control-Request-Headers", c.Headers)
+			}
+			w := httptest.NewRecorder()
+			CORSPreflightHandler(testCORSPolicies).ServeHTTP(w, r)
+			if w.Code != http.StatusNoContent {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusNoContent)
+			}
+			expected := map[string]string{
+				"Access-Control-Allow-Origin":  c.AllowOrigin,
+				"Access-Control-Allow-Methods": c.AllowMethods,
+				"Access-Control-Allow-Headers": c.AllowHeaders,
+				"Access-Control-Max-Age":       c.MaxAge,
+			}
+			for h, v := range expected {
+				if got := w.Header().Get(h); got != v {
+					t.Errorf("got %s %q, expected %q", h, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestCORSPreflightHandlerNotPreflight(t *testing.T) {
+	r := httptest.NewRequest("OPTIONS", "/", nil)
+	w := httptest.NewRecorder()
+	CORSPreflightHandler(testCORSPolicies).ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusNoContent)
+	}
+	if allow := w.Header().Get("Allow"); allow != "GET, POST, OPTIONS" {
+		t.Errorf("got Allow %q, expected %q", allow, "GET, POST, OPTIONS")
+	}
+	if v := w.Header().Get("Access-Control-Allow-Origin"); v != "" {
+		t.Errorf("got Access-Control-Allow-Origin %q, expected none", v)
+	}
+}
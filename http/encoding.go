@@ -3,9 +3,7 @@ package http
 import (
 	"bytes"
 	"context"
-	"encoding/gob"
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -23,6 +21,28 @@ const (
 	// response Content-Type header when explicitly set in the DSL. The value
 	// may be used by encoders to set the header appropriately.
 	ContentTypeKey
+	// RequestURLKey is the context key used to store the URL of the HTTP
+	// request handled by paginated methods. The value is used to compute
+	// the URL of the next page.
+	RequestURLKey
+	// ConditionalRequestKey is the context key used to store the HTTP
+	// request handled by methods that define ETag or Last-Modified
+	// validators. The value is used to evaluate the If-None-Match and
+	// If-Modified-Since request headers.
+	ConditionalRequestKey
+	// IfNoneMatchKey is the context key used by clients to store the value
+	// of the If-None-Match header of conditional requests, typically the
+	// entity tag returned in the ETag header of a previous response.
+	IfNoneMatchKey
+	// IfModifiedSinceKey is the context key used by clients to store the
+	// value of the If-Modified-Since header of conditional requests. The
+	// value is either a string, typically the Last-Modified header of a
+	// previous response, or a time.Time.
+	IfModifiedSinceKey
+	// ProducesKey is the context key used to store the media types listed
+	// in the design of the endpoint handling the request. The value is used
+	// by ResponseEncoder to negotiate the response content type.
+	ProducesKey
 )
 
 type (
@@ -49,11 +69,9 @@ type (
 )
 
 // RequestDecoder returns a HTTP request body decoder suitable for the given
-// request. The decoder handles the following mime types:
-//
-//     * application/json using package encoding/json
-//     * application/xml using package encoding/xml
-//     * application/gob using package encoding/gob
+// request. The decoder uses the codec registered in Codecs for the media type
+// given by the request "Content-Type" header, see Codecs for the list of
+// media types supported by default.
 //
 // RequestDecoder defaults to the JSON decoder if the request "Content-Type"
 // header does not match any of the supported mime type or is missing
@@ -63,53 +81,31 @@ func RequestDecoder(r *http.Request) Decoder {
 	if contentType == "" {
 		// default to JSON
 		contentType = "application/json"
-	} else {
-		// sanitize
-		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
-			contentType = mediaType
-		}
 	}
-	switch contentType {
-	case "application/json":
-		return json.NewDecoder(r.Body)
-	case "application/gob":
-		return gob.NewDecoder(r.Body)
-	case "application/xml":
-		return xml.NewDecoder(r.Body)
-	case "text/html", "text/plain":
-		return newTextDecoder(r.Body, contentType)
-	default:
-		return json.NewDecoder(r.Body)
+	if dec := Codecs.NewDecoder(contentType, r.Body); dec != nil {
+		return dec
 	}
+	return json.NewDecoder(r.Body)
 }
 
 // ResponseEncoder returns a HTTP response encoder leveraging the mime type
 // set in the context under the AcceptTypeKey or the ContentTypeKey if any.
-// The encoder supports the following mime types:
+// The encoder uses the codecs registered in Codecs, see Codecs for the list
+// of media types supported by default.
 //
-//     * application/json using package encoding/json
-//     * application/xml using package encoding/xml
-//     * application/gob using package encoding/gob
-//     * text/html and text/plain for strings
+// If the ContentTypeKey value is set the encoder is inferred from it.
+// Otherwise ResponseEncoder negotiates the media type using the Accept header
+// stored under AcceptTypeKey: the media types stored under ProducesKey that
+// have a registered codec are ranked using NegotiateContentType and the
+// "Vary" response header is set accordingly. If ProducesKey is not set the
+// JSON, XML and gob media types are negotiated, the other registered media
+// types are only used if the Accept header is exactly one of them.
 //
-// ResponseEncoder defaults to the JSON encoder if the context AcceptTypeKey or
+// ResponseEncoder defaults to the JSON encoder (or to the first supported
+// media type listed in ProducesKey) if the context AcceptTypeKey or
 // ContentTypeKey value does not match any of the supported mime types or is
 // missing altogether.
 func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
-	negotiate := func(a string) (Encoder, string) {
-		switch a {
-		case "", "application/json":
-			// default to JSON
-			return json.NewEncoder(w), "application/json"
-		case "application/xml":
-			return xml.NewEncoder(w), "application/xml"
-		case "application/gob":
-			return gob.NewEncoder(w), "application/gob"
-		case "text/html", "text/plain":
-			return newTextEncoder(w, a), a
-		}
-		return nil, ""
-	}
 	var accept string
 	{
 		if a := ctx.Value(AcceptTypeKey); a != nil {
@@ -132,76 +128,67 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 			// If content type explicitly set in the DSL, infer the response encoder
 			// from the content type context key.
 			if mt, _, err = mime.ParseMediaType(ct); err == nil {
-				switch {
-				case ct == "application/json" || strings.HasSuffix(ct, "+json"):
-					enc = json.NewEncoder(w)
-				case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
-					enc = xml.NewEncoder(w)
-				case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
-					enc = gob.NewEncoder(w)
-				case ct == "text/html" || ct == "text/plain" ||
-					strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
-					enc = newTextEncoder(w, ct)
-				default:
+				if enc = Codecs.NewEncoder(ct, w); enc == nil {
 					enc = json.NewEncoder(w)
 				}
 			}
 			SetContentType(w, mt)
 			return enc
 		}
-		// If Accept header exists in the request, infer the response encoder
-		// from the header value.
-		if enc, mt = negotiate(accept); enc == nil {
-			// attempt to normalize
-			if mt, _, err = mime.ParseMediaType(accept); err == nil {
-				enc, mt = negotiate(mt)
+		offers := []string{"application/json", "application/xml", "application/gob"}
+		if produces, ok := ctx.Value(ProducesKey).([]string); ok {
+			// Negotiate against the same media types as CheckAcceptable.
+			if supported := encodableMediaTypes(produces); len(supported) > 0 {
+				offers = supported
+			}
+		} else if a, _, err := mime.ParseMediaType(accept); err == nil {
+			if c := Codecs.Lookup(a); c != nil && c.NewEncoder != nil && strings.EqualFold(c.MediaType, a) {
+				SetContentType(w, a)
+				return c.NewEncoder(w)
 			}
 		}
-		if enc == nil {
-			enc, mt = negotiate("")
+		// Negotiate the response encoder from the Accept header value.
+		AddVary(w.Header(), "Accept")
+		if mt, _ = NegotiateContentType(accept, offers); mt == "" {
+			mt = offers[0]
 		}
+		enc = Codecs.NewEncoder(mt, w)
 	}
 	SetContentType(w, mt)
 	return enc
 }
 
 // RequestEncoder returns a HTTP request encoder.
-// The encoder uses package encoding/json.
+// The encoder uses the codec registered in Codecs for the media type given
+// by the request Content-Type header if set and package encoding/json
+// otherwise.
 func RequestEncoder(r *http.Request) Encoder {
 	var buf bytes.Buffer
 	r.Body = ioutil.NopCloser(&buf)
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if enc := Codecs.NewEncoder(ct, &buf); enc != nil {
+			return enc
+		}
+	}
 	return json.NewEncoder(&buf)
 }
 
 // ResponseDecoder returns a HTTP response decoder.
-// The decoder handles the following content types:
-//
-//   * application/json using package encoding/json (default)
-//   * application/xml using package encoding/xml
-//   * application/gob using package encoding/gob
-//   * text/html and text/plain for strings
+// The decoder uses the codec registered in Codecs for the media type given
+// by the response Content-Type header, see Codecs for the list of media
+// types supported by default.
 //
+// ResponseDecoder defaults to the JSON decoder if the Content-Type header
+// does not match any of the supported media types or is missing altogether.
 func ResponseDecoder(resp *http.Response) Decoder {
 	ct := resp.Header.Get("Content-Type")
 	if ct == "" {
 		return json.NewDecoder(resp.Body)
 	}
-	if mediaType, _, err := mime.ParseMediaType(ct); err == nil {
-		ct = mediaType
-	}
-	switch {
-	case ct == "application/json" || strings.HasSuffix(ct, "+json"):
-		return json.NewDecoder(resp.Body)
-	case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
-		return xml.NewDecoder(resp.Body)
-	case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
-		return gob.NewDecoder(resp.Body)
-	case ct == "text/html" || ct == "text/plain" ||
-		strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
-		return newTextDecoder(resp.Body, ct)
-	default:
-		return json.NewDecoder(resp.Body)
+	if dec := Codecs.NewDecoder(ct, resp.Body); dec != nil {
+		return dec
 	}
+	return json.NewDecoder(resp.Body)
 }
 
 // ErrorEncoder returns an encoder that encodes errors returned by service
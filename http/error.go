@@ -23,6 +23,9 @@ type (
 		Timeout bool `json:"timeout" xml:"timeout" form:"timeout"`
 		// Fault indicates whether the error is a server-side fault.
 		Fault bool `json:"fault" xml:"fault" form:"fault"`
+		// Violations lists the validation failures that caused the
+		// error if any.
+		Violations []*goa.Violation `json:"violations,omitempty" xml:"violations,omitempty" form:"violations,omitempty"`
 	}
 )
 
@@ -30,17 +33,31 @@ type (
 func NewErrorResponse(err error) *ErrorResponse {
 	if gerr, ok := err.(*goa.ServiceError); ok {
 		return &ErrorResponse{
-			Name:      gerr.Name,
-			ID:        gerr.ID,
-			Message:   gerr.Message,
-			Timeout:   gerr.Timeout,
-			Temporary: gerr.Temporary,
-			Fault:     gerr.Fault,
+			Name:       gerr.Name,
+			ID:         gerr.ID,
+			Message:    gerr.Message,
+			Timeout:    gerr.Timeout,
+			Temporary:  gerr.Temporary,
+			Fault:      gerr.Fault,
+			Violations: gerr.Violations,
 		}
 	}
 	return NewErrorResponse(goa.Fault(err.Error()))
 }
 
+// serviceError returns the service error described by resp.
+func (resp *ErrorResponse) serviceError() *goa.ServiceError {
+	return &goa.ServiceError{
+		Name:       resp.Name,
+		ID:         resp.ID,
+		Message:    resp.Message,
+		Timeout:    resp.Timeout,
+		Temporary:  resp.Temporary,
+		Fault:      resp.Fault,
+		Violations: resp.Violations,
+	}
+}
+
 // StatusCode implements a heuristic that computes a HTTP response status code
 // appropriate for the timeout, temporary and fault characteristics of the
 // error. This method is used by the generated server code when the error is not
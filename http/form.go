@@ -0,0 +1,455 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormContentType is the content type of HTML form posts.
+const FormContentType = "application/x-www-form-urlencoded"
+
+type (
+	// formEncoder encodes structs and maps as form-urlencoded bodies.
+	formEncoder struct {
+		w io.Writer
+	}
+
+	// formDecoder decodes form-urlencoded bodies into structs and maps.
+	formDecoder struct {
+		r io.Reader
+	}
+
+	// formNode is a node of the tree built from the keys of a form. Leaf
+	// nodes hold values, inner nodes hold the nested keys.
+	formNode struct {
+		values   []string
+		children map[string]*formNode
+	}
+)
+
+// NewFormEncoder returns an encoder that writes values as
+// application/x-www-form-urlencoded bodies. The values must be structs or
+// maps, struct fields are named after their "form" tag. Nested objects use
+// bracketed keys (e.g. "address[city]"), arrays of primitive values repeat
+// the key and arrays of objects use indexed keys (e.g. "items[0][name]").
+func NewFormEncoder(w io.Writer) Encoder {
+	return &formEncoder{w}
+}
+
+// NewFormDecoder returns a decoder that reads
+// application/x-www-form-urlencoded bodies. The decoder understands the keys
+// written by the encoder returned by NewFormEncoder as well as dotted keys
+// (e.g. "address.city") and empty brackets (e.g. "tags[]").
+func NewFormDecoder(r io.Reader) Decoder {
+	return &formDecoder{r}
+}
+
+// Encode writes the form encoding of v.
+func (e *formEncoder) Encode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Map {
+		return fmt.Errorf("can't encode %T as %s", v, FormContentType)
+	}
+	values := make(url.Values)
+	if err := encodeForm(values, "", rv); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, values.Encode())
+	return err
+}
+
+// Decode reads the form body and loads it into v which must be a pointer.
+func (d *formDecoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("can't decode %s to %T", FormContentType, v)
+	}
+	b, err := ioutil.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	values, err := url.ParseQuery(string(bytes.TrimSpace(b)))
+	if err != nil {
+		return err
+	}
+	root := &formNode{}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		n := root
+		for _, seg := range formKeySegments(k) {
+			n = n.child(seg)
+		}
+		n.values = append(n.values, values[k]...)
+	}
+	return decodeForm(root, rv, "")
+}
+
+// encodeForm adds the form encoding of v under key to values.
+func encodeForm(values url.Values, key string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return encodeForm(values, key, v.Elem())
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, omitempty, ok := formFieldName(t.Field(i))
+			if !ok {
+				continue
+			}
+			fv := v.Field(i)
+			if omitempty && isEmptyFormValue(fv) {
+				continue
+			}
+			if err := encodeForm(values, formKey(key, name), fv); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = fmt.Sprint(k.Interface())
+		}
+		idx := make([]int, len(keys))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.Slice(idx, func(i, j int) bool { return names[idx[i]] < names[idx[j]] })
+		for _, i := range idx {
+			if err := encodeForm(values, formKey(key, names[i]), v.MapIndex(keys[i])); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			values.Add(key, string(v.Bytes()))
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			elem := v.Index(i)
+			for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+				if elem.IsNil() {
+					break
+				}
+				elem = elem.Elem()
+			}
+			if s, ok := formatFormValue(elem); ok {
+				values.Add(key, s)
+				continue
+			}
+			if err := encodeForm(values, formKey(key, strconv.Itoa(i)), elem); err != nil {
+				return err
+			}
+		}
+	default:
+		s, ok := formatFormValue(v)
+		if !ok {
+			return fmt.Errorf("can't encode %s as %s", v.Type(), FormContentType)
+		}
+		values.Add(key, s)
+	}
+	return nil
+}
+
+// decodeForm loads the values of n into v.
+func decodeForm(n *formNode, v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeForm(n, v.Elem(), path)
+	case reflect.Interface:
+		if v.NumMethod() > 0 {
+			return fmt.Errorf("can't decode %s to %s", FormContentType, v.Type())
+		}
+		v.Set(reflect.ValueOf(n.generic()))
+	case reflect.Struct:
+		if len(n.values) > 0 {
+			return fmt.Errorf("invalid value for %q, expected an object", path)
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, _, ok := formFieldName(t.Field(i))
+			if !ok {
+				continue
+			}
+			c, ok := n.children[name]
+			if !ok {
+				continue
+			}
+			if err := decodeForm(c, v.Field(i), formKey(path, name)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if len(n.values) > 0 {
+			return fmt.Errorf("invalid value for %q, expected an object", path)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for _, name := range n.sortedKeys() {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := parseFormValue(key, name); err != nil {
+				return fmt.Errorf("invalid key %q for %q: %s", name, path, err)
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeForm(n.children[name], elem, formKey(path, name)); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if len(n.values) > 0 {
+				v.SetBytes([]byte(n.values[0]))
+			}
+			return nil
+		}
+		elems := make([]*formNode, 0, len(n.values)+len(n.children))
+		for _, val := range n.values {
+			elems = append(elems, &formNode{values: []string{val}})
+		}
+		indices := make([]int, 0, len(n.children))
+		for k := range n.children {
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 {
+				return fmt.Errorf("invalid index %q for %q", k, path)
+			}
+			indices = append(indices, i)
+		}
+		sort.Ints(indices)
+		for _, i := range indices {
+			elems = append(elems, n.children[strconv.Itoa(i)])
+		}
+		s := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, e := range elems {
+			if err := decodeForm(e, s.Index(i), formKey(path, strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	default:
+		if len(n.children) > 0 {
+			return fmt.Errorf("invalid value for %q, expected a %s", path, v.Type())
+		}
+		if len(n.values) == 0 {
+			return nil
+		}
+		if err := parseFormValue(v, n.values[0]); err != nil {
+			return fmt.Errorf("invalid value %q for %q: %s", n.values[0], path, err)
+		}
+	}
+	return nil
+}
+
+// child returns the child node with the given key, creating it if needed.
+// An empty key designates the node itself so that "tags[]" and "tags" are
+// equivalent.
+func (n *formNode) child(key string) *formNode {
+	if key == "" {
+		return n
+	}
+	if n.children == nil {
+		n.children = make(map[string]*formNode)
+	}
+	c, ok := n.children[key]
+	if !ok {
+		c = &formNode{}
+		n.children[key] = c
+	}
+	return c
+}
+
+// sortedKeys returns the keys of the node children in lexical order.
+func (n *formNode) sortedKeys() []string {
+	keys := make([]string, 0, len(n.children))
+	for k := range n.children {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// generic returns the value of the node when decoded into an empty
+// interface: a map for inner nodes, a string or a slice of strings for
+// leaves.
+func (n *formNode) generic() interface{} {
+	if len(n.children) > 0 {
+		m := make(map[string]interface{}, len(n.children))
+		for k, c := range n.children {
+			m[k] = c.generic()
+		}
+		return m
+	}
+	switch len(n.values) {
+	case 0:
+		return nil
+	case 1:
+		return n.values[0]
+	}
+	vals := make([]interface{}, len(n.values))
+	for i, v := range n.values {
+		vals[i] = v
+	}
+	return vals
+}
+
+// formKeySegments splits a form key into the names of the nested fields it
+// designates, e.g. "a[b][0]" and "a.b.0" both produce "a", "b" and "0".
+func formKeySegments(key string) []string {
+	i := strings.IndexByte(key, '[')
+	if i < 0 {
+		return strings.Split(key, ".")
+	}
+	segs := strings.Split(key[:i], ".")
+	rest := key[i:]
+	for len(rest) > 0 {
+		if rest[0] != '[' {
+			return []string{key}
+		}
+		j := strings.IndexByte(rest, ']')
+		if j < 0 {
+			return []string{key}
+		}
+		segs = append(segs, rest[1:j])
+		rest = rest[j+1:]
+	}
+	return segs
+}
+
+// formKey returns the key of the field name nested in prefix.
+func formKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
+
+// formFieldName returns the form name of the struct field and whether the
+// field tag specifies omitempty. ok is false if the field must be skipped.
+func formFieldName(f reflect.StructField) (name string, omitempty, ok bool) {
+	return taggedFieldName(f, "form")
+}
+
+// taggedFieldName returns the name of the struct field given by the field tag
+// with the given key or the json tag if there is none, and whether the tag
+// specifies omitempty. ok is false if the field must be skipped.
+func taggedFieldName(f reflect.StructField, key string) (name string, omitempty, ok bool) {
+	if f.PkgPath != "" {
+		return "", false, false
+	}
+	tag, found := f.Tag.Lookup(key)
+	if !found {
+		tag, found = f.Tag.Lookup("json")
+	}
+	if tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, true
+}
+
+// formatFormValue returns the string representation of primitive values.
+func formatFormValue(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), true
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true
+	}
+	return "", false
+}
+
+// parseFormValue sets the primitive value v from its string representation.
+func parseFormValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// isEmptyFormValue returns true if v is the zero value of its type using the
+// same rules as encoding/json for omitempty.
+func isEmptyFormValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
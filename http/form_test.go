@@ -0,0 +1,151 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type (
+	formAddress struct {
+		Street *string `form:"street,omitempty" json:"street,omitempty"`
+		City   *string `form:"city,omitempty" json:"city,omitempty"`
+	}
+
+	formItem struct {
+		Name     *string `form:"name,omitempty" json:"name,omitempty"`
+		Quantity *int    `form:"quantity,omitempty" json:"quantity,omitempty"`
+	}
+
+	formBody struct {
+		Name    *string           `form:"name,omitempty" json:"name,omitempty"`
+		Age     *int              `form:"age,omitempty" json:"age,omitempty"`
+		Ratio   float64           `form:"ratio" json:"ratio"`
+		Active  *bool             `form:"active,omitempty" json:"active,omitempty"`
+		Tags    []string          `form:"tags,omitempty" json:"tags,omitempty"`
+		Address *formAddress      `form:"address,omitempty" json:"address,omitempty"`
+		Items   []*formItem       `form:"items,omitempty" json:"items,omitempty"`
+		Labels  map[string]string `form:"labels,omitempty" json:"labels,omitempty"`
+		Raw     []byte            `form:"raw,omitempty" json:"raw,omitempty"`
+	}
+)
+
+func TestFormDecoder(t *testing.T) {
+	var (
+		name   = "joe"
+		age    = 42
+		active = true
+		street = "1 Main St"
+		city   = "Paris"
+		item1  = "a"
+		item2  = "b"
+		qty    = 2
+	)
+	cases := []struct {
+		name     string
+		body     string
+		expected *formBody
+		err      string
+	}{
+		{"empty", "", &formBody{}, ""},
+		{"primitives", "name=joe&age=42&ratio=0.5&active=true", &formBody{Name: &name, Age: &age, Ratio: 0.5, Active: &active}, ""},
+		{"repeated", "tags=a&tags=b", &formBody{Tags: []string{"a", "b"}}, ""},
+		{"brackets", "tags[]=a&tags[]=b", &formBody{Tags: []string{"a", "b"}}, ""},
+		{"indexed", "tags[1]=b&tags[0]=a", &formBody{Tags: []string{"a", "b"}}, ""},
+		{"nested", "address[street]=1+Main+St&address[city]=Paris", &formBody{Address: &formAddress{Street: &street, City: &city}}, ""},
+		{"dotted", "address.street=1+Main+St&address.city=Paris", &formBody{Address: &formAddress{Street: &street, City: &city}}, ""},
+		{"array-of-objects", "items[0][name]=a&items[1][name]=b&items[1][quantity]=2", &formBody{Items: []*formItem{{Name: &item1}, {Name: &item2, Quantity: &qty}}}, ""},
+		{"map", "labels[x]=1&labels[y]=2", &formBody{Labels: map[string]string{"x": "1", "y": "2"}}, ""},
+		{"bytes", "raw=abc", &formBody{Raw: []byte("abc")}, ""},
+		{"unknown", "foo=bar&name=joe", &formBody{Name: &name}, ""},
+		{"invalid-int", "age=abc", nil, `invalid value "abc" for "age"`},
+		{"invalid-object", "address=Paris", nil, `invalid value for "address", expected an object`},
+		{"invalid-index", "items[x][name]=a", nil, `invalid index "x" for "items"`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var body formBody
+			err := NewFormDecoder(strings.NewReader(c.body)).Decode(&body)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("got error %v, expected %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(&body, c.expected) {
+				t.Errorf("got %+v, expected %+v", body, *c.expected)
+			}
+		})
+	}
+}
+
+func TestFormEncoder(t *testing.T) {
+	var (
+		name  = "joe"
+		age   = 42
+		city  = "Paris"
+		item1 = "a"
+		qty   = 2
+	)
+	cases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"empty", &formBody{}, "ratio=0"},
+		{"primitives", &formBody{Name: &name, Age: &age, Ratio: 1.5}, "age=42&name=joe&ratio=1.5"},
+		{"array", formBody{Tags: []string{"a", "b"}}, "ratio=0&tags=a&tags=b"},
+		{"nested", &formBody{Address: &formAddress{City: &city}}, "address%5Bcity%5D=Paris&ratio=0"},
+		{"array-of-objects", &formBody{Items: []*formItem{{Name: &item1, Quantity: &qty}}}, "items%5B0%5D%5Bname%5D=a&items%5B0%5D%5Bquantity%5D=2&ratio=0"},
+		{"map", map[string]interface{}{"b": 2, "a": []int{1, 2}}, "a=1&a=2&b=2"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewFormEncoder(&buf).Encode(c.value); err != nil {
+				t.Fatal(err)
+			}
+			if actual := buf.String(); actual != c.expected {
+				t.Errorf("got %q, expected %q", actual, c.expected)
+			}
+		})
+	}
+	t.Run("not-an-object", func(t *testing.T) {
+		if err := NewFormEncoder(&bytes.Buffer{}).Encode("foo"); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestFormRoundTrip(t *testing.T) {
+	var (
+		name   = "joe"
+		street = "1 Main St"
+		item1  = "a"
+		qty    = 2
+	)
+	body := &formBody{
+		Name:    &name,
+		Tags:    []string{"x", "y"},
+		Address: &formAddress{Street: &street},
+		Items:   []*formItem{{Name: &item1}, {Quantity: &qty}},
+		Labels:  map[string]string{"k": "v"},
+	}
+	req, _ := http.NewRequest("POST", "http://example.com", nil)
+	req.Header.Set("Content-Type", FormContentType)
+	if err := RequestEncoder(req).Encode(body); err != nil {
+		t.Fatal(err)
+	}
+	var decoded formBody
+	if err := RequestDecoder(req).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, body) {
+		t.Errorf("got %+v, expected %+v", decoded, *body)
+	}
+}
@@ -0,0 +1,343 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+const (
+	// IdempotencyKeyHeader is the name of the HTTP header holding the key
+	// that identifies the requests made to idempotent endpoints.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is the name of the HTTP header set to
+	// "true" in responses replayed from the idempotency store.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultIdempotencyTTL is the duration during which the in-memory
+	// store used by default by idempotent endpoints keeps the responses.
+	DefaultIdempotencyTTL = 24 * time.Hour
+)
+
+// ErrIdempotencyKeyInUse is the error returned by IdempotencyStore.Acquire
+// when a request using the same key is being processed.
+var ErrIdempotencyKeyInUse = errors.New("idempotency key in use")
+
+// IdempotentMaxBodySize is the maximum size in bytes of the bodies of the
+// requests made to idempotent endpoints with an idempotency key.
+// IdempotentHandler reads the body to compute the request fingerprint and
+// rejects larger bodies with 413 Request Entity Too Large.
+var IdempotentMaxBodySize int64 = 10 << 20
+
+type (
+	// IdempotencyStore stores the responses of the requests made to
+	// idempotent endpoints so that retries can be replayed. Implementations
+	// must be safe for concurrent use and may be shared across servers,
+	// for example using a database.
+	// The keys given to the store are not the Idempotency-Key header
+	// values: IdempotentHandler scopes them by endpoint and principal so
+	// that the same header value sent to different endpoints or by
+	// different clients identifies different requests.
+	IdempotencyStore interface {
+		// Acquire returns the response stored for key if any. If there
+		// is none Acquire reserves key until Save or Release is called
+		// so that concurrent requests using the same key are rejected.
+		// Acquire returns ErrIdempotencyKeyInUse if key is already
+		// reserved.
+		Acquire(ctx context.Context, key string) (*IdempotentResponse, error)
+		// Save stores the response for key and releases the key.
+		Save(ctx context.Context, key string, resp *IdempotentResponse) error
+		// Release releases key without storing a response so that the
+		// request may be retried.
+		Release(ctx context.Context, key string) error
+	}
+
+	// IdempotentResponse is a response stored in an IdempotencyStore.
+	IdempotentResponse struct {
+		// Fingerprint identifies the request that produced the response.
+		// Requests that reuse a key with a different fingerprint are
+		// rejected.
+		Fingerprint string
+		// StatusCode is the response status code.
+		StatusCode int
+		// Header contains the response headers written by the endpoint
+		// handler.
+		Header http.Header
+		// Body is the response body.
+		Body []byte
+	}
+
+	// memoryIdempotencyStore is an IdempotencyStore that keeps the
+	// responses in memory.
+	memoryIdempotencyStore struct {
+		ttl     time.Duration
+		mu      sync.Mutex
+		entries map[string]*memoryIdempotencyEntry
+	}
+
+	// memoryIdempotencyEntry is an entry of the in-memory store. resp is
+	// nil while the request is being processed.
+	memoryIdempotencyEntry struct {
+		resp    *IdempotentResponse
+		expires time.Time
+	}
+
+	// idempotencyRecorder records the response written by the handler of
+	// an idempotent endpoint.
+	idempotencyRecorder struct {
+		http.ResponseWriter
+		// before contains the headers set prior to calling the
+		// handler, for example by outer middlewares.
+		before http.Header
+		status int
+		header http.Header
+		body   bytes.Buffer
+	}
+)
+
+// NewMemoryIdempotencyStore returns an IdempotencyStore that keeps the
+// responses in memory for the given duration. The store is not shared across
+// processes which makes it mostly suitable for development and tests.
+func NewMemoryIdempotencyStore(ttl time.Duration) IdempotencyStore {
+	return &memoryIdempotencyStore{ttl: ttl, entries: make(map[string]*memoryIdempotencyEntry)}
+}
+
+// IdempotentHandler wraps the handler of an idempotent endpoint. Requests that
+// set the Idempotency-Key header are processed once: the response is saved in
+// store and replayed with the Idempotent-Replayed header set when the request
+// is retried with the same key. Keys are scoped by request method and path
+// and by principal: the Authorization and Cookie headers are part of the key
+// under which the response is stored so that a client may not replay the
+// responses of another. Requests reusing a key with a different URL or body
+// are rejected with 422 Unprocessable Entity and requests made while a request
+// with the same key is being processed with 409 Conflict. Responses with a 5xx
+// status code are not saved so that the request may be retried. Only the
+// headers written by h are saved and replayed, the headers set by outer
+// middlewares such as CORS headers are not. The key is stored in the request context under
+// goa.IdempotencyKeyKey. Requests whose body is larger than
+// IdempotentMaxBodySize are rejected with 413 Request Entity Too Large.
+// IdempotentHandler uses an in-memory store if store is nil and encodes errors
+// with enc.
+func IdempotentHandler(store IdempotencyStore, enc func(context.Context, http.ResponseWriter) Encoder, h http.Handler) http.Handler {
+	if store == nil {
+		store = NewMemoryIdempotencyStore(DefaultIdempotencyTTL)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ctx := context.WithValue(r.Context(), goa.IdempotencyKeyKey, key)
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, IdempotentMaxBodySize+1))
+		if err != nil {
+			idempotencyError(ctx, w, enc, http.StatusBadRequest, goa.DecodePayloadError(err.Error()))
+			return
+		}
+		if int64(len(body)) > IdempotentMaxBodySize {
+			// Close the connection rather than reading the rest of the
+			// body.
+			w.Header().Set("Connection", "close")
+			idempotencyError(ctx, w, enc, http.StatusRequestEntityTooLarge,
+				goa.PermanentError(RequestTooLarge, "request body exceeds %d bytes", IdempotentMaxBodySize))
+			return
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(r, body)
+		skey := idempotencyStoreKey(r, key)
+
+		stored, err := store.Acquire(ctx, skey)
+		if err == ErrIdempotencyKeyInUse {
+			idempotencyError(ctx, w, enc, http.StatusConflict,
+				goa.TemporaryError("idempotency_key_in_use", "a request with idempotency key %q is being processed", key))
+			return
+		}
+		if err != nil {
+			idempotencyError(ctx, w, enc, http.StatusInternalServerError, goa.Fault(err.Error()))
+			return
+		}
+		if stored != nil {
+			if stored.Fingerprint != fingerprint {
+				idempotencyError(ctx, w, enc, http.StatusUnprocessableEntity,
+					goa.PermanentError("idempotency_key_reused", "idempotency key %q was used with a different request", key))
+				return
+			}
+			for k, v := range stored.Header {
+				w.Header()[k] = v
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(stored.StatusCode)
+			w.Write(stored.Body)
+			return
+		}
+
+		saved := false
+		defer func() {
+			if !saved {
+				store.Release(ctx, skey)
+			}
+		}()
+		rec := &idempotencyRecorder{ResponseWriter: w, before: cloneHeader(w.Header())}
+		h.ServeHTTP(rec, r.WithContext(ctx))
+		if rec.status == 0 {
+			rec.WriteHeader(http.StatusOK)
+		}
+		if rec.status >= 500 {
+			return
+		}
+		resp := &IdempotentResponse{
+			Fingerprint: fingerprint,
+			StatusCode:  rec.status,
+			Header:      rec.header,
+			Body:        rec.body.Bytes(),
+		}
+		if err := store.Save(ctx, skey, resp); err == nil {
+			saved = true
+		}
+	})
+}
+
+// SetIdempotencyKey sets the Idempotency-Key header of req to the key stored
+// in ctx under goa.IdempotencyKeyKey or to a new random key if there is none.
+// The generated service clients store a key in the context of each call made
+// to an idempotent endpoint so that the requests made when the call is
+// retried by the client endpoint middlewares share the key.
+func SetIdempotencyKey(ctx context.Context, req *http.Request) {
+	var key string
+	if ctx != nil {
+		key, _ = ctx.Value(goa.IdempotencyKeyKey).(string)
+	}
+	if key == "" {
+		key = goa.NewIdempotencyKey()
+	}
+	req.Header.Set(IdempotencyKeyHeader, key)
+}
+
+// Acquire implements IdempotencyStore.
+func (s *memoryIdempotencyStore) Acquire(_ context.Context, key string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if e, ok := s.entries[key]; ok && (e.resp == nil || now.Before(e.expires)) {
+		if e.resp == nil {
+			return nil, ErrIdempotencyKeyInUse
+		}
+		return e.resp, nil
+	}
+	for k, e := range s.entries {
+		if e.resp != nil && !now.Before(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = &memoryIdempotencyEntry{}
+	return nil, nil
+}
+
+// Save implements IdempotencyStore.
+func (s *memoryIdempotencyStore) Save(_ context.Context, key string, resp *IdempotentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = &memoryIdempotencyEntry{resp: resp, expires: time.Now().Add(s.ttl)}
+	return nil
+}
+
+// Release implements IdempotencyStore.
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok && e.resp == nil {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// WriteHeader records the status code and the headers set by the handler
+// before writing them.
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	if r.status != 0 {
+		return
+	}
+	r.status = status
+	r.header = make(http.Header)
+	for k, v := range r.ResponseWriter.Header() {
+		if !equalValues(r.before[k], v) {
+			r.header[k] = append([]string(nil), v...)
+		}
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records b before writing it.
+func (r *idempotencyRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// idempotencyStoreKey computes the key used to store the response of r in
+// the idempotency store. The key is a hash of the request method and path, of
+// the Authorization and Cookie headers and of the Idempotency-Key header
+// value.
+func idempotencyStoreKey(r *http.Request, key string) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	for _, n := range []string{"Authorization", "Cookie"} {
+		for _, v := range r.Header[n] {
+			io.WriteString(h, n+": "+v+"\n")
+		}
+	}
+	io.WriteString(h, key)
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// requestFingerprint computes a hash of the request method, URL and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyError writes the response for an error raised while handling
+// the idempotency key.
+func idempotencyError(ctx context.Context, w http.ResponseWriter, enc func(context.Context, http.ResponseWriter) Encoder, status int, err error) {
+	e := enc(ctx, w)
+	w.WriteHeader(status)
+	e.Encode(NewErrorResponse(err))
+}
+
+// cloneHeader returns a copy of h.
+func cloneHeader(h http.Header) http.Header {
+	c := make(http.Header, len(h))
+	for k, v := range h {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
+
+// equalValues returns true if a and b contain the same values in the same
+// order.
+func equalValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,232 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestIdempotentHandler(t *testing.T) {
+	var calls int
+	h := IdempotentHandler(nil, ResponseEncoder, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if key, _ := r.Context().Value(goa.IdempotencyKeyKey).(string); key != "abc" {
+			t.Errorf("got key %q in context, expected %q", key, "abc")
+		}
+		w.Header().Set("Location", "/payments/1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	}))
+	do := func(key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/payments", strings.NewReader(body))
+		if key != "" {
+			r.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do("abc", `{"amount":10}`)
+	if w.Code != http.StatusCreated || calls != 1 {
+		t.Fatalf("first request: got status %d and %d calls, expected %d and 1", w.Code, calls, http.StatusCreated)
+	}
+	if w.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("first request: got %s header", IdempotentReplayedHeader)
+	}
+
+	w = do("abc", `{"amount":10}`)
+	if w.Code != http.StatusCreated || calls != 1 {
+		t.Errorf("retry: got status %d and %d calls, expected %d and 1", w.Code, calls, http.StatusCreated)
+	}
+	if w.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("retry: missing %s header", IdempotentReplayedHeader)
+	}
+	if w.Header().Get("Location") != "/payments/1" || w.Body.String() != `{"id":1}` {
+		t.Errorf("retry: got Location %q and body %q", w.Header().Get("Location"), w.Body.String())
+	}
+
+	w = do("abc", `{"amount":20}`)
+	if w.Code != http.StatusUnprocessableEntity || calls != 1 {
+		t.Errorf("reused key: got status %d and %d calls, expected %d and 1", w.Code, calls, http.StatusUnprocessableEntity)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Name != "idempotency_key_reused" {
+		t.Errorf("reused key: got error %q (%v), expected %q", resp.Name, err, "idempotency_key_reused")
+	}
+}
+
+func TestIdempotentHandlerScope(t *testing.T) {
+	var calls int
+	h := IdempotentHandler(nil, ResponseEncoder, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Location", "/payments/1")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	do := func(path, auth, origin string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", path, strings.NewReader(`{"amount":10}`))
+		r.Header.Set(IdempotencyKeyHeader, "abc")
+		r.Header.Set("Authorization", auth)
+		w := httptest.NewRecorder()
+		// Simulate an outer CORS middleware.
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	do("/payments", "Bearer alice", "https://a.example.com")
+	if w := do("/payments", "Bearer bob", "https://a.example.com"); calls != 2 || w.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("other principal: got %d calls and replayed %q, expected 2 calls and no replay", calls, w.Header().Get(IdempotentReplayedHeader))
+	}
+	if w := do("/refunds", "Bearer alice", "https://a.example.com"); calls != 3 || w.Code != http.StatusCreated {
+		t.Errorf("other endpoint: got %d calls and status %d, expected 3 calls and %d", calls, w.Code, http.StatusCreated)
+	}
+	w := do("/payments", "Bearer alice", "https://b.example.com")
+	if calls != 3 || w.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatalf("retry: got %d calls and replayed %q, expected 3 calls and replay", calls, w.Header().Get(IdempotentReplayedHeader))
+	}
+	if o := w.Header().Get("Access-Control-Allow-Origin"); o != "https://b.example.com" {
+		t.Errorf("retry: got Access-Control-Allow-Origin %q, expected the header set by the middleware", o)
+	}
+	if l := w.Header().Get("Location"); l != "/payments/1" {
+		t.Errorf("retry: got Location %q, expected %q", l, "/payments/1")
+	}
+}
+
+func TestIdempotentHandlerNoKey(t *testing.T) {
+	var calls int
+	h := IdempotentHandler(nil, ResponseEncoder, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	for i := 0; i < 2; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", nil))
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, expected 2", calls)
+	}
+}
+
+func TestIdempotentHandlerServerError(t *testing.T) {
+	var calls int
+	h := IdempotentHandler(nil, ResponseEncoder, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.Header.Set(IdempotencyKeyHeader, "abc")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, expected 2", calls)
+	}
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryIdempotencyStore(time.Hour)
+	if resp, err := s.Acquire(ctx, "abc"); resp != nil || err != nil {
+		t.Fatalf("acquire: got %v, %v, expected nil, nil", resp, err)
+	}
+	if _, err := s.Acquire(ctx, "abc"); err != ErrIdempotencyKeyInUse {
+		t.Errorf("acquire in use: got error %v, expected %v", err, ErrIdempotencyKeyInUse)
+	}
+	s.Release(ctx, "abc")
+	if _, err := s.Acquire(ctx, "abc"); err != nil {
+		t.Errorf("acquire released: got error %v", err)
+	}
+	stored := &IdempotentResponse{StatusCode: http.StatusOK}
+	s.Save(ctx, "abc", stored)
+	if resp, err := s.Acquire(ctx, "abc"); resp != stored || err != nil {
+		t.Errorf("acquire saved: got %v, %v, expected %v, nil", resp, err, stored)
+	}
+
+	expired := NewMemoryIdempotencyStore(0)
+	expired.Acquire(ctx, "abc")
+	expired.Save(ctx, "abc", stored)
+	if resp, err := expired.Acquire(ctx, "abc"); resp != nil || err != nil {
+		t.Errorf("acquire expired: got %v, %v, expected nil, nil", resp, err)
+	}
+}
+
+func TestSetIdempotencyKey(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", nil)
+	SetIdempotencyKey(context.Background(), req)
+	if req.Header.Get(IdempotencyKeyHeader) == "" {
+		t.Errorf("got no key, expected a generated key")
+	}
+	ctx := goa.WithIdempotencyKey(context.Background())
+	if goa.WithIdempotencyKey(ctx) != ctx {
+		t.Errorf("got new context, expected the context holding a key to be returned")
+	}
+	req1 := httptest.NewRequest("POST", "/", nil)
+	req2 := httptest.NewRequest("POST", "/", nil)
+	SetIdempotencyKey(ctx, req1)
+	SetIdempotencyKey(ctx, req2)
+	if k1, k2 := req1.Header.Get(IdempotencyKeyHeader), req2.Header.Get(IdempotencyKeyHeader); k1 == "" || k1 != k2 {
+		t.Errorf("got keys %q and %q, expected the same key", k1, k2)
+	}
+}
+
+func TestSetIdempotencyKeyRetry(t *testing.T) {
+	var keys []string
+	endpoint := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := httptest.NewRequest("POST", "/", nil)
+		SetIdempotencyKey(ctx, r)
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		return nil, goa.TemporaryError("unavailable", "service unavailable")
+	}
+	retry := middleware.RetryEndpoint(3, time.Millisecond, nil)(endpoint)
+	if _, err := retry(goa.WithIdempotencyKey(context.Background()), nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(keys) != 3 {
+		t.Fatalf("got %d attempts, expected 3", len(keys))
+	}
+	for _, k := range keys[1:] {
+		if k == "" || k != keys[0] {
+			t.Errorf("got keys %v, expected a single key", keys)
+			break
+		}
+	}
+}
+
+func TestIdempotentHandlerBodyTooLarge(t *testing.T) {
+	defer func(max int64) { IdempotentMaxBodySize = max }(IdempotentMaxBodySize)
+	IdempotentMaxBodySize = 4
+	var calls int
+	h := IdempotentHandler(nil, ResponseEncoder, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	cases := []struct {
+		Name     string
+		Body     string
+		Expected int
+	}{
+		{"limit", "1234", http.StatusOK},
+		{"too-large", "12345", http.StatusRequestEntityTooLarge},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			r.Header.Set(IdempotencyKeyHeader, c.Name)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != c.Expected {
+				t.Errorf("got status %d, expected %d", w.Code, c.Expected)
+			}
+		})
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, expected 1", calls)
+	}
+}
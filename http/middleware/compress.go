@@ -0,0 +1,503 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+// DefaultCompressionMinSize is the default minimum size in bytes of the
+// bodies compressed by the compression middleware and client.
+const DefaultCompressionMinSize = 1024
+
+type (
+	// CompressOption configures the compression middleware and client.
+	CompressOption func(*compressOptions)
+
+	// compressOptions contains the compression settings.
+	compressOptions struct {
+		level           int
+		minSize         int
+		requestEncoding string
+	}
+
+	// compressor is implemented by the gzip and zlib writers.
+	compressor interface {
+		io.WriteCloser
+		Flush() error
+		Reset(io.Writer)
+	}
+
+	// compressWriter is a http.ResponseWriter that compresses the response
+	// body once it is known to be large enough and compressible.
+	compressWriter struct {
+		http.ResponseWriter
+		req      *http.Request
+		encoding string
+		minSize  int
+		pool     *sync.Pool
+		status   int
+		buf      []byte
+		started  bool
+		hijacked bool
+		cw       compressor
+	}
+
+	// compressDoer is a client Doer that compresses requests and
+	// decompresses responses.
+	compressDoer struct {
+		Doer
+		options *compressOptions
+	}
+
+	// decompressBody reads the decompressed content of a body and closes
+	// both the decompressor and the body.
+	decompressBody struct {
+		io.ReadCloser
+		body io.Closer
+	}
+)
+
+// Compress returns a middleware that compresses the response bodies using the
+// gzip or deflate content coding negotiated with the request Accept-Encoding
+// header. Responses smaller than the minimum size (DefaultCompressionMinSize
+// by default), responses that set the Content-Encoding header, partial
+// content responses and responses whose content type is already compressed
+// (images, audio, video and archives) are written as is. Strong ETags of
+// compressed responses are made weak as the compressed content differs from
+// the original representation byte for byte. The middleware also decompresses
+// the bodies of requests whose Content-Encoding header is gzip or deflate
+// transparently.
+//
+// Responses are compressed on the fly so that Flush may be used to stream
+// compressed content, connections may be hijacked as long as no content was
+// written prior.
+//
+// Example:
+//
+//    var handler http.Handler = goahttp.NewMuxer()
+//    handler = middleware.Compress(middleware.CompressionLevel(gzip.BestSpeed))(handler)
+//
+func Compress(opts ...CompressOption) func(http.Handler) http.Handler {
+	o := newCompressOptions(opts)
+	pools := map[string]*sync.Pool{
+		"gzip": {New: func() interface{} {
+			w, _ := gzip.NewWriterLevel(ioutil.Discard, o.level)
+			return w
+		}},
+		"deflate": {New: func() interface{} {
+			w, _ := zlib.NewWriterLevel(ioutil.Discard, o.level)
+			return w
+		}},
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := decompressRequest(r); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			goahttp.AddVary(w.Header(), "Accept-Encoding")
+			enc := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if enc == "" || r.Header.Get("Upgrade") != "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{
+				ResponseWriter: w,
+				req:            r,
+				encoding:       enc,
+				minSize:        o.minSize,
+				pool:           pools[enc],
+			}
+			defer cw.close()
+			h.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// CompressDoer wraps doer so that it advertises the gzip and deflate content
+// codings in the Accept-Encoding header of the requests that do not set it and
+// decompresses the response bodies accordingly. The request bodies are also
+// compressed if the CompressRequests option is given.
+//
+// Example:
+//
+//    var doer goahttp.Doer = &http.Client{}
+//    doer = middleware.CompressDoer(doer, middleware.CompressRequests("gzip"))
+//
+func CompressDoer(doer Doer, opts ...CompressOption) Doer {
+	return &compressDoer{Doer: doer, options: newCompressOptions(opts)}
+}
+
+// CompressionLevel sets the compression level, see package compress/flate.
+// Invalid levels are ignored.
+func CompressionLevel(level int) CompressOption {
+	return func(o *compressOptions) {
+		if level >= flate.HuffmanOnly && level <= flate.BestCompression {
+			o.level = level
+		}
+	}
+}
+
+// CompressionMinSize sets the minimum size in bytes of the bodies that get
+// compressed.
+func CompressionMinSize(size int) CompressOption {
+	return func(o *compressOptions) {
+		o.minSize = size
+	}
+}
+
+// CompressRequests makes the client returned by CompressDoer compress the
+// request bodies using the given content coding, either "gzip" or "deflate".
+// The server must support compressed requests, see Compress.
+func CompressRequests(encoding string) CompressOption {
+	return func(o *compressOptions) {
+		o.requestEncoding = "gzip"
+		if strings.ToLower(encoding) == "deflate" {
+			o.requestEncoding = "deflate"
+		}
+	}
+}
+
+// Do advertises the supported content codings, compresses the request body if
+// configured to and decompresses the response body.
+func (d *compressDoer) Do(r *http.Request) (*http.Response, error) {
+	if r.Header.Get("Accept-Encoding") == "" {
+		r.Header.Set("Accept-Encoding", "gzip, deflate")
+	}
+	if d.options.requestEncoding != "" && r.Body != nil && r.Body != http.NoBody && r.Header.Get("Content-Encoding") == "" {
+		if err := compressRequest(r, d.options); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := d.Doer.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	body, ok, err := newDecompressor(resp.Header.Get("Content-Encoding"), resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("invalid compressed response body: %s", err)
+	}
+	if ok {
+		resp.Body = body
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	return resp, nil
+}
+
+// WriteHeader records the status code, the header is written once the
+// middleware knows whether to compress the response.
+func (w *compressWriter) WriteHeader(code int) {
+	if w.started {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	if !bodyAllowed(code) {
+		w.start(false)
+	}
+}
+
+// Write buffers the content until it reaches the minimum size and compresses
+// it from then on if the response is compressible.
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.started {
+		if w.cw != nil {
+			return w.cw.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.buf = append(w.buf, b...)
+	if len(w.buf) < w.minSize {
+		return len(b), nil
+	}
+	if err := w.start(true); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Flush writes the buffered content, compressing it if the response is
+// compressible regardless of its size, and flushes the underlying writer.
+func (w *compressWriter) Flush() {
+	if !w.started {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		w.start(true)
+	}
+	if w.cw != nil {
+		w.cw.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack supports the http.Hijacker interface.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.started || len(w.buf) > 0 {
+		return nil, nil, fmt.Errorf("cannot hijack connection after writing the response")
+	}
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", w.ResponseWriter)
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		w.hijacked = true
+	}
+	return conn, rw, err
+}
+
+// start writes the response header and the buffered content. The response is
+// compressed if large is true and the response is compressible.
+func (w *compressWriter) start(large bool) error {
+	w.started = true
+	if large && w.compressible() {
+		h := w.Header()
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", w.encoding)
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		w.cw = w.pool.Get().(compressor)
+		w.cw.Reset(w.ResponseWriter)
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if len(w.buf) == 0 {
+		return nil
+	}
+	buf := w.buf
+	w.buf = nil
+	var err error
+	if w.cw != nil {
+		_, err = w.cw.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// close writes the buffered content if any and terminates the compressed
+// stream.
+func (w *compressWriter) close() {
+	if w.hijacked {
+		return
+	}
+	if !w.started {
+		w.start(false)
+	}
+	if w.cw != nil {
+		w.cw.Close()
+		w.pool.Put(w.cw)
+		w.cw = nil
+	}
+}
+
+// compressible returns true if the response may be compressed.
+func (w *compressWriter) compressible() bool {
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" || w.req.Method == "HEAD" || !bodyAllowed(w.status) {
+		return false
+	}
+	if w.status == http.StatusPartialContent || h.Get("Content-Range") != "" {
+		// Compressing would invalidate the byte ranges.
+		return false
+	}
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		ct = http.DetectContentType(w.buf)
+	}
+	return !isCompressedContentType(ct)
+}
+
+// Close closes the decompressor and the body.
+func (b *decompressBody) Close() error {
+	err := b.ReadCloser.Close()
+	if cerr := b.body.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// bodyAllowed returns true if responses with the given status code may have
+// a body.
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// isCompressedContentType returns true if the content of the given type is
+// already compressed.
+func isCompressedContentType(ct string) bool {
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mt == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mt, "image/"), strings.HasPrefix(mt, "audio/"), strings.HasPrefix(mt, "video/"):
+		return true
+	}
+	switch mt {
+	case "application/gzip", "application/x-gzip", "application/zip", "application/x-bzip2",
+		"application/x-7z-compressed", "application/x-rar-compressed", "application/x-xz",
+		"application/zstd", "font/woff", "font/woff2":
+		return true
+	}
+	return false
+}
+
+// negotiateEncoding returns the content coding of the response given the
+// request Accept-Encoding header value, the empty string if the response must
+// not be compressed. gzip is preferred over deflate when both are equally
+// acceptable.
+func negotiateEncoding(accept string) string {
+	if accept == "" {
+		return ""
+	}
+	qs := make(map[string]float64)
+	for _, entry := range strings.Split(accept, ",") {
+		parts := strings.Split(entry, ";")
+		coding := strings.ToLower(strings.TrimSpace(parts[0]))
+		q := 1.0
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				v, err := strconv.ParseFloat(p[2:], 64)
+				if err != nil {
+					v = 0
+				}
+				q = v
+			}
+		}
+		if coding == "x-gzip" {
+			coding = "gzip"
+		}
+		qs[coding] = q
+	}
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, enc := range []string{"gzip", "deflate"} {
+		q, ok := qs[enc]
+		if !ok {
+			q = qs["*"]
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// decompressRequest replaces the body of r with a reader that decompresses it
+// if the request Content-Encoding header is gzip or deflate.
+func decompressRequest(r *http.Request) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	body, ok, err := newDecompressor(r.Header.Get("Content-Encoding"), r.Body)
+	if err != nil {
+		return fmt.Errorf("invalid compressed request body: %s", err)
+	}
+	if ok {
+		r.Body = body
+		r.Header.Del("Content-Encoding")
+		r.Header.Del("Content-Length")
+		r.ContentLength = -1
+	}
+	return nil
+}
+
+// compressRequest replaces the body of r with its compressed content if it is
+// at least as large as the minimum size.
+func compressRequest(r *http.Request, o *compressOptions) error {
+	b, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return err
+	}
+	if len(b) >= o.minSize {
+		var (
+			buf bytes.Buffer
+			cw  compressor
+		)
+		if o.requestEncoding == "deflate" {
+			cw, _ = zlib.NewWriterLevel(&buf, o.level)
+		} else {
+			cw, _ = gzip.NewWriterLevel(&buf, o.level)
+		}
+		if _, err := cw.Write(b); err != nil {
+			return err
+		}
+		if err := cw.Close(); err != nil {
+			return err
+		}
+		b = buf.Bytes()
+		r.Header.Set("Content-Encoding", o.requestEncoding)
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	r.ContentLength = int64(len(b))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	return nil
+}
+
+// newDecompressor returns a reader that decompresses body given the value of
+// the Content-Encoding header. ok is false if the content coding is not gzip
+// or deflate or if body is empty.
+func newDecompressor(encoding string, body io.ReadCloser) (r io.ReadCloser, ok bool, err error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(body)
+	case "deflate":
+		r, err = zlib.NewReader(body)
+	default:
+		return nil, false, nil
+	}
+	if err == io.EOF {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &decompressBody{ReadCloser: r, body: body}, true, nil
+}
+
+// newCompressOptions returns the compression settings given the options.
+func newCompressOptions(opts []CompressOption) *compressOptions {
+	o := &compressOptions{level: gzip.DefaultCompression, minSize: DefaultCompressionMinSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,292 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+func TestCompress(t *testing.T) {
+	large := strings.Repeat("goa ", 1024)
+	cases := []struct {
+		Name           string
+		Method         string
+		AcceptEncoding string
+		ContentType    string
+		Status         int
+		Body           string
+		Expected       string
+	}{
+		{"gzip", "GET", "gzip", "application/json", http.StatusOK, large, "gzip"},
+		{"deflate", "GET", "deflate", "application/json", http.StatusOK, large, "deflate"},
+		{"x-gzip", "GET", "x-gzip", "application/json", http.StatusOK, large, "gzip"},
+		{"prefer-gzip", "GET", "deflate, gzip", "application/json", http.StatusOK, large, "gzip"},
+		{"quality", "GET", "gzip;q=0.5, deflate", "application/json", http.StatusOK, large, "deflate"},
+		{"wildcard", "GET", "*", "application/json", http.StatusOK, large, "gzip"},
+		{"excluded", "GET", "gzip;q=0, *", "application/json", http.StatusOK, large, "deflate"},
+		{"not-acceptable", "GET", "br", "application/json", http.StatusOK, large, ""},
+		{"no-accept-encoding", "GET", "", "application/json", http.StatusOK, large, ""},
+		{"small", "GET", "gzip", "application/json", http.StatusOK, "small", ""},
+		{"compressed-type", "GET", "gzip", "image/png", http.StatusOK, large, ""},
+		{"detected-type", "GET", "gzip", "", http.StatusOK, large, "gzip"},
+		{"head", "HEAD", "gzip", "application/json", http.StatusOK, large, ""},
+		{"no-content", "GET", "gzip", "application/json", http.StatusNoContent, "", ""},
+		{"error", "GET", "gzip", "application/json", http.StatusBadRequest, large, "gzip"},
+		{"partial-content", "GET", "gzip", "application/json", http.StatusPartialContent, large, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := httpm.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if c.ContentType != "" {
+					w.Header().Set("Content-Type", c.ContentType)
+				}
+				w.WriteHeader(c.Status)
+				// Write in two steps to exercise buffering.
+				io.WriteString(w, c.Body[:len(c.Body)/2])
+				io.WriteString(w, c.Body[len(c.Body)/2:])
+			}))
+			r := httptest.NewRequest(c.Method, "/", nil)
+			if c.AcceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", c.AcceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+				t.Errorf("got Vary %q, expected Accept-Encoding", vary)
+			}
+			if ce := w.Header().Get("Content-Encoding"); ce != c.Expected {
+				t.Fatalf("got Content-Encoding %q, expected %q", ce, c.Expected)
+			}
+			if body := decompress(t, c.Expected, w.Body); body != c.Body {
+				t.Errorf("got body of length %d, expected %d", len(body), len(c.Body))
+			}
+		})
+	}
+}
+
+func TestCompressEncodedResponse(t *testing.T) {
+	h := httpm.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "br")
+		w.Write(bytes.Repeat([]byte{1}, 2048))
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if ce := w.Header().Get("Content-Encoding"); ce != "br" {
+		t.Errorf("got Content-Encoding %q, expected br", ce)
+	}
+	if w.Body.Len() != 2048 {
+		t.Errorf("got body of length %d, expected 2048", w.Body.Len())
+	}
+}
+
+func TestCompressContentRange(t *testing.T) {
+	h := httpm.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Range", "bytes 0-2047/4096")
+		w.Write(bytes.Repeat([]byte{'a'}, 2048))
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if ce := w.Header().Get("Content-Encoding"); ce != "" {
+		t.Errorf("got Content-Encoding %q, expected none", ce)
+	}
+	if w.Body.Len() != 2048 {
+		t.Errorf("got body of length %d, expected 2048", w.Body.Len())
+	}
+}
+
+func TestCompressETag(t *testing.T) {
+	cases := []struct {
+		Name     string
+		ETag     string
+		Expected string
+	}{
+		{"strong", `"v1"`, `W/"v1"`},
+		{"weak", `W/"v1"`, `W/"v1"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := httpm.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("ETag", c.ETag)
+				w.Write(bytes.Repeat([]byte{'a'}, 2048))
+			}))
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept-Encoding", "gzip")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if etag := w.Header().Get("ETag"); etag != c.Expected {
+				t.Errorf("got ETag %q, expected %q", etag, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCompressFlush(t *testing.T) {
+	h := httpm.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "data: 1\n\n")
+		w.(http.Flusher).Flush()
+		io.WriteString(w, "data: 2\n\n")
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if !w.Flushed {
+		t.Error("response was not flushed")
+	}
+	if ce := w.Header().Get("Content-Encoding"); ce != "gzip" {
+		t.Fatalf("got Content-Encoding %q, expected gzip", ce)
+	}
+	if body := decompress(t, "gzip", w.Body); body != "data: 1\n\ndata: 2\n\n" {
+		t.Errorf("got body %q", body)
+	}
+}
+
+func TestCompressCapture(t *testing.T) {
+	var capture *httpm.ResponseCapture
+	h := httpm.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(bytes.Repeat([]byte("a"), 4096))
+	}))
+	logged := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capture = httpm.CaptureResponse(w)
+		h.ServeHTTP(capture, r)
+	})
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	logged.ServeHTTP(w, r)
+	if capture.StatusCode != http.StatusOK {
+		t.Errorf("got captured status %d, expected 200", capture.StatusCode)
+	}
+	if capture.ContentLength != w.Body.Len() || capture.ContentLength >= 4096 {
+		t.Errorf("got captured length %d, expected compressed length %d", capture.ContentLength, w.Body.Len())
+	}
+}
+
+func TestCompressRequestBody(t *testing.T) {
+	const content = `{"name":"goa"}`
+	cases := []struct {
+		Name     string
+		Encoding string
+		Body     []byte
+		Status   int
+	}{
+		{"gzip", "gzip", compress(t, "gzip", content), http.StatusOK},
+		{"deflate", "deflate", compress(t, "deflate", content), http.StatusOK},
+		{"identity", "", []byte(content), http.StatusOK},
+		{"invalid", "gzip", []byte(content), http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := httpm.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(b) != content {
+					t.Errorf("got body %q, expected %q", b, content)
+				}
+				if ce := r.Header.Get("Content-Encoding"); ce != "" {
+					t.Errorf("got Content-Encoding %q, expected none", ce)
+				}
+			}))
+			r := httptest.NewRequest("POST", "/", bytes.NewReader(c.Body))
+			if c.Encoding != "" {
+				r.Header.Set("Content-Encoding", c.Encoding)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+		})
+	}
+}
+
+func TestCompressDoer(t *testing.T) {
+	large := strings.Repeat("goa ", 1024)
+	var requestEncoding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestEncoding = r.Header.Get("Content-Encoding")
+		httpm.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(w, r.Body)
+		})).ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	doer := httpm.CompressDoer(http.DefaultClient, httpm.CompressRequests("deflate"))
+	req, _ := http.NewRequest("POST", srv.URL, strings.NewReader(large))
+	resp, err := doer.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if requestEncoding != "deflate" {
+		t.Errorf("got request Content-Encoding %q, expected deflate", requestEncoding)
+	}
+	if ae := req.Header.Get("Accept-Encoding"); ae != "gzip, deflate" {
+		t.Errorf("got Accept-Encoding %q, expected %q", ae, "gzip, deflate")
+	}
+	if !resp.Uncompressed || resp.Header.Get("Content-Encoding") != "" {
+		t.Errorf("response was not decompressed")
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != large {
+		t.Errorf("got body of length %d, expected %d", len(b), len(large))
+	}
+}
+
+func compress(t *testing.T, encoding, content string) []byte {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+	if encoding == "deflate" {
+		w = zlib.NewWriter(&buf)
+	} else {
+		w = gzip.NewWriter(&buf)
+	}
+	io.WriteString(w, content)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decompress(t *testing.T, encoding string, body io.Reader) string {
+	var (
+		r   io.Reader = body
+		err error
+	)
+	switch encoding {
+	case "gzip":
+		r, err = gzip.NewReader(body)
+	case "deflate":
+		r, err = zlib.NewReader(body)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -8,6 +8,9 @@ The package contains the following middlewares:
     a HTTP request.
   * Tracing middleware for server and client.
   * AWS X-Ray middleware for server and client that produce X-Ray segments.
+  * Compression middleware for server and client that compress responses and
+    request bodies using gzip or deflate.
+  * Recover server middleware that converts panics into internal errors.
 
 Example to use the server middleware:
 
@@ -18,6 +21,7 @@ Example to use the client middleware:
 
     var doer goahttp.Doer = &http.Client{}
     doer = xray.WrapDoer(doer)
+    doer = middleware.CompressDoer(doer, middleware.CompressRequests("gzip"))
 
 */
 package middleware
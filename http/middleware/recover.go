@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+	"goa.design/goa/v3/middleware/xray"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Recover returns a middleware that recovers from panics raised by the
+// handler and converts them into goa.Fault errors. The middleware logs the
+// panic value and stack trace with l if not nil, records the panic in the
+// X-Ray segment stored in the request context if any and writes the error
+// response using encodeError. The error message only includes the request ID
+// so that the panic value does not leak to clients.
+//
+// encodeError is typically the function returned by goahttp.ErrorEncoder or
+// goahttp.ProblemErrorEncoder given the encoder used to create the service
+// HTTP servers, goahttp.ErrorEncoder(goahttp.ResponseEncoder) is used if nil.
+// The error response is not written if the handler already started writing
+// the response. Panics raised with the http.ErrAbortHandler value are not
+// recovered so that the HTTP server aborts the response.
+//
+// Recover must wrap the handler before the RequestID and X-Ray middlewares so
+// that the request ID is logged and the panic recorded in the request segment.
+//
+// Example:
+//
+//    var handler http.Handler = goahttp.NewMuxer()
+//    handler = middleware.Recover(logger, nil)(handler)
+//    handler = middleware.RequestID()(handler)
+//
+func Recover(l middleware.Logger, encodeError func(context.Context, http.ResponseWriter, error) error) func(http.Handler) http.Handler {
+	if encodeError == nil {
+		encodeError = goahttp.ErrorEncoder(goahttp.ResponseEncoder)
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := CaptureResponse(w)
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				ctx := r.Context()
+				reqID := ctx.Value(middleware.RequestIDKey)
+				if reqID == nil {
+					reqID = shortID()
+				}
+				if l != nil {
+					l.Log("id", reqID,
+						"req", r.Method+" "+r.URL.String(),
+						"panic", p,
+						"stack", string(debug.Stack()))
+				}
+				if s, ok := ctx.Value(xray.SegKey).(*xray.Segment); ok {
+					s.RecordPanic(p)
+				}
+				if rw.StatusCode != 0 || rw.ContentLength > 0 {
+					return
+				}
+				ctx = context.WithValue(ctx, goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+				if err := encodeError(ctx, rw, goa.Fault("internal error, request ID: %v", reqID)); err != nil && l != nil {
+					l.Log("id", reqID, "err", err)
+				}
+			}()
+			h.ServeHTTP(rw, r)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+	"goa.design/goa/v3/middleware/xray"
+)
+
+func TestRecover(t *testing.T) {
+	var buf bytes.Buffer
+	logger := middleware.NewLogger(log.New(&buf, "", 0))
+	h := httpm.Recover(logger, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	r := httptest.NewRequest("GET", "/panic", nil)
+	r = r.WithContext(context.WithValue(r.Context(), middleware.RequestIDKey, "reqid"))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+	var resp goahttp.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode error response %q: %s", w.Body.String(), err)
+	}
+	if !resp.Fault || resp.Message != "internal error, request ID: reqid" {
+		t.Errorf("got error response %+v, expected fault with message %q", resp, "internal error, request ID: reqid")
+	}
+	if strings.Contains(w.Body.String(), "boom") {
+		t.Errorf("error response %q leaks the panic value", w.Body.String())
+	}
+	logs := buf.String()
+	for _, s := range []string{"id=reqid", "req=GET /panic", "panic=boom", "recover_test.go"} {
+		if !strings.Contains(logs, s) {
+			t.Errorf("logs %q do not contain %q", logs, s)
+		}
+	}
+}
+
+func TestRecoverEncodeError(t *testing.T) {
+	h := httpm.Recover(nil, goahttp.ProblemErrorEncoder(goahttp.ResponseEncoder))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != goahttp.ProblemContentType {
+		t.Errorf("got Content-Type %q, expected %q", ct, goahttp.ProblemContentType)
+	}
+}
+
+func TestRecoverWritten(t *testing.T) {
+	h := httpm.Recover(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("partial"))
+		panic("boom")
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusAccepted {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusAccepted)
+	}
+	if body := w.Body.String(); body != "partial" {
+		t.Errorf("got body %q, expected %q", body, "partial")
+	}
+}
+
+func TestRecoverAbortHandler(t *testing.T) {
+	h := httpm.Recover(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Errorf("got panic %v, expected %v", p, http.ErrAbortHandler)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
+
+func TestRecoverXRay(t *testing.T) {
+	conn, err := net.Dial("udp", "127.0.0.1:62111")
+	if err != nil {
+		t.Fatalf("failed to connect to daemon - %s", err)
+	}
+	seg := xray.NewSegment("test", xray.NewTraceID(), xray.NewID(), conn)
+	h := httpm.Recover(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), xray.SegKey, seg))
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if !seg.Fault {
+		t.Errorf("segment fault not set")
+	}
+	if seg.Cause == nil || len(seg.Cause.Exceptions) != 1 || seg.Cause.Exceptions[0].Message != "panic: boom" {
+		t.Errorf("got segment cause %+v, expected panic exception", seg.Cause)
+	}
+}
@@ -0,0 +1,314 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// MsgPackContentType is the media type of MessagePack encoded content.
+const MsgPackContentType = "application/msgpack"
+
+// NewMsgPackEncoder returns an encoder that writes values to w using the
+// MessagePack format (https://msgpack.org). Struct fields are written as map
+// entries named after their "msgpack" tag, falling back to the "json" tag,
+// following the same rules as encoding/json for omitempty. Byte slices are
+// written using the bin format family.
+func NewMsgPackEncoder(w io.Writer) Encoder {
+	return EncodingFunc(func(v interface{}) error {
+		var e msgpackWriter
+		if err := encodeBinary(&e, reflect.ValueOf(v), "msgpack"); err != nil {
+			return fmt.Errorf("can't encode %T as %s: %s", v, MsgPackContentType, err)
+		}
+		_, err := w.Write(e.Bytes())
+		return err
+	})
+}
+
+// NewMsgPackDecoder returns a decoder that reads MessagePack values from r.
+// See NewMsgPackEncoder for how values map to Go types. Extension types are
+// not supported.
+func NewMsgPackDecoder(r io.Reader) Decoder {
+	br := bufio.NewReader(r)
+	return EncodingFunc(func(v interface{}) error {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return fmt.Errorf("can't decode %s content to %T", MsgPackContentType, v)
+		}
+		g, err := readMsgPack(br)
+		if err != nil {
+			return err
+		}
+		return decodeBinary(rv.Elem(), g, "msgpack")
+	})
+}
+
+// msgpackWriter implements binaryWriter for the MessagePack format.
+type msgpackWriter struct {
+	bytes.Buffer
+}
+
+func (e *msgpackWriter) writeNil() { e.WriteByte(0xc0) }
+
+func (e *msgpackWriter) writeBool(b bool) {
+	if b {
+		e.WriteByte(0xc3)
+		return
+	}
+	e.WriteByte(0xc2)
+}
+
+func (e *msgpackWriter) writeInt(i int64) {
+	switch {
+	case i >= 0:
+		e.writeUint(uint64(i))
+	case i >= -32:
+		e.WriteByte(byte(int8(i)))
+	case i >= math.MinInt8:
+		e.WriteByte(0xd0)
+		e.WriteByte(byte(int8(i)))
+	case i >= math.MinInt16:
+		e.WriteByte(0xd1)
+		e.writeBE(uint64(uint16(i)), 2)
+	case i >= math.MinInt32:
+		e.WriteByte(0xd2)
+		e.writeBE(uint64(uint32(i)), 4)
+	default:
+		e.WriteByte(0xd3)
+		e.writeBE(uint64(i), 8)
+	}
+}
+
+func (e *msgpackWriter) writeUint(u uint64) {
+	switch {
+	case u < 0x80:
+		e.WriteByte(byte(u))
+	case u <= math.MaxUint8:
+		e.WriteByte(0xcc)
+		e.WriteByte(byte(u))
+	case u <= math.MaxUint16:
+		e.WriteByte(0xcd)
+		e.writeBE(u, 2)
+	case u <= math.MaxUint32:
+		e.WriteByte(0xce)
+		e.writeBE(u, 4)
+	default:
+		e.WriteByte(0xcf)
+		e.writeBE(u, 8)
+	}
+}
+
+func (e *msgpackWriter) writeFloat(f float64, bits int) {
+	if bits == 32 {
+		e.WriteByte(0xca)
+		e.writeBE(uint64(math.Float32bits(float32(f))), 4)
+		return
+	}
+	e.WriteByte(0xcb)
+	e.writeBE(math.Float64bits(f), 8)
+}
+
+func (e *msgpackWriter) writeString(s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		e.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		e.WriteByte(0xd9)
+		e.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		e.WriteByte(0xda)
+		e.writeBE(uint64(n), 2)
+	default:
+		e.WriteByte(0xdb)
+		e.writeBE(uint64(n), 4)
+	}
+	e.WriteString(s)
+}
+
+func (e *msgpackWriter) writeBytes(b []byte) {
+	n := len(b)
+	switch {
+	case n <= math.MaxUint8:
+		e.WriteByte(0xc4)
+		e.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		e.WriteByte(0xc5)
+		e.writeBE(uint64(n), 2)
+	default:
+		e.WriteByte(0xc6)
+		e.writeBE(uint64(n), 4)
+	}
+	e.Write(b)
+}
+
+func (e *msgpackWriter) writeArrayHeader(n int) {
+	switch {
+	case n < 16:
+		e.WriteByte(0x90 | byte(n))
+	case n <= math.MaxUint16:
+		e.WriteByte(0xdc)
+		e.writeBE(uint64(n), 2)
+	default:
+		e.WriteByte(0xdd)
+		e.writeBE(uint64(n), 4)
+	}
+}
+
+func (e *msgpackWriter) writeMapHeader(n int) {
+	switch {
+	case n < 16:
+		e.WriteByte(0x80 | byte(n))
+	case n <= math.MaxUint16:
+		e.WriteByte(0xde)
+		e.writeBE(uint64(n), 2)
+	default:
+		e.WriteByte(0xdf)
+		e.writeBE(uint64(n), 4)
+	}
+}
+
+// writeBE writes the size least significant bytes of u in big-endian order.
+func (e *msgpackWriter) writeBE(u uint64, size int) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], u)
+	e.Write(b[8-size:])
+}
+
+// readMsgPack reads the next MessagePack value from r and returns its
+// generic representation, see decodeBinary.
+func readMsgPack(r *bufio.Reader) (interface{}, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == 0x80:
+		return readMsgPackMap(r, uint64(c&0x0f))
+	case c&0xf0 == 0x90:
+		return readMsgPackArray(r, uint64(c&0x0f))
+	case c&0xe0 == 0xa0:
+		b, err := readBinary(r, uint64(c&0x1f))
+		return string(b), err
+	}
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readBE(r, 1<<(c-0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return readBinary(r, n)
+	case 0xca:
+		u, err := readBE(r, 4)
+		return float64(math.Float32frombits(uint32(u))), err
+	case 0xcb:
+		u, err := readBE(r, 8)
+		return math.Float64frombits(u), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := readBE(r, 1<<(c-0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if u <= math.MaxInt64 {
+			return int64(u), nil
+		}
+		return u, nil
+	case 0xd0:
+		u, err := readBE(r, 1)
+		return int64(int8(u)), err
+	case 0xd1:
+		u, err := readBE(r, 2)
+		return int64(int16(u)), err
+	case 0xd2:
+		u, err := readBE(r, 4)
+		return int64(int32(u)), err
+	case 0xd3:
+		u, err := readBE(r, 8)
+		return int64(u), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := readBE(r, 1<<(c-0xd9))
+		if err != nil {
+			return nil, err
+		}
+		b, err := readBinary(r, n)
+		return string(b), err
+	case 0xdc, 0xdd:
+		n, err := readBE(r, 2<<(c-0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackArray(r, n)
+	case 0xde, 0xdf:
+		n, err := readBE(r, 2<<(c-0xde))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackMap(r, n)
+	}
+	return nil, fmt.Errorf("unsupported MessagePack format 0x%x", c)
+}
+
+// readMsgPackArray reads the n elements of a MessagePack array.
+func readMsgPackArray(r *bufio.Reader, n uint64) ([]interface{}, error) {
+	var elems []interface{}
+	for i := uint64(0); i < n; i++ {
+		e, err := readMsgPack(r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		elems = append(elems, e)
+	}
+	if elems == nil {
+		elems = []interface{}{}
+	}
+	return elems, nil
+}
+
+// readMsgPackMap reads the n entries of a MessagePack map.
+func readMsgPackMap(r *bufio.Reader, n uint64) (binaryMap, error) {
+	m := binaryMap{}
+	for i := uint64(0); i < n; i++ {
+		k, err := readMsgPack(r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		v, err := readMsgPack(r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		m = append(m, binaryMapItem{k, v})
+	}
+	return m, nil
+}
+
+// readBE reads a size bytes big-endian unsigned integer from r.
+func readBE(r io.Reader, size int) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[8-size:]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// unexpectedEOF converts io.EOF errors to io.ErrUnexpectedEOF for reads
+// made in the middle of a value.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMsgPackEncoder(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected string
+	}{
+		{"nil", nil, "c0"},
+		{"bool", true, "c3"},
+		{"fixint", 5, "05"},
+		{"negative-fixint", -1, "ff"},
+		{"int8", -100, "d09c"},
+		{"uint8", 200, "ccc8"},
+		{"int16", -1000, "d1fc18"},
+		{"uint32", 70000, "ce00011170"},
+		{"int64", int64(math.MinInt64), "d38000000000000000"},
+		{"float32", float32(1.5), "ca3fc00000"},
+		{"float64", 1.5, "cb3ff8000000000000"},
+		{"fixstr", "abc", "a3616263"},
+		{"bin", []byte{1, 2}, "c4020102"},
+		{"array", []int{1, 2}, "920102"},
+		{"map", map[string]bool{"b": false, "a": true}, "82a161c3a162c2"},
+		{"struct", &formAddress{City: strPtr("x")}, "81a463697479a178"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewMsgPackEncoder(&buf).Encode(c.Value); err != nil {
+				t.Fatal(err)
+			}
+			if actual := hex.EncodeToString(buf.Bytes()); actual != c.Expected {
+				t.Errorf("got %s, expected %s", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestMsgPackDecoder(t *testing.T) {
+	var (
+		city  = "Paris"
+		title = strings.Repeat("x", 40)
+	)
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected interface{}
+		Err      string
+	}{
+		{"struct", "82a463697479a55061726973a3666f6fc0", &formAddress{City: &city}, ""},
+		{"str8", "81a5746974" + "6c65d928" + hex.EncodeToString([]byte(title)), &multipartBody{Title: &title}, ""},
+		{"slice", "81a47461677392a161a162", &multipartBody{Tags: []string{"a", "b"}}, ""},
+		{"bytes", "81a466696c65c40100", &multipartBody{File: []byte{0}}, ""},
+		{"interface", "82a161cb3ff8000000000000a16292c0a163", &map[string]interface{}{"a": 1.5, "b": []interface{}{nil, "c"}}, ""},
+		{"invalid-type", "81a5636f756e74a3616263", nil, `invalid value for "count": cannot decode string into int`},
+		{"overflow", "81a5636f756e74cf8000000000000000", nil, `overflows int`},
+		{"truncated", "82a463697479", nil, "unexpected EOF"},
+		{"extension", "d40100", nil, "unsupported MessagePack format 0xd4"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := hex.DecodeString(c.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var v interface{}
+			if c.Expected != nil {
+				v = reflect.New(reflect.TypeOf(c.Expected).Elem()).Interface()
+			} else {
+				v = &multipartBody{}
+			}
+			err = NewMsgPackDecoder(bytes.NewReader(b)).Decode(v)
+			if c.Err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.Err) {
+					t.Fatalf("got error %v, expected %q", err, c.Err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(v, c.Expected) {
+				t.Errorf("got %#v, expected %#v", v, c.Expected)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// RequestTooLarge is the name of the error returned by DecodeMultipart when
+// a part or the multipart content exceeds the size limits. The design of
+// multipart endpoints maps errors with this name to 413 Request Entity Too
+// Large responses.
+const RequestTooLarge = "request_too_large"
+
+var (
+	// MultipartMaxPartSize is the maximum size in bytes of the parts read
+	// by DecodeMultipart.
+	MultipartMaxPartSize int64 = 10 << 20
+
+	// MultipartMaxSize is the maximum total size in bytes of the parts
+	// read by DecodeMultipart.
+	MultipartMaxSize int64 = 32 << 20
+)
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// EncodeMultipart writes the fields of the struct v to the multipart writer
+// mw. The fields are named after their "form" tag. Primitive values are
+// written as form fields, byte slices as file parts and slices of either
+// as repeated parts with the same name. Other values are written as parts
+// containing their JSON encoding. Nil and omitted empty fields are skipped.
+//
+// EncodeMultipart is used by the multipart request encoders generated for
+// endpoints that use MultipartRequest.
+func EncodeMultipart(mw *multipart.Writer, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("can't encode %T as multipart content", v)
+	}
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, omitempty, ok := formFieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if omitempty && isEmptyFormValue(fv) {
+			continue
+		}
+		if err := encodePart(mw, name, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMultipart reads the parts of the multipart reader mr into the
+// fields of the struct v points to. Parts are matched to fields using the
+// "form" tag of the fields and are read in memory as they are received.
+// Parts that do not match a field are skipped. DecodeMultipart returns an
+// error named RequestTooLarge if a part is larger than MultipartMaxPartSize
+// or if the parts are larger than MultipartMaxSize in total. See
+// EncodeMultipart for the encoding of the fields.
+//
+// DecodeMultipart is used by the multipart request decoders generated for
+// endpoints that use MultipartRequest.
+func DecodeMultipart(mr *multipart.Reader, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("can't decode multipart content to %T", v)
+	}
+	rv = rv.Elem()
+	fields := make(map[string]int)
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, ok := formFieldName(t.Field(i)); ok {
+			fields[name] = i
+		}
+	}
+	remaining := MultipartMaxSize
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		i, ok := fields[part.FormName()]
+		if !ok {
+			part.Close()
+			continue
+		}
+		limit := MultipartMaxPartSize
+		if remaining < limit {
+			limit = remaining
+		}
+		r := &countingReader{r: io.LimitReader(part, limit+1)}
+		err = decodePart(r, rv.Field(i))
+		part.Close()
+		if r.n > limit {
+			if limit == MultipartMaxPartSize {
+				return goa.PermanentError(RequestTooLarge, "part %q exceeds %d bytes", part.FormName(), MultipartMaxPartSize)
+			}
+			return goa.PermanentError(RequestTooLarge, "multipart content exceeds %d bytes", MultipartMaxSize)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value for %q: %s", part.FormName(), err)
+		}
+		remaining -= r.n
+	}
+}
+
+// encodePart writes the value v using one or more parts named name.
+func encodePart(mw *multipart.Writer, name string, v reflect.Value) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if isByteSlice(v) {
+		w, err := mw.CreateFormFile(name, name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(v.Bytes())
+		return err
+	}
+	if s, ok := formatFormValue(v); ok {
+		return mw.WriteField(name, s)
+	}
+	if v.Kind() == reflect.Slice && isMultipartElem(v.Type().Elem()) {
+		for i := 0; i < v.Len(); i++ {
+			if err := encodePart(mw, name, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q`, name))
+	h.Set("Content-Type", "application/json")
+	w, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(v.Interface())
+}
+
+// decodePart reads the content of part into v. Slices of primitive values or
+// byte slices are appended to so that repeated parts accumulate.
+func decodePart(part io.Reader, v reflect.Value) error {
+	if v.Kind() == reflect.Slice && !isByteSlice(v) && isMultipartElem(v.Type().Elem()) {
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := decodePart(part, elem); err != nil {
+			return err
+		}
+		v.Set(reflect.Append(v, elem))
+		return nil
+	}
+	if v.Kind() == reflect.Ptr && isMultipartElem(v.Type().Elem()) {
+		elem := reflect.New(v.Type().Elem())
+		if err := decodePart(part, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	if !isMultipartElem(v.Type()) {
+		return json.NewDecoder(part).Decode(v.Addr().Interface())
+	}
+	b, err := ioutil.ReadAll(part)
+	if err != nil {
+		return err
+	}
+	if isByteSlice(v) {
+		v.SetBytes(b)
+		return nil
+	}
+	return parseFormValue(v, string(b))
+}
+
+// isMultipartElem returns true if values of type t are written to a single
+// part as is, that is t is a primitive type or a byte slice.
+func isMultipartElem(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	_, ok := formatFormValue(reflect.Zero(t))
+	return ok
+}
+
+// isByteSlice returns true if v is a byte slice.
+func isByteSlice(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+}
+
+// Read reads from the underlying reader and counts the bytes read.
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"bytes"
+	"mime/multipart"
+	"reflect"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type multipartBody struct {
+	Title  *string           `form:"title,omitempty" json:"title,omitempty"`
+	Count  *int              `form:"count,omitempty" json:"count,omitempty"`
+	Ratio  float64           `form:"ratio" json:"ratio"`
+	Tags   []string          `form:"tags,omitempty" json:"tags,omitempty"`
+	File   []byte            `form:"file,omitempty" json:"file,omitempty"`
+	Files  [][]byte          `form:"files,omitempty" json:"files,omitempty"`
+	Labels map[string]string `form:"labels,omitempty" json:"labels,omitempty"`
+	Addr   *formAddress      `form:"addr,omitempty" json:"addr,omitempty"`
+}
+
+func TestMultipartRoundTrip(t *testing.T) {
+	var (
+		title = "report"
+		count = 2
+		city  = "Paris"
+	)
+	body := &multipartBody{
+		Title:  &title,
+		Count:  &count,
+		Ratio:  0.25,
+		Tags:   []string{"a", "b"},
+		File:   []byte("binary\x00content"),
+		Files:  [][]byte{[]byte("one"), []byte("two")},
+		Labels: map[string]string{"k": "v"},
+		Addr:   &formAddress{City: &city},
+	}
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := EncodeMultipart(mw, body); err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var decoded multipartBody
+	if err := DecodeMultipart(multipart.NewReader(&buf, mw.Boundary()), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, body) {
+		t.Errorf("got %+v, expected %+v", decoded, *body)
+	}
+}
+
+func TestDecodeMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	mw.WriteField("title", "report")
+	mw.WriteField("unknown", "ignored")
+	fw, _ := mw.CreateFormFile("file", "report.pdf")
+	fw.Write([]byte("%PDF"))
+	mw.Close()
+	var body multipartBody
+	if err := DecodeMultipart(multipart.NewReader(bytes.NewReader(buf.Bytes()), mw.Boundary()), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Title == nil || *body.Title != "report" {
+		t.Errorf("got title %v, expected %q", body.Title, "report")
+	}
+	if string(body.File) != "%PDF" {
+		t.Errorf("got file %q, expected %q", body.File, "%PDF")
+	}
+
+	buf.Reset()
+	mw = multipart.NewWriter(&buf)
+	mw.WriteField("count", "abc")
+	mw.Close()
+	err := DecodeMultipart(multipart.NewReader(&buf, mw.Boundary()), &body)
+	if err == nil || !strings.Contains(err.Error(), `invalid value for "count"`) {
+		t.Errorf("got error %v, expected invalid count", err)
+	}
+}
+
+func TestDecodeMultipartTooLarge(t *testing.T) {
+	defer func(part, total int64) {
+		MultipartMaxPartSize, MultipartMaxSize = part, total
+	}(MultipartMaxPartSize, MultipartMaxSize)
+	MultipartMaxPartSize, MultipartMaxSize = 8, 12
+	cases := []struct {
+		Name     string
+		Files    []string
+		Expected string
+	}{
+		{"within-limits", []string{"12345678", "1234"}, ""},
+		{"part-too-large", []string{"123456789"}, `part "files" exceeds 8 bytes`},
+		{"total-too-large", []string{"12345678", "12345"}, "multipart content exceeds 12 bytes"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mw := multipart.NewWriter(&buf)
+			for _, f := range c.Files {
+				fw, _ := mw.CreateFormFile("files", "f")
+				fw.Write([]byte(f))
+			}
+			mw.Close()
+			var body multipartBody
+			err := DecodeMultipart(multipart.NewReader(&buf, mw.Boundary()), &body)
+			if c.Expected == "" {
+				if err != nil {
+					t.Errorf("got error %v", err)
+				}
+				return
+			}
+			gerr, ok := err.(*goa.ServiceError)
+			if !ok || gerr.Name != RequestTooLarge || gerr.Message != c.Expected {
+				t.Errorf("got error %v, expected %s error %q", err, RequestTooLarge, c.Expected)
+			}
+		})
+	}
+}
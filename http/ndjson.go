@@ -0,0 +1,307 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+const (
+	// NDJSONContentType is the content type of newline-delimited JSON
+	// streams.
+	NDJSONContentType = "application/x-ndjson"
+
+	// NDJSONErrorTrailer is the name of the HTTP trailer that contains the
+	// JSON encoded ErrorResponse describing the error that ended the
+	// stream if any.
+	NDJSONErrorTrailer = "Goa-Error"
+)
+
+var (
+	// ErrHTTP2Required is the error returned when a bidirectional NDJSON
+	// stream is made using HTTP/1.x.
+	ErrHTTP2Required = errors.New("bidirectional NDJSON streams require HTTP/2")
+
+	// errResponseReceived is the error returned when writing to the body
+	// of a HTTP/1.x request after the response has been received.
+	errResponseReceived = errors.New("response received before the end of the request body")
+)
+
+type (
+	// NDJSONWriter writes newline-delimited JSON values to a HTTP response
+	// using chunked transfer encoding. The response headers are written
+	// when the first value is sent or when Start is called, whichever
+	// happens first. NDJSONWriter methods may be called concurrently.
+	NDJSONWriter struct {
+		w       http.ResponseWriter
+		mu      sync.Mutex
+		started bool
+	}
+
+	// NDJSONReader reads newline-delimited JSON values from a HTTP request
+	// or response body.
+	NDJSONReader struct {
+		body io.ReadCloser
+		dec  *json.Decoder
+		resp *http.Response
+	}
+
+	// NDJSONRequestStream streams newline-delimited JSON values in the body
+	// of a HTTP request. The request is sent as soon as the stream is
+	// created so that the server may start streaming values back while the
+	// client is still sending. Bidirectional streaming requires HTTP/2,
+	// servers reject bidirectional streams made using HTTP/1.x with a 505
+	// HTTP Version Not Supported response.
+	NDJSONRequestStream struct {
+		service string
+		method  string
+		pw      *io.PipeWriter
+		enc     *json.Encoder
+		done    chan struct{}
+		resp    *http.Response
+		err     error
+	}
+)
+
+// NewNDJSONWriter returns a writer that streams values to w.
+func NewNDJSONWriter(w http.ResponseWriter) *NDJSONWriter {
+	return &NDJSONWriter{w: w}
+}
+
+// Header returns the response headers. Changes made after the stream has
+// started have no effect.
+func (s *NDJSONWriter) Header() http.Header {
+	return s.w.Header()
+}
+
+// Start writes the response headers if not already done.
+func (s *NDJSONWriter) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+}
+
+// Started returns true if the response headers have been written. Errors
+// cannot be written to the response once the stream has started.
+func (s *NDJSONWriter) Started() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// Send writes the JSON encoding of v followed by a newline and flushes the
+// response.
+func (s *NDJSONWriter) Send(v interface{}) error {
+	data, err := marshalNDJSON(v)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+	if _, err := s.w.Write(data); err != nil {
+		return err
+	}
+	s.flush()
+	return nil
+}
+
+// SetError reports err to the client in the NDJSON error trailer. SetError
+// is used to report errors that occur once the stream has started, the
+// trailer is sent when the handler returns.
+func (s *NDJSONWriter) SetError(err error) {
+	js, merr := json.Marshal(NewErrorResponse(err))
+	if merr != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+	s.w.Header().Set(NDJSONErrorTrailer, string(js))
+}
+
+// start writes the response headers if not already done. The caller must hold
+// the lock.
+func (s *NDJSONWriter) start() {
+	if s.started {
+		return
+	}
+	h := s.w.Header()
+	h.Set("Content-Type", NDJSONContentType)
+	h.Set("Trailer", NDJSONErrorTrailer)
+	h.Del("Content-Length")
+	s.w.WriteHeader(http.StatusOK)
+	s.flush()
+	s.started = true
+}
+
+// flush sends any buffered data to the client.
+func (s *NDJSONWriter) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// CheckNDJSONProtocol writes a 505 HTTP Version Not Supported response and
+// returns false if r was made using HTTP/1.x. The generated servers call
+// CheckNDJSONProtocol to reject bidirectional NDJSON streams that require
+// HTTP/2 to send the response while the request body is being read.
+func CheckNDJSONProtocol(w http.ResponseWriter, r *http.Request) bool {
+	if r.ProtoMajor >= 2 {
+		return true
+	}
+	// Close the connection so that the server does not wait for the end
+	// of the request body before writing the response.
+	w.Header().Set("Connection", "close")
+	ctx := context.WithValue(r.Context(), AcceptTypeKey, r.Header.Get("Accept"))
+	enc := ResponseEncoder(ctx, w)
+	w.WriteHeader(http.StatusHTTPVersionNotSupported)
+	enc.Encode(NewErrorResponse(ErrHTTP2Required))
+	return false
+}
+
+// IsNDJSON returns true if the response content type is
+// application/x-ndjson.
+func IsNDJSON(resp *http.Response) bool {
+	mt, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mt == NDJSONContentType
+}
+
+// NewNDJSONReader returns a reader that reads the newline-delimited JSON
+// values from body.
+func NewNDJSONReader(body io.ReadCloser) *NDJSONReader {
+	return &NDJSONReader{body: body, dec: json.NewDecoder(body)}
+}
+
+// NewNDJSONResponseReader returns a reader that reads the newline-delimited
+// JSON values from the body of resp. Decode returns the error reported by
+// the server in the NDJSON error trailer instead of io.EOF if any.
+func NewNDJSONResponseReader(resp *http.Response) *NDJSONReader {
+	r := NewNDJSONReader(resp.Body)
+	r.resp = resp
+	return r
+}
+
+// Decode decodes the next value into v. Decode returns io.EOF once the
+// stream is closed or a *goa.ServiceError if the server ended the stream with
+// an error.
+func (r *NDJSONReader) Decode(v interface{}) error {
+	err := r.dec.Decode(v)
+	if err != io.EOF || r.resp == nil {
+		return err
+	}
+	trailer := r.resp.Trailer.Get(NDJSONErrorTrailer)
+	if trailer == "" {
+		return io.EOF
+	}
+	var resp ErrorResponse
+	if err := json.Unmarshal([]byte(trailer), &resp); err != nil {
+		return fmt.Errorf("invalid %s trailer %q: %s", NDJSONErrorTrailer, trailer, err)
+	}
+	return resp.serviceError()
+}
+
+// Close closes the underlying body.
+func (r *NDJSONReader) Close() error {
+	return r.body.Close()
+}
+
+// NewNDJSONRequestStream sets the body of req to a stream of
+// newline-delimited JSON values and sends the request using doer. service
+// and method are used to build the errors returned when the request fails.
+func NewNDJSONRequestStream(service, method string, doer Doer, req *http.Request) *NDJSONRequestStream {
+	pr, pw := io.Pipe()
+	req.Body = pr
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", NDJSONContentType)
+	req.Header.Set("Accept", NDJSONContentType)
+	s := &NDJSONRequestStream{
+		service: service,
+		method:  method,
+		pw:      pw,
+		enc:     json.NewEncoder(pw),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		s.resp, s.err = doer.Do(req)
+		// Close done first so that Send reports the request error.
+		close(s.done)
+		if s.err != nil {
+			pr.CloseWithError(s.err)
+		} else if s.resp.ProtoMajor < 2 {
+			// The request body cannot be sent once the response has
+			// been received with HTTP/1.x.
+			pr.CloseWithError(errResponseReceived)
+		}
+	}()
+	return s
+}
+
+// Send writes the JSON encoding of v followed by a newline to the request
+// body.
+func (s *NDJSONRequestStream) Send(v interface{}) error {
+	if err := s.enc.Encode(v); err != nil {
+		select {
+		case <-s.done:
+			if s.err != nil {
+				return ErrRequestError(s.service, s.method, s.err)
+			}
+			if s.resp.StatusCode == http.StatusHTTPVersionNotSupported {
+				return ErrRequestError(s.service, s.method, ErrHTTP2Required)
+			}
+		default:
+		}
+		return err
+	}
+	return nil
+}
+
+// Close ends the request body. The response may still be read after the
+// request body is closed.
+func (s *NDJSONRequestStream) Close() error {
+	return s.pw.Close()
+}
+
+// Response waits for the HTTP response and returns it. decode is called to
+// build the error returned by Response if the response does not stream
+// newline-delimited JSON values, e.g. because the server returned an error
+// response.
+func (s *NDJSONRequestStream) Response(decode func(*http.Response) (interface{}, error)) (*http.Response, error) {
+	if err := s.wait(); err != nil {
+		return nil, ErrRequestError(s.service, s.method, err)
+	}
+	if s.resp.StatusCode == http.StatusHTTPVersionNotSupported {
+		s.resp.Body.Close()
+		return nil, ErrRequestError(s.service, s.method, ErrHTTP2Required)
+	}
+	if !IsNDJSON(s.resp) {
+		defer s.resp.Body.Close()
+		if _, err := decode(s.resp); err != nil {
+			return nil, err
+		}
+		return nil, ErrDecodingError(s.service, s.method, fmt.Errorf("unexpected response content type %q", s.resp.Header.Get("Content-Type")))
+	}
+	return s.resp, nil
+}
+
+// wait waits for the HTTP request to complete and returns the request error
+// if any.
+func (s *NDJSONRequestStream) wait() error {
+	<-s.done
+	return s.err
+}
+
+// marshalNDJSON returns the JSON encoding of v followed by a newline.
+func marshalNDJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
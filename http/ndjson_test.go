@@ -0,0 +1,233 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestNDJSONWriter(t *testing.T) {
+	w := httptest.NewRecorder()
+	s := NewNDJSONWriter(w)
+	if s.Started() {
+		t.Fatal("stream started before sending values")
+	}
+	if err := s.Send(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send("x\ny"); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Started() {
+		t.Error("stream not started after sending values")
+	}
+	if ct := w.Header().Get("Content-Type"); ct != NDJSONContentType {
+		t.Errorf("got content type %q, expected %q", ct, NDJSONContentType)
+	}
+	expected := "{\"a\":1}\n\"x\\ny\"\n"
+	if body := w.Body.String(); body != expected {
+		t.Errorf("got body %q, expected %q", body, expected)
+	}
+}
+
+func TestNDJSONWriterConcurrentSend(t *testing.T) {
+	const n = 50
+	w := httptest.NewRecorder()
+	s := NewNDJSONWriter(w)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.Started()
+			if err := s.Send(map[string]int{"a": i}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	r := NewNDJSONReader(ioutil.NopCloser(w.Body))
+	seen := make(map[int]bool)
+	for {
+		var v struct{ A int }
+		err := r.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[v.A] = true
+	}
+	if len(seen) != n {
+		t.Errorf("got %d distinct values, expected %d", len(seen), n)
+	}
+}
+
+func TestNDJSONReader(t *testing.T) {
+	r := NewNDJSONReader(ioutil.NopCloser(strings.NewReader("{\"a\":1}\n\n{\"a\":2}\n")))
+	var actual []int
+	for {
+		var v struct{ A int }
+		err := r.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, v.A)
+	}
+	if len(actual) != 2 || actual[0] != 1 || actual[1] != 2 {
+		t.Errorf("got values %v, expected [1 2]", actual)
+	}
+}
+
+func TestNDJSONRequestStream(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != NDJSONContentType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rd := NewNDJSONReader(r.Body)
+		wr := NewNDJSONWriter(w)
+		n := 0
+		for {
+			var v int
+			if err := rd.Decode(&v); err != nil {
+				break
+			}
+			n += v
+		}
+		wr.Send(n)
+	})
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	req, _ := http.NewRequest("POST", srv.URL, nil)
+	s := NewNDJSONRequestStream("service", "method", srv.Client(), req)
+	for i := 1; i <= 3; i++ {
+		if err := s.Send(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	decode := func(resp *http.Response) (interface{}, error) {
+		t.Fatalf("unexpected call to decode, status %d", resp.StatusCode)
+		return nil, nil
+	}
+	resp, err := s.Response(decode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewNDJSONReader(resp.Body)
+	defer r.Close()
+	var total int
+	if err := r.Decode(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 6 {
+		t.Errorf("got total %d, expected 6", total)
+	}
+}
+
+func TestNDJSONErrorTrailer(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nw := NewNDJSONWriter(w)
+		nw.Send(1)
+		nw.SetError(goa.PermanentError("failed", "stream failed"))
+	})
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewNDJSONResponseReader(resp)
+	defer r.Close()
+	var v int
+	if err := r.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v != 1 {
+		t.Errorf("got value %d, expected 1", v)
+	}
+	err = r.Decode(&v)
+	serr, ok := err.(*goa.ServiceError)
+	if !ok {
+		t.Fatalf("got error %#v, expected *goa.ServiceError", err)
+	}
+	if serr.Name != "failed" || serr.Message != "stream failed" {
+		t.Errorf("got error %+v, expected name %q and message %q", serr, "failed", "stream failed")
+	}
+}
+
+func TestNDJSONReaderNoErrorTrailer(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewNDJSONWriter(w).Send(1)
+	})
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewNDJSONResponseReader(resp)
+	defer r.Close()
+	var v int
+	if err := r.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Decode(&v); err != io.EOF {
+		t.Errorf("got error %v, expected io.EOF", err)
+	}
+}
+
+func TestNDJSONRequestStreamHTTP1(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !CheckNDJSONProtocol(w, r) {
+			return
+		}
+		nw := NewNDJSONWriter(w)
+		nw.Start()
+		rd := NewNDJSONReader(r.Body)
+		for {
+			var v int
+			if err := rd.Decode(&v); err != nil {
+				return
+			}
+			nw.Send(v)
+		}
+	})
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	req, _ := http.NewRequest("POST", srv.URL, nil)
+	s := NewNDJSONRequestStream("service", "method", srv.Client(), req)
+	done := make(chan error)
+	go func() {
+		_, err := s.Response(func(resp *http.Response) (interface{}, error) {
+			return nil, fmt.Errorf("unexpected call to decode, status %d", resp.StatusCode)
+		})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), ErrHTTP2Required.Error()) {
+			t.Errorf("got error %v, expected %q", err, ErrHTTP2Required)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the response")
+	}
+	if err := s.Send(1); err == nil || !strings.Contains(err.Error(), ErrHTTP2Required.Error()) {
+		t.Errorf("got send error %v, expected %q", err, ErrHTTP2Required)
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// NotAcceptable is the name of the error returned by CheckAcceptable when
+// none of the media types produced by an endpoint is acceptable to the
+// client. The design of endpoints that negotiate the response content type
+// maps errors with this name to 406 Not Acceptable responses.
+const NotAcceptable = "not_acceptable"
+
+// acceptRange is a media range listed in an Accept header.
+type acceptRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// NegotiateContentType returns the media type in offers that best matches
+// the Accept header value accept as described in RFC 7231 section 5.3.2.
+// The media ranges listed in accept are matched against the offers with
+// exact matches taking precedence over "type/*" ranges and "type/*" ranges
+// taking precedence over "*/*". The offer with the highest quality value
+// wins, ties go to the offer listed first. Media ranges with a quality value
+// of 0 exclude the matching offers. NegotiateContentType returns the first
+// offer if accept is empty and false if no offer is acceptable.
+func NegotiateContentType(accept string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+	ranges := parseAccept(accept)
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, offer := range offers {
+		mt, _, err := mime.ParseMediaType(offer)
+		if err != nil {
+			continue
+		}
+		typ, subtype := splitMediaType(mt)
+		q, spec := 0.0, -1
+		for _, r := range ranges {
+			s := -1
+			switch {
+			case r.typ == typ && r.subtype == subtype:
+				s = 2
+			case r.typ == typ && r.subtype == "*":
+				s = 1
+			case r.typ == "*" && r.subtype == "*":
+				s = 0
+			}
+			if s > spec {
+				q, spec = r.q, s
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// CheckAcceptable adds "Accept" to the Vary header of w and returns an error
+// named NotAcceptable if none of the media types listed in produces that can
+// be encoded using the codecs registered in Codecs is acceptable according to
+// the Accept header of r. The generated servers call CheckAcceptable prior to
+// calling the endpoints whose design lists the media types produced by their
+// responses. ResponseEncoder negotiates the response content type against
+// the same media types.
+func CheckAcceptable(w http.ResponseWriter, r *http.Request, produces []string) error {
+	AddVary(w.Header(), "Accept")
+	accept := r.Header.Get("Accept")
+	offers := encodableMediaTypes(produces)
+	if _, ok := NegotiateContentType(accept, offers); ok {
+		return nil
+	}
+	if len(offers) == 0 {
+		return goa.PermanentError(NotAcceptable, "no codec is registered to encode any of the media types %s", strings.Join(produces, ", "))
+	}
+	return goa.PermanentError(NotAcceptable, "none of the media types %s matches the Accept header %q", strings.Join(offers, ", "), accept)
+}
+
+// encodableMediaTypes returns the media types listed in produces for which a
+// codec with an encoder is registered in Codecs. The media type parameters
+// are removed.
+func encodableMediaTypes(produces []string) []string {
+	var mts []string
+	for _, p := range produces {
+		if c := Codecs.Lookup(p); c != nil && c.NewEncoder != nil {
+			if mt, _, err := mime.ParseMediaType(p); err == nil {
+				mts = append(mts, mt)
+			}
+		}
+	}
+	return mts
+}
+
+// AddVary adds the header name to the Vary header of h unless it is already
+// listed.
+func AddVary(h http.Header, name string) {
+	for _, v := range h["Vary"] {
+		for _, n := range strings.Split(v, ",") {
+			if n = strings.TrimSpace(n); n == "*" || strings.EqualFold(n, name) {
+				return
+			}
+		}
+	}
+	h.Add("Vary", name)
+}
+
+// parseAccept returns the media ranges listed in the Accept header value
+// accept. Invalid entries are ignored.
+func parseAccept(accept string) []*acceptRange {
+	var ranges []*acceptRange
+	for _, entry := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(entry))
+		if err != nil {
+			continue
+		}
+		if mt == "*" {
+			// Some clients use "*" as a shorthand for "*/*".
+			mt = "*/*"
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		typ, subtype := splitMediaType(mt)
+		if subtype == "" {
+			continue
+		}
+		ranges = append(ranges, &acceptRange{typ: typ, subtype: subtype, q: q})
+	}
+	return ranges
+}
+
+// splitMediaType returns the type and subtype of the media type mt.
+func splitMediaType(mt string) (string, string) {
+	i := strings.Index(mt, "/")
+	if i < 0 {
+		return mt, ""
+	}
+	return mt[:i], mt[i+1:]
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{"application/json", "application/xml", "text/plain"}
+	cases := []struct {
+		Name     string
+		Accept   string
+		Expected string
+		OK       bool
+	}{
+		{"empty", "", "application/json", true},
+		{"exact", "application/xml", "application/xml", true},
+		{"case-insensitive", "Application/XML", "application/xml", true},
+		{"any", "*/*", "application/json", true},
+		{"any-shorthand", "*", "application/json", true},
+		{"type-wildcard", "text/*", "text/plain", true},
+		{"quality", "application/json;q=0.5, application/xml", "application/xml", true},
+		{"quality-tie", "application/xml, application/json", "application/json", true},
+		{"exact-over-wildcard", "application/*;q=0.8, application/json;q=0.2", "application/xml", true},
+		{"excluded", "application/json;q=0, */*;q=0.1", "application/xml", true},
+		{"browser", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "application/xml", true},
+		{"params", "application/json; charset=utf-8", "application/json", true},
+		{"invalid-entry", "application/xml;q=abc, text/plain", "text/plain", true},
+		{"no-match", "image/png", "", false},
+		{"all-excluded", "*/*;q=0", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			actual, ok := NegotiateContentType(c.Accept, offers)
+			if actual != c.Expected || ok != c.OK {
+				t.Errorf("got %q, %v, expected %q, %v", actual, ok, c.Expected, c.OK)
+			}
+		})
+	}
+}
+
+func TestCheckAcceptable(t *testing.T) {
+	produces := []string{"application/json", "application/xml"}
+	cases := []struct {
+		Name     string
+		Accept   string
+		Produces []string
+		Vary     []string
+		Expected bool
+	}{
+		{"acceptable", "application/xml", produces, nil, false},
+		{"missing", "", produces, nil, false},
+		{"not-acceptable", "text/csv", produces, nil, true},
+		{"not-encodable", "text/csv", []string{"text/csv", "application/json"}, nil, true},
+		{"not-encodable-missing", "", []string{"text/csv"}, nil, true},
+		{"encodable", "text/csv, application/json;q=0.5", []string{"text/csv", "application/json"}, nil, false},
+		{"vary-existing", "application/json", produces, []string{"Origin"}, false},
+		{"vary-duplicate", "application/json", produces, []string{"Origin, accept"}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.Accept != "" {
+				r.Header.Set("Accept", c.Accept)
+			}
+			w := httptest.NewRecorder()
+			for _, v := range c.Vary {
+				w.Header().Add("Vary", v)
+			}
+			err := CheckAcceptable(w, r, c.Produces)
+			if !c.Expected {
+				if err != nil {
+					t.Errorf("got error %v, expected none", err)
+				}
+			} else if err == nil {
+				t.Fatal("expected an error")
+			} else if name := NewErrorResponse(err).Name; name != NotAcceptable {
+				t.Errorf("got error name %q, expected %q", name, NotAcceptable)
+			}
+			vary := w.Header()["Vary"]
+			expected := append(c.Vary, "Accept")
+			if c.Name == "vary-duplicate" {
+				expected = c.Vary
+			}
+			if fmt.Sprint(vary) != fmt.Sprint(expected) {
+				t.Errorf("got Vary %v, expected %v", vary, expected)
+			}
+		})
+	}
+}
+
+func TestResponseEncoderNegotiation(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Accept      string
+		Produces    []string
+		ContentType string
+	}{
+		{"default", "", nil, "application/json"},
+		{"default-quality", "application/json;q=0.1, application/xml", nil, "application/xml"},
+		{"default-no-match", "image/png", nil, "application/json"},
+		{"produces", "", []string{"application/xml", "application/json"}, "application/xml"},
+		{"produces-wildcard", "application/*", []string{"text/plain", "application/vnd.goa+json"}, "application/vnd.goa+json"},
+		{"produces-unsupported", "", []string{"application/pdf", "text/plain"}, "text/plain"},
+		{"produces-no-match", "image/png", []string{"application/xml"}, "application/xml"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.Accept)
+			if c.Produces != nil {
+				ctx = context.WithValue(ctx, ProducesKey, c.Produces)
+			}
+			w := httptest.NewRecorder()
+			ResponseEncoder(ctx, w)
+			if ct := w.Header().Get("Content-Type"); ct != c.ContentType {
+				t.Errorf("got Content-Type %q, expected %q", ct, c.ContentType)
+			}
+			if vary := w.Header().Get("Vary"); vary != "Accept" {
+				t.Errorf("got Vary %q, expected %q", vary, "Accept")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SetNextCursorLink sets the Link header of the response to the URL of the
+// next page of a method using cursor pagination. The URL is built from the
+// request URL stored in ctx under RequestURLKey by setting the query string
+// parameter param to cursor. SetNextCursorLink does nothing if cursor is empty
+// or if the request URL is not in ctx.
+func SetNextCursorLink(ctx context.Context, w http.ResponseWriter, param, cursor string) {
+	if cursor == "" {
+		return
+	}
+	u, ok := ctx.Value(RequestURLKey).(*url.URL)
+	if !ok || u == nil {
+		return
+	}
+	setNextLink(w, u, map[string]string{param: cursor})
+}
+
+// SetNextOffsetLink sets the Link header of the response to the URL of the
+// next page of a method using offset pagination. The URL is built from the
+// request URL stored in ctx under RequestURLKey by adding count to the value
+// of the offsetParam query string parameter. limitParam is the name of the
+// query string parameter holding the page size if any and def its default
+// value. count is the number of items in the response. SetNextOffsetLink does
+// nothing if count is zero or lower than the page size.
+func SetNextOffsetLink(ctx context.Context, w http.ResponseWriter, offsetParam, limitParam string, def, count int) {
+	if count <= 0 {
+		return
+	}
+	u, ok := ctx.Value(RequestURLKey).(*url.URL)
+	if !ok || u == nil {
+		return
+	}
+	q := u.Query()
+	limit := def
+	if limitParam != "" {
+		if v, err := strconv.Atoi(q.Get(limitParam)); err == nil {
+			limit = v
+		}
+	}
+	if limit > 0 && count < limit {
+		return
+	}
+	offset, _ := strconv.Atoi(q.Get(offsetParam))
+	setNextLink(w, u, map[string]string{offsetParam: strconv.Itoa(offset + count)})
+}
+
+// setNextLink sets the Link header to the URL of the next page computed by
+// overriding the given query string parameters of u.
+func setNextLink(w http.ResponseWriter, u *url.URL, params map[string]string) {
+	q := u.Query()
+	for k, v := range params {
+		q.Set(k, v)
+	}
+	next := url.URL{Path: u.Path, RawPath: u.RawPath, RawQuery: q.Encode()}
+	w.Header().Add("Link", "<"+next.String()+`>; rel="next"`)
+}
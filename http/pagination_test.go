@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestSetNextCursorLink(t *testing.T) {
+	cases := []struct {
+		Name     string
+		URL      string
+		Cursor   string
+		Expected string
+	}{
+		{"no-cursor", "/items?limit=10", "", ""},
+		{"first-page", "/items?limit=10", "abc", `</items?cursor=abc&limit=10>; rel="next"`},
+		{"next-page", "/items?cursor=abc&limit=10", "def", `</items?cursor=def&limit=10>; rel="next"`},
+		{"escaped", "/items", "a b", `</items?cursor=a+b>; rel="next"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			u, err := url.Parse(c.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.WithValue(context.Background(), RequestURLKey, u)
+			w := httptest.NewRecorder()
+			SetNextCursorLink(ctx, w, "cursor", c.Cursor)
+			if actual := w.Header().Get("Link"); actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestSetNextOffsetLink(t *testing.T) {
+	cases := []struct {
+		Name     string
+		URL      string
+		Default  int
+		Count    int
+		Expected string
+	}{
+		{"empty-page", "/items", 10, 0, ""},
+		{"short-page", "/items?limit=5", 10, 4, ""},
+		{"default-limit", "/items", 10, 10, `</items?offset=10>; rel="next"`},
+		{"limit", "/items?limit=5&offset=5", 10, 5, `</items?limit=5&offset=10>; rel="next"`},
+		{"no-limit", "/items?offset=3", 0, 2, `</items?offset=5>; rel="next"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			u, err := url.Parse(c.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.WithValue(context.Background(), RequestURLKey, u)
+			w := httptest.NewRecorder()
+			SetNextOffsetLink(ctx, w, "offset", "limit", c.Default, c.Count)
+			if actual := w.Header().Get("Link"); actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestSetNextLinkNoURL(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetNextCursorLink(context.Background(), w, "cursor", "abc")
+	SetNextOffsetLink(context.Background(), w, "offset", "", 0, 10)
+	if actual := w.Header().Get("Link"); actual != "" {
+		t.Errorf("got %q, expected no Link header", actual)
+	}
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// ProblemContentType is the content type of RFC 7807 problem details
+// documents.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is the data structure encoded in HTTP error responses that
+// use the RFC 7807 problem details format. See
+// https://tools.ietf.org/html/rfc7807.
+type ProblemDetails struct {
+	// Type is a URI reference that identifies the problem type.
+	Type string
+	// Title is a short, human-readable summary of the problem type.
+	Title string
+	// Status is the HTTP status code of the response.
+	Status int
+	// Detail is a human-readable explanation specific to this occurrence
+	// of the problem.
+	Detail string
+	// Instance is a URI reference that identifies the specific occurrence
+	// of the problem.
+	Instance string
+	// Extensions contains the additional members of the problem details
+	// document.
+	Extensions map[string]interface{}
+}
+
+// NewProblemDetails creates the problem details document for the given error.
+// typ is the problem type URI, "about:blank" is used if empty. The members of
+// body - typically the error response body built by the generated code - are
+// added as extension members if body encodes to a JSON object. If err is a goa
+// ServiceError then the error message and ID are used to initialize the
+// document detail and instance members and the corresponding body members are
+// omitted, otherwise the detail member is initialized with err.Error().
+func NewProblemDetails(status int, typ string, err error, body interface{}) (*ProblemDetails, error) {
+	if typ == "" {
+		typ = "about:blank"
+	}
+	p := &ProblemDetails{
+		Type:   typ,
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+			p.Extensions = m
+		}
+	}
+	if gerr, ok := err.(*goa.ServiceError); ok {
+		p.Detail = gerr.Message
+		p.Instance = gerr.ID
+		delete(p.Extensions, "message")
+		delete(p.Extensions, "id")
+	} else if err != nil {
+		p.Detail = err.Error()
+	}
+	return p, nil
+}
+
+// MarshalJSON encodes the problem details document. The extension members are
+// encoded alongside the standard members, standard members take precedence
+// over extension members with the same name.
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	m["type"] = p.Type
+	m["title"] = p.Title
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	} else {
+		delete(m, "detail")
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	} else {
+		delete(m, "instance")
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes a problem details document. Members other than the
+// standard members are stored in Extensions.
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return err
+	}
+	*p = ProblemDetails{}
+	for k, v := range m {
+		switch k {
+		case "type":
+			p.Type, _ = v.(string)
+		case "title":
+			p.Title, _ = v.(string)
+		case "status":
+			if n, ok := v.(json.Number); ok {
+				s, _ := n.Int64()
+				p.Status = int(s)
+			}
+		case "detail":
+			p.Detail, _ = v.(string)
+		case "instance":
+			p.Instance, _ = v.(string)
+		default:
+			if p.Extensions == nil {
+				p.Extensions = make(map[string]interface{})
+			}
+			p.Extensions[k] = v
+		}
+	}
+	return nil
+}
+
+// EncodeProblem writes the problem details document built with
+// NewProblemDetails to w. EncodeProblem does not write the response headers,
+// see ProblemErrorEncoder.
+func EncodeProblem(w io.Writer, status int, typ string, err error, body interface{}) error {
+	p, perr := NewProblemDetails(status, typ, err, body)
+	if perr != nil {
+		return perr
+	}
+	return json.NewEncoder(w).Encode(p)
+}
+
+// ProblemErrorEncoder returns an encoder that encodes errors returned by
+// service methods as RFC 7807 problem details documents. The status code is
+// computed the same way as ErrorEncoder and the ErrorResponse fields are
+// encoded as extension members. The encoder argument is not used, it makes it
+// possible for ProblemErrorEncoder to be used in place of ErrorEncoder.
+func ProblemErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder) func(context.Context, http.ResponseWriter, error) error {
+	return func(ctx context.Context, w http.ResponseWriter, err error) error {
+		gerr, ok := err.(*goa.ServiceError)
+		if !ok {
+			gerr = goa.Fault(err.Error())
+		}
+		resp := NewErrorResponse(gerr)
+		status := resp.StatusCode()
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(status)
+		return EncodeProblem(w, status, "", gerr, resp)
+	}
+}
+
+// IsProblem returns true if the response content type is
+// application/problem+json.
+func IsProblem(resp *http.Response) bool {
+	mt, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mt == ProblemContentType
+}
+
+// ProblemDecoder wraps the given response decoder so that RFC 7807 problem
+// details documents get decoded into the error response body types generated
+// by goa. The extension members are decoded into the body fields with the
+// same names, the detail and instance members initialize the "message" and
+// "id" fields respectively unless the document has extension members with the
+// same names. Responses with a content type other than
+// application/problem+json are decoded with decoder.
+func ProblemDecoder(decoder func(*http.Response) Decoder) func(*http.Response) Decoder {
+	return func(resp *http.Response) Decoder {
+		if !IsProblem(resp) {
+			return decoder(resp)
+		}
+		return EncodingFunc(func(v interface{}) error {
+			var p ProblemDetails
+			if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+				return err
+			}
+			m := make(map[string]interface{}, len(p.Extensions)+2)
+			for k, v := range p.Extensions {
+				m[k] = v
+			}
+			if _, ok := m["message"]; !ok && p.Detail != "" {
+				m["message"] = p.Detail
+			}
+			if _, ok := m["id"]; !ok && p.Instance != "" {
+				m["id"] = p.Instance
+			}
+			b, err := json.Marshal(m)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(b, v)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestEncodeProblem(t *testing.T) {
+	type custom struct {
+		Reason string `json:"reason"`
+		Field  string `json:"field"`
+	}
+	gerr := &goa.ServiceError{Name: "not_found", ID: "abc", Message: "no such item"}
+	cases := []struct {
+		name     string
+		status   int
+		typ      string
+		err      error
+		body     interface{}
+		expected string
+	}{
+		{"no-body", 400, "", errors.New("bad"), nil,
+			`{"detail":"bad","status":400,"title":"Bad Request","type":"about:blank"}`},
+		{"service-error", 404, "https://example.com/probs/not-found", gerr, NewErrorResponse(gerr),
+			`{"detail":"no such item","fault":false,"instance":"abc","name":"not_found","status":404,"temporary":false,"timeout":false,"title":"Not Found","type":"https://example.com/probs/not-found"}`},
+		{"custom", 422, "", errors.New("invalid"), &custom{Reason: "too long", Field: "name"},
+			`{"detail":"invalid","field":"name","reason":"too long","status":422,"title":"Unprocessable Entity","type":"about:blank"}`},
+		{"non-object-body", 500, "", errors.New("oops"), "oops",
+			`{"detail":"oops","status":500,"title":"Internal Server Error","type":"about:blank"}`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := EncodeProblem(&sb, c.status, c.typ, c.err, c.body); err != nil {
+				t.Fatal(err)
+			}
+			if actual := strings.TrimSpace(sb.String()); actual != c.expected {
+				t.Errorf("got %s, expected %s", actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestProblemErrorEncoder(t *testing.T) {
+	w := httptest.NewRecorder()
+	encode := ProblemErrorEncoder(ResponseEncoder)
+	if err := encode(context.Background(), w, goa.TemporaryError("unavailable", "try later")); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusServiceUnavailable)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("got content type %q, expected %q", ct, ProblemContentType)
+	}
+	var p ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != http.StatusServiceUnavailable {
+		t.Errorf("got problem status %d, expected %d", p.Status, http.StatusServiceUnavailable)
+	}
+	if p.Detail != "try later" {
+		t.Errorf("got problem detail %q, expected %q", p.Detail, "try later")
+	}
+	if p.Extensions["name"] != "unavailable" || p.Extensions["temporary"] != true {
+		t.Errorf("got problem extensions %v, expected name and temporary members", p.Extensions)
+	}
+}
+
+func TestProblemDecoder(t *testing.T) {
+	cases := []struct {
+		name     string
+		ct       string
+		body     string
+		expected ErrorResponse
+	}{
+		{"problem", ProblemContentType,
+			`{"type":"about:blank","title":"Not Found","status":404,"detail":"missing","instance":"abc","name":"not_found","fault":true}`,
+			ErrorResponse{Name: "not_found", ID: "abc", Message: "missing", Fault: true}},
+		{"extensions-first", ProblemContentType,
+			`{"status":404,"detail":"missing","message":"original","name":"not_found"}`,
+			ErrorResponse{Name: "not_found", Message: "original"}},
+		{"json", "application/json",
+			`{"name":"not_found","id":"abc","message":"missing"}`,
+			ErrorResponse{Name: "not_found", ID: "abc", Message: "missing"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{"Content-Type": {c.ct}},
+				Body:   ioutil.NopCloser(strings.NewReader(c.body)),
+			}
+			var actual ErrorResponse
+			if err := ProblemDecoder(ResponseDecoder)(resp).Decode(&actual); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("got %+v, expected %+v", actual, c.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// ProtobufContentType is the media type of protocol buffer encoded content.
+const ProtobufContentType = "application/x-protobuf"
+
+type (
+	// ProtoConverter converts HTTP bodies of a given type to and from the
+	// protocol buffer message used to encode them with the
+	// application/x-protobuf codec.
+	ProtoConverter struct {
+		// NewMessage returns a new empty protocol buffer message.
+		NewMessage func() proto.Message
+		// ToMessage builds the protocol buffer message from a pointer to
+		// the HTTP body. It may be nil if the body is never encoded.
+		ToMessage func(body interface{}) proto.Message
+		// FromMessage builds a pointer to the HTTP body from the protocol
+		// buffer message. It may be nil if the body is never decoded.
+		FromMessage func(msg proto.Message) interface{}
+	}
+)
+
+var (
+	// protoConverters maps HTTP body types to the converters used to
+	// encode and decode them.
+	protoConverters = make(map[reflect.Type]*ProtoConverter)
+	// protoConvertersMu protects protoConverters.
+	protoConvertersMu sync.RWMutex
+)
+
+// RegisterProtoMessage registers the converter used by the
+// application/x-protobuf codec to encode and decode HTTP bodies of the type of
+// body. This makes it possible to reuse the protocol buffer types generated
+// for the gRPC transport of a service to encode the bodies of its HTTP
+// transport. The code generated for services that define both transports
+// registers the converters of the request and response bodies automatically.
+//
+// Example:
+//
+//    goahttp.RegisterProtoMessage(&AddRequestBody{}, &goahttp.ProtoConverter{
+//        NewMessage: func() proto.Message { return &calcpb.AddRequest{} },
+//        FromMessage: func(msg proto.Message) interface{} {
+//            return NewAddRequestBodyFromProto(msg.(*calcpb.AddRequest))
+//        },
+//    })
+//
+func RegisterProtoMessage(body interface{}, c *ProtoConverter) {
+	protoConvertersMu.Lock()
+	defer protoConvertersMu.Unlock()
+	protoConverters[indirectType(reflect.TypeOf(body))] = c
+}
+
+// NewProtobufEncoder returns an encoder that writes values to w using the
+// protocol buffer binary format. The values must either be protocol buffer
+// messages or have a type registered with RegisterProtoMessage.
+func NewProtobufEncoder(w io.Writer) Encoder {
+	return EncodingFunc(func(v interface{}) error {
+		msg, ok := v.(proto.Message)
+		if !ok {
+			c := protoConverter(v)
+			if c == nil || c.ToMessage == nil {
+				return fmt.Errorf("can't encode %T as %s, the type must be a protocol buffer message or registered with RegisterProtoMessage", v, ProtobufContentType)
+			}
+			msg = c.ToMessage(bodyPtr(reflect.ValueOf(v)).Interface())
+		}
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	})
+}
+
+// NewProtobufDecoder returns a decoder that reads protocol buffer encoded
+// values from r. The values must either be protocol buffer messages or have
+// a type registered with RegisterProtoMessage.
+func NewProtobufDecoder(r io.Reader) Decoder {
+	return EncodingFunc(func(v interface{}) error {
+		msg, ok := v.(proto.Message)
+		var c *ProtoConverter
+		if !ok {
+			c = protoConverter(v)
+			if c == nil || c.FromMessage == nil || reflect.ValueOf(v).Kind() != reflect.Ptr {
+				return fmt.Errorf("can't decode %s content to %T, the type must be a protocol buffer message or registered with RegisterProtoMessage", ProtobufContentType, v)
+			}
+			msg = c.NewMessage()
+		}
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if err := proto.Unmarshal(b, msg); err != nil {
+			return err
+		}
+		if c == nil {
+			return nil
+		}
+		body := reflect.ValueOf(c.FromMessage(msg))
+		bodyPtr(reflect.ValueOf(v)).Elem().Set(body.Elem())
+		return nil
+	})
+}
+
+// protoConverter returns the converter registered for the type of v, nil if
+// there is none.
+func protoConverter(v interface{}) *ProtoConverter {
+	protoConvertersMu.RLock()
+	defer protoConvertersMu.RUnlock()
+	return protoConverters[indirectType(reflect.TypeOf(v))]
+}
+
+// bodyPtr returns a pointer to the body held by v. v may be the body, a
+// pointer to the body or a pointer to a pointer to the body. Nil pointers to
+// pointers are initialized.
+func bodyPtr(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Ptr {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p
+	}
+	for v.Elem().Kind() == reflect.Ptr {
+		if v.Elem().IsNil() {
+			v.Elem().Set(reflect.New(v.Elem().Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// indirectType returns the type pointed to by t if t is a pointer, t
+// otherwise.
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
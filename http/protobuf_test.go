@@ -0,0 +1,106 @@
+package http_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/duration"
+	goahttp "goa.design/goa/v3/http"
+	peoplepb "goa.design/goa/v3/http/testdata/protobuf/gen/grpc/people/pb"
+	"goa.design/goa/v3/http/testdata/protobuf/gen/http/people/server"
+)
+
+func TestProtobufMessage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := goahttp.NewProtobufEncoder(&buf).Encode(&duration.Duration{Seconds: 3}); err != nil {
+		t.Fatal(err)
+	}
+	var d duration.Duration
+	if err := goahttp.NewProtobufDecoder(&buf).Decode(&d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Seconds != 3 {
+		t.Errorf("got %d seconds, expected 3", d.Seconds)
+	}
+}
+
+func TestProtobufRequestBody(t *testing.T) {
+	msg := &peoplepb.AddRequest{
+		FirstName: "Ada",
+		Tags:      []*peoplepb.ArrayOfString{{Field: []string{"a", "b"}}, {Field: []string{"c"}}},
+		Friend:    &peoplepb.Person{FirstName: "Bob", Contact: &peoplepb.Person_Phone{Phone: 42}},
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body server.AddRequestBody
+	if err := goahttp.NewProtobufDecoder(bytes.NewReader(b)).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.FirstName == nil || *body.FirstName != "Ada" {
+		t.Errorf("got first name %v, expected %q", body.FirstName, "Ada")
+	}
+	if !reflect.DeepEqual(body.Tags, [][]string{{"a", "b"}, {"c"}}) {
+		t.Errorf("got tags %v", body.Tags)
+	}
+	f := body.Friend
+	if f == nil || f.FirstName == nil || *f.FirstName != "Bob" || f.Contact == nil || f.Contact.Phone == nil || *f.Contact.Phone != 42 || f.Contact.Email != nil {
+		t.Errorf("got friend %+v, expected Bob with phone 42", f)
+	}
+}
+
+func TestProtobufResponseBody(t *testing.T) {
+	email := "bob@goa.design"
+	body := &server.AddResponseBody{
+		FirstName: "Ada",
+		Tags:      [][]string{{"a"}},
+		Friend:    &server.PersonResponseBody{FirstName: "Bob", Contact: &server.PersonContactResponseBody{Email: &email}},
+	}
+	var buf bytes.Buffer
+	if err := goahttp.NewProtobufEncoder(&buf).Encode(body); err != nil {
+		t.Fatal(err)
+	}
+	var msg peoplepb.AddResponse
+	if err := proto.Unmarshal(buf.Bytes(), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.FirstName != "Ada" || len(msg.Tags) != 1 || !reflect.DeepEqual(msg.Tags[0].Field, []string{"a"}) {
+		t.Errorf("got message %v", &msg)
+	}
+	if msg.Friend == nil || msg.Friend.GetEmail() != email {
+		t.Errorf("got friend %v, expected email %q", msg.Friend, email)
+	}
+
+	buf.Reset()
+	if err := goahttp.NewProtobufEncoder(&buf).Encode(&server.AddResponseBodyTiny{FirstName: "Ada"}); err != nil {
+		t.Fatal(err)
+	}
+	msg.Reset()
+	if err := proto.Unmarshal(buf.Bytes(), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.FirstName != "Ada" || msg.Tags != nil || msg.Friend != nil {
+		t.Errorf("got message %v, expected only first name", &msg)
+	}
+}
+
+func TestProtobufUnregistered(t *testing.T) {
+	type unregistered struct{ Name string }
+	err := goahttp.NewProtobufEncoder(&bytes.Buffer{}).Encode(&unregistered{})
+	if err == nil || !strings.Contains(err.Error(), "RegisterProtoMessage") {
+		t.Errorf("got error %v, expected unregistered type error", err)
+	}
+	err = goahttp.NewProtobufDecoder(&bytes.Buffer{}).Decode(&unregistered{})
+	if err == nil || !strings.Contains(err.Error(), "RegisterProtoMessage") {
+		t.Errorf("got error %v, expected unregistered type error", err)
+	}
+	// server request bodies are only decoded
+	err = goahttp.NewProtobufEncoder(&bytes.Buffer{}).Encode(&server.AddRequestBody{})
+	if err == nil {
+		t.Error("expected an error encoding a server request body, got none")
+	}
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+const (
+	// EventStreamContentType is the content type of server-sent events
+	// streams.
+	EventStreamContentType = "text/event-stream"
+
+	// EventStreamErrorEvent is the name of the event whose data is the
+	// JSON encoded ErrorResponse describing the error that ended the
+	// stream.
+	EventStreamErrorEvent = "error"
+)
+
+type (
+	// EventStream writes server-sent events to a HTTP response. The
+	// response headers are written when the first event is sent or when
+	// Start is called, whichever happens first. EventStream methods may be
+	// called concurrently.
+	EventStream struct {
+		w       http.ResponseWriter
+		mu      sync.Mutex
+		started bool
+	}
+
+	// EventReader reads server-sent events from a HTTP response body.
+	EventReader struct {
+		body io.ReadCloser
+		r    *bufio.Reader
+	}
+)
+
+// NewEventStream returns an event stream that writes to w.
+func NewEventStream(w http.ResponseWriter) *EventStream {
+	return &EventStream{w: w}
+}
+
+// Header returns the response headers. Changes made after the stream has
+// started have no effect.
+func (s *EventStream) Header() http.Header {
+	return s.w.Header()
+}
+
+// Start writes the response headers if not already done.
+func (s *EventStream) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+}
+
+// Started returns true if the response headers have been written. Errors
+// cannot be written to the response once the stream has started.
+func (s *EventStream) Started() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// Send writes an event whose data is the JSON encoding of v and flushes the
+// response.
+func (s *EventStream) Send(v interface{}) error {
+	return s.SendEvent("", v)
+}
+
+// SendEvent writes an event with the given name whose data is the JSON
+// encoding of v and flushes the response. The event has no name if event is
+// empty.
+func (s *EventStream) SendEvent(event string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if event != "" {
+		buf.WriteString("event: " + event + "\n")
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+	if _, err := s.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	s.flush()
+	return nil
+}
+
+// SendError reports err to the client in an event named
+// EventStreamErrorEvent whose data is the JSON encoding of the corresponding
+// ErrorResponse. SendError is used to report errors that occur once the
+// stream has started.
+func (s *EventStream) SendError(err error) error {
+	return s.SendEvent(EventStreamErrorEvent, NewErrorResponse(err))
+}
+
+// start writes the response headers if not already done. The caller must hold
+// the lock.
+func (s *EventStream) start() {
+	if s.started {
+		return
+	}
+	h := s.w.Header()
+	h.Set("Content-Type", EventStreamContentType)
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	s.w.WriteHeader(http.StatusOK)
+	s.flush()
+	s.started = true
+}
+
+// flush sends any buffered data to the client.
+func (s *EventStream) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// IsEventStream returns true if the response content type is
+// text/event-stream.
+func IsEventStream(resp *http.Response) bool {
+	mt, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mt == EventStreamContentType
+}
+
+// NewEventReader returns a reader that reads the server-sent events from
+// body.
+func NewEventReader(body io.ReadCloser) *EventReader {
+	return &EventReader{body: body, r: bufio.NewReader(body)}
+}
+
+// Next returns the data of the next event. Multiple data lines are joined
+// with newlines, comments and the event, id and retry fields are ignored.
+// Next returns io.EOF once the stream is closed.
+func (r *EventReader) Next() ([]byte, error) {
+	_, data, err := r.NextEvent()
+	return data, err
+}
+
+// NextEvent returns the name and data of the next event. The name is empty if
+// the event does not set it. NextEvent returns io.EOF once the stream is
+// closed.
+func (r *EventReader) NextEvent() (string, []byte, error) {
+	var (
+		event   string
+		data    []byte
+		hasData bool
+	)
+	for {
+		line, err := r.r.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			if err == io.EOF && hasData {
+				return event, data, nil
+			}
+			return "", nil, err
+		}
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			if hasData {
+				return event, data, nil
+			}
+			event = ""
+			continue
+		}
+		if line[0] == ':' {
+			continue // comment
+		}
+		field, value := line, []byte(nil)
+		if i := bytes.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], bytes.TrimPrefix(line[i+1:], []byte(" "))
+		}
+		switch string(field) {
+		case "data":
+			if hasData {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+			hasData = true
+		case "event":
+			event = string(value)
+		}
+	}
+}
+
+// Decode reads the next event and decodes its JSON data into v. Decode
+// returns the *goa.ServiceError described by the event if it is named
+// EventStreamErrorEvent and io.EOF once the stream is closed.
+func (r *EventReader) Decode(v interface{}) error {
+	event, data, err := r.NextEvent()
+	if err != nil {
+		return err
+	}
+	if event == EventStreamErrorEvent {
+		var resp ErrorResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return fmt.Errorf("invalid %s event %q: %s", EventStreamErrorEvent, data, err)
+		}
+		return resp.serviceError()
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Close closes the underlying response body.
+func (r *EventReader) Close() error {
+	return r.body.Close()
+}
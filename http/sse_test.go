@@ -0,0 +1,139 @@
+package http
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestEventStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	s := NewEventStream(w)
+	if s.Started() {
+		t.Fatal("stream started before sending events")
+	}
+	s.Header().Set("goa-view", "tiny")
+	if err := s.Send(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SendEvent("update", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Started() {
+		t.Error("stream not started after sending events")
+	}
+	if ct := w.Header().Get("Content-Type"); ct != EventStreamContentType {
+		t.Errorf("got content type %q, expected %q", ct, EventStreamContentType)
+	}
+	if v := w.Header().Get("goa-view"); v != "tiny" {
+		t.Errorf("got view header %q, expected %q", v, "tiny")
+	}
+	expected := "data: {\"a\":1}\n\nevent: update\ndata: \"x\"\n\n"
+	if body := w.Body.String(); body != expected {
+		t.Errorf("got body %q, expected %q", body, expected)
+	}
+}
+
+func TestEventStreamError(t *testing.T) {
+	w := httptest.NewRecorder()
+	s := NewEventStream(w)
+	if err := s.Send(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SendError(goa.Fault("boom")); err != nil {
+		t.Fatal(err)
+	}
+	r := NewEventReader(ioutil.NopCloser(w.Body))
+	var v int
+	if err := r.Decode(&v); err != nil || v != 1 {
+		t.Fatalf("got value %d and error %v, expected 1 and no error", v, err)
+	}
+	err := r.Decode(&v)
+	serr, ok := err.(*goa.ServiceError)
+	if !ok {
+		t.Fatalf("got error %#v, expected a service error", err)
+	}
+	if !serr.Fault || serr.Message != "boom" {
+		t.Errorf("got error %+v, expected fault with message %q", serr, "boom")
+	}
+}
+
+func TestEventStreamConcurrentSend(t *testing.T) {
+	const n = 50
+	w := httptest.NewRecorder()
+	s := NewEventStream(w)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.Started()
+			if err := s.Send(map[string]int{"a": i}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	r := NewEventReader(ioutil.NopCloser(w.Body))
+	seen := make(map[int]bool)
+	for {
+		var v struct{ A int }
+		err := r.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[v.A] = true
+	}
+	if len(seen) != n {
+		t.Errorf("got %d distinct values, expected %d", len(seen), n)
+	}
+}
+
+func TestEventReader(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"single", "data: 1\n\n", []string{"1"}},
+		{"multiple", "data: 1\n\ndata: 2\n\n", []string{"1", "2"}},
+		{"multi-line", "data: [1,\ndata: 2]\n\n", []string{"[1,\n2]"}},
+		{"fields", ": comment\nevent: e\nid: 1\nretry: 10\ndata: 1\n\n", []string{"1"}},
+		{"crlf", "data: 1\r\n\r\n", []string{"1"}},
+		{"no-space", "data:1\n\n", []string{"1"}},
+		{"unterminated", "data: 1", []string{"1"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := NewEventReader(ioutil.NopCloser(strings.NewReader(c.body)))
+			var actual []string
+			for {
+				data, err := r.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				actual = append(actual, string(data))
+			}
+			if len(actual) != len(c.expected) {
+				t.Fatalf("got %d events, expected %d", len(actual), len(c.expected))
+			}
+			for i, a := range actual {
+				if a != c.expected[i] {
+					t.Errorf("got event data %q at index %d, expected %q", a, i, c.expected[i])
+				}
+			}
+		})
+	}
+}
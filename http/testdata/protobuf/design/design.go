@@ -0,0 +1,51 @@
+package design
+
+import . "goa.design/goa/v3/dsl"
+
+var _ = API("people", func() {
+	Title("Protocol buffer codec test API")
+})
+
+var Person = Type("Person", func() {
+	Field(1, "firstName", String)
+	Field(2, "tags", ArrayOf(ArrayOf(String)))
+	OneOf("contact", func() {
+		Field(3, "email", String)
+		Field(4, "phone", Int)
+	})
+	Required("firstName")
+})
+
+var PersonResult = ResultType("application/vnd.person", func() {
+	TypeName("PersonResult")
+	Attributes(func() {
+		Field(1, "firstName", String)
+		Field(2, "tags", ArrayOf(ArrayOf(String)))
+		Field(3, "friend", Person)
+		Required("firstName")
+	})
+	View("default", func() {
+		Attribute("firstName")
+		Attribute("tags")
+		Attribute("friend")
+	})
+	View("tiny", func() {
+		Attribute("firstName")
+	})
+})
+
+var _ = Service("people", func() {
+	Method("add", func() {
+		Payload(func() {
+			Field(1, "firstName", String)
+			Field(2, "tags", ArrayOf(ArrayOf(String)))
+			Field(3, "friend", Person)
+			Required("firstName")
+		})
+		Result(PersonResult)
+		HTTP(func() {
+			POST("/people")
+		})
+		GRPC(func() {})
+	})
+})
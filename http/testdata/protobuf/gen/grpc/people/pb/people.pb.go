@@ -0,0 +1,372 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: people.proto
+
+package peoplepb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type AddRequest struct {
+	FirstName            string           `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	Tags                 []*ArrayOfString `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Friend               *Person          `protobuf:"bytes,3,opt,name=friend,proto3" json:"friend,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *AddRequest) Reset()         { *m = AddRequest{} }
+func (m *AddRequest) String() string { return proto.CompactTextString(m) }
+func (*AddRequest) ProtoMessage()    {}
+func (*AddRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09461903b56db210, []int{0}
+}
+
+func (m *AddRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddRequest.Unmarshal(m, b)
+}
+func (m *AddRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddRequest.Marshal(b, m, deterministic)
+}
+func (m *AddRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddRequest.Merge(m, src)
+}
+func (m *AddRequest) XXX_Size() int {
+	return xxx_messageInfo_AddRequest.Size(m)
+}
+func (m *AddRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddRequest proto.InternalMessageInfo
+
+func (m *AddRequest) GetFirstName() string {
+	if m != nil {
+		return m.FirstName
+	}
+	return ""
+}
+
+func (m *AddRequest) GetTags() []*ArrayOfString {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *AddRequest) GetFriend() *Person {
+	if m != nil {
+		return m.Friend
+	}
+	return nil
+}
+
+type ArrayOfString struct {
+	Field                []string `protobuf:"bytes,1,rep,name=field,proto3" json:"field,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArrayOfString) Reset()         { *m = ArrayOfString{} }
+func (m *ArrayOfString) String() string { return proto.CompactTextString(m) }
+func (*ArrayOfString) ProtoMessage()    {}
+func (*ArrayOfString) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09461903b56db210, []int{1}
+}
+
+func (m *ArrayOfString) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArrayOfString.Unmarshal(m, b)
+}
+func (m *ArrayOfString) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArrayOfString.Marshal(b, m, deterministic)
+}
+func (m *ArrayOfString) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArrayOfString.Merge(m, src)
+}
+func (m *ArrayOfString) XXX_Size() int {
+	return xxx_messageInfo_ArrayOfString.Size(m)
+}
+func (m *ArrayOfString) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArrayOfString.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArrayOfString proto.InternalMessageInfo
+
+func (m *ArrayOfString) GetField() []string {
+	if m != nil {
+		return m.Field
+	}
+	return nil
+}
+
+type Person struct {
+	FirstName string           `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	Tags      []*ArrayOfString `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Types that are valid to be assigned to Contact:
+	//	*Person_Email
+	//	*Person_Phone
+	Contact              isPerson_Contact `protobuf_oneof:"contact"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Person) Reset()         { *m = Person{} }
+func (m *Person) String() string { return proto.CompactTextString(m) }
+func (*Person) ProtoMessage()    {}
+func (*Person) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09461903b56db210, []int{2}
+}
+
+func (m *Person) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Person.Unmarshal(m, b)
+}
+func (m *Person) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Person.Marshal(b, m, deterministic)
+}
+func (m *Person) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Person.Merge(m, src)
+}
+func (m *Person) XXX_Size() int {
+	return xxx_messageInfo_Person.Size(m)
+}
+func (m *Person) XXX_DiscardUnknown() {
+	xxx_messageInfo_Person.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Person proto.InternalMessageInfo
+
+func (m *Person) GetFirstName() string {
+	if m != nil {
+		return m.FirstName
+	}
+	return ""
+}
+
+func (m *Person) GetTags() []*ArrayOfString {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type isPerson_Contact interface {
+	isPerson_Contact()
+}
+
+type Person_Email struct {
+	Email string `protobuf:"bytes,3,opt,name=email,proto3,oneof"`
+}
+
+type Person_Phone struct {
+	Phone int32 `protobuf:"zigzag32,4,opt,name=phone,proto3,oneof"`
+}
+
+func (*Person_Email) isPerson_Contact() {}
+
+func (*Person_Phone) isPerson_Contact() {}
+
+func (m *Person) GetContact() isPerson_Contact {
+	if m != nil {
+		return m.Contact
+	}
+	return nil
+}
+
+func (m *Person) GetEmail() string {
+	if x, ok := m.GetContact().(*Person_Email); ok {
+		return x.Email
+	}
+	return ""
+}
+
+func (m *Person) GetPhone() int32 {
+	if x, ok := m.GetContact().(*Person_Phone); ok {
+		return x.Phone
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Person) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Person_Email)(nil),
+		(*Person_Phone)(nil),
+	}
+}
+
+type AddResponse struct {
+	FirstName            string           `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	Tags                 []*ArrayOfString `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Friend               *Person          `protobuf:"bytes,3,opt,name=friend,proto3" json:"friend,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *AddResponse) Reset()         { *m = AddResponse{} }
+func (m *AddResponse) String() string { return proto.CompactTextString(m) }
+func (*AddResponse) ProtoMessage()    {}
+func (*AddResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09461903b56db210, []int{3}
+}
+
+func (m *AddResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddResponse.Unmarshal(m, b)
+}
+func (m *AddResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddResponse.Marshal(b, m, deterministic)
+}
+func (m *AddResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddResponse.Merge(m, src)
+}
+func (m *AddResponse) XXX_Size() int {
+	return xxx_messageInfo_AddResponse.Size(m)
+}
+func (m *AddResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddResponse proto.InternalMessageInfo
+
+func (m *AddResponse) GetFirstName() string {
+	if m != nil {
+		return m.FirstName
+	}
+	return ""
+}
+
+func (m *AddResponse) GetTags() []*ArrayOfString {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *AddResponse) GetFriend() *Person {
+	if m != nil {
+		return m.Friend
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*AddRequest)(nil), "people.AddRequest")
+	proto.RegisterType((*ArrayOfString)(nil), "people.ArrayOfString")
+	proto.RegisterType((*Person)(nil), "people.Person")
+	proto.RegisterType((*AddResponse)(nil), "people.AddResponse")
+}
+
+func init() { proto.RegisterFile("people.proto", fileDescriptor_09461903b56db210) }
+
+var fileDescriptor_09461903b56db210 = []byte{
+	// 270 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x92, 0x4f, 0x4b, 0xc4, 0x30,
+	0x10, 0xc5, 0x8d, 0xdd, 0xad, 0x76, 0xaa, 0x82, 0xf1, 0x0f, 0x45, 0x10, 0x4a, 0x41, 0xa9, 0x97,
+	0x1e, 0xea, 0xc5, 0x6b, 0xf7, 0xe4, 0x49, 0x25, 0xde, 0xbc, 0x48, 0x76, 0x33, 0x5d, 0x0b, 0x6d,
+	0x12, 0x93, 0x78, 0xf0, 0xa2, 0x9f, 0xc0, 0xef, 0x2c, 0x4d, 0xd6, 0x85, 0xbd, 0xcb, 0x1e, 0xdf,
+	0x6f, 0x66, 0x1e, 0x8f, 0x97, 0xc0, 0x81, 0x46, 0xa5, 0x7b, 0xac, 0xb4, 0x51, 0x4e, 0xd1, 0x38,
+	0xa8, 0xe2, 0x0b, 0xa0, 0x11, 0x82, 0xe1, 0xfb, 0x07, 0x5a, 0x47, 0x2f, 0x01, 0xda, 0xce, 0x58,
+	0xf7, 0x2a, 0xf9, 0x80, 0x19, 0xc9, 0x49, 0x99, 0xb0, 0xc4, 0x93, 0x07, 0x3e, 0x20, 0xbd, 0x81,
+	0x89, 0xe3, 0x4b, 0x9b, 0xed, 0xe6, 0x51, 0x99, 0xd6, 0x67, 0xd5, 0xca, 0xb1, 0x31, 0x86, 0x7f,
+	0x3e, 0xb6, 0xcf, 0xce, 0x74, 0x72, 0xc9, 0xfc, 0x0a, 0xbd, 0x86, 0xb8, 0x35, 0x1d, 0x4a, 0x91,
+	0x45, 0x39, 0x29, 0xd3, 0xfa, 0xe8, 0x6f, 0xf9, 0x09, 0x8d, 0x55, 0x92, 0xad, 0xa6, 0xc5, 0x15,
+	0x1c, 0x6e, 0x9c, 0xd3, 0x53, 0x98, 0xb6, 0x1d, 0xf6, 0x22, 0x23, 0x79, 0x54, 0x26, 0x2c, 0x88,
+	0xe2, 0x87, 0x40, 0x1c, 0x2e, 0xff, 0x31, 0xe3, 0x39, 0x4c, 0x71, 0xe0, 0x5d, 0xef, 0x23, 0x26,
+	0xf7, 0x3b, 0x2c, 0xc8, 0x91, 0xeb, 0x37, 0x25, 0x31, 0x9b, 0xe4, 0xa4, 0x3c, 0x1e, 0xb9, 0x97,
+	0xb3, 0x04, 0xf6, 0x16, 0x4a, 0x3a, 0xbe, 0x70, 0xc5, 0x37, 0xa4, 0xbe, 0x36, 0xab, 0x95, 0xb4,
+	0xb8, 0xfd, 0xde, 0xea, 0xbb, 0xb1, 0x8f, 0x71, 0x40, 0x2b, 0x88, 0x1a, 0x21, 0x28, 0x5d, 0xbb,
+	0xae, 0x9f, 0xf3, 0xe2, 0x64, 0x83, 0x85, 0xac, 0x33, 0x78, 0xd9, 0x0f, 0x54, 0xcf, 0xe7, 0xb1,
+	0xff, 0x0c, 0xb7, 0xbf, 0x03, 0x00, 0xe8, 0xf4, 0x8e, 0x91, 0x1c, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PeopleClient is the client API for People service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PeopleClient interface {
+	// Add implements add.
+	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error)
+}
+
+type peopleClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPeopleClient(cc *grpc.ClientConn) PeopleClient {
+	return &peopleClient{cc}
+}
+
+func (c *peopleClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error) {
+	out := new(AddResponse)
+	err := c.cc.Invoke(ctx, "/people.People/Add", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PeopleServer is the server API for People service.
+type PeopleServer interface {
+	// Add implements add.
+	Add(context.Context, *AddRequest) (*AddResponse, error)
+}
+
+func RegisterPeopleServer(s *grpc.Server, srv PeopleServer) {
+	s.RegisterService(&_People_serviceDesc, srv)
+}
+
+func _People_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeopleServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/people.People/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeopleServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _People_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "people.People",
+	HandlerType: (*PeopleServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _People_Add_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "people.proto",
+}
@@ -0,0 +1,142 @@
+// Code generated by goa v3.0.6, DO NOT EDIT.
+//
+// people HTTP server encoders and decoders
+//
+// Command:
+// $ goa gen goa.design/goa/v3/http/testdata/protobuf/design
+
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+	people "goa.design/goa/v3/http/testdata/protobuf/gen/people"
+	peopleviews "goa.design/goa/v3/http/testdata/protobuf/gen/people/views"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// EncodeAddResponse returns an encoder for responses returned by the people
+// add endpoint.
+func EncodeAddResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*peopleviews.PersonResult)
+		w.Header().Set("goa-view", res.View)
+		enc := encoder(ctx, w)
+		var body interface{}
+		switch res.View {
+		case "default", "":
+			body = NewAddResponseBody(res.Projected)
+		case "tiny":
+			body = NewAddResponseBodyTiny(res.Projected)
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeAddRequest returns a decoder for requests sent to the people add
+// endpoint.
+func DecodeAddRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body AddRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateAddRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewAddPayload(&body)
+
+		return payload, nil
+	}
+}
+
+// unmarshalPersonRequestBodyToPeoplePerson builds a value of type
+// *people.Person from a value of type *PersonRequestBody.
+func unmarshalPersonRequestBodyToPeoplePerson(v *PersonRequestBody) *people.Person {
+	if v == nil {
+		return nil
+	}
+	res := &people.Person{
+		FirstName: *v.FirstName,
+	}
+	if v.Tags != nil {
+		res.Tags = make([][]string, len(v.Tags))
+		for i, val := range v.Tags {
+			res.Tags[i] = make([]string, len(val))
+			for j, val := range val {
+				res.Tags[i][j] = val
+			}
+		}
+	}
+	if v.Contact != nil {
+		res.Contact = unmarshalPersonContactRequestBodyToPeoplePersonContact(v.Contact)
+	}
+
+	return res
+}
+
+// unmarshalPersonContactRequestBodyToPeoplePersonContact builds a value of
+// type *people.PersonContact from a value of type *PersonContactRequestBody.
+func unmarshalPersonContactRequestBodyToPeoplePersonContact(v *PersonContactRequestBody) *people.PersonContact {
+	if v == nil {
+		return nil
+	}
+	res := &people.PersonContact{
+		Email: v.Email,
+		Phone: v.Phone,
+	}
+
+	return res
+}
+
+// marshalPeopleviewsPersonViewToPersonResponseBody builds a value of type
+// *PersonResponseBody from a value of type *peopleviews.PersonView.
+func marshalPeopleviewsPersonViewToPersonResponseBody(v *peopleviews.PersonView) *PersonResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &PersonResponseBody{
+		FirstName: *v.FirstName,
+	}
+	if v.Tags != nil {
+		res.Tags = make([][]string, len(v.Tags))
+		for i, val := range v.Tags {
+			res.Tags[i] = make([]string, len(val))
+			for j, val := range val {
+				res.Tags[i][j] = val
+			}
+		}
+	}
+	if v.Contact != nil {
+		res.Contact = marshalPeopleviewsPersonContactViewToPersonContactResponseBody(v.Contact)
+	}
+
+	return res
+}
+
+// marshalPeopleviewsPersonContactViewToPersonContactResponseBody builds a
+// value of type *PersonContactResponseBody from a value of type
+// *peopleviews.PersonContactView.
+func marshalPeopleviewsPersonContactViewToPersonContactResponseBody(v *peopleviews.PersonContactView) *PersonContactResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &PersonContactResponseBody{
+		Email: v.Email,
+		Phone: v.Phone,
+	}
+
+	return res
+}
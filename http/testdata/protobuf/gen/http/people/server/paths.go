@@ -0,0 +1,13 @@
+// Code generated by goa v3.0.6, DO NOT EDIT.
+//
+// HTTP request path constructors for the people service.
+//
+// Command:
+// $ goa gen goa.design/goa/v3/http/testdata/protobuf/design
+
+package server
+
+// AddPeoplePath returns the URL path to the people service add HTTP endpoint.
+func AddPeoplePath() string {
+	return "/people"
+}
@@ -0,0 +1,146 @@
+// Code generated by goa v3.0.6, DO NOT EDIT.
+//
+// people HTTP server protocol buffer conversions
+//
+// Command:
+// $ goa gen goa.design/goa/v3/http/testdata/protobuf/design
+
+package server
+
+import (
+	"github.com/golang/protobuf/proto"
+	goahttp "goa.design/goa/v3/http"
+	peoplepb "goa.design/goa/v3/http/testdata/protobuf/gen/grpc/people/pb"
+)
+
+func init() {
+	goahttp.RegisterProtoMessage(&AddRequestBody{}, &goahttp.ProtoConverter{
+		NewMessage:  func() proto.Message { return &peoplepb.AddRequest{} },
+		FromMessage: func(msg proto.Message) interface{} { return NewAddRequestBodyFromProto(msg.(*peoplepb.AddRequest)) },
+	})
+	goahttp.RegisterProtoMessage(&AddResponseBody{}, &goahttp.ProtoConverter{
+		NewMessage: func() proto.Message { return &peoplepb.AddResponse{} },
+		ToMessage:  func(body interface{}) proto.Message { return NewProtoFromAddResponseBody(body.(*AddResponseBody)) },
+	})
+	goahttp.RegisterProtoMessage(&AddResponseBodyTiny{}, &goahttp.ProtoConverter{
+		NewMessage: func() proto.Message { return &peoplepb.AddResponse{} },
+		ToMessage: func(body interface{}) proto.Message {
+			return NewProtoFromAddResponseBodyTiny(body.(*AddResponseBodyTiny))
+		},
+	})
+}
+
+// NewAddRequestBodyFromProto builds the HTTP request body AddRequestBody from
+// the gRPC request message.
+func NewAddRequestBodyFromProto(message *peoplepb.AddRequest) *AddRequestBody {
+	v := &AddRequestBody{
+		FirstName: &message.FirstName,
+	}
+	if message.Tags != nil {
+		v.Tags = make([][]string, len(message.Tags))
+		for i, val := range message.Tags {
+			v.Tags[i] = make([]string, len(val.Field))
+			for j, val := range val.Field {
+				v.Tags[i][j] = val
+			}
+		}
+	}
+	if message.Friend != nil {
+		v.Friend = protobufPeoplepbPersonToPersonRequestBody(message.Friend)
+	}
+	return v
+}
+
+// NewProtoFromAddResponseBody builds the gRPC response message from the HTTP
+// response body AddResponseBody.
+func NewProtoFromAddResponseBody(body *AddResponseBody) *peoplepb.AddResponse {
+	v := &peoplepb.AddResponse{
+		FirstName: body.FirstName,
+	}
+	if body.Tags != nil {
+		v.Tags = make([]*peoplepb.ArrayOfString, len(body.Tags))
+		for i, val := range body.Tags {
+			v.Tags[i] = &peoplepb.ArrayOfString{}
+			v.Tags[i].Field = make([]string, len(val))
+			for j, val := range val {
+				v.Tags[i].Field[j] = val
+			}
+		}
+	}
+	if body.Friend != nil {
+		v.Friend = svcPersonResponseBodyToPeoplepbPerson(body.Friend)
+	}
+	return v
+}
+
+// NewProtoFromAddResponseBodyTiny builds the gRPC response message from the
+// HTTP response body AddResponseBodyTiny.
+func NewProtoFromAddResponseBodyTiny(body *AddResponseBodyTiny) *peoplepb.AddResponse {
+	v := &peoplepb.AddResponse{
+		FirstName: body.FirstName,
+	}
+	return v
+}
+
+// protobufPeoplepbPersonToPersonRequestBody builds a value of type
+// *PersonRequestBody from a value of type *peoplepb.Person.
+func protobufPeoplepbPersonToPersonRequestBody(v *peoplepb.Person) *PersonRequestBody {
+	if v == nil {
+		return nil
+	}
+	res := &PersonRequestBody{
+		FirstName: &v.FirstName,
+	}
+	if v.Tags != nil {
+		res.Tags = make([][]string, len(v.Tags))
+		for i, val := range v.Tags {
+			res.Tags[i] = make([]string, len(val.Field))
+			for j, val := range val.Field {
+				res.Tags[i][j] = val
+			}
+		}
+	}
+	if v.Contact != nil {
+		res.Contact = &PersonContactRequestBody{}
+		switch val := v.Contact.(type) {
+		case *peoplepb.Person_Email:
+			res.Contact.Email = &val.Email
+		case *peoplepb.Person_Phone:
+			ptr := int(val.Phone)
+			res.Contact.Phone = &ptr
+		}
+	}
+
+	return res
+}
+
+// svcPersonResponseBodyToPeoplepbPerson builds a value of type
+// *peoplepb.Person from a value of type *PersonResponseBody.
+func svcPersonResponseBodyToPeoplepbPerson(v *PersonResponseBody) *peoplepb.Person {
+	if v == nil {
+		return nil
+	}
+	res := &peoplepb.Person{
+		FirstName: v.FirstName,
+	}
+	if v.Tags != nil {
+		res.Tags = make([]*peoplepb.ArrayOfString, len(v.Tags))
+		for i, val := range v.Tags {
+			res.Tags[i] = &peoplepb.ArrayOfString{}
+			res.Tags[i].Field = make([]string, len(val))
+			for j, val := range val {
+				res.Tags[i].Field[j] = val
+			}
+		}
+	}
+	if v.Contact != nil {
+		switch {
+		case v.Contact.Email != nil:
+			res.Contact = &peoplepb.Person_Email{Email: *v.Contact.Email}
+		case v.Contact.Phone != nil:
+			res.Contact = &peoplepb.Person_Phone{Phone: int32(*v.Contact.Phone)}
+		}
+	}
+
+	return res
+}
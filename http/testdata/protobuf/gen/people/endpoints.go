@@ -0,0 +1,51 @@
+// Code generated by goa v3.0.6, DO NOT EDIT.
+//
+// people endpoints
+//
+// Command:
+// $ goa gen goa.design/goa/v3/http/testdata/protobuf/design
+
+package people
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Endpoints wraps the "people" service endpoints.
+type Endpoints struct {
+	Add goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "people" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Add: NewAddEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "people" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Add = m(e.Add)
+}
+
+// UseAdd applies the given middleware to the "add" endpoint of the "people"
+// service.
+func (e *Endpoints) UseAdd(m func(goa.Endpoint) goa.Endpoint) {
+	e.Add = m(e.Add)
+}
+
+// NewAddEndpoint returns an endpoint function that calls the method "add" of
+// service "people".
+func NewAddEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*AddPayload)
+		res, view, err := s.Add(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		vres := NewViewedPersonResult(res, view)
+		return vres, nil
+	}
+}
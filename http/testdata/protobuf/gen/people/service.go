@@ -0,0 +1,226 @@
+// Code generated by goa v3.0.6, DO NOT EDIT.
+//
+// people service
+//
+// Command:
+// $ goa gen goa.design/goa/v3/http/testdata/protobuf/design
+
+package people
+
+import (
+	"context"
+
+	peopleviews "goa.design/goa/v3/http/testdata/protobuf/gen/people/views"
+)
+
+// Service is the people service interface.
+type Service interface {
+	// Add implements add.
+	// The "view" return value must have one of the following views
+	//	- "default"
+	//	- "tiny"
+	Add(context.Context, *AddPayload) (res *PersonResult, view string, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "people"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"add"}
+
+// AddPayload is the payload type of the people service add method.
+type AddPayload struct {
+	FirstName string
+	Tags      [][]string
+	Friend    *Person
+}
+
+// PersonResult is the result type of the people service add method.
+type PersonResult struct {
+	FirstName string
+	Tags      [][]string
+	Friend    *Person
+}
+
+type Person struct {
+	FirstName string
+	Tags      [][]string
+	Contact   *PersonContact
+}
+
+type PersonContact struct {
+	Email *string
+	Phone *int
+}
+
+// NewPersonResult initializes result type PersonResult from viewed result type
+// PersonResult.
+func NewPersonResult(vres *peopleviews.PersonResult) *PersonResult {
+	var res *PersonResult
+	switch vres.View {
+	case "default", "":
+		res = newPersonResult(vres.Projected)
+	case "tiny":
+		res = newPersonResultTiny(vres.Projected)
+	}
+	return res
+}
+
+// NewViewedPersonResult initializes viewed result type PersonResult from
+// result type PersonResult using the given view.
+func NewViewedPersonResult(res *PersonResult, view string) *peopleviews.PersonResult {
+	var vres *peopleviews.PersonResult
+	switch view {
+	case "default", "":
+		p := newPersonResultView(res)
+		vres = &peopleviews.PersonResult{p, "default"}
+	case "tiny":
+		p := newPersonResultViewTiny(res)
+		vres = &peopleviews.PersonResult{p, "tiny"}
+	}
+	return vres
+}
+
+// newPersonResult converts projected type PersonResult to service type
+// PersonResult.
+func newPersonResult(vres *peopleviews.PersonResultView) *PersonResult {
+	res := &PersonResult{}
+	if vres.FirstName != nil {
+		res.FirstName = *vres.FirstName
+	}
+	if vres.Tags != nil {
+		res.Tags = make([][]string, len(vres.Tags))
+		for i, val := range vres.Tags {
+			res.Tags[i] = make([]string, len(val))
+			for j, val := range val {
+				res.Tags[i][j] = val
+			}
+		}
+	}
+	if vres.Friend != nil {
+		res.Friend = transformPeopleviewsPersonViewToPerson(vres.Friend)
+	}
+	return res
+}
+
+// newPersonResultTiny converts projected type PersonResult to service type
+// PersonResult.
+func newPersonResultTiny(vres *peopleviews.PersonResultView) *PersonResult {
+	res := &PersonResult{}
+	if vres.FirstName != nil {
+		res.FirstName = *vres.FirstName
+	}
+	return res
+}
+
+// newPersonResultView projects result type PersonResult to projected type
+// PersonResultView using the "default" view.
+func newPersonResultView(res *PersonResult) *peopleviews.PersonResultView {
+	vres := &peopleviews.PersonResultView{
+		FirstName: &res.FirstName,
+	}
+	if res.Tags != nil {
+		vres.Tags = make([][]string, len(res.Tags))
+		for i, val := range res.Tags {
+			vres.Tags[i] = make([]string, len(val))
+			for j, val := range val {
+				vres.Tags[i][j] = val
+			}
+		}
+	}
+	if res.Friend != nil {
+		vres.Friend = transformPersonToPeopleviewsPersonView(res.Friend)
+	}
+	return vres
+}
+
+// newPersonResultViewTiny projects result type PersonResult to projected type
+// PersonResultView using the "tiny" view.
+func newPersonResultViewTiny(res *PersonResult) *peopleviews.PersonResultView {
+	vres := &peopleviews.PersonResultView{
+		FirstName: &res.FirstName,
+	}
+	return vres
+}
+
+// transformPeopleviewsPersonViewToPerson builds a value of type *Person from a
+// value of type *peopleviews.PersonView.
+func transformPeopleviewsPersonViewToPerson(v *peopleviews.PersonView) *Person {
+	if v == nil {
+		return nil
+	}
+	res := &Person{
+		FirstName: *v.FirstName,
+	}
+	if v.Tags != nil {
+		res.Tags = make([][]string, len(v.Tags))
+		for i, val := range v.Tags {
+			res.Tags[i] = make([]string, len(val))
+			for j, val := range val {
+				res.Tags[i][j] = val
+			}
+		}
+	}
+	if v.Contact != nil {
+		res.Contact = transformPeopleviewsPersonContactViewToPersonContact(v.Contact)
+	}
+
+	return res
+}
+
+// transformPeopleviewsPersonContactViewToPersonContact builds a value of type
+// *PersonContact from a value of type *peopleviews.PersonContactView.
+func transformPeopleviewsPersonContactViewToPersonContact(v *peopleviews.PersonContactView) *PersonContact {
+	if v == nil {
+		return nil
+	}
+	res := &PersonContact{
+		Email: v.Email,
+		Phone: v.Phone,
+	}
+
+	return res
+}
+
+// transformPersonToPeopleviewsPersonView builds a value of type
+// *peopleviews.PersonView from a value of type *Person.
+func transformPersonToPeopleviewsPersonView(v *Person) *peopleviews.PersonView {
+	if v == nil {
+		return nil
+	}
+	res := &peopleviews.PersonView{
+		FirstName: &v.FirstName,
+	}
+	if v.Tags != nil {
+		res.Tags = make([][]string, len(v.Tags))
+		for i, val := range v.Tags {
+			res.Tags[i] = make([]string, len(val))
+			for j, val := range val {
+				res.Tags[i][j] = val
+			}
+		}
+	}
+	if v.Contact != nil {
+		res.Contact = transformPersonContactToPeopleviewsPersonContactView(v.Contact)
+	}
+
+	return res
+}
+
+// transformPersonContactToPeopleviewsPersonContactView builds a value of type
+// *peopleviews.PersonContactView from a value of type *PersonContact.
+func transformPersonContactToPeopleviewsPersonContactView(v *PersonContact) *peopleviews.PersonContactView {
+	if v == nil {
+		return nil
+	}
+	res := &peopleviews.PersonContactView{
+		Email: v.Email,
+		Phone: v.Phone,
+	}
+
+	return res
+}
@@ -0,0 +1,122 @@
+// Code generated by goa v3.0.6, DO NOT EDIT.
+//
+// people views
+//
+// Command:
+// $ goa gen goa.design/goa/v3/http/testdata/protobuf/design
+
+package views
+
+import (
+	goa "goa.design/goa/v3/pkg"
+)
+
+// PersonResult is the viewed result type that is projected based on a view.
+type PersonResult struct {
+	// Type to project
+	Projected *PersonResultView
+	// View to render
+	View string
+}
+
+// PersonResultView is a type that runs validations on a projected type.
+type PersonResultView struct {
+	FirstName *string
+	Tags      [][]string
+	Friend    *PersonView
+}
+
+// PersonView is a type that runs validations on a projected type.
+type PersonView struct {
+	FirstName *string
+	Tags      [][]string
+	Contact   *PersonContactView
+}
+
+// PersonContactView is a type that runs validations on a projected type.
+type PersonContactView struct {
+	Email *string
+	Phone *int
+}
+
+var (
+	// PersonResultMap is a map of attribute names in result type PersonResult
+	// indexed by view name.
+	PersonResultMap = map[string][]string{
+		"default": []string{
+			"firstName",
+			"tags",
+			"friend",
+		},
+		"tiny": []string{
+			"firstName",
+		},
+	}
+)
+
+// ValidatePersonResult runs the validations defined on the viewed result type
+// PersonResult.
+func ValidatePersonResult(result *PersonResult) (err error) {
+	switch result.View {
+	case "default", "":
+		err = ValidatePersonResultView(result.Projected)
+	case "tiny":
+		err = ValidatePersonResultViewTiny(result.Projected)
+	default:
+		err = goa.InvalidEnumValueError("view", result.View, []interface{}{"default", "tiny"})
+	}
+	return
+}
+
+// ValidatePersonResultView runs the validations defined on PersonResultView
+// using the "default" view.
+func ValidatePersonResultView(result *PersonResultView) (err error) {
+	if result.FirstName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("firstName", "result"))
+	}
+	if result.Friend != nil {
+		if err2 := ValidatePersonView(result.Friend); err2 != nil {
+			err = goa.MergeErrors(err, goa.NestedError(err2, "result.friend"))
+		}
+	}
+	return
+}
+
+// ValidatePersonResultViewTiny runs the validations defined on
+// PersonResultView using the "tiny" view.
+func ValidatePersonResultViewTiny(result *PersonResultView) (err error) {
+	if result.FirstName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("firstName", "result"))
+	}
+	return
+}
+
+// ValidatePersonView runs the validations defined on PersonView.
+func ValidatePersonView(result *PersonView) (err error) {
+	if result.FirstName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("firstName", "result"))
+	}
+	if result.Contact != nil {
+		if err2 := ValidatePersonContactView(result.Contact); err2 != nil {
+			err = goa.MergeErrors(err, goa.NestedError(err2, "result.contact"))
+		}
+	}
+	return
+}
+
+// ValidatePersonContactView runs the validations defined on PersonContactView.
+func ValidatePersonContactView(result *PersonContactView) (err error) {
+	{
+		var n int
+		if result.Email != nil {
+			n++
+		}
+		if result.Phone != nil {
+			n++
+		}
+		if n != 1 {
+			err = goa.MergeErrors(err, goa.InvalidUnionError("result", []string{"email", "phone"}, n))
+		}
+	}
+	return
+}
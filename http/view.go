@@ -0,0 +1,30 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// RequestedView returns the name of the view selected by the client to render
+// the response. The view is read from the query string parameter with the
+// given name and if not present from the parameter with the same name of the
+// first Accept header media type that defines it. RequestedView returns an
+// empty string if the client does not select a view.
+func RequestedView(r *http.Request, name string) string {
+	if view := r.URL.Query().Get(name); view != "" {
+		return view
+	}
+	for _, accept := range r.Header["Accept"] {
+		for _, mt := range strings.Split(accept, ",") {
+			_, params, err := mime.ParseMediaType(strings.TrimSpace(mt))
+			if err != nil {
+				continue
+			}
+			if view := params[name]; view != "" {
+				return view
+			}
+		}
+	}
+	return ""
+}
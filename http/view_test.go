@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestedView(t *testing.T) {
+	cases := []struct {
+		Name     string
+		URL      string
+		Accept   []string
+		Expected string
+	}{
+		{"none", "/items", nil, ""},
+		{"query", "/items?view=tiny", nil, "tiny"},
+		{"accept", "/items", []string{"application/json; view=tiny"}, "tiny"},
+		{"accept-list", "/items", []string{"text/html, application/json; view=tiny"}, "tiny"},
+		{"accept-headers", "/items", []string{"text/html", "application/json;view=tiny"}, "tiny"},
+		{"accept-no-view", "/items", []string{"application/json; q=0.9"}, ""},
+		{"query-first", "/items?view=default", []string{"application/json; view=tiny"}, "default"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", c.URL, nil)
+			for _, a := range c.Accept {
+				r.Header.Add("Accept", a)
+			}
+			if actual := RequestedView(r, "view"); actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
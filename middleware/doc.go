@@ -6,5 +6,18 @@ apply additional transformations prior to and after calling the original. The
 middlewares included in this package include a logger middleware to log incoming
 requests, a request ID middleware that makes sure every request as a unique ID
 stored in the context and a couple of middlewares used to implement tracing.
+
+The package also includes endpoint middlewares that can be applied to the
+generated service and client endpoints with the Use functions, for example:
+
+	endpoints.Use(middleware.ChainEndpoint(
+		middleware.LogEndpoint(logger),
+		middleware.TimeEndpoint(recordDuration),
+	))
+	endpoints.UseShow(middleware.TimeoutEndpoint(time.Second))
+
+Such middlewares apply identically regardless of the transport. Panics are
+recovered by the transport specific Recover middlewares of the http/middleware
+and grpc/middleware packages.
 */
 package middleware
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// ChainEndpoint returns a middleware that applies the given middlewares in
+// order: the first middleware is the outermost one and thus the first to be
+// invoked.
+func ChainEndpoint(ms ...func(goa.Endpoint) goa.Endpoint) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		for i := len(ms) - 1; i >= 0; i-- {
+			e = ms[i](e)
+		}
+		return e
+	}
+}
+
+// LogEndpoint returns an endpoint middleware that logs the service and method
+// names of each call together with the call duration and error if any. The
+// request ID set by the RequestID middleware is logged as well when present in
+// the context.
+func LogEndpoint(l Logger) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			started := time.Now()
+			res, err := e(ctx, req)
+			keyvals := []interface{}{
+				"svc", ctx.Value(goa.ServiceKey),
+				"method", ctx.Value(goa.MethodKey),
+				"time", time.Since(started).String(),
+			}
+			if id := ctx.Value(RequestIDKey); id != nil {
+				keyvals = append([]interface{}{"id", id}, keyvals...)
+			}
+			if err != nil {
+				keyvals = append(keyvals, "err", err.Error())
+			}
+			l.Log(keyvals...)
+			return res, err
+		}
+	}
+}
+
+// TimeEndpoint returns an endpoint middleware that calls record with the
+// duration and error of each call. The service and method names are available
+// in the context under the goa.ServiceKey and goa.MethodKey keys.
+func TimeEndpoint(record func(ctx context.Context, d time.Duration, err error)) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			started := time.Now()
+			res, err := e(ctx, req)
+			record(ctx, time.Since(started), err)
+			return res, err
+		}
+	}
+}
+
+// TimeoutEndpoint returns an endpoint middleware that cancels the context
+// given to the endpoint after d. The middleware returns a timeout error if the
+// endpoint fails after the deadline is exceeded. TimeoutEndpoint should not be
+// used with client endpoints of streaming methods as the returned streams use
+// the context after the endpoint returns.
+func TimeoutEndpoint(d time.Duration) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			res, err := e(ctx, req)
+			if err != nil && ctx.Err() == context.DeadlineExceeded {
+				return nil, goa.TemporaryTimeoutError("timeout", "%v.%v timed out after %s",
+					ctx.Value(goa.ServiceKey), ctx.Value(goa.MethodKey), d)
+			}
+			return res, err
+		}
+	}
+}
+
+// RetryEndpoint returns a client endpoint middleware that calls the endpoint
+// up to attempts times until it succeeds. The middleware waits backoff before
+// the first retry and doubles the wait before each subsequent retry. Only the
+// errors for which retryable returns true are retried, if retryable is nil
+// then goa service errors that are temporary or timeouts and errors that
+// implement a Temporary method returning true are retried.
+func RetryEndpoint(attempts int, backoff time.Duration, retryable func(error) bool) func(goa.Endpoint) goa.Endpoint {
+	if retryable == nil {
+		retryable = isTemporary
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			wait := backoff
+			for i := 1; ; i++ {
+				res, err := e(ctx, req)
+				if err == nil || i >= attempts || !retryable(err) {
+					return res, err
+				}
+				t := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					t.Stop()
+					return nil, err
+				case <-t.C:
+				}
+				wait *= 2
+			}
+		}
+	}
+}
+
+// isTemporary returns true if err is a temporary error.
+func isTemporary(err error) bool {
+	switch e := err.(type) {
+	case *goa.ServiceError:
+		return e.Temporary || e.Timeout
+	case interface{ Temporary() bool }:
+		return e.Temporary()
+	}
+	return false
+}
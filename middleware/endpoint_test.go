@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type recordLogger struct {
+	entries [][]interface{}
+}
+
+func (l *recordLogger) Log(keyvals ...interface{}) error {
+	l.entries = append(l.entries, keyvals)
+	return nil
+}
+
+func TestChainEndpoint(t *testing.T) {
+	var calls []string
+	mw := func(name string) func(goa.Endpoint) goa.Endpoint {
+		return func(e goa.Endpoint) goa.Endpoint {
+			return func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls = append(calls, name)
+				return e(ctx, req)
+			}
+		}
+	}
+	e := ChainEndpoint(mw("a"), mw("b"), mw("c"))(func(context.Context, interface{}) (interface{}, error) {
+		calls = append(calls, "endpoint")
+		return nil, nil
+	})
+	if _, err := e(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if actual := strings.Join(calls, ","); actual != "a,b,c,endpoint" {
+		t.Errorf("got calls %s, expected a,b,c,endpoint", actual)
+	}
+}
+
+func TestLogEndpoint(t *testing.T) {
+	l := &recordLogger{}
+	ctx := context.WithValue(context.Background(), goa.ServiceKey, "svc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "method")
+	ctx = context.WithValue(ctx, RequestIDKey, "id")
+	e := LogEndpoint(l)(func(context.Context, interface{}) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	if _, err := e(ctx, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(l.entries) != 1 {
+		t.Fatalf("got %d log entries, expected 1", len(l.entries))
+	}
+	kv := l.entries[0]
+	expected := []interface{}{"id", "id", "svc", "svc", "method", "method"}
+	for i, v := range expected {
+		if kv[i] != v {
+			t.Errorf("got %v at index %d, expected %v", kv[i], i, v)
+		}
+	}
+	if kv[len(kv)-2] != "err" || kv[len(kv)-1] != "boom" {
+		t.Errorf("got %v, expected entry to end with the error", kv)
+	}
+}
+
+func TestTimeEndpoint(t *testing.T) {
+	var recorded time.Duration
+	e := TimeEndpoint(func(_ context.Context, d time.Duration, _ error) {
+		recorded = d
+	})(func(context.Context, interface{}) (interface{}, error) {
+		time.Sleep(time.Millisecond)
+		return nil, nil
+	})
+	if _, err := e(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if recorded < time.Millisecond {
+		t.Errorf("got duration %s, expected at least 1ms", recorded)
+	}
+}
+
+func TestTimeoutEndpoint(t *testing.T) {
+	e := TimeoutEndpoint(time.Millisecond)(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	_, err := e(context.Background(), nil)
+	gerr, ok := err.(*goa.ServiceError)
+	if !ok {
+		t.Fatalf("got error %#v, expected a service error", err)
+	}
+	if !gerr.Timeout {
+		t.Errorf("got error %+v, expected a timeout", gerr)
+	}
+}
+
+func TestRetryEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		Err      error
+		Attempts int
+	}{
+		"temporary": {goa.TemporaryError("unavailable", "try again"), 3},
+		"permanent": {goa.PermanentError("bad", "do not retry"), 1},
+		"other":     {errors.New("do not retry"), 1},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var n int
+			e := RetryEndpoint(3, time.Microsecond, nil)(func(context.Context, interface{}) (interface{}, error) {
+				n++
+				return nil, c.Err
+			})
+			if _, err := e(context.Background(), nil); err != c.Err {
+				t.Errorf("got error %v, expected %v", err, c.Err)
+			}
+			if n != c.Attempts {
+				t.Errorf("got %d attempts, expected %d", n, c.Attempts)
+			}
+		})
+	}
+}
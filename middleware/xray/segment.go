@@ -208,6 +208,17 @@ func (s *Segment) RecordError(e error) {
 	}
 }
 
+// RecordPanic traces the value recovered from a panic. It sets the fault
+// field of s and records an exception whose stack trace includes the function
+// that panicked. RecordPanic must be called by the deferred function that
+// recovers from the panic.
+func (s *Segment) RecordPanic(v interface{}) {
+	s.Lock()
+	s.Fault = true
+	s.Unlock()
+	s.RecordError(errors.Errorf("panic: %v", v))
+}
+
 // Capture creates a subsegment to record the execution of the given function.
 // Usage:
 //
@@ -109,3 +109,35 @@ func TestSegment_SubmitInProgress(t *testing.T) {
 		}
 	})
 }
+
+func TestSegment_RecordPanic(t *testing.T) {
+	conn, err := net.Dial("udp", udplisten)
+	if err != nil {
+		t.Fatalf("failed to connect to daemon - %s", err)
+	}
+	s := xray.NewSegment("hello", xray.NewTraceID(), xray.NewID(), conn)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				s.RecordPanic(r)
+			}
+		}()
+		panic("boom")
+	}()
+	if !s.Fault {
+		t.Errorf("segment fault not set")
+	}
+	if s.Error {
+		t.Errorf("segment error set, expected fault only")
+	}
+	if s.Cause == nil || len(s.Cause.Exceptions) != 1 {
+		t.Fatalf("expected one exception, got cause %+v", s.Cause)
+	}
+	exc := s.Cause.Exceptions[0]
+	if exc.Message != "panic: boom" {
+		t.Errorf("got exception message %q, expected %q", exc.Message, "panic: boom")
+	}
+	if len(exc.Stack) == 0 {
+		t.Errorf("exception stack trace not recorded")
+	}
+}
@@ -12,6 +12,24 @@ const (
 	// service as defined in the design. The generated transport code
 	// initializes the corresponding value prior to invoking the endpoint.
 	ServiceKey
+
+	// FieldMaskKey is the request context key used to store the FieldMask
+	// that selects the result fields returned to the client. The generated
+	// transport code initializes the corresponding value server side from
+	// the request and encodes it in the request client side.
+	FieldMaskKey
+
+	// ViewKey is the request context key used to store the name of the view
+	// selected by the client to render the method result. The generated
+	// transport code initializes the corresponding value server side from
+	// the request and encodes it in the request client side.
+	ViewKey
+
+	// IdempotencyKeyKey is the request context key used to store the key
+	// that identifies the requests made to idempotent endpoints. The
+	// generated transport code initializes the corresponding value server
+	// side from the request and encodes it in the request client side.
+	IdempotencyKeyKey
 )
 
 type (
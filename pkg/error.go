@@ -24,6 +24,31 @@ type (
 		Temporary bool
 		// Is the error a server-side fault?
 		Fault bool
+		// Violations lists the validation failures that caused the
+		// error if any.
+		Violations []*Violation
+	}
+
+	// Violation describes a single validation failure.
+	Violation struct {
+		// Field is the JSON pointer (RFC 6901) to the invalid field
+		// relative to the validated value. Array elements and map
+		// values are referenced by index and key respectively.
+		Field string `json:"field" xml:"field" form:"field"`
+		// Rule is the name of the validation rule that failed, one of
+		// "required", "type", "enum", "format", "pattern", "minimum",
+		// "maximum", "exclusive_minimum", "exclusive_maximum",
+		// "multiple_of", "unique_items", "min_length", "max_length",
+		// "union" or "field_mask".
+		Rule string `json:"rule" xml:"rule" form:"rule"`
+		// Expected describes the value expected by the rule, e.g. the
+		// pattern or the minimum length.
+		Expected interface{} `json:"expected,omitempty" xml:"expected,omitempty" form:"expected,omitempty"`
+		// Actual is the invalid value or for length and union rules the
+		// actual length or number of union values set.
+		Actual interface{} `json:"actual,omitempty" xml:"actual,omitempty" form:"actual,omitempty"`
+		// Message describes the validation failure.
+		Message string `json:"message" xml:"message" form:"message"`
 	}
 )
 
@@ -75,13 +100,13 @@ func DecodePayloadError(msg string) error {
 // InvalidFieldTypeError is the error produced by the generated code when the
 // type of a payload field does not match the type defined in the design.
 func InvalidFieldTypeError(name string, val interface{}, expected string) error {
-	return PermanentError("invalid_field_type", "invalid value %#v for %q, must be a %s", val, name, expected)
+	return newValidationError("invalid_field_type", name, "type", expected, val, "invalid value %#v for %q, must be a %s", val, name, expected)
 }
 
 // MissingFieldError is the error produced by the generated code when a payload
 // is missing a required field.
 func MissingFieldError(name, context string) error {
-	return PermanentError("missing_field", "%q is missing from %s", name, context)
+	return newValidationError("missing_field", context+"."+name, "required", nil, nil, "%q is missing from %s", name, context)
 }
 
 // InvalidEnumValueError is the error produced by the generated code when the
@@ -92,43 +117,80 @@ func InvalidEnumValueError(name string, val interface{}, allowed []interface{})
 	for i, a := range allowed {
 		elems[i] = fmt.Sprintf("%#v", a)
 	}
-	return PermanentError("invalid_enum_value", "value of %s must be one of %s but got value %#v", name, strings.Join(elems, ", "), val)
+	return newValidationError("invalid_enum_value", name, "enum", allowed, val, "value of %s must be one of %s but got value %#v", name, strings.Join(elems, ", "), val)
 }
 
 // InvalidFormatError is the error produced by the generated code when the value
 // of a payload field does not match the format validation defined in the
 // design.
 func InvalidFormatError(name, target string, format Format, formatError error) error {
-	return PermanentError("invalid_format", "%s must be formatted as a %s but got value %q, %s", name, format, target, formatError.Error())
+	return newValidationError("invalid_format", name, "format", string(format), target, "%s must be formatted as a %s but got value %q, %s", name, format, target, formatError.Error())
 }
 
 // InvalidPatternError is the error produced by the generated code when the
 // value of a payload field does not match the pattern validation defined in the
 // design.
 func InvalidPatternError(name, target string, pattern string) error {
-	return PermanentError("invalid_pattern", "%s must match the regexp %q but got value %q", name, pattern, target)
+	return newValidationError("invalid_pattern", name, "pattern", pattern, target, "%s must match the regexp %q but got value %q", name, pattern, target)
 }
 
 // InvalidRangeError is the error produced by the generated code when the value
 // of a payload field does not match the range validation defined in the design.
 // value may be an int or a float64.
 func InvalidRangeError(name string, target interface{}, value interface{}, min bool) error {
-	comp := "greater or equal"
+	comp, rule := "greater or equal", "minimum"
 	if !min {
-		comp = "lesser or equal"
+		comp, rule = "lesser or equal", "maximum"
 	}
-	return PermanentError("invalid_range", "%s must be %s than %d but got value %#v", name, comp, value, target)
+	return newValidationError("invalid_range", name, rule, value, target, "%s must be %s than %d but got value %#v", name, comp, value, target)
+}
+
+// InvalidExclusiveRangeError is the error produced by the generated code when
+// the value of a payload field does not match the exclusive range validation
+// defined in the design. value may be an int or a float64.
+func InvalidExclusiveRangeError(name string, target interface{}, value interface{}, min bool) error {
+	comp, rule := "greater", "exclusive_minimum"
+	if !min {
+		comp, rule = "lesser", "exclusive_maximum"
+	}
+	return newValidationError("invalid_range", name, rule, value, target, "%s must be %s than %v but got value %#v", name, comp, value, target)
+}
+
+// InvalidMultipleOfError is the error produced by the generated code when the
+// value of a payload field is not a multiple of the value defined in the
+// design. value may be an int or a float64.
+func InvalidMultipleOfError(name string, target interface{}, value interface{}) error {
+	return newValidationError("invalid_multiple_of", name, "multiple_of", value, target, "%s must be a multiple of %v but got value %#v", name, value, target)
+}
+
+// InvalidUniqueItemsError is the error produced by the generated code when the
+// elements of a payload array field are not unique. dup is the first element
+// that appears more than once.
+func InvalidUniqueItemsError(name string, dup interface{}) error {
+	return newValidationError("invalid_unique_items", name, "unique_items", nil, dup, "elements of %s must be unique but got duplicate value %#v", name, dup)
 }
 
 // InvalidLengthError is the error produced by the generated code when the value
 // of a payload field does not match the length validation defined in the
 // design.
 func InvalidLengthError(name string, target interface{}, ln, value int, min bool) error {
-	comp := "greater or equal"
+	comp, rule := "greater or equal", "min_length"
 	if !min {
-		comp = "lesser or equal"
+		comp, rule = "lesser or equal", "max_length"
 	}
-	return PermanentError("invalid_length", "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln)
+	return newValidationError("invalid_length", name, rule, value, ln, "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln)
+}
+
+// InvalidUnionError is the error produced by the generated code when the value
+// of a union field does not have exactly one of the union values set.
+func InvalidUnionError(name string, values []string, count int) error {
+	return newValidationError("invalid_union", name, "union", 1, count, "exactly one of %s must be set in %s but got %d", strings.Join(values, ", "), name, count)
+}
+
+// InvalidFieldMaskError is the error produced by the generated code when a
+// field mask selects a field that is not defined by the method result.
+func InvalidFieldMaskError(name, path string) error {
+	return newValidationError("invalid_field_mask", name, "field_mask", nil, path, "invalid %s path %q, no such field in result", name, path)
 }
 
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
@@ -153,6 +215,8 @@ func NewErrorID() string {
 //
 // * computes Timeout and Temporary by "and"ing the fields of both errors.
 //
+// * appends the violations of other to the violations of err.
+//
 // Merge returns the updated error. This makes it possible to return other when
 // err is nil.
 func MergeErrors(err, other error) error {
@@ -174,10 +238,31 @@ func MergeErrors(err, other error) error {
 	e.Timeout = e.Timeout && o.Timeout
 	e.Temporary = e.Temporary && o.Temporary
 	e.Fault = e.Fault && o.Fault
+	e.Violations = append(e.Violations, o.Violations...)
 
 	return e
 }
 
+// NestedError prefixes the fields of the validation violations of err with
+// the JSON pointer of the field named by context. The generated code uses
+// NestedError to make the violations returned when validating nested user
+// types relative to the top level validated value. context is the name of the
+// nested field as used in error messages, e.g. "body.items[2]". NestedError
+// returns err.
+func NestedError(err error, context string) error {
+	gerr, ok := err.(*ServiceError)
+	if !ok {
+		return err
+	}
+	if segs := nameSegments(context); len(segs) > 1 {
+		prefix := jsonPointer(segs[1:])
+		for _, v := range gerr.Violations {
+			v.Field = prefix + v.Field
+		}
+	}
+	return err
+}
+
 // Error returns the error message.
 func (s *ServiceError) Error() string { return s.Message }
 
@@ -195,6 +280,54 @@ func newError(name string, timeout, temporary, fault bool, format string, v ...i
 	}
 }
 
+// newValidationError creates a permanent error with a single violation of the
+// given rule by the field with the given name.
+func newValidationError(name, field, rule string, expected, actual interface{}, format string, v ...interface{}) *ServiceError {
+	err := newError(name, false, false, false, format, v...)
+	err.Violations = []*Violation{{
+		Field:    fieldPointer(field),
+		Rule:     rule,
+		Expected: expected,
+		Actual:   actual,
+		Message:  err.Message,
+	}}
+	return err
+}
+
+// fieldPointer returns the JSON pointer corresponding to the field name used
+// in validation error messages, e.g. "body.items[2].name" is converted to
+// "/items/2/name". The first segment names the validated value and is omitted
+// unless it is the only segment.
+func fieldPointer(name string) string {
+	segs := nameSegments(name)
+	if len(segs) > 1 {
+		segs = segs[1:]
+	}
+	return jsonPointer(segs)
+}
+
+// nameSegments splits the given field name into its dot and bracket separated
+// segments.
+func nameSegments(name string) []string {
+	var segs []string
+	for _, s := range strings.Split(strings.NewReplacer("[", ".", "]", "").Replace(name), ".") {
+		if s != "" {
+			segs = append(segs, s)
+		}
+	}
+	return segs
+}
+
+// jsonPointer returns the JSON pointer made of the given reference tokens.
+func jsonPointer(segs []string) string {
+	var b strings.Builder
+	for _, s := range segs {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(s))
+	}
+	return b.String()
+}
+
 func asError(err error) *ServiceError {
 	e, ok := err.(*ServiceError)
 	if !ok {
@@ -0,0 +1,71 @@
+package goa
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFieldPointer(t *testing.T) {
+	cases := map[string]string{
+		"page":                 "/page",
+		"body.name":            "/name",
+		"body.items[2].name":   "/items/2/name",
+		"body.map[foo]":        "/map/foo",
+		"body.map.key":         "/map/key",
+		"body[0]":              "/0",
+		"message.a/b":          "/a~1b",
+		"body.tilde~":          "/tilde~0",
+		"body.nested.deep.val": "/nested/deep/val",
+	}
+	for name, expected := range cases {
+		if actual := fieldPointer(name); actual != expected {
+			t.Errorf("%s: got %q, expected %q", name, actual, expected)
+		}
+	}
+}
+
+func TestMergeErrorsViolations(t *testing.T) {
+	var err error
+	err = MergeErrors(err, MissingFieldError("name", "body"))
+	err = MergeErrors(err, InvalidPatternError("body.code", "x", "^[0-9]+$"))
+	err = MergeErrors(err, InvalidLengthError("body.tags", []string{"a"}, 1, 2, true))
+	err = MergeErrors(err, errors.New("not a validation error"))
+
+	gerr, ok := err.(*ServiceError)
+	if !ok {
+		t.Fatalf("got error type %T, expected *ServiceError", err)
+	}
+	if gerr.Name != "missing_field" {
+		t.Errorf("got name %q, expected %q", gerr.Name, "missing_field")
+	}
+	expected := []*Violation{
+		{Field: "/name", Rule: "required", Message: `"name" is missing from body`},
+		{Field: "/code", Rule: "pattern", Expected: "^[0-9]+$", Actual: "x", Message: `body.code must match the regexp "^[0-9]+$" but got value "x"`},
+		{Field: "/tags", Rule: "min_length", Expected: 2, Actual: 1, Message: `length of body.tags must be greater or equal than 2 but got value []string{"a"} (len=1)`},
+	}
+	if !reflect.DeepEqual(gerr.Violations, expected) {
+		for i, v := range gerr.Violations {
+			t.Logf("violation %d: %+v", i, v)
+		}
+		t.Errorf("got %d violations, expected %d", len(gerr.Violations), len(expected))
+	}
+}
+
+func TestNestedError(t *testing.T) {
+	err := NestedError(MissingFieldError("radius", "body"), "body.items[1]")
+	gerr, ok := err.(*ServiceError)
+	if !ok {
+		t.Fatalf("got error type %T, expected *ServiceError", err)
+	}
+	if len(gerr.Violations) != 1 {
+		t.Fatalf("got %d violations, expected 1", len(gerr.Violations))
+	}
+	if f := gerr.Violations[0].Field; f != "/items/1/radius" {
+		t.Errorf("got field %q, expected %q", f, "/items/1/radius")
+	}
+	other := errors.New("not a validation error")
+	if err := NestedError(other, "body.items[1]"); err != other {
+		t.Errorf("got %v, expected error to be returned unchanged", err)
+	}
+}
@@ -0,0 +1,120 @@
+package goa
+
+import (
+	"strings"
+)
+
+// PreconditionFailed is the name of the error returned by CheckIfMatch. The
+// design of HTTP endpoints that define IfMatch maps errors with this name to
+// 412 Precondition Failed responses and the gRPC transport maps them to
+// FailedPrecondition status codes.
+const PreconditionFailed = "precondition_failed"
+
+// CheckIfMatch returns an error if ifMatch, the value of the If-Match header
+// of a conditional request, does not match etag, the current entity tag of
+// the resource. CheckIfMatch returns nil if ifMatch is empty. The comparison
+// uses the strong comparison function defined in RFC 7232 section 2.3.2. The
+// view and field mask appended by the HTTP transport to the entity tags of
+// the representations of the resource, e.g. "v1;view=tiny", are ignored.
+func CheckIfMatch(ifMatch, etag string) error {
+	if ifMatch == "" || MatchETag(resourceETags(ifMatch), etag, false) {
+		return nil
+	}
+	return PreconditionFailedError("entity tag %s does not match %s", etag, ifMatch)
+}
+
+// PreconditionFailedError is the error returned when a precondition given in
+// a conditional request does not hold, for example when the entity tag given
+// in the If-Match header does not match the current entity tag of the
+// resource. PreconditionFailedError creates the error given a format and
+// values a la fmt.Printf.
+func PreconditionFailedError(format string, v ...interface{}) error {
+	return PermanentError(PreconditionFailed, format, v...)
+}
+
+// MatchETag returns true if etag matches one of the entity tags listed in
+// header, the value of a If-Match or If-None-Match HTTP header. The special
+// value "*" matches any entity tag. weak indicates whether to use the weak
+// comparison function which ignores the weakness indicator of entity tags or
+// the strong comparison function which requires both entity tags to be
+// strong. Unquoted entity tags are compared as if they were quoted.
+func MatchETag(header, etag string, weak bool) bool {
+	if etag == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	opaque, isWeak := parseETag(etag)
+	if isWeak && !weak {
+		return false
+	}
+	for _, tag := range splitETags(header) {
+		o, w := parseETag(tag)
+		if w && !weak {
+			continue
+		}
+		if o == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// parseETag returns the opaque tag of the given entity tag and whether the
+// entity tag is weak.
+func parseETag(tag string) (string, bool) {
+	tag = strings.TrimSpace(tag)
+	weak := strings.HasPrefix(tag, "W/")
+	if weak {
+		tag = tag[2:]
+	}
+	if len(tag) >= 2 && tag[0] == '"' && tag[len(tag)-1] == '"' {
+		tag = tag[1 : len(tag)-1]
+	}
+	return tag, weak
+}
+
+// splitETags splits the comma separated list of entity tags in header. Commas
+// that appear in quoted entity tags do not separate entity tags.
+func splitETags(header string) []string {
+	var (
+		tags   []string
+		quoted bool
+		start  int
+	)
+	for i, c := range header {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				tags = append(tags, header[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(tags, header[start:])
+}
+
+// resourceETags returns the comma separated list of entity tags in header with
+// the view and field mask of the representations removed from each tag.
+func resourceETags(header string) string {
+	tags := splitETags(header)
+	for i, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		j := strings.Index(tag, ";view=")
+		if k := strings.Index(tag, ";fields="); j < 0 || k >= 0 && k < j {
+			j = k
+		}
+		if j < 0 {
+			continue
+		}
+		if strings.HasSuffix(tag, `"`) {
+			tags[i] = tag[:j] + `"`
+		} else {
+			tags[i] = tag[:j]
+		}
+	}
+	return strings.Join(tags, ",")
+}
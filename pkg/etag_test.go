@@ -0,0 +1,54 @@
+package goa
+
+import (
+	"testing"
+)
+
+func TestMatchETag(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Header   string
+		ETag     string
+		Weak     bool
+		Expected bool
+	}{
+		{"empty", "", `"v1"`, true, false},
+		{"empty-etag", `"v1"`, "", true, false},
+		{"any", "*", `"v1"`, false, true},
+		{"strong", `"v1"`, `"v1"`, false, true},
+		{"mismatch", `"v2"`, `"v1"`, true, false},
+		{"list", `"v2", "v1"`, `"v1"`, false, true},
+		{"quoted-comma", `"a,b"`, `"a,b"`, false, true},
+		{"unquoted", `"v1"`, "v1", false, true},
+		{"weak-header", `W/"v1"`, `"v1"`, true, true},
+		{"weak-header-strong", `W/"v1"`, `"v1"`, false, false},
+		{"weak-etag", `"v1"`, `W/"v1"`, true, true},
+		{"weak-etag-strong", `"v1"`, `W/"v1"`, false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if actual := MatchETag(c.Header, c.ETag, c.Weak); actual != c.Expected {
+				t.Errorf("got %v, expected %v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	if err := CheckIfMatch("", `"v1"`); err != nil {
+		t.Errorf("empty header: got error %v", err)
+	}
+	if err := CheckIfMatch(`"v1"`, `"v1"`); err != nil {
+		t.Errorf("match: got error %v", err)
+	}
+	if err := CheckIfMatch(`"v0", "v1;view=tiny;fields=id,name"`, `"v1"`); err != nil {
+		t.Errorf("representation match: got error %v", err)
+	}
+	err := CheckIfMatch(`"v2"`, `"v1"`)
+	if err == nil {
+		t.Fatal("mismatch: got nil error")
+	}
+	if name := err.(*ServiceError).Name; name != PreconditionFailed {
+		t.Errorf("got error name %q, expected %q", name, PreconditionFailed)
+	}
+}
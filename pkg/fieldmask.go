@@ -0,0 +1,75 @@
+package goa
+
+import "strings"
+
+// FieldMask lists the paths of the result fields selected by a client. Paths
+// use dots to separate the names of nested fields, e.g. "author.name". An
+// empty field mask selects all the fields.
+type FieldMask []string
+
+// ParseFieldMask returns the field mask corresponding to the given comma
+// separated list of paths, e.g. "id,author.name".
+func ParseFieldMask(s string) FieldMask {
+	var mask FieldMask
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			mask = append(mask, p)
+		}
+	}
+	return mask
+}
+
+// String returns the comma separated list of paths.
+func (m FieldMask) String() string {
+	return strings.Join(m, ",")
+}
+
+// Includes returns true if the mask selects the field with the given name or
+// one of its nested fields.
+func (m FieldMask) Includes(field string) bool {
+	if len(m) == 0 {
+		return true
+	}
+	for _, p := range m {
+		if p == field || strings.HasPrefix(p, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Sub returns the mask that applies to the nested fields of the field with
+// the given name. The returned mask is empty (selects all the nested fields)
+// if m is empty or selects the field itself.
+func (m FieldMask) Sub(field string) FieldMask {
+	var sub FieldMask
+	for _, p := range m {
+		if p == field {
+			return nil
+		}
+		if strings.HasPrefix(p, field+".") {
+			sub = append(sub, p[len(field)+1:])
+		}
+	}
+	return sub
+}
+
+// Validate returns an error if m contains paths not listed in paths. The
+// error is produced with InvalidFieldMaskError and uses name to identify the
+// field mask.
+func (m FieldMask) Validate(name string, paths ...string) error {
+	var err error
+	for _, p := range m {
+		found := false
+		for _, valid := range paths {
+			if p == valid {
+				found = true
+				break
+			}
+		}
+		if !found {
+			err = MergeErrors(err, InvalidFieldMaskError(name, p))
+		}
+	}
+	return err
+}
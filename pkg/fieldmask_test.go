@@ -0,0 +1,87 @@
+package goa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldMask(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected FieldMask
+	}{
+		{"empty", "", nil},
+		{"single", "id", FieldMask{"id"}},
+		{"multiple", "id, author.name,,", FieldMask{"id", "author.name"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			actual := ParseFieldMask(c.Value)
+			if !reflect.DeepEqual(actual, c.Expected) {
+				t.Errorf("got %#v, expected %#v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestFieldMaskIncludes(t *testing.T) {
+	mask := FieldMask{"id", "author.name"}
+	cases := map[string]bool{
+		"id":       true,
+		"author":   true,
+		"auth":     false,
+		"title":    false,
+		"id.value": false,
+	}
+	for field, expected := range cases {
+		if actual := mask.Includes(field); actual != expected {
+			t.Errorf("%s: got %v, expected %v", field, actual, expected)
+		}
+	}
+	if !FieldMask(nil).Includes("title") {
+		t.Error("empty mask must include all fields")
+	}
+}
+
+func TestFieldMaskSub(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Mask     FieldMask
+		Field    string
+		Expected FieldMask
+	}{
+		{"empty", nil, "author", nil},
+		{"nested", FieldMask{"id", "author.name", "author.address.city"}, "author", FieldMask{"name", "address.city"}},
+		{"whole-field", FieldMask{"author.name", "author"}, "author", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			actual := c.Mask.Sub(c.Field)
+			if !reflect.DeepEqual(actual, c.Expected) {
+				t.Errorf("got %#v, expected %#v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestFieldMaskValidate(t *testing.T) {
+	paths := []string{"id", "author", "author.name"}
+	if err := (FieldMask{"id", "author.name"}).Validate("fields", paths...); err != nil {
+		t.Errorf("got error %v, expected none", err)
+	}
+	err := (FieldMask{"id", "author.age"}).Validate("fields", paths...)
+	if err == nil {
+		t.Fatal("got no error, expected invalid field mask error")
+	}
+	serr, ok := err.(*ServiceError)
+	if !ok {
+		t.Fatalf("got error of type %T, expected *ServiceError", err)
+	}
+	if serr.Name != "invalid_field_mask" {
+		t.Errorf("got error name %q, expected %q", serr.Name, "invalid_field_mask")
+	}
+	if len(serr.Violations) != 1 || serr.Violations[0].Actual != "author.age" {
+		t.Errorf("got violations %#v, expected one violation for %q", serr.Violations, "author.age")
+	}
+}
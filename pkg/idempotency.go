@@ -0,0 +1,26 @@
+package goa
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+)
+
+// WithIdempotencyKey returns a copy of ctx that holds a new random idempotency
+// key under IdempotencyKeyKey unless ctx already holds one. Client requests
+// made to idempotent endpoints with the returned context use the same key so
+// that the server processes them once.
+func WithIdempotencyKey(ctx context.Context) context.Context {
+	if key, ok := ctx.Value(IdempotencyKeyKey).(string); ok && key != "" {
+		return ctx
+	}
+	return context.WithValue(ctx, IdempotencyKeyKey, NewIdempotencyKey())
+}
+
+// NewIdempotencyKey returns a new random idempotency key.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	io.ReadFull(rand.Reader, b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
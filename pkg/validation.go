@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
 	"net/mail"
 	"net/url"
+	"reflect"
 	"regexp"
 	"sync"
 	"time"
@@ -164,6 +166,39 @@ func ValidatePattern(name, val, p string) error {
 	return nil
 }
 
+// ValidateMultipleOf returns an error if val is not a multiple of m. It
+// tolerates the rounding errors inherent to floating point arithmetic. name is
+// the name of the variable used in error messages.
+func ValidateMultipleOf(name string, val, m float64) error {
+	q := val / m
+	if math.Abs(q-math.Round(q)) > 1e-9*math.Max(1, math.Abs(q)) {
+		return InvalidMultipleOfError(name, val, m)
+	}
+	return nil
+}
+
+// ValidateUniqueItems returns an error if the slice val contains two elements
+// that are deeply equal. name is the name of the variable used in error
+// messages.
+func ValidateUniqueItems(name string, val interface{}) error {
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i).Interface()
+		for j := i + 1; j < v.Len(); j++ {
+			if reflect.DeepEqual(e, v.Index(j).Interface()) {
+				if dup := v.Index(i); dup.Kind() == reflect.Ptr && !dup.IsNil() {
+					e = dup.Elem().Interface()
+				}
+				return InvalidUniqueItemsError(name, e)
+			}
+		}
+	}
+	return nil
+}
+
 // The following formats are supported:
 // "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 // "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
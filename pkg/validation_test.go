@@ -123,3 +123,58 @@ func TestValidatePattern(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateMultipleOf(t *testing.T) {
+	name := "foo"
+	cases := map[string]struct {
+		val      float64
+		m        float64
+		expected error
+	}{
+		"integer multiple":  {10, 5, nil},
+		"decimal multiple":  {0.3, 0.1, nil},
+		"negative multiple": {-1.5, 0.5, nil},
+		"not a multiple":    {0.35, 0.1, InvalidMultipleOfError(name, 0.35, 0.1)},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateMultipleOf(name, tc.val, tc.m)
+		if actual != tc.expected {
+			// Compare only the messages because the error has always a new error ID.
+			if actual == nil || tc.expected == nil || actual.Error() != tc.expected.Error() {
+				t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+			}
+		}
+	}
+}
+
+func TestValidateUniqueItems(t *testing.T) {
+	var (
+		name = "foo"
+		one  = 1
+		uno  = 1
+		two  = 2
+	)
+	cases := map[string]struct {
+		val      interface{}
+		expected error
+	}{
+		"nil slice":           {[]string(nil), nil},
+		"unique values":       {[]string{"a", "b"}, nil},
+		"duplicate values":    {[]string{"a", "b", "a"}, InvalidUniqueItemsError(name, "a")},
+		"unique pointers":     {[]*int{&one, &two}, nil},
+		"duplicate pointers":  {[]*int{&one, &uno}, InvalidUniqueItemsError(name, 1)},
+		"duplicate maps":      {[]map[string]int{{"a": 1}, {"a": 1}}, InvalidUniqueItemsError(name, map[string]int{"a": 1})},
+		"unique nested lists": {[][]int{{1, 2}, {2, 1}}, nil},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateUniqueItems(name, tc.val)
+		if actual != tc.expected {
+			// Compare only the messages because the error has always a new error ID.
+			if actual == nil || tc.expected == nil || actual.Error() != tc.expected.Error() {
+				t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+			}
+		}
+	}
+}